@@ -19,6 +19,7 @@ export LOCALSTACK_URL="${AWS_ENDPOINT_URL}"
 # Create buckets
 awslocal s3 mb "s3://${AWS_BUCKET_NAME}"
 awslocal s3api put-bucket-cors --bucket "${AWS_BUCKET_NAME}" --cors-configuration file:///etc/localstack/init/bucket-cors.json
+awslocal s3 mb "s3://${AWS_CURATED_BUCKET_NAME}"
 
 
 # Get SQS Event ARN
@@ -1,33 +1,268 @@
 package main
 
 import (
+	"fmt"
 	"os"
 	"time"
 
 	"github.com/codingexplorations/data-lake/pkg"
+	"github.com/codingexplorations/data-lake/pkg/cli"
+	"github.com/codingexplorations/data-lake/pkg/compaction"
 	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/codingexplorations/data-lake/pkg/events"
+	"github.com/codingexplorations/data-lake/pkg/freshness"
 	"github.com/codingexplorations/data-lake/pkg/ingest"
 	"github.com/codingexplorations/data-lake/pkg/log"
+	"github.com/codingexplorations/data-lake/pkg/logs"
+	"github.com/codingexplorations/data-lake/pkg/preview"
+	"github.com/codingexplorations/data-lake/pkg/query"
+	"github.com/codingexplorations/data-lake/pkg/server"
+	"github.com/codingexplorations/data-lake/pkg/tracing"
 )
 
 // main function that processes a local file
 func main() {
-	logger := log.NewConsoleLog()
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "query":
+			os.Exit(runQuery(os.Args[2:]))
+		case "preview":
+			os.Exit(runPreview(os.Args[2:]))
+		case "profile":
+			os.Exit(runProfile(os.Args[2:]))
+		case "consume-logs":
+			os.Exit(runLogConsumer())
+		case "validate-config":
+			os.Exit(runValidateConfig())
+		}
+	}
 
-	for _, e := range os.Environ() {
-		// pair := strings.SplitN(e, "=", 2)
-		logger.Info(e)
+	// a config that doesn't validate stops the lake before it does anything with it
+	conf, err := config.Load()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
 
-	conf := config.GetConfig()
-	processor := ingest.GetIngestProcessor(conf)
+	newApp(conf).serve()
+}
 
-	r := pkg.NewRunner(conf, processor)
+// app is what the parts of the lake are made from, a config and the logger of it along with the levels the logger
+// follows. Every part is handed them rather than reading globals, so apps of different configs can run side by side.
+type app struct {
+	conf   *config.Config
+	logger log.Logger
+	levels *log.Levels
+}
+
+// newApp returns the app of a config, logging to its LOGGER_SINKS or to the console when they can't be created.
+func newApp(conf *config.Config) *app {
+	levels := log.NewLevelsOf(conf)
+	return &app{conf: conf, logger: log.NewLoggerOrConsole(conf, levels), levels: levels}
+}
+
+// newConsoleApp returns the app of a config logging to the console.
+func newConsoleApp(conf *config.Config) *app {
+	levels := log.NewLevelsOf(conf)
+	return &app{conf: conf, logger: log.NewLeveledConsoleLog(levels), levels: levels}
+}
+
+// readConfig returns the config commands run with, or nil when it can't be read or doesn't validate.
+func readConfig() *config.Config {
+	conf, err := config.Load()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return nil
+	}
+	return conf
+}
+
+// serve ingests the data folder every 10 seconds, never returning, with the server, freshness monitor and outbox
+// relay running alongside when they can be created.
+func (a *app) serve() {
+	conf, logger := a.conf, a.logger
+
+	// libraries logging with log/slog go through the configured logger
+	log.SetSlogDefault(logger)
+
+	// runs never stop, the batches of spans are exported as they fill up
+	if _, err := tracing.Setup(conf); err != nil {
+		logger.Error("couldn't set up tracing", log.Err(err))
+	}
+
+	// without a processor there is nothing to run
+	processor, err := ingest.GetIngestProcessor(conf, logger)
+	if err != nil {
+		logger.Error("couldn't create ingest processor", log.Err(err))
+		os.Exit(1)
+	}
+
+	// a compactor that couldn't be created leaves the curated files as they are
+	compactor, err := compaction.NewCompactor(conf, logger)
+	if err != nil {
+		logger.Error("couldn't create compactor", log.Err(err))
+	}
+
+	engine, err := query.NewEngine(conf, logger)
+	if err != nil {
+		logger.Error("couldn't create query engine", log.Err(err))
+	}
+	previewer, err := preview.NewPreviewer(conf, logger)
+	if err != nil {
+		logger.Error("couldn't create previewer", log.Err(err))
+	}
+	if engine != nil && previewer != nil && conf.HttpAddress != "" {
+		go func() {
+			if err := server.NewServer(conf, logger, a.levels, engine, previewer).ListenAndServe(); err != nil {
+				logger.Error("http server stopped", log.Err(err))
+			}
+		}()
+	}
+
+	// the monitor runs on its own so late datasets are still noticed while ingest is stuck
+	if monitor, err := freshness.NewMonitor(conf, logger); err != nil {
+		logger.Error("couldn't create freshness monitor", log.Err(err))
+	} else {
+		go func() {
+			for {
+				_, _ = monitor.Check()
+				time.Sleep(conf.FreshnessCheckInterval)
+			}
+		}()
+	}
+
+	// the relay delivers the events ingest and compaction commit to the outboxes of the catalog
+	if relay, err := events.NewRelay(conf, logger); err != nil {
+		logger.Error("couldn't create outbox relay", log.Err(err))
+	} else if relay != nil {
+		go func() {
+			for {
+				_, _ = relay.Relay()
+				time.Sleep(conf.OutboxRelayInterval)
+			}
+		}()
+	}
+
+	r := pkg.NewRunner(conf, processor, compactor)
 
 	r.Config.Print()
 
+	// the log levels pick up changes of CONFIG_FILE, the rest takes a restart
+	if conf.ConfigWatchInterval > 0 {
+		watcher := config.NewWatcher(conf)
+		watcher.Subscribe(func(conf *config.Config) {
+			if err := a.levels.Configure(conf); err != nil {
+				logger.Error("couldn't apply reloaded log levels", log.Err(err))
+			}
+		})
+		go watcher.Watch()
+	}
+
 	for {
 		r.Run()
 		time.Sleep(10 * time.Second)
 	}
 }
+
+// runQuery runs `data-lake query [-format table|json] "SELECT ..."` and returns the exit code.
+func runQuery(args []string) int {
+	conf := readConfig()
+	if conf == nil {
+		return 1
+	}
+	a := newApp(conf)
+
+	engine, err := query.NewEngine(a.conf, a.logger)
+	if err != nil {
+		a.logger.Error("couldn't create query engine", log.Err(err))
+		return 1
+	}
+
+	if err := cli.Query(engine, args, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	return 0
+}
+
+// runProfile runs `data-lake profile [-format table|json] dataset` and returns the exit code.
+func runProfile(args []string) int {
+	conf := readConfig()
+	if conf == nil {
+		return 1
+	}
+	a := newApp(conf)
+
+	engine, err := query.NewEngine(a.conf, a.logger)
+	if err != nil {
+		a.logger.Error("couldn't create query engine", log.Err(err))
+		return 1
+	}
+
+	if err := cli.Profile(engine, args, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	return 0
+}
+
+// runPreview runs `data-lake preview [-format table|json] [-limit n] [-dataset name] [location]` and returns the exit
+// code.
+func runPreview(args []string) int {
+	conf := readConfig()
+	if conf == nil {
+		return 1
+	}
+	a := newApp(conf)
+
+	previewer, err := preview.NewPreviewer(a.conf, a.logger)
+	if err != nil {
+		a.logger.Error("couldn't create previewer", log.Err(err))
+		return 1
+	}
+
+	if err := cli.Preview(previewer, args, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	return 0
+}
+
+// runValidateConfig runs `data-lake validate-config`, printing every problem of the configuration, and returns the exit
+// code.
+func runValidateConfig() int {
+	if _, err := config.Load(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	fmt.Println("configuration is valid")
+	return 0
+}
+
+// runLogConsumer runs `data-lake consume-logs`, landing the logs shipped to the logger queue in the lake until it's
+// stopped, and returns the exit code.
+func runLogConsumer() int {
+	conf := readConfig()
+	if conf == nil {
+		return 1
+	}
+
+	// logs the consumer shipped to the queue it drains would keep it busy landing its own logs
+	a := newConsoleApp(conf)
+	consumer, err := logs.NewConsumer(a.conf, a.logger)
+	if err != nil {
+		a.logger.Error("couldn't create log consumer", log.Err(err))
+		return 1
+	}
+
+	for {
+		// receiving long polls the queue, only failures to receive back off
+		if _, err := consumer.Consume(); err != nil {
+			time.Sleep(10 * time.Second)
+		}
+	}
+}
@@ -0,0 +1,15 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsSdkConfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/codingexplorations/data-lake/pkg/tracing"
+)
+
+// loadConfig loads the AWS config clients are made from, tracing every call they make.
+func loadConfig() (aws.Config, error) {
+	return awsSdkConfig.LoadDefaultConfig(context.TODO(), awsSdkConfig.WithAPIOptions([]func(*middleware.Stack) error{tracing.AwsCalls}))
+}
@@ -3,9 +3,9 @@ package aws
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	awsSdkConfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/codingexplorations/data-lake/pkg/log"
@@ -14,14 +14,20 @@ import (
 type S3Client interface {
 	ListObjects(bucketName string, prefix *string) ([]types.Object, error)
 	HeadObject(bucketName string, objectKey string) (*s3.HeadObjectOutput, error)
+	GetObject(bucketName string, objectKey string) (*s3.GetObjectOutput, error)
+	GetObjectRange(bucketName string, objectKey string, offset int64, length int64) (*s3.GetObjectOutput, error)
+	PutObject(bucketName string, objectKey string, body io.Reader, contentType string) (*s3.PutObjectOutput, error)
+	PutObjectIfAbsent(bucketName string, objectKey string, body io.Reader, contentType string) (*s3.PutObjectOutput, error)
+	DeleteObject(bucketName string, objectKey string) (*s3.DeleteObjectOutput, error)
 }
 
 type S3 struct {
 	Client *s3.Client
+	ctx    context.Context
 }
 
 func NewS3() (S3, error) {
-	cfg, err := awsSdkConfig.LoadDefaultConfig(context.TODO())
+	cfg, err := loadConfig()
 	if err != nil {
 		return S3{}, err
 	}
@@ -35,7 +41,32 @@ func NewS3() (S3, error) {
 	return s3Client, nil
 }
 
-// ListObjects lists the objects in a bucket.
+// WithContext returns the client making its calls in ctx, so they're traced as part of the span in it.
+func (client *S3) WithContext(ctx context.Context) S3Client {
+	bound := *client
+	bound.ctx = ctx
+	return &bound
+}
+
+// S3WithContext returns the client making its calls in ctx. Clients that can't, like mocks, are returned as they are.
+func S3WithContext(client S3Client, ctx context.Context) S3Client {
+	if bindable, ok := client.(interface {
+		WithContext(ctx context.Context) S3Client
+	}); ok {
+		return bindable.WithContext(ctx)
+	}
+	return client
+}
+
+// callContext returns the context calls are made in, the one the client was bound to if any.
+func (client *S3) callContext() context.Context {
+	if client.ctx == nil {
+		return context.TODO()
+	}
+	return client.ctx
+}
+
+// ListObjects lists the objects in a bucket, following continuation tokens past the first 1000 keys.
 func (client *S3) ListObjects(bucketName string, prefix *string) ([]types.Object, error) {
 	config := &s3.ListObjectsV2Input{
 		Bucket: aws.String(bucketName),
@@ -44,16 +75,19 @@ func (client *S3) ListObjects(bucketName string, prefix *string) ([]types.Object
 		config.Prefix = prefix
 	}
 
-	result, err := client.Client.ListObjectsV2(context.TODO(), config)
-
 	var contents []types.Object
-	if err != nil {
-		log.NewConsoleLog().Error(fmt.Sprintf("couldn't list objects in bucket %v.\n", bucketName))
-	} else {
-		contents = result.Contents
+
+	paginator := s3.NewListObjectsV2Paginator(client.Client, config)
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(client.callContext())
+		if err != nil {
+			log.NewConsoleLog().Error(fmt.Sprintf("couldn't list objects in bucket %v.\n", bucketName))
+			return nil, err
+		}
+		contents = append(contents, result.Contents...)
 	}
 
-	return contents, err
+	return contents, nil
 }
 
 func (client *S3) HeadObject(bucket, key string) (*s3.HeadObjectOutput, error) {
@@ -62,7 +96,7 @@ func (client *S3) HeadObject(bucket, key string) (*s3.HeadObjectOutput, error) {
 		Key:    aws.String(key),
 	}
 
-	result, err := client.Client.HeadObject(context.TODO(), input)
+	result, err := client.Client.HeadObject(client.callContext(), input)
 
 	if err != nil {
 		return nil, err
@@ -70,3 +104,60 @@ func (client *S3) HeadObject(bucket, key string) (*s3.HeadObjectOutput, error) {
 
 	return result, nil
 }
+
+// GetObject gets an object from a bucket. The caller must close the returned body.
+func (client *S3) GetObject(bucket, key string) (*s3.GetObjectOutput, error) {
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}
+
+	return client.Client.GetObject(client.callContext(), input)
+}
+
+// PutObject uploads an object to a bucket.
+func (client *S3) PutObject(bucket, key string, body io.Reader, contentType string) (*s3.PutObjectOutput, error) {
+	input := &s3.PutObjectInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(key),
+		Body:        body,
+		ContentType: aws.String(contentType),
+	}
+
+	return client.Client.PutObject(client.callContext(), input)
+}
+
+// DeleteObject deletes an object from a bucket.
+func (client *S3) DeleteObject(bucket, key string) (*s3.DeleteObjectOutput, error) {
+	input := &s3.DeleteObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}
+
+	return client.Client.DeleteObject(client.callContext(), input)
+}
+
+// PutObjectIfAbsent uploads an object only if there is no object at the key yet. S3 answers with a PreconditionFailed
+// error when there is.
+func (client *S3) PutObjectIfAbsent(bucket, key string, body io.Reader, contentType string) (*s3.PutObjectOutput, error) {
+	input := &s3.PutObjectInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(key),
+		Body:        body,
+		ContentType: aws.String(contentType),
+		IfNoneMatch: aws.String("*"),
+	}
+
+	return client.Client.PutObject(client.callContext(), input)
+}
+
+// GetObjectRange gets length bytes of an object starting at offset. The caller must close the returned body.
+func (client *S3) GetObjectRange(bucket, key string, offset, length int64) (*s3.GetObjectOutput, error) {
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		Range:  aws.String(fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)),
+	}
+
+	return client.Client.GetObject(client.callContext(), input)
+}
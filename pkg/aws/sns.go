@@ -0,0 +1,38 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/codingexplorations/data-lake/pkg/log"
+)
+
+type SnsClient interface {
+	Publish(topicArn string, message string) (*sns.PublishOutput, error)
+}
+
+type Sns struct {
+	Client *sns.Client
+}
+
+func NewSns() (SnsClient, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		log.NewConsoleLog().Error(fmt.Sprintf("cannot load the AWS configs: %s", err))
+		return Sns{}, err
+	}
+
+	return &Sns{Client: sns.NewFromConfig(cfg)}, nil
+}
+
+// Publish sends a message to an Amazon SNS topic, or any SNS-compatible topic the endpoint points to.
+func (client Sns) Publish(topicArn string, message string) (*sns.PublishOutput, error) {
+	input := &sns.PublishInput{
+		TopicArn: aws.String(topicArn),
+		Message:  aws.String(message),
+	}
+
+	return client.Client.Publish(context.TODO(), input)
+}
@@ -0,0 +1,18 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnsClient_Publish(t *testing.T) {
+	conf := config.GetConfig()
+	snsClient, _ := NewSns()
+
+	result, err := snsClient.Publish(conf.AwsIngestEventTopicArn, "{\"location\": \"orders/2024/01.csv\"}")
+	assert.NoError(t, err, fmt.Sprintf("Got an error publishing the message: %v", err))
+	assert.NotNil(t, result.MessageId)
+}
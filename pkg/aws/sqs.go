@@ -5,15 +5,17 @@ import (
 	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	awsSdkConfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
 	"github.com/codingexplorations/data-lake/pkg/log"
 )
 
 type SqsClient interface {
 	GetQueueUrl(queueName string) (*sqs.GetQueueUrlOutput, error)
-	GetMessages(attributeNames []string, queueURL *string, maxMessages int32, timeout int32) (*sqs.ReceiveMessageOutput, error)
+	GetMessages(attributeNames []string, queueURL *string, maxMessages int32, timeout int32, waitTime int32) (*sqs.ReceiveMessageOutput, error)
 	RemoveMessage(queueURL *string, messageHandle *string) (*sqs.DeleteMessageOutput, error)
+	ChangeMessageVisibility(queueURL *string, messageHandle *string, timeout int32) (*sqs.ChangeMessageVisibilityOutput, error)
+	SendMessage(queueURL *string, body string, attributes map[string]types.MessageAttributeValue) (*sqs.SendMessageOutput, error)
 }
 
 type Sqs struct {
@@ -21,7 +23,7 @@ type Sqs struct {
 }
 
 func NewSqs() (SqsClient, error) {
-	cfg, err := awsSdkConfig.LoadDefaultConfig(context.TODO())
+	cfg, err := loadConfig()
 	if err != nil {
 		log.NewConsoleLog().Error(fmt.Sprintf("cannot load the AWS configs: %s", err))
 		return Sqs{}, err
@@ -39,13 +41,17 @@ func (client Sqs) GetQueueUrl(queueName string) (*sqs.GetQueueUrlOutput, error)
 	return client.Client.GetQueueUrl(context.TODO(), qUInput)
 }
 
-// GetMessages gets the most recent message from an Amazon SQS queue.
-func (client Sqs) GetMessages(attributeNames []string, queueURL *string, maxMessages int32, timeout int32) (*sqs.ReceiveMessageOutput, error) {
+// GetMessages gets the most recent message from an Amazon SQS queue, hiding them from other consumers for timeout
+// seconds. It waits up to waitTime seconds for messages to arrive when the queue is empty. The messages come with
+// their ApproximateReceiveCount attribute.
+func (client Sqs) GetMessages(attributeNames []string, queueURL *string, maxMessages int32, timeout int32, waitTime int32) (*sqs.ReceiveMessageOutput, error) {
 	input := &sqs.ReceiveMessageInput{
+		AttributeNames:        []types.QueueAttributeName{types.QueueAttributeName(types.MessageSystemAttributeNameApproximateReceiveCount)},
 		MessageAttributeNames: attributeNames,
 		QueueUrl:              queueURL,
 		MaxNumberOfMessages:   maxMessages,
 		VisibilityTimeout:     timeout,
+		WaitTimeSeconds:       waitTime,
 	}
 
 	return client.Client.ReceiveMessage(context.TODO(), input)
@@ -60,3 +66,26 @@ func (client Sqs) RemoveMessage(queueURL *string, messageHandle *string) (*sqs.D
 
 	return client.Client.DeleteMessage(context.TODO(), input)
 }
+
+// ChangeMessageVisibility hides a received message from other consumers for timeout seconds from now, a timeout of
+// zero makes it visible again right away.
+func (client Sqs) ChangeMessageVisibility(queueURL *string, messageHandle *string, timeout int32) (*sqs.ChangeMessageVisibilityOutput, error) {
+	input := &sqs.ChangeMessageVisibilityInput{
+		QueueUrl:          queueURL,
+		ReceiptHandle:     messageHandle,
+		VisibilityTimeout: timeout,
+	}
+
+	return client.Client.ChangeMessageVisibility(context.TODO(), input)
+}
+
+// SendMessage sends a message with optional attributes to an Amazon SQS queue.
+func (client Sqs) SendMessage(queueURL *string, body string, attributes map[string]types.MessageAttributeValue) (*sqs.SendMessageOutput, error) {
+	input := &sqs.SendMessageInput{
+		QueueUrl:          queueURL,
+		MessageBody:       aws.String(body),
+		MessageAttributes: attributes,
+	}
+
+	return client.Client.SendMessage(context.TODO(), input)
+}
@@ -0,0 +1,334 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/codingexplorations/data-lake/pkg/log"
+	"github.com/codingexplorations/data-lake/pkg/tracing"
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// maxReceiveMessages is the most messages SQS hands out per receive
+	maxReceiveMessages = 10
+	// maxVisibilityTimeout is the longest SQS hides a message for
+	maxVisibilityTimeout = 12 * time.Hour
+	// minHeartbeat is the most often the visibility of messages in flight is extended
+	minHeartbeat = time.Second
+)
+
+// MessageHandler works on a message received from a queue, returning an error when the message should be retried. ctx
+// holds the span of handling the message, part of the trace the message was sent in.
+type MessageHandler func(ctx context.Context, message types.Message) error
+
+// BatchHandler works on messages received from a queue together, returning an error when all of them should be
+// retried.
+type BatchHandler func(messages []types.Message) error
+
+// SqsConsumer receives the messages of a queue and hands them to a handler one at a time. While messages are in
+// flight the consumer keeps extending their visibility, so work that takes longer than SQS_VISIBILITY_TIMEOUT doesn't
+// see them handed to another consumer. A handled message is removed from the queue. A message the handler failed on
+// becomes visible again after SQS_RETRY_BACKOFF, doubled for every time it was received, up to SQS_MAX_RETRY_BACKOFF.
+// With a dead-letter queue, messages received more than SQS_MAX_RECEIVES times are moved to it instead of being
+// handled again.
+type SqsConsumer struct {
+	logger             log.Logger
+	sqs                SqsClient
+	queueUrl           *string
+	deadLetterQueueUrl *string
+	visibilityTimeout  time.Duration
+	heartbeat          time.Duration
+	waitTime           time.Duration
+	maxReceives        int
+	backoff            time.Duration
+	maxBackoff         time.Duration
+}
+
+// NewSqsConsumer returns a consumer of the named queue, moving poison messages to the named dead-letter queue when
+// there is one.
+func NewSqsConsumer(conf *config.Config, logger log.Logger, queueName string, deadLetterQueueName string) (*SqsConsumer, error) {
+	sqs, err := NewSqs()
+	if err != nil {
+		return nil, err
+	}
+
+	queueUrl, err := sqs.GetQueueUrl(queueName)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get the url of queue %v: %v", queueName, err)
+	}
+
+	var deadLetterQueueUrl *string
+	if deadLetterQueueName != "" {
+		output, err := sqs.GetQueueUrl(deadLetterQueueName)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get the url of queue %v: %v", deadLetterQueueName, err)
+		}
+		deadLetterQueueUrl = output.QueueUrl
+	}
+
+	return &SqsConsumer{
+		logger:             logger,
+		sqs:                sqs,
+		queueUrl:           queueUrl.QueueUrl,
+		deadLetterQueueUrl: deadLetterQueueUrl,
+		visibilityTimeout:  conf.SqsVisibilityTimeout,
+		heartbeat:          heartbeatOf(conf.SqsVisibilityTimeout),
+		waitTime:           conf.SqsWaitTime,
+		maxReceives:        conf.SqsMaxReceives,
+		backoff:            conf.SqsRetryBackoff,
+		maxBackoff:         conf.SqsMaxRetryBackoff,
+	}, nil
+}
+
+// Poll long polls the queue for SQS_WAIT_TIME and hands the messages it receives to the handler, returning how many
+// were handled.
+func (consumer *SqsConsumer) Poll(handler MessageHandler) (int, error) {
+	output, err := consumer.sqs.GetMessages([]string{string(types.QueueAttributeNameAll)}, consumer.queueUrl, maxReceiveMessages, seconds(consumer.visibilityTimeout), seconds(consumer.waitTime))
+	if err != nil {
+		return 0, fmt.Errorf("couldn't receive messages: %v", err)
+	}
+	if len(output.Messages) == 0 {
+		return 0, nil
+	}
+
+	flight := newInFlight(output.Messages)
+	stop := consumer.keepInvisible(flight)
+	defer stop()
+
+	handled := 0
+	for _, message := range output.Messages {
+		if consumer.handle(flight, message, handler) {
+			handled++
+		}
+	}
+
+	return handled, nil
+}
+
+// PollBatch receives messages until it holds size of them, a receive comes back empty or wait has passed, and hands
+// them to the handler together, returning how many were handled. The messages are removed once the handler succeeds,
+// when it fails they are all retried.
+func (consumer *SqsConsumer) PollBatch(handler BatchHandler, size int, wait time.Duration) (int, error) {
+	flight := newInFlight(nil)
+	stop := consumer.keepInvisible(flight)
+	defer stop()
+
+	messages := make([]types.Message, 0, size)
+	deadline := time.Now().Add(wait)
+	for len(messages) < size {
+		output, err := consumer.sqs.GetMessages([]string{string(types.QueueAttributeNameAll)}, consumer.queueUrl, int32(min(maxReceiveMessages, size-len(messages))), seconds(consumer.visibilityTimeout), seconds(consumer.waitTime))
+		if err != nil {
+			if len(messages) == 0 {
+				return 0, fmt.Errorf("couldn't receive messages: %v", err)
+			}
+			// the messages received so far are still worth handling
+			break
+		}
+
+		for _, message := range output.Messages {
+			if receives := receiveCount(message); consumer.deadLetterQueueUrl != nil && consumer.maxReceives > 0 && receives > consumer.maxReceives {
+				consumer.deadLetter(message, receives)
+				continue
+			}
+			flight.add(message)
+			messages = append(messages, message)
+		}
+
+		if len(output.Messages) == 0 || !time.Now().Before(deadline) {
+			break
+		}
+	}
+	if len(messages) == 0 {
+		return 0, nil
+	}
+
+	err := handler(messages)
+	// the messages have to land before they're settled, or a heartbeat could undo the backoff
+	for _, message := range messages {
+		flight.land(message)
+	}
+
+	if err != nil {
+		consumer.logger.Warn("couldn't handle messages, retrying", slog.Int("messages", len(messages)), log.Err(err))
+		for _, message := range messages {
+			if _, err := consumer.sqs.ChangeMessageVisibility(consumer.queueUrl, message.ReceiptHandle, seconds(consumer.retryBackoff(receiveCount(message)))); err != nil {
+				consumer.logger.Warn("couldn't delay message", messageId(message), log.Err(err))
+			}
+		}
+		return 0, nil
+	}
+
+	for _, message := range messages {
+		if _, err := consumer.sqs.RemoveMessage(consumer.queueUrl, message.ReceiptHandle); err != nil {
+			// the message comes back once its visibility times out and is handled again
+			consumer.logger.Warn("couldn't remove handled message", messageId(message), log.Err(err))
+		}
+	}
+
+	return len(messages), nil
+}
+
+// handle hands a message to the handler and settles it, telling whether the handler succeeded.
+func (consumer *SqsConsumer) handle(flight *inFlight, message types.Message, handler MessageHandler) bool {
+	receives := receiveCount(message)
+
+	if consumer.deadLetterQueueUrl != nil && consumer.maxReceives > 0 && receives > consumer.maxReceives {
+		flight.land(message)
+		consumer.deadLetter(message, receives)
+		return false
+	}
+
+	ctx, span := otel.Tracer(tracing.TracerName).Start(tracing.Extract(context.Background(), message), "SqsConsumer.handle",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(semconv.MessagingSystemAWSSqs, semconv.MessagingMessageID(aws.ToString(message.MessageId))),
+	)
+	err := handler(ctx, message)
+	tracing.End(span, err)
+	// the message has to land before it's settled, or a heartbeat could undo the backoff
+	flight.land(message)
+
+	if err != nil {
+		timeout := consumer.retryBackoff(receives)
+		consumer.logger.Warn("couldn't handle message, retrying", messageId(message), slog.Duration("backoff", timeout), log.Err(err))
+		if _, err := consumer.sqs.ChangeMessageVisibility(consumer.queueUrl, message.ReceiptHandle, seconds(timeout)); err != nil {
+			consumer.logger.Warn("couldn't delay message", messageId(message), log.Err(err))
+		}
+		return false
+	}
+
+	if _, err := consumer.sqs.RemoveMessage(consumer.queueUrl, message.ReceiptHandle); err != nil {
+		// the message comes back once its visibility times out and is handled again
+		consumer.logger.Warn("couldn't remove handled message", messageId(message), log.Err(err))
+	}
+
+	return true
+}
+
+// deadLetter moves a message that was received too often to the dead-letter queue. A message that couldn't be moved
+// is left in the queue for the next attempt.
+func (consumer *SqsConsumer) deadLetter(message types.Message, receives int) {
+	consumer.logger.Error("moving message to the dead-letter queue", messageId(message), slog.Int("receives", receives))
+
+	if _, err := consumer.sqs.SendMessage(consumer.deadLetterQueueUrl, *message.Body, message.MessageAttributes); err != nil {
+		consumer.logger.Error("couldn't move message to the dead-letter queue", messageId(message), log.Err(err))
+		return
+	}
+
+	if _, err := consumer.sqs.RemoveMessage(consumer.queueUrl, message.ReceiptHandle); err != nil {
+		consumer.logger.Warn("couldn't remove dead-lettered message", messageId(message), log.Err(err))
+	}
+}
+
+// retryBackoff returns how long a message the handler failed on stays hidden, doubling with every receive.
+func (consumer *SqsConsumer) retryBackoff(receives int) time.Duration {
+	backoff := consumer.backoff
+	for i := 1; i < receives && backoff < consumer.maxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, consumer.maxBackoff, maxVisibilityTimeout)
+}
+
+// keepInvisible extends the visibility of the messages in flight every heartbeat until stopped.
+func (consumer *SqsConsumer) keepInvisible(flight *inFlight) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(consumer.heartbeat)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				flight.each(func(message types.Message) {
+					if _, err := consumer.sqs.ChangeMessageVisibility(consumer.queueUrl, message.ReceiptHandle, seconds(consumer.visibilityTimeout)); err != nil {
+						consumer.logger.Warn("couldn't extend the visibility of message", messageId(message), log.Err(err))
+					}
+				})
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// inFlight holds the received messages that weren't settled yet.
+type inFlight struct {
+	lock     sync.Mutex
+	messages map[string]types.Message
+}
+
+func newInFlight(messages []types.Message) *inFlight {
+	flight := &inFlight{messages: make(map[string]types.Message, len(messages))}
+	for _, message := range messages {
+		flight.messages[*message.ReceiptHandle] = message
+	}
+	return flight
+}
+
+// add puts a message in flight.
+func (flight *inFlight) add(message types.Message) {
+	flight.lock.Lock()
+	defer flight.lock.Unlock()
+
+	flight.messages[*message.ReceiptHandle] = message
+}
+
+// land takes a message out of flight, waiting for a heartbeat going on to finish.
+func (flight *inFlight) land(message types.Message) {
+	flight.lock.Lock()
+	defer flight.lock.Unlock()
+
+	delete(flight.messages, *message.ReceiptHandle)
+}
+
+func (flight *inFlight) each(f func(message types.Message)) {
+	flight.lock.Lock()
+	defer flight.lock.Unlock()
+
+	for _, message := range flight.messages {
+		f(message)
+	}
+}
+
+// receiveCount returns how often a message was received, counting the current receive.
+func receiveCount(message types.Message) int {
+	count, err := strconv.Atoi(message.Attributes[string(types.MessageSystemAttributeNameApproximateReceiveCount)])
+	if err != nil {
+		return 1
+	}
+	return count
+}
+
+// heartbeatOf returns how often the visibility of messages in flight is extended, halfway through the visibility
+// timeout to leave the other half for the call to make it through, and no more often than every minHeartbeat.
+func heartbeatOf(visibilityTimeout time.Duration) time.Duration {
+	return max(visibilityTimeout/2, minHeartbeat)
+}
+
+// seconds rounds a duration up to the whole seconds SQS takes.
+func seconds(duration time.Duration) int32 {
+	return int32((duration + time.Second - 1) / time.Second)
+}
+
+// messageId returns the attribute of the id of a message.
+func messageId(message types.Message) slog.Attr {
+	return slog.String("message_id", aws.ToString(message.MessageId))
+}
@@ -0,0 +1,234 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/codingexplorations/data-lake/pkg/log"
+	awsMocks "github.com/codingexplorations/data-lake/test/mocks/pkg/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	queueUrl           = aws.String("http://localhost:4566/000000000000/ingest")
+	deadLetterQueueUrl = aws.String("http://localhost:4566/000000000000/ingest-dead-letter")
+)
+
+func newConsumer(sqsClient SqsClient) *SqsConsumer {
+	return &SqsConsumer{
+		logger:             log.NewConsoleLog(),
+		sqs:                sqsClient,
+		queueUrl:           queueUrl,
+		deadLetterQueueUrl: deadLetterQueueUrl,
+		visibilityTimeout:  time.Minute,
+		heartbeat:          30 * time.Second,
+		waitTime:           20 * time.Second,
+		maxReceives:        3,
+		backoff:            30 * time.Second,
+		maxBackoff:         5 * time.Minute,
+	}
+}
+
+func message(id string, receives string) types.Message {
+	return types.Message{
+		MessageId:     aws.String(id),
+		ReceiptHandle: aws.String("handle-" + id),
+		Body:          aws.String("{\"key\": \"orders/" + id + ".csv\"}"),
+		Attributes:    map[string]string{"ApproximateReceiveCount": receives},
+	}
+}
+
+func receive(sqsClient *awsMocks.SqsClient, messages ...types.Message) {
+	sqsClient.On("GetMessages", []string{"All"}, queueUrl, int32(10), int32(60), int32(20)).Return(&sqs.ReceiveMessageOutput{Messages: messages}, nil).Once()
+}
+
+func TestSqsConsumer_Poll(t *testing.T) {
+	sqsClient := awsMocks.NewSqsClient(t)
+	receive(sqsClient, message("1", "1"), message("2", "3"))
+	sqsClient.On("RemoveMessage", queueUrl, aws.String("handle-1")).Return(&sqs.DeleteMessageOutput{}, nil).Once()
+	// failed messages come back after a backoff that doubles with every receive
+	sqsClient.On("ChangeMessageVisibility", queueUrl, aws.String("handle-2"), int32(120)).Return(&sqs.ChangeMessageVisibilityOutput{}, nil).Once()
+
+	handled := make([]string, 0)
+	count, err := newConsumer(sqsClient).Poll(func(_ context.Context, message types.Message) error {
+		handled = append(handled, *message.MessageId)
+		if *message.MessageId == "2" {
+			return errors.New("bucket unreachable")
+		}
+		return nil
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []string{"1", "2"}, handled)
+}
+
+func TestSqsConsumer_Poll_Empty(t *testing.T) {
+	sqsClient := awsMocks.NewSqsClient(t)
+	receive(sqsClient)
+
+	count, err := newConsumer(sqsClient).Poll(func(_ context.Context, message types.Message) error {
+		t.Fatal("no message to handle")
+		return nil
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, count)
+
+	sqsClient.On("GetMessages", mock.Anything, queueUrl, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("queue gone")).Once()
+	_, err = newConsumer(sqsClient).Poll(func(_ context.Context, message types.Message) error { return nil })
+	assert.Equal(t, "couldn't receive messages: queue gone", err.Error())
+}
+
+func TestSqsConsumer_Poll_DeadLetter(t *testing.T) {
+	sqsClient := awsMocks.NewSqsClient(t)
+	poison := message("1", "4")
+	poison.MessageAttributes = map[string]types.MessageAttributeValue{"ce-type": {DataType: aws.String("String"), StringValue: aws.String("object.ingested")}}
+	receive(sqsClient, poison)
+	sqsClient.On("SendMessage", deadLetterQueueUrl, *poison.Body, poison.MessageAttributes).Return(&sqs.SendMessageOutput{}, nil).Once()
+	sqsClient.On("RemoveMessage", queueUrl, aws.String("handle-1")).Return(&sqs.DeleteMessageOutput{}, nil).Once()
+
+	count, err := newConsumer(sqsClient).Poll(func(_ context.Context, message types.Message) error {
+		t.Fatal("poison messages aren't handled again")
+		return nil
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestSqsConsumer_Poll_DeadLetterUndelivered(t *testing.T) {
+	sqsClient := awsMocks.NewSqsClient(t)
+	receive(sqsClient, message("1", "4"))
+	sqsClient.On("SendMessage", deadLetterQueueUrl, mock.Anything, mock.Anything).Return(nil, errors.New("queue gone")).Once()
+
+	// the message stays in the queue until it can be moved
+	_, err := newConsumer(sqsClient).Poll(func(_ context.Context, message types.Message) error { return nil })
+	assert.Nil(t, err)
+	sqsClient.AssertNotCalled(t, "RemoveMessage", mock.Anything, mock.Anything)
+
+	// without a dead-letter queue messages are retried for as long as it takes
+	consumer := newConsumer(sqsClient)
+	consumer.deadLetterQueueUrl = nil
+	receive(sqsClient, message("1", "9"))
+	sqsClient.On("ChangeMessageVisibility", queueUrl, aws.String("handle-1"), int32(300)).Return(&sqs.ChangeMessageVisibilityOutput{}, nil).Once()
+
+	_, err = consumer.Poll(func(_ context.Context, message types.Message) error { return errors.New("bucket unreachable") })
+	assert.Nil(t, err)
+}
+
+func TestSqsConsumer_Poll_Heartbeat(t *testing.T) {
+	sqsClient := awsMocks.NewSqsClient(t)
+	receive(sqsClient, message("1", "1"), message("2", "1"))
+
+	var extended atomic.Int32
+	sqsClient.On("ChangeMessageVisibility", queueUrl, mock.Anything, int32(60)).Run(func(args mock.Arguments) {
+		extended.Add(1)
+	}).Return(&sqs.ChangeMessageVisibilityOutput{}, nil)
+	sqsClient.On("RemoveMessage", queueUrl, mock.Anything).Return(&sqs.DeleteMessageOutput{}, nil).Twice()
+
+	consumer := newConsumer(sqsClient)
+	consumer.heartbeat = 10 * time.Millisecond
+
+	count, err := consumer.Poll(func(_ context.Context, message types.Message) error {
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+	// both messages were kept invisible while the first was handled, only the second while it was
+	assert.GreaterOrEqual(t, extended.Load(), int32(4))
+
+	// no heartbeat goes on once polling returned
+	after := extended.Load()
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, after, extended.Load())
+}
+
+func TestSqsConsumer_retryBackoff(t *testing.T) {
+	consumer := newConsumer(nil)
+
+	assert.Equal(t, 30*time.Second, consumer.retryBackoff(1))
+	assert.Equal(t, 60*time.Second, consumer.retryBackoff(2))
+	assert.Equal(t, 4*time.Minute, consumer.retryBackoff(4))
+	assert.Equal(t, 5*time.Minute, consumer.retryBackoff(5))
+	assert.Equal(t, 5*time.Minute, consumer.retryBackoff(100))
+
+	consumer.maxBackoff = 24 * time.Hour
+	assert.Equal(t, 12*time.Hour, consumer.retryBackoff(100))
+}
+
+func Test_receiveCount(t *testing.T) {
+	assert.Equal(t, 3, receiveCount(message("1", "3")))
+	assert.Equal(t, 1, receiveCount(types.Message{}))
+}
+
+func Test_heartbeatOf(t *testing.T) {
+	assert.Equal(t, 30*time.Second, heartbeatOf(time.Minute))
+	assert.Equal(t, time.Second, heartbeatOf(0))
+	assert.Equal(t, time.Second, heartbeatOf(time.Nanosecond))
+}
+
+func Test_seconds(t *testing.T) {
+	assert.Equal(t, int32(60), seconds(time.Minute))
+	assert.Equal(t, int32(2), seconds(1500*time.Millisecond))
+	assert.Equal(t, int32(0), seconds(0))
+}
+
+func TestSqsConsumer_PollBatch(t *testing.T) {
+	sqsClient := awsMocks.NewSqsClient(t)
+	// a receive is only asked for the messages the batch still has room for
+	sqsClient.On("GetMessages", []string{"All"}, queueUrl, int32(3), int32(60), int32(20)).Return(&sqs.ReceiveMessageOutput{Messages: []types.Message{message("1", "1"), message("2", "1")}}, nil).Once()
+	sqsClient.On("GetMessages", []string{"All"}, queueUrl, int32(1), int32(60), int32(20)).Return(&sqs.ReceiveMessageOutput{Messages: []types.Message{message("3", "4")}}, nil).Once()
+	sqsClient.On("SendMessage", deadLetterQueueUrl, "{\"key\": \"orders/3.csv\"}", map[string]types.MessageAttributeValue(nil)).Return(&sqs.SendMessageOutput{}, nil).Once()
+	sqsClient.On("RemoveMessage", queueUrl, aws.String("handle-3")).Return(&sqs.DeleteMessageOutput{}, nil).Once()
+	sqsClient.On("GetMessages", []string{"All"}, queueUrl, int32(1), int32(60), int32(20)).Return(&sqs.ReceiveMessageOutput{}, nil).Once()
+	sqsClient.On("RemoveMessage", queueUrl, aws.String("handle-1")).Return(&sqs.DeleteMessageOutput{}, nil).Once()
+	sqsClient.On("RemoveMessage", queueUrl, aws.String("handle-2")).Return(&sqs.DeleteMessageOutput{}, nil).Once()
+
+	handled := make([]string, 0)
+	count, err := newConsumer(sqsClient).PollBatch(func(messages []types.Message) error {
+		for _, message := range messages {
+			handled = append(handled, *message.MessageId)
+		}
+		return nil
+	}, 3, time.Minute)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []string{"1", "2"}, handled)
+}
+
+func TestSqsConsumer_PollBatch_Failure(t *testing.T) {
+	sqsClient := awsMocks.NewSqsClient(t)
+	receive(sqsClient, message("1", "1"), message("2", "2"))
+	sqsClient.On("ChangeMessageVisibility", queueUrl, aws.String("handle-1"), int32(30)).Return(&sqs.ChangeMessageVisibilityOutput{}, nil).Once()
+	sqsClient.On("ChangeMessageVisibility", queueUrl, aws.String("handle-2"), int32(60)).Return(&sqs.ChangeMessageVisibilityOutput{}, nil).Once()
+
+	count, err := newConsumer(sqsClient).PollBatch(func(messages []types.Message) error {
+		return errors.New("bucket unreachable")
+	}, 10, 0)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestSqsConsumer_PollBatch_ReceiveFailure(t *testing.T) {
+	sqsClient := awsMocks.NewSqsClient(t)
+	sqsClient.On("GetMessages", []string{"All"}, queueUrl, int32(10), int32(60), int32(20)).Return(nil, errors.New("queue gone")).Once()
+
+	_, err := newConsumer(sqsClient).PollBatch(func(messages []types.Message) error {
+		t.Fatal("no message to handle")
+		return nil
+	}, 10, time.Minute)
+
+	assert.Equal(t, "couldn't receive messages: queue gone", err.Error())
+}
@@ -62,7 +62,7 @@ func TestSqsClient_GetMessages(t *testing.T) {
 		return
 	}
 
-	msgResult, err := sqsClient.GetMessages([]string{string(types.QueueAttributeNameAll)}, result.QueueUrl, 1, 60)
+	msgResult, err := sqsClient.GetMessages([]string{string(types.QueueAttributeNameAll)}, result.QueueUrl, 1, 60, 1)
 	if err != nil {
 		t.Errorf("Got an error receiving messages: %v", err)
 		return
@@ -102,7 +102,7 @@ func TestSqsClient_RemoveMessage(t *testing.T) {
 		return
 	}
 
-	msgResult, err := sqsClient.GetMessages([]string{string(types.QueueAttributeNameAll)}, result.QueueUrl, 1, 60)
+	msgResult, err := sqsClient.GetMessages([]string{string(types.QueueAttributeNameAll)}, result.QueueUrl, 1, 60, 1)
 	if err != nil {
 		t.Errorf("Got an error receiving messages: %v", err)
 		return
@@ -112,6 +112,56 @@ func TestSqsClient_RemoveMessage(t *testing.T) {
 	assert.NoError(t, err, fmt.Sprintf("Got an error deleting the message: %v", err))
 }
 
+func TestSqsClient_ChangeMessageVisibility(t *testing.T) {
+	conf := config.GetConfig()
+	sqsClient, _ := NewSqs()
+
+	result, err := sqsClient.GetQueueUrl(conf.AwsIngestQueueName)
+	if err != nil {
+		t.Errorf("Got an error getting the queue URL: %v", err)
+		return
+	}
+
+	_, err = sendMessage(0, nil, "{\"dataset\": \"orders\"}", result.QueueUrl)
+	if err != nil {
+		t.Errorf("Got an error sending the message: %v", err)
+		return
+	}
+
+	msgResult, err := sqsClient.GetMessages(nil, result.QueueUrl, 1, 60, 1)
+	if err != nil || len(msgResult.Messages) == 0 {
+		t.Errorf("Got an error receiving messages: %v", err)
+		return
+	}
+	assert.Equal(t, "1", msgResult.Messages[0].Attributes[string(types.MessageSystemAttributeNameApproximateReceiveCount)])
+
+	// making the message visible again hands it out on the next receive
+	_, err = sqsClient.ChangeMessageVisibility(result.QueueUrl, msgResult.Messages[0].ReceiptHandle, 0)
+	assert.NoError(t, err, fmt.Sprintf("Got an error changing the visibility of the message: %v", err))
+
+	msgResult, err = sqsClient.GetMessages(nil, result.QueueUrl, 1, 60, 1)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, msgResult.Messages)
+	_, _ = sqsClient.RemoveMessage(result.QueueUrl, msgResult.Messages[0].ReceiptHandle)
+}
+
+func TestSqsClient_SendMessage(t *testing.T) {
+	conf := config.GetConfig()
+	sqsClient, _ := NewSqs()
+
+	result, err := sqsClient.GetQueueUrl(conf.AwsIngestQueueName)
+	if err != nil {
+		t.Errorf("Got an error getting the queue URL: %v", err)
+		return
+	}
+
+	sendResult, err := sqsClient.SendMessage(result.QueueUrl, "{\"dataset\": \"orders\"}", map[string]types.MessageAttributeValue{
+		"ce-type": {DataType: aws.String("String"), StringValue: aws.String("com.github.codingexplorations.datalake.object.ingested")},
+	})
+	assert.NoError(t, err, fmt.Sprintf("Got an error sending the message: %v", err))
+	assert.NotNil(t, sendResult.MessageId)
+}
+
 func sendMessage(delay int32, attributes map[string]types.MessageAttributeValue, body string, queueUrl *string) (*sqs.SendMessageOutput, error) {
 	cfg, err := awsSdkConfig.LoadDefaultConfig(context.TODO())
 	if err != nil {
@@ -0,0 +1,121 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/aws"
+	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/codingexplorations/data-lake/pkg/storage"
+)
+
+// ErrSnapshotNotFound is returned when reading a dataset as of a snapshot or time it has no snapshot for.
+var ErrSnapshotNotFound = errors.New("snapshot not found")
+
+// Catalog lists the files that make up each dataset. It also keeps an outbox per dataset, events committed together
+// with the changes they tell about until they are delivered.
+type Catalog interface {
+	Datasets() ([]string, error)
+	Files(dataset string) ([]*models_v1.DataFile, error)
+	// Add lists a file as part of its dataset, replacing the entry at the same location, and enqueues the outbox events
+	// in the same commit
+	Add(file *models_v1.DataFile, outbox ...*models_v1.OutboxEvent) error
+	// Swap replaces the files at the removed locations with the added files in one step, failing without changes if
+	// any of the removed files is no longer part of the dataset
+	Swap(dataset string, removed []string, added []*models_v1.DataFile) error
+	// Removed lists the files swapped out of a dataset that haven't been purged yet
+	Removed(dataset string) ([]*models_v1.DataFile, error)
+	// Arrive records an object that arrived without a file to list, like an unstructured or quarantined one, and
+	// enqueues the outbox events in the same commit
+	Arrive(arrival *models_v1.Lineage, outbox ...*models_v1.OutboxEvent) error
+	// Arrived lists the last arrival of each object recorded with Arrive, oldest first
+	Arrived(dataset string) ([]*models_v1.Lineage, error)
+	// Purge forgets removed files once they are deleted and enqueues the outbox events in the same commit
+	Purge(dataset string, locations []string, outbox ...*models_v1.OutboxEvent) error
+	// Snapshots lists the snapshots of a dataset, oldest first
+	Snapshots(dataset string) ([]*models_v1.Snapshot, error)
+	// FilesAt returns the files of a dataset as of a snapshot
+	FilesAt(dataset string, snapshotId int64) ([]*models_v1.DataFile, error)
+	// FilesAsOf returns the files of a dataset as of the last snapshot committed at or before a time
+	FilesAsOf(dataset string, timestamp time.Time) ([]*models_v1.DataFile, error)
+	// Enqueue commits events that don't go with any change of the files to the outbox of a dataset
+	Enqueue(dataset string, outbox ...*models_v1.OutboxEvent) error
+	// Outbox lists the events of a dataset that weren't delivered yet, oldest first
+	Outbox(dataset string) ([]*models_v1.OutboxEvent, error)
+	// Delivered takes delivered events out of the outbox of a dataset
+	Delivered(dataset string, ids []string) error
+}
+
+// GetCatalog returns the catalog for the configured ingest processor. Its transaction logs live next to the curated
+// files, in CATALOG_FOLDER or under AWS_CATALOG_PREFIX in the curated bucket.
+func GetCatalog(conf *config.Config) (Catalog, error) {
+	switch conf.IngestProcessorType {
+	case "localstack":
+		s3Client, err := aws.NewS3()
+		if err != nil {
+			return nil, err
+		}
+		return NewTableCatalog(storage.NewS3Storage(&s3Client, conf.AwsCuratedBucketName), conf.AwsCatalogPrefix), nil
+	default:
+		return NewTableCatalog(storage.NewLocalStorage(), conf.CatalogFolder), nil
+	}
+}
+
+type ConflictError struct {
+	Dataset  string
+	Location string
+}
+
+func (err *ConflictError) Error() string {
+	return fmt.Sprintf("file %v is no longer part of dataset %v", err.Location, err.Dataset)
+}
+
+// Catalogued tells whether a source object with the given checksum already made it into the dataset, either into one
+// of its files, directly or through a file compacted from it, or as an arrival without a file. A source overwritten
+// with other content isn't catalogued, its new content is still to be ingested. Sources catalogued without a checksum,
+// or looked up without one, match by location alone.
+func Catalogued(catalog Catalog, dataset string, source string, checksum string) (bool, error) {
+	files, err := catalog.Files(dataset)
+	if err != nil {
+		return false, err
+	}
+	arrived, err := catalog.Arrived(dataset)
+	if err != nil {
+		return false, err
+	}
+
+	lineages := make([]*models_v1.Lineage, 0, len(files)+len(arrived))
+	for _, file := range files {
+		lineages = append(lineages, file.GetLineage())
+	}
+	lineages = append(lineages, arrived...)
+
+	for _, lineage := range lineages {
+		checksums := SourceChecksums(lineage)
+		for i, lineageSource := range lineage.GetSources() {
+			if lineageSource == source && (checksum == "" || checksums[i] == "" || checksums[i] == checksum) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// SourceChecksums returns the checksum of each source of a lineage, empty for the sources of files catalogued before
+// checksums were kept.
+func SourceChecksums(lineage *models_v1.Lineage) []string {
+	checksums := make([]string, len(lineage.GetSources()))
+	copy(checksums, lineage.GetChecksums())
+	return checksums
+}
+
+func validDatasetName(dataset string) error {
+	if dataset == "" || strings.ContainsAny(dataset, "/\\") || strings.HasPrefix(dataset, ".") {
+		return fmt.Errorf("invalid dataset name %q", dataset)
+	}
+	return nil
+}
@@ -0,0 +1,518 @@
+package catalog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/storage"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxCommitAttempts bounds how often a commit is retried after losing a race to another writer.
+const maxCommitAttempts = 10
+
+const logFolder = "_log"
+
+// TableCatalogImpl keeps an append-only transaction log per dataset at <root>/<dataset>/_log/<snapshot id>.json.
+// The files of a dataset are whatever replaying its log gives, so readers only ever see whole commits. Writers
+// commit optimistically: they create the next entry of the log if nobody else did first, and otherwise re-read the
+// log, check their changes still apply and try the entry after. Outbox events ride in the same entries as the changes
+// they tell about, so a crash can't lose the event of a change or leave an event for a change that wasn't made.
+type TableCatalogImpl struct {
+	storage storage.Storage
+	root    string
+	lock    sync.Mutex
+	// tables caches the replayed log of each dataset, only entries committed since are read on the next access
+	tables map[string]*table
+}
+
+type table struct {
+	commits []*models_v1.Commit
+	state   *models_v1.DatasetFiles
+	// listed is whether the log was listed, after that only the entry after the last one read is looked up
+	listed bool
+}
+
+func NewTableCatalog(storage storage.Storage, root string) *TableCatalogImpl {
+	return &TableCatalogImpl{
+		storage: storage,
+		root:    root,
+		tables:  make(map[string]*table),
+	}
+}
+
+func (catalog *TableCatalogImpl) Datasets() ([]string, error) {
+	locations, err := catalog.storage.List(catalog.root)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	for _, location := range locations {
+		parts := strings.Split(strings.TrimPrefix(strings.TrimPrefix(location, catalog.root), "/"), "/")
+		if len(parts) == 3 && parts[1] == logFolder {
+			names[parts[0]] = true
+		}
+	}
+
+	datasets := make([]string, 0, len(names))
+	for name := range names {
+		datasets = append(datasets, name)
+	}
+	sort.Strings(datasets)
+
+	return datasets, nil
+}
+
+func (catalog *TableCatalogImpl) Files(dataset string) ([]*models_v1.DataFile, error) {
+	catalog.lock.Lock()
+	defer catalog.lock.Unlock()
+
+	t, err := catalog.refresh(dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	return cloneFiles(t.state.Files), nil
+}
+
+func (catalog *TableCatalogImpl) Add(file *models_v1.DataFile, outbox ...*models_v1.OutboxEvent) error {
+	return catalog.commit(file.Dataset, models_v1.Commit_APPEND, func(state *models_v1.DatasetFiles) ([]*models_v1.Action, error) {
+		return append([]*models_v1.Action{addAction(file)}, enqueueActions(outbox)...), nil
+	})
+}
+
+func (catalog *TableCatalogImpl) Swap(dataset string, removed []string, added []*models_v1.DataFile) error {
+	return catalog.commit(dataset, models_v1.Commit_COMPACT, func(state *models_v1.DatasetFiles) ([]*models_v1.Action, error) {
+		actions := make([]*models_v1.Action, 0, len(removed)+len(added))
+
+		for _, location := range removed {
+			if find(state.Files, location) == nil {
+				return nil, &ConflictError{Dataset: dataset, Location: location}
+			}
+			actions = append(actions, &models_v1.Action{Action: &models_v1.Action_Remove{Remove: location}})
+		}
+
+		for _, file := range added {
+			actions = append(actions, addAction(file))
+		}
+
+		return actions, nil
+	})
+}
+
+func (catalog *TableCatalogImpl) Removed(dataset string) ([]*models_v1.DataFile, error) {
+	catalog.lock.Lock()
+	defer catalog.lock.Unlock()
+
+	t, err := catalog.refresh(dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	return cloneFiles(t.state.Removed), nil
+}
+
+func (catalog *TableCatalogImpl) Purge(dataset string, locations []string, outbox ...*models_v1.OutboxEvent) error {
+	return catalog.commit(dataset, models_v1.Commit_PURGE, func(state *models_v1.DatasetFiles) ([]*models_v1.Action, error) {
+		actions := make([]*models_v1.Action, 0, len(locations))
+		for _, location := range locations {
+			// another writer purging the same file first is fine
+			if find(state.Removed, location) != nil {
+				actions = append(actions, &models_v1.Action{Action: &models_v1.Action_Purge{Purge: location}})
+			}
+		}
+		if len(actions) == 0 {
+			return nil, nil
+		}
+		return append(actions, enqueueActions(outbox)...), nil
+	})
+}
+
+func (catalog *TableCatalogImpl) Arrive(arrival *models_v1.Lineage, outbox ...*models_v1.OutboxEvent) error {
+	return catalog.commit(arrival.Dataset, models_v1.Commit_ARRIVE, func(state *models_v1.DatasetFiles) ([]*models_v1.Action, error) {
+		return append([]*models_v1.Action{{Action: &models_v1.Action_Arrive{Arrive: arrival}}}, enqueueActions(outbox)...), nil
+	})
+}
+
+func (catalog *TableCatalogImpl) Arrived(dataset string) ([]*models_v1.Lineage, error) {
+	catalog.lock.Lock()
+	defer catalog.lock.Unlock()
+
+	t, err := catalog.refresh(dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	arrived := make([]*models_v1.Lineage, 0, len(t.state.Arrived))
+	for _, arrival := range t.state.Arrived {
+		arrived = append(arrived, proto.Clone(arrival).(*models_v1.Lineage))
+	}
+
+	return arrived, nil
+}
+
+func (catalog *TableCatalogImpl) Snapshots(dataset string) ([]*models_v1.Snapshot, error) {
+	catalog.lock.Lock()
+	defer catalog.lock.Unlock()
+
+	t, err := catalog.refresh(dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]*models_v1.Snapshot, 0, len(t.commits))
+	state := &models_v1.DatasetFiles{}
+	for _, commit := range t.commits {
+		apply(state, commit)
+		if commit.Operation == models_v1.Commit_OUTBOX || commit.Operation == models_v1.Commit_ARRIVE {
+			continue
+		}
+
+		snapshot := &models_v1.Snapshot{
+			SnapshotId: commit.SnapshotId,
+			Timestamp:  commit.Timestamp,
+			Operation:  commit.Operation,
+			Files:      int32(len(state.Files)),
+		}
+		for _, file := range state.Files {
+			snapshot.Records += int64(file.Records)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, nil
+}
+
+func (catalog *TableCatalogImpl) FilesAt(dataset string, snapshotId int64) ([]*models_v1.DataFile, error) {
+	catalog.lock.Lock()
+	defer catalog.lock.Unlock()
+
+	t, err := catalog.refresh(dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	if snapshotId < 1 || snapshotId > int64(len(t.commits)) {
+		return nil, fmt.Errorf("dataset %v has no snapshot %d: %w", dataset, snapshotId, ErrSnapshotNotFound)
+	}
+
+	return replay(t.commits[:snapshotId]).Files, nil
+}
+
+func (catalog *TableCatalogImpl) FilesAsOf(dataset string, timestamp time.Time) ([]*models_v1.DataFile, error) {
+	catalog.lock.Lock()
+	defer catalog.lock.Unlock()
+
+	t, err := catalog.refresh(dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	// commit timestamps never go backwards, see commit
+	count := sort.Search(len(t.commits), func(i int) bool {
+		return t.commits[i].Timestamp > timestamp.UnixMilli()
+	})
+	if count == 0 {
+		return nil, fmt.Errorf("dataset %v has no snapshot as of %v: %w", dataset, timestamp.Format(time.RFC3339), ErrSnapshotNotFound)
+	}
+
+	return replay(t.commits[:count]).Files, nil
+}
+
+func (catalog *TableCatalogImpl) Enqueue(dataset string, outbox ...*models_v1.OutboxEvent) error {
+	return catalog.commit(dataset, models_v1.Commit_OUTBOX, func(state *models_v1.DatasetFiles) ([]*models_v1.Action, error) {
+		return enqueueActions(outbox), nil
+	})
+}
+
+func (catalog *TableCatalogImpl) Outbox(dataset string) ([]*models_v1.OutboxEvent, error) {
+	catalog.lock.Lock()
+	defer catalog.lock.Unlock()
+
+	t, err := catalog.refresh(dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	outbox := make([]*models_v1.OutboxEvent, 0, len(t.state.Outbox))
+	for _, event := range t.state.Outbox {
+		outbox = append(outbox, proto.Clone(event).(*models_v1.OutboxEvent))
+	}
+
+	return outbox, nil
+}
+
+func (catalog *TableCatalogImpl) Delivered(dataset string, ids []string) error {
+	return catalog.commit(dataset, models_v1.Commit_OUTBOX, func(state *models_v1.DatasetFiles) ([]*models_v1.Action, error) {
+		actions := make([]*models_v1.Action, 0, len(ids))
+		for _, id := range ids {
+			// another relay delivering the same event first is fine
+			if findEvent(state.Outbox, id) != nil {
+				actions = append(actions, &models_v1.Action{Action: &models_v1.Action_Deliver{Deliver: id}})
+			}
+		}
+		return actions, nil
+	})
+}
+
+// commit appends the actions build returns for the latest state of the dataset to its log, retrying with the new
+// latest state whenever another writer got there first. build returning no actions commits nothing.
+func (catalog *TableCatalogImpl) commit(dataset string, operation models_v1.Commit_Operation, build func(state *models_v1.DatasetFiles) ([]*models_v1.Action, error)) error {
+	catalog.lock.Lock()
+	defer catalog.lock.Unlock()
+
+	for attempt := 0; attempt < maxCommitAttempts; attempt++ {
+		t, err := catalog.refresh(dataset)
+		if err != nil {
+			return err
+		}
+
+		actions, err := build(t.state)
+		if err != nil || len(actions) == 0 {
+			return err
+		}
+
+		commit := &models_v1.Commit{
+			SnapshotId: int64(len(t.commits)) + 1,
+			Timestamp:  time.Now().UnixMilli(),
+			Operation:  operation,
+			Actions:    actions,
+		}
+		// keep the log ordered by time even if this writer's clock is behind the last one's
+		if len(t.commits) > 0 && commit.Timestamp < t.commits[len(t.commits)-1].Timestamp {
+			commit.Timestamp = t.commits[len(t.commits)-1].Timestamp
+		}
+
+		data, err := protojson.Marshal(commit)
+		if err != nil {
+			return err
+		}
+
+		err = catalog.storage.Create(catalog.commitLocation(dataset, commit.SnapshotId), bytes.NewReader(data), "application/json")
+		if errors.Is(err, storage.ErrExists) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		t.commits = append(t.commits, commit)
+		apply(t.state, commit)
+
+		return nil
+	}
+
+	return fmt.Errorf("failed to commit to dataset %v after %d attempts, too many concurrent writers", dataset, maxCommitAttempts)
+}
+
+// refresh reads the entries committed to the log of a dataset since it was last read. The log is listed once, the
+// entries after that are created in order without gaps, so the ones after the last read are read until one isn't
+// there yet. A refresh with nothing new is a single lookup instead of a listing of the whole log.
+func (catalog *TableCatalogImpl) refresh(dataset string) (*table, error) {
+	if err := validDatasetName(dataset); err != nil {
+		return nil, err
+	}
+
+	t, ok := catalog.tables[dataset]
+	if !ok {
+		t = &table{state: &models_v1.DatasetFiles{}}
+		catalog.tables[dataset] = t
+	}
+
+	if !t.listed {
+		if err := catalog.list(dataset, t); err != nil {
+			return nil, err
+		}
+		t.listed = true
+		return t, nil
+	}
+
+	for {
+		commit, err := catalog.readCommit(dataset, int64(len(t.commits))+1)
+		if errors.Is(err, fs.ErrNotExist) {
+			return t, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		t.commits = append(t.commits, commit)
+		apply(t.state, commit)
+	}
+}
+
+// list reads the entries of the log of a dataset after the ones already read, failing on gaps.
+func (catalog *TableCatalogImpl) list(dataset string, t *table) error {
+	locations, err := catalog.storage.List(path.Join(catalog.root, dataset, logFolder) + "/")
+	if err != nil {
+		return err
+	}
+
+	snapshotIds := make([]int64, 0, len(locations))
+	for _, location := range locations {
+		snapshotId, err := strconv.ParseInt(strings.TrimSuffix(path.Base(location), ".json"), 10, 64)
+		if err == nil && snapshotId > int64(len(t.commits)) {
+			snapshotIds = append(snapshotIds, snapshotId)
+		}
+	}
+	sort.Slice(snapshotIds, func(i, j int) bool { return snapshotIds[i] < snapshotIds[j] })
+
+	for _, snapshotId := range snapshotIds {
+		if snapshotId != int64(len(t.commits))+1 {
+			return fmt.Errorf("log of dataset %v is missing snapshot %d", dataset, len(t.commits)+1)
+		}
+
+		commit, err := catalog.readCommit(dataset, snapshotId)
+		if err != nil {
+			return err
+		}
+
+		t.commits = append(t.commits, commit)
+		apply(t.state, commit)
+	}
+
+	return nil
+}
+
+func (catalog *TableCatalogImpl) readCommit(dataset string, snapshotId int64) (*models_v1.Commit, error) {
+	body, err := catalog.storage.Read(catalog.commitLocation(dataset, snapshotId))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	commit := &models_v1.Commit{}
+	if err := protojson.Unmarshal(data, commit); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %d of dataset %v: %v", snapshotId, dataset, err)
+	}
+
+	return commit, nil
+}
+
+// commitLocation zero pads the snapshot id so the entries of a log list in order.
+func (catalog *TableCatalogImpl) commitLocation(dataset string, snapshotId int64) string {
+	return path.Join(catalog.root, dataset, logFolder, fmt.Sprintf("%020d.json", snapshotId))
+}
+
+func replay(commits []*models_v1.Commit) *models_v1.DatasetFiles {
+	state := &models_v1.DatasetFiles{}
+	for _, commit := range commits {
+		apply(state, commit)
+	}
+	return state
+}
+
+func apply(state *models_v1.DatasetFiles, commit *models_v1.Commit) {
+	for _, action := range commit.Actions {
+		switch a := action.Action.(type) {
+		case *models_v1.Action_Add:
+			file := proto.Clone(a.Add).(*models_v1.DataFile)
+			if file.Timestamp == 0 {
+				file.Timestamp = commit.Timestamp
+			}
+			// adding a file at a location already in the dataset replaces it
+			state.Files = append(without(state.Files, file.Location), file)
+		case *models_v1.Action_Remove:
+			if file := find(state.Files, a.Remove); file != nil {
+				state.Files = without(state.Files, a.Remove)
+				file = proto.Clone(file).(*models_v1.DataFile)
+				file.Removed = commit.Timestamp
+				state.Removed = append(state.Removed, file)
+			}
+		case *models_v1.Action_Purge:
+			state.Removed = without(state.Removed, a.Purge)
+		case *models_v1.Action_Enqueue:
+			// enqueueing an event again, like when a commit is retried, keeps the first one
+			if findEvent(state.Outbox, a.Enqueue.Id) == nil {
+				state.Outbox = append(state.Outbox, a.Enqueue)
+			}
+		case *models_v1.Action_Deliver:
+			outbox := make([]*models_v1.OutboxEvent, 0, len(state.Outbox))
+			for _, event := range state.Outbox {
+				if event.Id != a.Deliver {
+					outbox = append(outbox, event)
+				}
+			}
+			state.Outbox = outbox
+		case *models_v1.Action_Arrive:
+			arrival := proto.Clone(a.Arrive).(*models_v1.Lineage)
+			if arrival.Timestamp == 0 {
+				arrival.Timestamp = commit.Timestamp
+			}
+			// only the last arrival of a source is kept
+			arrived := make([]*models_v1.Lineage, 0, len(state.Arrived)+1)
+			for _, previous := range state.Arrived {
+				if !slices.Equal(previous.Sources, arrival.Sources) {
+					arrived = append(arrived, previous)
+				}
+			}
+			state.Arrived = append(arrived, arrival)
+		}
+	}
+}
+
+func addAction(file *models_v1.DataFile) *models_v1.Action {
+	return &models_v1.Action{Action: &models_v1.Action_Add{Add: file}}
+}
+
+func enqueueActions(outbox []*models_v1.OutboxEvent) []*models_v1.Action {
+	actions := make([]*models_v1.Action, 0, len(outbox))
+	for _, event := range outbox {
+		actions = append(actions, &models_v1.Action{Action: &models_v1.Action_Enqueue{Enqueue: event}})
+	}
+	return actions
+}
+
+func cloneFiles(files []*models_v1.DataFile) []*models_v1.DataFile {
+	clones := make([]*models_v1.DataFile, 0, len(files))
+	for _, file := range files {
+		clones = append(clones, proto.Clone(file).(*models_v1.DataFile))
+	}
+	return clones
+}
+
+func find(files []*models_v1.DataFile, location string) *models_v1.DataFile {
+	for _, file := range files {
+		if file.Location == location {
+			return file
+		}
+	}
+	return nil
+}
+
+func findEvent(outbox []*models_v1.OutboxEvent, id string) *models_v1.OutboxEvent {
+	for _, event := range outbox {
+		if event.Id == id {
+			return event
+		}
+	}
+	return nil
+}
+
+func without(files []*models_v1.DataFile, location string) []*models_v1.DataFile {
+	kept := make([]*models_v1.DataFile, 0, len(files))
+	for _, file := range files {
+		if file.Location != location {
+			kept = append(kept, file)
+		}
+	}
+	return kept
+}
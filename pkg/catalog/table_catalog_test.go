@@ -0,0 +1,371 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func dataFile(location string, sources ...string) *models_v1.DataFile {
+	return &models_v1.DataFile{
+		Location: location,
+		Dataset:  "orders",
+		Format:   models_v1.Schema_PARQUET,
+		Records:  1,
+		Lineage:  &models_v1.Lineage{Sources: sources},
+	}
+}
+
+func locations(files []*models_v1.DataFile) []string {
+	result := make([]string, 0, len(files))
+	for _, file := range files {
+		result = append(result, file.Location)
+	}
+	return result
+}
+
+func TestTableCatalogImpl_Add(t *testing.T) {
+	catalog := NewTableCatalog(storage.NewLocalStorage(), t.TempDir())
+
+	assert.Nil(t, catalog.Add(dataFile("orders/01.parquet", "orders/01.csv")))
+	assert.Nil(t, catalog.Add(dataFile("orders/02.parquet", "orders/02.csv")))
+	// adding a file at the same location replaces it
+	assert.Nil(t, catalog.Add(&models_v1.DataFile{Location: "orders/01.parquet", Dataset: "orders", Records: 5}))
+
+	files, err := catalog.Files("orders")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"orders/02.parquet", "orders/01.parquet"}, locations(files))
+	assert.Equal(t, int32(5), files[1].Records)
+	assert.NotZero(t, files[1].Timestamp)
+
+	datasets, err := catalog.Datasets()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"orders"}, datasets)
+}
+
+func TestTableCatalogImpl_Swap(t *testing.T) {
+	catalog := NewTableCatalog(storage.NewLocalStorage(), t.TempDir())
+
+	_ = catalog.Add(dataFile("orders/01.parquet", "orders/01.csv"))
+	_ = catalog.Add(dataFile("orders/02.parquet", "orders/02.csv"))
+	_ = catalog.Add(dataFile("orders/03.parquet", "orders/03.csv"))
+
+	err := catalog.Swap("orders", []string{"orders/01.parquet", "orders/02.parquet"}, []*models_v1.DataFile{
+		dataFile("orders/compacted.parquet", "orders/01.csv", "orders/02.csv"),
+	})
+	assert.Nil(t, err)
+
+	files, _ := catalog.Files("orders")
+	assert.Equal(t, []string{"orders/03.parquet", "orders/compacted.parquet"}, locations(files))
+
+	removed, _ := catalog.Removed("orders")
+	assert.Equal(t, []string{"orders/01.parquet", "orders/02.parquet"}, locations(removed))
+	assert.NotZero(t, removed[0].Removed)
+
+	catalogued, err := Catalogued(catalog, "orders", "orders/02.csv", "")
+	assert.Nil(t, err)
+	assert.True(t, catalogued)
+
+	catalogued, _ = Catalogued(catalog, "orders", "orders/04.csv", "")
+	assert.False(t, catalogued)
+
+	assert.Nil(t, catalog.Purge("orders", []string{"orders/01.parquet"}))
+	removed, _ = catalog.Removed("orders")
+	assert.Equal(t, []string{"orders/02.parquet"}, locations(removed))
+}
+
+func TestCatalogued_Checksum(t *testing.T) {
+	catalog := NewTableCatalog(storage.NewLocalStorage(), t.TempDir())
+
+	checksummed := dataFile("orders/01.parquet", "orders/01.csv")
+	checksummed.Lineage.Checksums = []string{"a1"}
+	_ = catalog.Add(checksummed)
+	_ = catalog.Add(dataFile("orders/02.parquet", "orders/02.csv"))
+
+	catalogued, err := Catalogued(catalog, "orders", "orders/01.csv", "a1")
+	assert.Nil(t, err)
+	assert.True(t, catalogued)
+
+	// a source overwritten with other content is still to be ingested
+	catalogued, _ = Catalogued(catalog, "orders", "orders/01.csv", "b2")
+	assert.False(t, catalogued)
+
+	// sources catalogued without a checksum match by location
+	catalogued, _ = Catalogued(catalog, "orders", "orders/02.csv", "b2")
+	assert.True(t, catalogued)
+}
+
+func TestTableCatalogImpl_Arrive(t *testing.T) {
+	root := t.TempDir()
+	catalog := NewTableCatalog(storage.NewLocalStorage(), root)
+
+	arrival := func(source string, checksum string) *models_v1.Lineage {
+		return &models_v1.Lineage{Sources: []string{source}, Checksums: []string{checksum}, Dataset: "documents"}
+	}
+
+	assert.Nil(t, catalog.Arrive(arrival("documents/a.pdf", "a1"), outboxEvent("1")))
+	assert.Nil(t, catalog.Arrive(arrival("documents/b.pdf", "b1")))
+	// an object arriving again replaces its last arrival
+	assert.Nil(t, catalog.Arrive(arrival("documents/a.pdf", "a2")))
+
+	arrived, err := NewTableCatalog(storage.NewLocalStorage(), root).Arrived("documents")
+	assert.Nil(t, err)
+	assert.Len(t, arrived, 2)
+	assert.Equal(t, []string{"documents/b.pdf"}, arrived[0].Sources)
+	assert.Equal(t, []string{"a2"}, arrived[1].Checksums)
+	assert.NotZero(t, arrived[1].Timestamp)
+
+	catalogued, err := Catalogued(catalog, "documents", "documents/a.pdf", "a2")
+	assert.Nil(t, err)
+	assert.True(t, catalogued)
+
+	catalogued, _ = Catalogued(catalog, "documents", "documents/a.pdf", "a1")
+	assert.False(t, catalogued)
+
+	outbox, _ := catalog.Outbox("documents")
+	assert.Equal(t, []string{"1"}, ids(outbox))
+
+	// arrivals list no files, so they aren't snapshots of the dataset
+	snapshots, _ := catalog.Snapshots("documents")
+	assert.Empty(t, snapshots)
+}
+
+func TestSourceChecksums(t *testing.T) {
+	// lineages kept before checksums were have an empty one for each source, so merged ones stay aligned
+	assert.Equal(t, []string{"", ""}, SourceChecksums(&models_v1.Lineage{Sources: []string{"a.csv", "b.csv"}}))
+	assert.Equal(t, []string{"a1"}, SourceChecksums(&models_v1.Lineage{Sources: []string{"a.csv"}, Checksums: []string{"a1"}}))
+	assert.Empty(t, SourceChecksums(nil))
+}
+
+func TestTableCatalogImpl_SwapConflict(t *testing.T) {
+	catalog := NewTableCatalog(storage.NewLocalStorage(), t.TempDir())
+
+	_ = catalog.Add(dataFile("orders/01.parquet"))
+
+	err := catalog.Swap("orders", []string{"orders/01.parquet", "orders/02.parquet"}, []*models_v1.DataFile{dataFile("orders/compacted.parquet")})
+
+	assert.Equal(t, &ConflictError{Dataset: "orders", Location: "orders/02.parquet"}, err)
+	assert.Equal(t, "file orders/02.parquet is no longer part of dataset orders", err.Error())
+
+	// nothing was committed
+	files, _ := catalog.Files("orders")
+	assert.Equal(t, []string{"orders/01.parquet"}, locations(files))
+	snapshots, _ := catalog.Snapshots("orders")
+	assert.Len(t, snapshots, 1)
+}
+
+func TestTableCatalogImpl_Snapshots(t *testing.T) {
+	catalog := NewTableCatalog(storage.NewLocalStorage(), t.TempDir())
+
+	_ = catalog.Add(dataFile("orders/01.parquet"))
+	_ = catalog.Add(dataFile("orders/02.parquet"))
+	_ = catalog.Swap("orders", []string{"orders/01.parquet", "orders/02.parquet"}, []*models_v1.DataFile{dataFile("orders/compacted.parquet")})
+	_ = catalog.Purge("orders", []string{"orders/01.parquet", "orders/02.parquet"})
+	// purging files that are already gone commits nothing
+	_ = catalog.Purge("orders", []string{"orders/01.parquet"})
+
+	snapshots, err := catalog.Snapshots("orders")
+	assert.Nil(t, err)
+	assert.Len(t, snapshots, 4)
+
+	operations := make([]models_v1.Commit_Operation, 0, len(snapshots))
+	for i, snapshot := range snapshots {
+		assert.Equal(t, int64(i+1), snapshot.SnapshotId)
+		operations = append(operations, snapshot.Operation)
+	}
+	assert.Equal(t, []models_v1.Commit_Operation{models_v1.Commit_APPEND, models_v1.Commit_APPEND, models_v1.Commit_COMPACT, models_v1.Commit_PURGE}, operations)
+	assert.Equal(t, int32(2), snapshots[1].Files)
+	assert.Equal(t, int64(2), snapshots[1].Records)
+	assert.Equal(t, int32(1), snapshots[2].Files)
+
+	files, err := catalog.FilesAt("orders", 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"orders/01.parquet", "orders/02.parquet"}, locations(files))
+
+	files, err = catalog.FilesAt("orders", 3)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"orders/compacted.parquet"}, locations(files))
+
+	_, err = catalog.FilesAt("orders", 5)
+	assert.True(t, errors.Is(err, ErrSnapshotNotFound))
+	assert.Equal(t, "dataset orders has no snapshot 5: snapshot not found", err.Error())
+}
+
+func TestTableCatalogImpl_FilesAsOf(t *testing.T) {
+	catalog := NewTableCatalog(storage.NewLocalStorage(), t.TempDir())
+
+	before := time.Now().Add(-time.Hour)
+	_ = catalog.Add(dataFile("orders/01.parquet"))
+	snapshots, _ := catalog.Snapshots("orders")
+	first := time.UnixMilli(snapshots[0].Timestamp)
+
+	time.Sleep(2 * time.Millisecond)
+	_ = catalog.Add(dataFile("orders/02.parquet"))
+
+	files, err := catalog.FilesAsOf("orders", first)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"orders/01.parquet"}, locations(files))
+
+	files, err = catalog.FilesAsOf("orders", time.Now())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"orders/01.parquet", "orders/02.parquet"}, locations(files))
+
+	_, err = catalog.FilesAsOf("orders", before)
+	assert.True(t, errors.Is(err, ErrSnapshotNotFound))
+}
+
+func TestTableCatalogImpl_ConcurrentWriters(t *testing.T) {
+	root := t.TempDir()
+	writers := []*TableCatalogImpl{
+		NewTableCatalog(storage.NewLocalStorage(), root),
+		NewTableCatalog(storage.NewLocalStorage(), root),
+	}
+
+	// both writers start from the same snapshot, so one of them loses the race for each entry and has to retry
+	_ = writers[0].Add(dataFile("orders/00.parquet"))
+	_, _ = writers[1].Files("orders")
+
+	var wg sync.WaitGroup
+	for i, writer := range writers {
+		wg.Add(1)
+		go func(i int, writer *TableCatalogImpl) {
+			defer wg.Done()
+			for j := 0; j < 3; j++ {
+				assert.Nil(t, writer.Add(dataFile(fmt.Sprintf("orders/%d-%d.parquet", i, j))))
+			}
+		}(i, writer)
+	}
+	wg.Wait()
+
+	for _, writer := range writers {
+		files, err := writer.Files("orders")
+		assert.Nil(t, err)
+		assert.Len(t, files, 7)
+	}
+
+	snapshots, _ := NewTableCatalog(storage.NewLocalStorage(), root).Snapshots("orders")
+	assert.Len(t, snapshots, 7)
+	for i := 1; i < len(snapshots); i++ {
+		assert.GreaterOrEqual(t, snapshots[i].Timestamp, snapshots[i-1].Timestamp)
+	}
+}
+
+// listCounting counts the listings of a storage.
+type listCounting struct {
+	storage.Storage
+	lists int
+}
+
+func (counting *listCounting) List(prefix string) ([]string, error) {
+	counting.lists++
+	return counting.Storage.List(prefix)
+}
+
+func TestTableCatalogImpl_RefreshListsOnce(t *testing.T) {
+	root := t.TempDir()
+	counting := &listCounting{Storage: storage.NewLocalStorage()}
+	reader := NewTableCatalog(counting, root)
+	writer := NewTableCatalog(storage.NewLocalStorage(), root)
+
+	_ = writer.Add(dataFile("orders/01.parquet", "orders/01.csv"))
+	files, err := reader.Files("orders")
+	assert.Nil(t, err)
+	assert.Len(t, files, 1)
+
+	// after the first listing only the entries after the last one read are looked up
+	_ = writer.Add(dataFile("orders/02.parquet", "orders/02.csv"))
+	_ = writer.Add(dataFile("orders/03.parquet", "orders/03.csv"))
+	files, err = reader.Files("orders")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"orders/01.parquet", "orders/02.parquet", "orders/03.parquet"}, locations(files))
+
+	_, _ = reader.Files("orders")
+	assert.Equal(t, 1, counting.lists)
+}
+
+func TestTableCatalogImpl_Failure(t *testing.T) {
+	root := t.TempDir()
+	catalog := NewTableCatalog(storage.NewLocalStorage(), root)
+
+	_, err := catalog.Files("../orders")
+	assert.Error(t, err)
+
+	_ = os.MkdirAll(root+"/broken/_log", 0755)
+	_ = os.WriteFile(root+"/broken/_log/00000000000000000001.json", []byte("{"), 0644)
+	_, err = catalog.Files("broken")
+	assert.Error(t, err)
+
+	// a log with a gap has lost a commit
+	_ = os.MkdirAll(root+"/gap/_log", 0755)
+	_ = os.WriteFile(root+"/gap/_log/00000000000000000002.json", []byte("{}"), 0644)
+	_, err = catalog.Files("gap")
+	assert.Equal(t, "log of dataset gap is missing snapshot 1", err.Error())
+
+	datasets, err := NewTableCatalog(storage.NewLocalStorage(), root+"/missing").Datasets()
+	assert.Nil(t, err)
+	assert.Empty(t, datasets)
+}
+
+func outboxEvent(id string) *models_v1.OutboxEvent {
+	return &models_v1.OutboxEvent{Id: id, Timestamp: time.Now().UnixMilli(), Event: []byte(fmt.Sprintf("{\"id\":%q}", id))}
+}
+
+func ids(outbox []*models_v1.OutboxEvent) []string {
+	result := make([]string, 0, len(outbox))
+	for _, event := range outbox {
+		result = append(result, event.Id)
+	}
+	return result
+}
+
+func TestTableCatalogImpl_Outbox(t *testing.T) {
+	root := t.TempDir()
+	catalog := NewTableCatalog(storage.NewLocalStorage(), root)
+
+	assert.Nil(t, catalog.Add(dataFile("orders/01.parquet"), outboxEvent("1")))
+	assert.Nil(t, catalog.Enqueue("orders", outboxEvent("2"), outboxEvent("3")))
+	// an event enqueued again keeps its place
+	assert.Nil(t, catalog.Enqueue("orders", outboxEvent("1")))
+
+	outbox, err := catalog.Outbox("orders")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1", "2", "3"}, ids(outbox))
+	assert.Equal(t, []byte("{\"id\":\"1\"}"), outbox[0].Event)
+
+	assert.Nil(t, catalog.Delivered("orders", []string{"1", "3"}))
+	// delivering events that are no longer in the outbox commits nothing
+	assert.Nil(t, catalog.Delivered("orders", []string{"1"}))
+
+	// other writers see the same outbox
+	outbox, err = NewTableCatalog(storage.NewLocalStorage(), root).Outbox("orders")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2"}, ids(outbox))
+
+	// commits that only change the outbox aren't snapshots of the dataset
+	snapshots, _ := catalog.Snapshots("orders")
+	assert.Len(t, snapshots, 1)
+	assert.Equal(t, models_v1.Commit_APPEND, snapshots[0].Operation)
+	entries, _ := os.ReadDir(root + "/orders/_log")
+	assert.Len(t, entries, 4)
+}
+
+func TestTableCatalogImpl_Outbox_Purge(t *testing.T) {
+	catalog := NewTableCatalog(storage.NewLocalStorage(), t.TempDir())
+
+	_ = catalog.Add(dataFile("orders/01.parquet"))
+	_ = catalog.Swap("orders", []string{"orders/01.parquet"}, []*models_v1.DataFile{dataFile("orders/compacted.parquet")})
+
+	assert.Nil(t, catalog.Purge("orders", []string{"orders/01.parquet"}, outboxEvent("1")))
+	// events of files another writer purged first were enqueued by it
+	assert.Nil(t, catalog.Purge("orders", []string{"orders/01.parquet"}, outboxEvent("2")))
+
+	outbox, _ := catalog.Outbox("orders")
+	assert.Equal(t, []string{"1"}, ids(outbox))
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/codingexplorations/data-lake/pkg/preview"
+)
+
+// Preview prints the first records of an ingested object, or of a dataset with -dataset, as a table or as JSON with
+// -format json.
+func Preview(previewer preview.Previewer, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("preview", flag.ContinueOnError)
+	flags.SetOutput(out)
+	format := flags.String("format", "table", "output format, table or json")
+	dataset := flags.String("dataset", "", "dataset to preview, the location is then one of its files")
+	limit := flags.Int("limit", 0, "number of records, PREVIEW_LIMIT when not set")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	location := strings.Join(flags.Args(), " ")
+	if location == "" && *dataset == "" {
+		return fmt.Errorf("usage: data-lake preview [-format table|json] [-limit n] [-dataset name] [location]")
+	}
+
+	var result *preview.Preview
+	var err error
+	if *dataset != "" {
+		result, err = previewer.PreviewDataset(*dataset, location, *limit)
+	} else {
+		result, err = previewer.PreviewFile(location, *limit)
+	}
+	if err != nil {
+		return err
+	}
+
+	switch *format {
+	case "json":
+		return json.NewEncoder(out).Encode(result)
+	case "table":
+		if err := WriteTable(out, result.Columns, result.Rows()); err != nil {
+			return err
+		}
+		files := make([]string, 0, len(result.Files))
+		for _, file := range result.Files {
+			description := file.Format
+			if file.Compression != "" {
+				description += ", " + file.Compression
+			}
+			files = append(files, fmt.Sprintf("%v (%v)", file.Location, description))
+		}
+		footer := fmt.Sprintf("(%d records from %v)", len(result.Records), strings.Join(files, ", "))
+		if result.Truncated {
+			footer += ", more records not shown"
+		}
+		_, err := fmt.Fprintln(out, footer)
+		return err
+	default:
+		return fmt.Errorf("unknown output format %v", *format)
+	}
+}
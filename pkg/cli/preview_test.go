@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/codingexplorations/data-lake/pkg/preview"
+	previewMocks "github.com/codingexplorations/data-lake/test/mocks/pkg/preview"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreview(t *testing.T) {
+	previewer := previewMocks.NewPreviewer(t)
+	previewer.On("PreviewDataset", "orders", "", 2).Return(&preview.Preview{
+		Columns:   []string{"id", "status"},
+		Records:   []map[string]interface{}{{"id": int64(1), "status": "paid"}, {"id": int64(2)}},
+		Truncated: true,
+		Files: []*preview.PreviewFile{
+			{Location: "orders/01.parquet", Format: "PARQUET", Records: 1},
+			{Location: "orders/02.csv.gz", Format: "CSV", Compression: "gzip", Records: 1},
+		},
+	}, nil)
+
+	out := &bytes.Buffer{}
+	err := Preview(previewer, []string{"-dataset", "orders", "-limit", "2"}, out)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "id  status\n"+
+		"--  ------\n"+
+		"1   paid\n"+
+		"2   NULL\n"+
+		"(2 records from orders/01.parquet (PARQUET), orders/02.csv.gz (CSV, gzip)), more records not shown\n", out.String())
+}
+
+func TestPreview_Failure(t *testing.T) {
+	previewer := previewMocks.NewPreviewer(t)
+
+	err := Preview(previewer, []string{"-limit", "2"}, &bytes.Buffer{})
+	assert.Equal(t, "usage: data-lake preview [-format table|json] [-limit n] [-dataset name] [location]", err.Error())
+
+	previewer.On("PreviewFile", "orders/01.csv", 0).Return(&preview.Preview{}, nil)
+	err = Preview(previewer, []string{"-format", "xml", "orders/01.csv"}, &bytes.Buffer{})
+	assert.Equal(t, "unknown output format xml", err.Error())
+}
@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/codingexplorations/data-lake/pkg/query"
+)
+
+// Profile prints the column statistics of a dataset as a table, or as JSON with -format json.
+func Profile(engine query.Engine, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("profile", flag.ContinueOnError)
+	flags.SetOutput(out)
+	format := flags.String("format", "table", "output format, table or json")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		return fmt.Errorf("usage: data-lake profile [-format table|json] dataset")
+	}
+
+	profile, err := engine.Profile(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	switch *format {
+	case "json":
+		return json.NewEncoder(out).Encode(profile)
+	case "table":
+		if err := WriteTable(out, query.ProfileColumns, profile.Table()); err != nil {
+			return err
+		}
+		footer := fmt.Sprintf("(%d rows in %d files)", profile.Rows, profile.Files)
+		if profile.FilesWithoutStatistics > 0 {
+			footer += fmt.Sprintf(", %d files without statistics not profiled", profile.FilesWithoutStatistics)
+		}
+		_, err := fmt.Fprintln(out, footer)
+		return err
+	default:
+		return fmt.Errorf("unknown output format %v", *format)
+	}
+}
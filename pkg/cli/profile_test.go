@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/codingexplorations/data-lake/pkg/query"
+	queryMocks "github.com/codingexplorations/data-lake/test/mocks/pkg/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfile(t *testing.T) {
+	engine := queryMocks.NewEngine(t)
+	engine.On("Profile", "orders").Return(&query.Profile{
+		Dataset:                "orders",
+		Files:                  3,
+		FilesWithoutStatistics: 1,
+		Rows:                   5,
+		Columns: []*query.ColumnProfile{
+			{Name: "id", Type: "INTEGER", Values: 5, Distinct: 5, Min: int64(1), Max: int64(5)},
+			{Name: "status", Type: "STRING", Values: 4, Nulls: 1, Distinct: 3, Min: "open"},
+		},
+	}, nil)
+
+	out := &bytes.Buffer{}
+	err := Profile(engine, []string{"orders"}, out)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "column  type     values  nulls  distinct  min   max\n"+
+		"------  ----     ------  -----  --------  ---   ---\n"+
+		"id      INTEGER  5       0      5         1     5\n"+
+		"status  STRING   4       1      3         open  NULL\n"+
+		"(5 rows in 3 files), 1 files without statistics not profiled\n", out.String())
+
+	out.Reset()
+	err = Profile(engine, []string{"-format", "json", "orders"}, out)
+
+	assert.Nil(t, err)
+	assert.Contains(t, out.String(), "{\"name\":\"status\",\"type\":\"STRING\",\"values\":4,\"nulls\":1,\"distinct\":3,\"min\":\"open\",\"max\":null}")
+}
+
+func TestProfile_Failure(t *testing.T) {
+	engine := queryMocks.NewEngine(t)
+
+	err := Profile(engine, []string{}, &bytes.Buffer{})
+	assert.Equal(t, "usage: data-lake profile [-format table|json] dataset", err.Error())
+
+	engine.On("Profile", "customers").Return(nil, query.ErrDatasetNotFound)
+	err = Profile(engine, []string{"customers"}, &bytes.Buffer{})
+	assert.ErrorIs(t, err, query.ErrDatasetNotFound)
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/codingexplorations/data-lake/pkg/query"
+)
+
+// Query runs the statement given as arguments and prints the result as a table, or as JSON with -format json.
+func Query(engine query.Engine, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("query", flag.ContinueOnError)
+	flags.SetOutput(out)
+	format := flags.String("format", "table", "output format, table or json")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	sql := strings.Join(flags.Args(), " ")
+	if sql == "" {
+		return fmt.Errorf("usage: data-lake query [-format table|json] \"SELECT ...\"")
+	}
+
+	result, err := engine.Query(sql)
+	if err != nil {
+		return err
+	}
+
+	switch *format {
+	case "json":
+		return json.NewEncoder(out).Encode(result)
+	case "table":
+		if err := WriteTable(out, result.Columns, result.Rows); err != nil {
+			return err
+		}
+		footer := fmt.Sprintf("(%d rows, %d files scanned, %d pruned)", len(result.Rows), result.FilesScanned, result.FilesPruned)
+		if result.Truncated {
+			footer += ", truncated by QUERY_ROW_LIMIT"
+		}
+		_, err := fmt.Fprintln(out, footer)
+		return err
+	default:
+		return fmt.Errorf("unknown output format %v", *format)
+	}
+}
+
+// WriteTable prints rows in aligned columns under a header.
+func WriteTable(out io.Writer, columns []string, rows [][]interface{}) error {
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(writer, strings.Join(columns, "\t"))
+	separators := make([]string, 0, len(columns))
+	for _, column := range columns {
+		separators = append(separators, strings.Repeat("-", len(column)))
+	}
+	fmt.Fprintln(writer, strings.Join(separators, "\t"))
+
+	for _, row := range rows {
+		values := make([]string, 0, len(row))
+		for _, value := range row {
+			values = append(values, cell(value))
+		}
+		fmt.Fprintln(writer, strings.Join(values, "\t"))
+	}
+
+	return writer.Flush()
+}
+
+func cell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case string:
+		return strings.NewReplacer("\t", " ", "\n", " ").Replace(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case map[string]interface{}, []interface{}:
+		data, err := json.Marshal(v)
+		if err == nil {
+			return string(data)
+		}
+	}
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/codingexplorations/data-lake/pkg/query"
+	queryMocks "github.com/codingexplorations/data-lake/test/mocks/pkg/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuery(t *testing.T) {
+	engine := queryMocks.NewEngine(t)
+	engine.On("Query", "SELECT * FROM orders").Return(&query.Result{
+		Columns: []string{"id", "status", "created", "items"},
+		Rows: [][]interface{}{
+			{int64(1), "paid", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), []interface{}{"a", "b"}},
+			{int64(20), nil, nil, nil},
+		},
+		Truncated:    true,
+		FilesScanned: 2,
+		FilesPruned:  1,
+	}, nil)
+
+	out := &bytes.Buffer{}
+	err := Query(engine, []string{"SELECT", "*", "FROM orders"}, out)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "id  status  created               items\n"+
+		"--  ------  -------               -----\n"+
+		"1   paid    2024-01-02T03:04:05Z  [\"a\",\"b\"]\n"+
+		"20  NULL    NULL                  NULL\n"+
+		"(2 rows, 2 files scanned, 1 pruned), truncated by QUERY_ROW_LIMIT\n", out.String())
+
+	out.Reset()
+	err = Query(engine, []string{"-format", "json", "SELECT * FROM orders"}, out)
+
+	assert.Nil(t, err)
+	assert.Contains(t, out.String(), "\"rows\":[[1,\"paid\",\"2024-01-02T03:04:05Z\",[\"a\",\"b\"]],[20,null,null,null]]")
+}
+
+func TestQuery_Failure(t *testing.T) {
+	engine := queryMocks.NewEngine(t)
+
+	err := Query(engine, []string{}, &bytes.Buffer{})
+	assert.Equal(t, "usage: data-lake query [-format table|json] \"SELECT ...\"", err.Error())
+
+	engine.On("Query", "SELECT id FROM orders").Return(&query.Result{}, nil)
+	err = Query(engine, []string{"-format", "csv", "SELECT id FROM orders"}, &bytes.Buffer{})
+	assert.Equal(t, "unknown output format csv", err.Error())
+}
@@ -0,0 +1,340 @@
+package compaction
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"sort"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2/event"
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/catalog"
+	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/codingexplorations/data-lake/pkg/convert"
+	"github.com/codingexplorations/data-lake/pkg/dataset"
+	"github.com/codingexplorations/data-lake/pkg/events"
+	"github.com/codingexplorations/data-lake/pkg/log"
+	"github.com/codingexplorations/data-lake/pkg/stats"
+	"github.com/codingexplorations/data-lake/pkg/storage"
+)
+
+type Compactor interface {
+	Compact() ([]*models_v1.DataFile, error)
+}
+
+// CompactorImpl merges the small curated files of each dataset partition into files close to the target size, and
+// deletes the files it replaced once their grace period is over.
+type CompactorImpl struct {
+	conf     *config.Config
+	logger   log.Logger
+	catalog  catalog.Catalog
+	storage  storage.Storage
+	datasets *dataset.Datasets
+	emitter  *events.Emitter
+}
+
+func NewCompactor(conf *config.Config, logger log.Logger) (Compactor, error) {
+	curated, err := storage.GetCuratedStorage(conf)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create curated storage: %v", err)
+	}
+
+	datasets, err := dataset.Load(conf)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't load datasets: %v", err)
+	}
+
+	tableCatalog, err := catalog.GetCatalog(conf)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create catalog: %v", err)
+	}
+
+	emitter, err := events.GetEmitter(conf, tableCatalog)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create event emitter: %v", err)
+	}
+
+	return &CompactorImpl{
+		conf:     conf,
+		logger:   logger,
+		catalog:  tableCatalog,
+		storage:  curated,
+		datasets: datasets,
+		emitter:  emitter,
+	}, nil
+}
+
+// Compact compacts every dataset in the catalog, returning the files it wrote. A dataset that fails doesn't stop the
+// others from being compacted, the first error is returned once all are done.
+func (compactor *CompactorImpl) Compact() ([]*models_v1.DataFile, error) {
+	names, err := compactor.catalog.Datasets()
+	if err != nil {
+		return nil, err
+	}
+
+	compacted := make([]*models_v1.DataFile, 0)
+	var firstErr error
+
+	for _, name := range names {
+		files, err := compactor.CompactDataset(name)
+		compacted = append(compacted, files...)
+		if err != nil {
+			compactor.logger.Error("error compacting dataset", log.Dataset(name), log.Err(err))
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+
+		if err := compactor.expire(name); err != nil {
+			compactor.logger.Error("error deleting compacted files of dataset", log.Dataset(name), log.Err(err))
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	return compacted, firstErr
+}
+
+// CompactDataset merges the small files of each partition of a dataset.
+func (compactor *CompactorImpl) CompactDataset(name string) ([]*models_v1.DataFile, error) {
+	ds := compactor.datasets.Get(name)
+	if ds == nil {
+		ds = compactor.datasets.Resolve(name + "/")
+	}
+
+	files, err := compactor.catalog.Files(name)
+	if err != nil {
+		return nil, err
+	}
+
+	compacted := make([]*models_v1.DataFile, 0)
+
+	for _, group := range plan(files, compactor.conf.CompactionSmallFileSize, compactor.conf.CompactionTargetSize, compactor.conf.CompactionMinFiles) {
+		file, err := compactor.merge(ds, group)
+		if err != nil {
+			return compacted, err
+		}
+
+		removed := make([]string, 0, len(group))
+		for _, source := range group {
+			removed = append(removed, source.Location)
+		}
+
+		if err := compactor.catalog.Swap(name, removed, []*models_v1.DataFile{file}); err != nil {
+			// the merged file never made it into the catalog, so nothing reads it
+			_ = compactor.storage.Delete(file.Location)
+
+			var conflict *catalog.ConflictError
+			if errors.As(err, &conflict) {
+				compactor.logger.Warn("skipped compacting partition", log.Dataset(name), slog.String("partition", file.Partition), log.Err(err))
+				continue
+			}
+			return compacted, err
+		}
+
+		compactor.logger.Info("compacted files", log.Dataset(name), log.Location(file.Location), slog.Int("files", len(group)))
+		compacted = append(compacted, file)
+	}
+
+	return compacted, nil
+}
+
+// merge writes the files of a group into one file next to the first of them.
+func (compactor *CompactorImpl) merge(ds *dataset.Dataset, group []*models_v1.DataFile) (*models_v1.DataFile, error) {
+	converter, err := convert.NewParquetConverter(ds.Compression, ds.RowGroupSize)
+	if err != nil {
+		return nil, err
+	}
+
+	contents := make([][]byte, 0, len(group))
+	sources := make([]string, 0)
+	checksums := make([]string, 0)
+	statistics := make([]*models_v1.Statistics, 0, len(group))
+	for _, file := range group {
+		content, err := compactor.read(file.Location)
+		if err != nil {
+			return nil, err
+		}
+		contents = append(contents, content)
+		sources = append(sources, file.GetLineage().GetSources()...)
+		checksums = append(checksums, catalog.SourceChecksums(file.GetLineage())...)
+		statistics = append(statistics, file.Statistics)
+	}
+
+	first := group[0]
+	now := time.Now()
+
+	lineage := &models_v1.Lineage{
+		Sources:       sources,
+		Checksums:     checksums,
+		Dataset:       first.Dataset,
+		SchemaVersion: first.SchemaVersion,
+		Timestamp:     now.UnixMilli(),
+	}
+
+	output := &bytes.Buffer{}
+	conversion, err := converter.Merge(contents, output, lineage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge files of partition %q: %v", first.Partition, err)
+	}
+
+	location := path.Join(path.Dir(first.Location), fmt.Sprintf("compacted-%d.parquet", now.UnixNano()))
+	if err := compactor.storage.Write(location, output, convert.ContentType); err != nil {
+		return nil, err
+	}
+
+	return &models_v1.DataFile{
+		Location:      location,
+		Dataset:       first.Dataset,
+		Partition:     first.Partition,
+		Format:        first.Format,
+		SchemaVersion: first.SchemaVersion,
+		Records:       conversion.Records,
+		ContentSize:   conversion.ContentSize,
+		Lineage:       lineage,
+		Statistics:    stats.Merge(statistics...),
+	}, nil
+}
+
+func (compactor *CompactorImpl) read(location string) ([]byte, error) {
+	body, err := compactor.storage.Read(location)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	return io.ReadAll(body)
+}
+
+// expire deletes the files compaction replaced once they've been out of the catalog for the grace period, giving
+// readers that listed them before the swap time to finish.
+func (compactor *CompactorImpl) expire(name string) error {
+	removed, err := compactor.catalog.Removed(name)
+	if err != nil {
+		return err
+	}
+
+	cutoff := time.Now().Add(-compactor.conf.CompactionGracePeriod).UnixMilli()
+
+	deleted := make([]string, 0)
+	for _, file := range removed {
+		if file.Removed > cutoff {
+			continue
+		}
+		if err := compactor.storage.Delete(file.Location); err != nil {
+			return err
+		}
+		deleted = append(deleted, file.Location)
+	}
+
+	if len(deleted) == 0 {
+		return nil
+	}
+
+	if compactor.emitter == nil {
+		return compactor.catalog.Purge(name, deleted)
+	}
+
+	// the deletion events are committed with the purge when there is an outbox, and emitted after it otherwise
+	deletions := compactor.deletions(name, deleted)
+	outbox, err := compactor.emitter.Stage(deletions...)
+	if err != nil {
+		return err
+	}
+
+	if err := compactor.catalog.Purge(name, deleted, outbox...); err != nil {
+		return err
+	}
+
+	if outbox == nil {
+		if err := compactor.emitter.Emit(deletions...); err != nil {
+			compactor.logger.Warn("couldn't emit deletions of dataset", log.Dataset(name), log.Err(err))
+		}
+	}
+
+	return nil
+}
+
+// deletions returns the events of files compaction replaced being deleted.
+func (compactor *CompactorImpl) deletions(name string, locations []string) []cloudevents.Event {
+	deletions := make([]cloudevents.Event, 0, len(locations))
+	now := time.Now().UnixMilli()
+	for _, location := range locations {
+		event, err := compactor.emitter.NewDeleted(&models_v1.Deletion{Dataset: name, Location: location, Reason: "compacted", Timestamp: now})
+		if err != nil {
+			compactor.logger.Warn("couldn't emit deletion", log.Location(location), log.Err(err))
+			continue
+		}
+		deletions = append(deletions, event)
+	}
+	return deletions
+}
+
+// plan groups the small files of each partition that share a format and schema version, oldest first, into groups
+// that stay within the target size. Only groups of at least minFiles files are worth rewriting.
+func plan(files []*models_v1.DataFile, smallFileSize int64, targetSize int64, minFiles int) [][]*models_v1.DataFile {
+	// merging a single file would only rewrite it
+	if minFiles < 2 {
+		minFiles = 2
+	}
+
+	type key struct {
+		partition     string
+		format        models_v1.Schema_Format
+		schemaVersion int32
+	}
+
+	candidates := make(map[key][]*models_v1.DataFile)
+	keys := make([]key, 0)
+	for _, file := range files {
+		// parquet is the only format we can merge
+		if file.Format != models_v1.Schema_PARQUET || file.ContentSize >= smallFileSize {
+			continue
+		}
+
+		k := key{partition: file.Partition, format: file.Format, schemaVersion: file.SchemaVersion}
+		if _, ok := candidates[k]; !ok {
+			keys = append(keys, k)
+		}
+		candidates[k] = append(candidates[k], file)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].partition != keys[j].partition {
+			return keys[i].partition < keys[j].partition
+		}
+		return keys[i].schemaVersion < keys[j].schemaVersion
+	})
+
+	groups := make([][]*models_v1.DataFile, 0)
+	for _, k := range keys {
+		partitionFiles := candidates[k]
+		sort.SliceStable(partitionFiles, func(i, j int) bool {
+			return partitionFiles[i].Timestamp < partitionFiles[j].Timestamp
+		})
+
+		group := make([]*models_v1.DataFile, 0)
+		var size int64
+		for _, file := range partitionFiles {
+			if len(group) > 0 && size+file.ContentSize > targetSize {
+				if len(group) >= minFiles {
+					groups = append(groups, group)
+				}
+				group = make([]*models_v1.DataFile, 0)
+				size = 0
+			}
+			group = append(group, file)
+			size += file.ContentSize
+		}
+		if len(group) >= minFiles {
+			groups = append(groups, group)
+		}
+	}
+
+	return groups
+}
@@ -0,0 +1,200 @@
+package compaction
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2/event"
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/catalog"
+	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/codingexplorations/data-lake/pkg/convert"
+	"github.com/codingexplorations/data-lake/pkg/dataset"
+	"github.com/codingexplorations/data-lake/pkg/events"
+	"github.com/codingexplorations/data-lake/pkg/log"
+	"github.com/codingexplorations/data-lake/pkg/records"
+	"github.com/codingexplorations/data-lake/pkg/stats"
+	"github.com/codingexplorations/data-lake/pkg/storage"
+	catalogMocks "github.com/codingexplorations/data-lake/test/mocks/pkg/catalog"
+	eventsMocks "github.com/codingexplorations/data-lake/test/mocks/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/proto"
+)
+
+var ordersSchema = &models_v1.Schema{
+	Format: models_v1.Schema_NDJSON,
+	Fields: []*models_v1.Field{{Name: "id", Type: models_v1.Field_INTEGER}},
+}
+
+func newCompactor(t *testing.T, conf *config.Config) (*CompactorImpl, *catalog.TableCatalogImpl) {
+	tableCatalog := catalog.NewTableCatalog(storage.NewLocalStorage(), t.TempDir())
+
+	return &CompactorImpl{
+		conf:     conf,
+		logger:   log.NewConsoleLog(),
+		catalog:  tableCatalog,
+		storage:  storage.NewLocalStorage(),
+		datasets: dataset.NewDatasets(conf, nil),
+	}, tableCatalog
+}
+
+// addFile writes a parquet file holding one record with the given id and lists it in the catalog.
+func addFile(t *testing.T, cat catalog.Catalog, location string, partition string, id int) {
+	converter, _ := convert.NewParquetConverter("snappy", 100)
+	collector := stats.NewCollector(ordersSchema)
+	converter.Observe(collector.Add)
+	source := strings.Replace(location, ".parquet", ".ndjson", 1)
+
+	output := &bytes.Buffer{}
+	lineage := &models_v1.Lineage{Sources: []string{source}, Checksums: []string{fmt.Sprintf("c%d", id)}, Dataset: "orders", SchemaVersion: 1}
+	conversion, err := converter.Convert(ordersSchema, records.NewJsonReader(strings.NewReader(fmt.Sprintf("{\"id\": %d}", id))), output, lineage)
+	assert.Nil(t, err)
+
+	assert.Nil(t, storage.NewLocalStorage().Write(location, output, convert.ContentType))
+	assert.Nil(t, cat.Add(&models_v1.DataFile{
+		Location:      location,
+		Dataset:       "orders",
+		Partition:     partition,
+		Format:        models_v1.Schema_PARQUET,
+		SchemaVersion: 1,
+		Records:       conversion.Records,
+		ContentSize:   conversion.ContentSize,
+		Lineage:       lineage,
+		Statistics:    collector.Statistics(),
+	}))
+}
+
+func TestCompactorImpl_Compact(t *testing.T) {
+	conf := &config.Config{ParquetCompression: "ZSTD", ParquetRowGroupSize: 100, CompactionSmallFileSize: 1024 * 1024, CompactionTargetSize: 128 * 1024 * 1024, CompactionMinFiles: 3, CompactionGracePeriod: time.Hour}
+	compactor, tableCatalog := newCompactor(t, conf)
+	folder := t.TempDir()
+
+	for i := 1; i <= 3; i++ {
+		addFile(t, tableCatalog, fmt.Sprintf("%v/orders/2024/%02d.parquet", folder, i), "2024", i)
+	}
+	// too few files in the partition to be worth compacting
+	addFile(t, tableCatalog, folder+"/orders/2023/01.parquet", "2023", 4)
+
+	compacted, err := compactor.Compact()
+
+	assert.Nil(t, err)
+	assert.Len(t, compacted, 1)
+	assert.True(t, strings.HasPrefix(compacted[0].Location, folder+"/orders/2024/compacted-"))
+	assert.Equal(t, "2024", compacted[0].Partition)
+	assert.Equal(t, int32(3), compacted[0].Records)
+	assert.Equal(t, []string{folder + "/orders/2024/01.ndjson", folder + "/orders/2024/02.ndjson", folder + "/orders/2024/03.ndjson"}, compacted[0].Lineage.Sources)
+	assert.Equal(t, []string{"c1", "c2", "c3"}, compacted[0].Lineage.Checksums)
+	// the statistics of the compacted file are merged from the ones of its files, without reading them again
+	assert.Equal(t, int64(3), compacted[0].Statistics.Rows)
+	assert.Equal(t, int64(3), compacted[0].Statistics.Columns[0].Distinct)
+	assert.Equal(t, int64(1), compacted[0].Statistics.Columns[0].Min.GetInteger())
+	assert.Equal(t, int64(3), compacted[0].Statistics.Columns[0].Max.GetInteger())
+
+	files, _ := tableCatalog.Files("orders")
+	assert.Len(t, files, 2)
+	assert.Equal(t, folder+"/orders/2023/01.parquet", files[0].Location)
+	assert.Equal(t, compacted[0].Location, files[1].Location)
+
+	// the originals stay around for the grace period
+	removed, _ := tableCatalog.Removed("orders")
+	assert.Len(t, removed, 3)
+	_, err = storage.NewLocalStorage().Read(removed[0].Location)
+	assert.Nil(t, err)
+
+	deleted := make([]*models_v1.Deletion, 0)
+	publisher := eventsMocks.NewPublisher(t)
+	publisher.On("Publish", mock.MatchedBy(func(event cloudevents.Event) bool {
+		return event.Type() == events.FileDeleted
+	})).Run(func(args mock.Arguments) {
+		deletion := &models_v1.Deletion{}
+		assert.Nil(t, proto.Unmarshal(args.Get(0).(cloudevents.Event).Data(), deletion))
+		deleted = append(deleted, deletion)
+	}).Return(nil)
+	compactor.emitter = events.NewEmitter("/data-lake", publisher)
+
+	compactor.conf.CompactionGracePeriod = 0
+	compacted, err = compactor.Compact()
+
+	assert.Nil(t, err)
+	assert.Empty(t, compacted)
+	removed, _ = tableCatalog.Removed("orders")
+	assert.Empty(t, removed)
+	_, err = storage.NewLocalStorage().Read(folder + "/orders/2024/01.parquet")
+	assert.Error(t, err)
+
+	// consumers hear about every file deleted once its grace period is over
+	assert.Len(t, deleted, 3)
+	assert.Equal(t, "orders", deleted[0].Dataset)
+	assert.Equal(t, "compacted", deleted[0].Reason)
+	assert.ElementsMatch(t, []string{folder + "/orders/2024/01.parquet", folder + "/orders/2024/02.parquet", folder + "/orders/2024/03.parquet"},
+		[]string{deleted[0].Location, deleted[1].Location, deleted[2].Location})
+}
+
+func TestCompactorImpl_CompactDatasetConflict(t *testing.T) {
+	conf := &config.Config{ParquetCompression: "SNAPPY", ParquetRowGroupSize: 100, CompactionSmallFileSize: 1024 * 1024, CompactionTargetSize: 1024 * 1024, CompactionMinFiles: 2}
+	compactor, tableCatalog := newCompactor(t, conf)
+	folder := t.TempDir()
+
+	addFile(t, tableCatalog, folder+"/orders/01.parquet", "", 1)
+	addFile(t, tableCatalog, folder+"/orders/02.parquet", "", 2)
+	files, _ := tableCatalog.Files("orders")
+
+	mockCatalog := catalogMocks.NewCatalog(t)
+	mockCatalog.On("Files", "orders").Return(files, nil)
+	mockCatalog.On("Swap", "orders", []string{folder + "/orders/01.parquet", folder + "/orders/02.parquet"}, mock.Anything).Return(&catalog.ConflictError{Dataset: "orders", Location: folder + "/orders/01.parquet"})
+	compactor.catalog = mockCatalog
+
+	compacted, err := compactor.CompactDataset("orders")
+
+	assert.Nil(t, err)
+	assert.Empty(t, compacted)
+
+	// the merged file was cleaned up again
+	entries, _ := os.ReadDir(folder + "/orders")
+	assert.Len(t, entries, 2)
+
+	mockCatalog = catalogMocks.NewCatalog(t)
+	mockCatalog.On("Files", "orders").Return(nil, errors.New("unreadable"))
+	compactor.catalog = mockCatalog
+
+	_, err = compactor.CompactDataset("orders")
+	assert.Error(t, err)
+}
+
+func Test_plan(t *testing.T) {
+	file := func(location string, partition string, size int64, version int32, timestamp int64) *models_v1.DataFile {
+		return &models_v1.DataFile{Location: location, Partition: partition, Format: models_v1.Schema_PARQUET, ContentSize: size, SchemaVersion: version, Timestamp: timestamp}
+	}
+
+	files := []*models_v1.DataFile{
+		file("a/3", "a", 40, 1, 3),
+		file("a/1", "a", 40, 1, 1),
+		file("a/2", "a", 40, 1, 2),
+		file("a/4", "a", 40, 1, 4),
+		file("a/big", "a", 500, 1, 5),
+		file("a/v2", "a", 10, 2, 6),
+		file("b/1", "b", 10, 1, 7),
+		file("b/2", "b", 10, 1, 8),
+		{Location: "b/csv", Partition: "b", Format: models_v1.Schema_CSV, ContentSize: 10, SchemaVersion: 1, Timestamp: 9},
+	}
+
+	groups := plan(files, 100, 100, 2)
+
+	names := make([][]string, 0)
+	for _, group := range groups {
+		groupNames := make([]string, 0)
+		for _, file := range group {
+			groupNames = append(groupNames, file.Location)
+		}
+		names = append(names, groupNames)
+	}
+
+	// files are packed oldest first until the target size, a group of one is left alone
+	assert.Equal(t, [][]string{{"a/1", "a/2"}, {"a/3", "a/4"}, {"b/1", "b/2"}}, names)
+}
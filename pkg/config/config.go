@@ -3,36 +3,121 @@ package config
 import (
 	"log"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
+// defaultConfigFile is read when CONFIG_FILE isn't set, it's fine for it not to be there.
+const defaultConfigFile = "/tmp/.env"
+
 var configLock = &sync.Mutex{}
 
-var configInstance *Config
+// configInstance is swapped when a Watcher reloads the configuration.
+var configInstance atomic.Pointer[Config]
 
 type Config struct {
-	ConfigFile          string `mapstructure:"CONFIG_FILE"`
-	DataFolder          string `mapstructure:"DATA_FOLDER"`
-	IngestProcessorType string `mapstructure:"INGEST_PROCESSOR_TYPE"`
-	AwsBucketName       string `mapstructure:"AWS_BUCKET_NAME"`
-	AwsIngestQueueName  string `mapstructure:"AWS_INGEST_QUEUE_NAME"`
-	AwsLoggerQueueName  string `mapstructure:"AWS_LOGGER_QUEUE_NAME"`
-	LoggerType          string `mapstructure:"LOGGER_TYPE"`
-	LoggerLevel         string `mapstructure:"LOGGER_LEVEL"`
+	ConfigFile                   string        `mapstructure:"CONFIG_FILE"`
+	DataFolder                   string        `mapstructure:"DATA_FOLDER"`
+	IngestProcessorType          string        `mapstructure:"INGEST_PROCESSOR_TYPE"`
+	AwsBucketName                string        `mapstructure:"AWS_BUCKET_NAME"`
+	AwsIngestQueueName           string        `mapstructure:"AWS_INGEST_QUEUE_NAME"`
+	AwsLoggerQueueName           string        `mapstructure:"AWS_LOGGER_QUEUE_NAME"`
+	LoggerType                   string        `mapstructure:"LOGGER_TYPE"`
+	LoggerLevel                  string        `mapstructure:"LOGGER_LEVEL"`
+	SchemaSampleSize             int           `mapstructure:"SCHEMA_SAMPLE_SIZE"`
+	SchemaRegistryFolder         string        `mapstructure:"SCHEMA_REGISTRY_FOLDER"`
+	AwsSchemaRegistryPrefix      string        `mapstructure:"AWS_SCHEMA_REGISTRY_PREFIX"`
+	SchemaCompatibility          string        `mapstructure:"SCHEMA_COMPATIBILITY"`
+	DatasetsFile                 string        `mapstructure:"DATASETS_FILE"`
+	DescriptorSetFile            string        `mapstructure:"DESCRIPTOR_SET_FILE"`
+	RecordViolationLimit         int           `mapstructure:"RECORD_VIOLATION_LIMIT"`
+	CuratedFolder                string        `mapstructure:"CURATED_FOLDER"`
+	AwsCuratedBucketName         string        `mapstructure:"AWS_CURATED_BUCKET_NAME"`
+	ParquetCompression           string        `mapstructure:"PARQUET_COMPRESSION"`
+	ParquetRowGroupSize          int           `mapstructure:"PARQUET_ROW_GROUP_SIZE"`
+	CatalogFolder                string        `mapstructure:"CATALOG_FOLDER"`
+	AwsCatalogPrefix             string        `mapstructure:"AWS_CATALOG_PREFIX"`
+	CompactionSmallFileSize      int64         `mapstructure:"COMPACTION_SMALL_FILE_SIZE"`
+	CompactionTargetSize         int64         `mapstructure:"COMPACTION_TARGET_SIZE"`
+	CompactionMinFiles           int           `mapstructure:"COMPACTION_MIN_FILES"`
+	CompactionGracePeriod        time.Duration `mapstructure:"COMPACTION_GRACE_PERIOD"`
+	HttpAddress                  string        `mapstructure:"HTTP_ADDRESS"`
+	QueryRowLimit                int           `mapstructure:"QUERY_ROW_LIMIT"`
+	PreviewLimit                 int           `mapstructure:"PREVIEW_LIMIT"`
+	PreviewMaxLimit              int           `mapstructure:"PREVIEW_MAX_LIMIT"`
+	QualityReportsFolder         string        `mapstructure:"QUALITY_REPORTS_FOLDER"`
+	AwsQualityPrefix             string        `mapstructure:"AWS_QUALITY_PREFIX"`
+	QuarantineFolder             string        `mapstructure:"QUARANTINE_FOLDER"`
+	AwsQuarantinePrefix          string        `mapstructure:"AWS_QUARANTINE_PREFIX"`
+	FreshnessCheckInterval       time.Duration `mapstructure:"FRESHNESS_CHECK_INTERVAL"`
+	FreshnessNotifiers           string        `mapstructure:"FRESHNESS_NOTIFIERS"`
+	FreshnessWebhookUrl          string        `mapstructure:"FRESHNESS_WEBHOOK_URL"`
+	AwsFreshnessQueueName        string        `mapstructure:"AWS_FRESHNESS_QUEUE_NAME"`
+	IngestEventSinks             string        `mapstructure:"INGEST_EVENT_SINKS"`
+	AwsIngestEventQueueName      string        `mapstructure:"AWS_INGEST_EVENT_QUEUE_NAME"`
+	AwsIngestEventTopicArn       string        `mapstructure:"AWS_INGEST_EVENT_TOPIC_ARN"`
+	IngestEventWebhookUrl        string        `mapstructure:"INGEST_EVENT_WEBHOOK_URL"`
+	IngestEventWebhookSecret     string        `mapstructure:"INGEST_EVENT_WEBHOOK_SECRET"`
+	IngestEventWebhookRetries    int           `mapstructure:"INGEST_EVENT_WEBHOOK_RETRIES"`
+	EventSource                  string        `mapstructure:"EVENT_SOURCE"`
+	EventMode                    string        `mapstructure:"EVENT_MODE"`
+	OutboxRelayInterval          time.Duration `mapstructure:"OUTBOX_RELAY_INTERVAL"`
+	AwsIngestDeadLetterQueueName string        `mapstructure:"AWS_INGEST_DEAD_LETTER_QUEUE_NAME"`
+	SqsVisibilityTimeout         time.Duration `mapstructure:"SQS_VISIBILITY_TIMEOUT"`
+	SqsWaitTime                  time.Duration `mapstructure:"SQS_WAIT_TIME"`
+	SqsMaxReceives               int           `mapstructure:"SQS_MAX_RECEIVES"`
+	SqsRetryBackoff              time.Duration `mapstructure:"SQS_RETRY_BACKOFF"`
+	SqsMaxRetryBackoff           time.Duration `mapstructure:"SQS_MAX_RETRY_BACKOFF"`
+	LoggerBufferSize             int           `mapstructure:"LOGGER_BUFFER_SIZE"`
+	LoggerFlushInterval          time.Duration `mapstructure:"LOGGER_FLUSH_INTERVAL"`
+	LoggerDropPolicy             string        `mapstructure:"LOGGER_DROP_POLICY"`
+	LoggerSpoolFolder            string        `mapstructure:"LOGGER_SPOOL_FOLDER"`
+	LoggerSpoolMaxSize           int64         `mapstructure:"LOGGER_SPOOL_MAX_SIZE"`
+	LoggerSinks                  string        `mapstructure:"LOGGER_SINKS"`
+	LoggerConsoleLevel           string        `mapstructure:"LOGGER_CONSOLE_LEVEL"`
+	LoggerJsonLevel              string        `mapstructure:"LOGGER_JSON_LEVEL"`
+	LoggerFileLevel              string        `mapstructure:"LOGGER_FILE_LEVEL"`
+	LoggerSqsLevel               string        `mapstructure:"LOGGER_SQS_LEVEL"`
+	LoggerFilePath               string        `mapstructure:"LOGGER_FILE_PATH"`
+	LoggerFileFormat             string        `mapstructure:"LOGGER_FILE_FORMAT"`
+	AwsLoggerDeadLetterQueueName string        `mapstructure:"AWS_LOGGER_DEAD_LETTER_QUEUE_NAME"`
+	LogConsumerDataset           string        `mapstructure:"LOG_CONSUMER_DATASET"`
+	LogConsumerFormat            string        `mapstructure:"LOG_CONSUMER_FORMAT"`
+	LogConsumerPrefix            string        `mapstructure:"LOG_CONSUMER_PREFIX"`
+	LogConsumerBatchSize         int           `mapstructure:"LOG_CONSUMER_BATCH_SIZE"`
+	LogConsumerBatchWait         time.Duration `mapstructure:"LOG_CONSUMER_BATCH_WAIT"`
+	LoggerFileMaxSize            int64         `mapstructure:"LOGGER_FILE_MAX_SIZE"`
+	LoggerFileRotateInterval     time.Duration `mapstructure:"LOGGER_FILE_ROTATE_INTERVAL"`
+	LoggerFileCompress           bool          `mapstructure:"LOGGER_FILE_COMPRESS"`
+	LoggerFileMaxAge             time.Duration `mapstructure:"LOGGER_FILE_MAX_AGE"`
+	LoggerFileMaxBackups         int           `mapstructure:"LOGGER_FILE_MAX_BACKUPS"`
+	LoggerCodec                  string        `mapstructure:"LOGGER_CODEC"`
+	TracingExporter              string        `mapstructure:"TRACING_EXPORTER"`
+	TracingOtlpEndpoint          string        `mapstructure:"TRACING_OTLP_ENDPOINT"`
+	TracingServiceName           string        `mapstructure:"TRACING_SERVICE_NAME"`
+	TracingSampleRatio           float64       `mapstructure:"TRACING_SAMPLE_RATIO"`
+	LoggerLevels                 string        `mapstructure:"LOGGER_LEVELS"`
+	AdminToken                   string        `mapstructure:"ADMIN_TOKEN"`
+	ConfigWatchInterval          time.Duration `mapstructure:"CONFIG_WATCH_INTERVAL"`
 }
 
+// GetConfig returns the config read once for the whole process, or the last one a Watcher reloaded. It's a shim for
+// code that isn't handed a config, Read and Load return one of its own.
 func GetConfig() *Config {
-	if configInstance == nil {
-		configLock.Lock()
-		defer configLock.Unlock()
-		if configInstance == nil {
-			config, _ := newConfig()
-			configInstance = config
-		}
+	if config := configInstance.Load(); config != nil {
+		return config
+	}
+
+	configLock.Lock()
+	defer configLock.Unlock()
+	if configInstance.Load() == nil {
+		config, _ := newConfig()
+		configInstance.Store(config)
 	}
 
-	return configInstance
+	return configInstance.Load()
 }
 
 func (conf *Config) Print() {
@@ -44,6 +129,82 @@ func (conf *Config) Print() {
 	log.Printf("AWS_LOGGER_QUEUE_NAME: %s\n", conf.AwsLoggerQueueName)
 	log.Printf("LOGGER_TYPE: %s\n", conf.LoggerType)
 	log.Printf("LOGGER_LEVEL: %s\n", conf.LoggerLevel)
+	log.Printf("SCHEMA_SAMPLE_SIZE: %d\n", conf.SchemaSampleSize)
+	log.Printf("SCHEMA_REGISTRY_FOLDER: %s\n", conf.SchemaRegistryFolder)
+	log.Printf("AWS_SCHEMA_REGISTRY_PREFIX: %s\n", conf.AwsSchemaRegistryPrefix)
+	log.Printf("SCHEMA_COMPATIBILITY: %s\n", conf.SchemaCompatibility)
+	log.Printf("DATASETS_FILE: %s\n", conf.DatasetsFile)
+	log.Printf("DESCRIPTOR_SET_FILE: %s\n", conf.DescriptorSetFile)
+	log.Printf("RECORD_VIOLATION_LIMIT: %d\n", conf.RecordViolationLimit)
+	log.Printf("CURATED_FOLDER: %s\n", conf.CuratedFolder)
+	log.Printf("AWS_CURATED_BUCKET_NAME: %s\n", conf.AwsCuratedBucketName)
+	log.Printf("PARQUET_COMPRESSION: %s\n", conf.ParquetCompression)
+	log.Printf("PARQUET_ROW_GROUP_SIZE: %d\n", conf.ParquetRowGroupSize)
+	log.Printf("CATALOG_FOLDER: %s\n", conf.CatalogFolder)
+	log.Printf("AWS_CATALOG_PREFIX: %s\n", conf.AwsCatalogPrefix)
+	log.Printf("COMPACTION_SMALL_FILE_SIZE: %d\n", conf.CompactionSmallFileSize)
+	log.Printf("COMPACTION_TARGET_SIZE: %d\n", conf.CompactionTargetSize)
+	log.Printf("COMPACTION_MIN_FILES: %d\n", conf.CompactionMinFiles)
+	log.Printf("COMPACTION_GRACE_PERIOD: %s\n", conf.CompactionGracePeriod)
+	log.Printf("HTTP_ADDRESS: %s\n", conf.HttpAddress)
+	log.Printf("QUERY_ROW_LIMIT: %d\n", conf.QueryRowLimit)
+	log.Printf("PREVIEW_LIMIT: %d\n", conf.PreviewLimit)
+	log.Printf("PREVIEW_MAX_LIMIT: %d\n", conf.PreviewMaxLimit)
+	log.Printf("QUALITY_REPORTS_FOLDER: %s\n", conf.QualityReportsFolder)
+	log.Printf("AWS_QUALITY_PREFIX: %s\n", conf.AwsQualityPrefix)
+	log.Printf("QUARANTINE_FOLDER: %s\n", conf.QuarantineFolder)
+	log.Printf("AWS_QUARANTINE_PREFIX: %s\n", conf.AwsQuarantinePrefix)
+	log.Printf("FRESHNESS_CHECK_INTERVAL: %s\n", conf.FreshnessCheckInterval)
+	log.Printf("FRESHNESS_NOTIFIERS: %s\n", conf.FreshnessNotifiers)
+	log.Printf("FRESHNESS_WEBHOOK_URL: %s\n", conf.FreshnessWebhookUrl)
+	log.Printf("AWS_FRESHNESS_QUEUE_NAME: %s\n", conf.AwsFreshnessQueueName)
+	log.Printf("INGEST_EVENT_SINKS: %s\n", conf.IngestEventSinks)
+	log.Printf("AWS_INGEST_EVENT_QUEUE_NAME: %s\n", conf.AwsIngestEventQueueName)
+	log.Printf("AWS_INGEST_EVENT_TOPIC_ARN: %s\n", conf.AwsIngestEventTopicArn)
+	log.Printf("INGEST_EVENT_WEBHOOK_URL: %s\n", conf.IngestEventWebhookUrl)
+	log.Printf("INGEST_EVENT_WEBHOOK_SECRET: %t\n", conf.IngestEventWebhookSecret != "")
+	log.Printf("INGEST_EVENT_WEBHOOK_RETRIES: %d\n", conf.IngestEventWebhookRetries)
+	log.Printf("EVENT_SOURCE: %s\n", conf.EventSource)
+	log.Printf("EVENT_MODE: %s\n", conf.EventMode)
+	log.Printf("OUTBOX_RELAY_INTERVAL: %v\n", conf.OutboxRelayInterval)
+	log.Printf("AWS_INGEST_DEAD_LETTER_QUEUE_NAME: %s\n", conf.AwsIngestDeadLetterQueueName)
+	log.Printf("SQS_VISIBILITY_TIMEOUT: %v\n", conf.SqsVisibilityTimeout)
+	log.Printf("SQS_WAIT_TIME: %v\n", conf.SqsWaitTime)
+	log.Printf("SQS_MAX_RECEIVES: %d\n", conf.SqsMaxReceives)
+	log.Printf("SQS_RETRY_BACKOFF: %v\n", conf.SqsRetryBackoff)
+	log.Printf("SQS_MAX_RETRY_BACKOFF: %v\n", conf.SqsMaxRetryBackoff)
+	log.Printf("LOGGER_BUFFER_SIZE: %d\n", conf.LoggerBufferSize)
+	log.Printf("LOGGER_FLUSH_INTERVAL: %v\n", conf.LoggerFlushInterval)
+	log.Printf("LOGGER_DROP_POLICY: %s\n", conf.LoggerDropPolicy)
+	log.Printf("LOGGER_SPOOL_FOLDER: %s\n", conf.LoggerSpoolFolder)
+	log.Printf("LOGGER_SPOOL_MAX_SIZE: %d\n", conf.LoggerSpoolMaxSize)
+	log.Printf("LOGGER_SINKS: %s\n", conf.LoggerSinks)
+	log.Printf("LOGGER_CONSOLE_LEVEL: %s\n", conf.LoggerConsoleLevel)
+	log.Printf("LOGGER_JSON_LEVEL: %s\n", conf.LoggerJsonLevel)
+	log.Printf("LOGGER_FILE_LEVEL: %s\n", conf.LoggerFileLevel)
+	log.Printf("LOGGER_SQS_LEVEL: %s\n", conf.LoggerSqsLevel)
+	log.Printf("LOGGER_FILE_PATH: %s\n", conf.LoggerFilePath)
+	log.Printf("LOGGER_FILE_FORMAT: %s\n", conf.LoggerFileFormat)
+	log.Printf("AWS_LOGGER_DEAD_LETTER_QUEUE_NAME: %s\n", conf.AwsLoggerDeadLetterQueueName)
+	log.Printf("LOG_CONSUMER_DATASET: %s\n", conf.LogConsumerDataset)
+	log.Printf("LOG_CONSUMER_FORMAT: %s\n", conf.LogConsumerFormat)
+	log.Printf("LOG_CONSUMER_PREFIX: %s\n", conf.LogConsumerPrefix)
+	log.Printf("LOG_CONSUMER_BATCH_SIZE: %d\n", conf.LogConsumerBatchSize)
+	log.Printf("LOG_CONSUMER_BATCH_WAIT: %v\n", conf.LogConsumerBatchWait)
+	log.Printf("LOGGER_FILE_MAX_SIZE: %d\n", conf.LoggerFileMaxSize)
+	log.Printf("LOGGER_FILE_ROTATE_INTERVAL: %v\n", conf.LoggerFileRotateInterval)
+	log.Printf("LOGGER_FILE_COMPRESS: %t\n", conf.LoggerFileCompress)
+	log.Printf("LOGGER_FILE_MAX_AGE: %v\n", conf.LoggerFileMaxAge)
+	log.Printf("LOGGER_FILE_MAX_BACKUPS: %d\n", conf.LoggerFileMaxBackups)
+	log.Printf("LOGGER_CODEC: %s\n", conf.LoggerCodec)
+	log.Printf("TRACING_EXPORTER: %s\n", conf.TracingExporter)
+	log.Printf("TRACING_OTLP_ENDPOINT: %s\n", conf.TracingOtlpEndpoint)
+	log.Printf("TRACING_SERVICE_NAME: %s\n", conf.TracingServiceName)
+	log.Printf("TRACING_SAMPLE_RATIO: %v\n", conf.TracingSampleRatio)
+	log.Printf("LOGGER_LEVELS: %s\n", conf.LoggerLevels)
+	// the token is a secret, only whether there is one is printed
+	log.Printf("ADMIN_TOKEN: %t\n", conf.AdminToken != "")
+	log.Printf("CONFIG_WATCH_INTERVAL: %v\n", conf.ConfigWatchInterval)
 }
 
 func newConfig() (*Config, error) {
@@ -78,10 +239,85 @@ func bindValues(v *viper.Viper) {
 	_ = v.BindEnv("AWS_LOGGER_QUEUE_NAME")
 	_ = v.BindEnv("LOGGER_LEVEL")
 	_ = v.BindEnv("LOGGER_LEVEL")
+	_ = v.BindEnv("SCHEMA_SAMPLE_SIZE")
+	_ = v.BindEnv("SCHEMA_REGISTRY_FOLDER")
+	_ = v.BindEnv("AWS_SCHEMA_REGISTRY_PREFIX")
+	_ = v.BindEnv("SCHEMA_COMPATIBILITY")
+	_ = v.BindEnv("DATASETS_FILE")
+	_ = v.BindEnv("DESCRIPTOR_SET_FILE")
+	_ = v.BindEnv("RECORD_VIOLATION_LIMIT")
+	_ = v.BindEnv("CURATED_FOLDER")
+	_ = v.BindEnv("AWS_CURATED_BUCKET_NAME")
+	_ = v.BindEnv("PARQUET_COMPRESSION")
+	_ = v.BindEnv("PARQUET_ROW_GROUP_SIZE")
+	_ = v.BindEnv("CATALOG_FOLDER")
+	_ = v.BindEnv("AWS_CATALOG_PREFIX")
+	_ = v.BindEnv("COMPACTION_SMALL_FILE_SIZE")
+	_ = v.BindEnv("COMPACTION_TARGET_SIZE")
+	_ = v.BindEnv("COMPACTION_MIN_FILES")
+	_ = v.BindEnv("COMPACTION_GRACE_PERIOD")
+	_ = v.BindEnv("HTTP_ADDRESS")
+	_ = v.BindEnv("QUERY_ROW_LIMIT")
+	_ = v.BindEnv("PREVIEW_LIMIT")
+	_ = v.BindEnv("PREVIEW_MAX_LIMIT")
+	_ = v.BindEnv("QUALITY_REPORTS_FOLDER")
+	_ = v.BindEnv("AWS_QUALITY_PREFIX")
+	_ = v.BindEnv("QUARANTINE_FOLDER")
+	_ = v.BindEnv("AWS_QUARANTINE_PREFIX")
+	_ = v.BindEnv("FRESHNESS_CHECK_INTERVAL")
+	_ = v.BindEnv("FRESHNESS_NOTIFIERS")
+	_ = v.BindEnv("FRESHNESS_WEBHOOK_URL")
+	_ = v.BindEnv("AWS_FRESHNESS_QUEUE_NAME")
+	_ = v.BindEnv("INGEST_EVENT_SINKS")
+	_ = v.BindEnv("AWS_INGEST_EVENT_QUEUE_NAME")
+	_ = v.BindEnv("AWS_INGEST_EVENT_TOPIC_ARN")
+	_ = v.BindEnv("INGEST_EVENT_WEBHOOK_URL")
+	_ = v.BindEnv("INGEST_EVENT_WEBHOOK_SECRET")
+	_ = v.BindEnv("INGEST_EVENT_WEBHOOK_RETRIES")
+	_ = v.BindEnv("EVENT_SOURCE")
+	_ = v.BindEnv("EVENT_MODE")
+	_ = v.BindEnv("OUTBOX_RELAY_INTERVAL")
+	_ = v.BindEnv("AWS_INGEST_DEAD_LETTER_QUEUE_NAME")
+	_ = v.BindEnv("SQS_VISIBILITY_TIMEOUT")
+	_ = v.BindEnv("SQS_WAIT_TIME")
+	_ = v.BindEnv("SQS_MAX_RECEIVES")
+	_ = v.BindEnv("SQS_RETRY_BACKOFF")
+	_ = v.BindEnv("SQS_MAX_RETRY_BACKOFF")
+	_ = v.BindEnv("LOGGER_BUFFER_SIZE")
+	_ = v.BindEnv("LOGGER_FLUSH_INTERVAL")
+	_ = v.BindEnv("LOGGER_DROP_POLICY")
+	_ = v.BindEnv("LOGGER_SPOOL_FOLDER")
+	_ = v.BindEnv("LOGGER_SPOOL_MAX_SIZE")
+	_ = v.BindEnv("LOGGER_SINKS")
+	_ = v.BindEnv("LOGGER_CONSOLE_LEVEL")
+	_ = v.BindEnv("LOGGER_JSON_LEVEL")
+	_ = v.BindEnv("LOGGER_FILE_LEVEL")
+	_ = v.BindEnv("LOGGER_SQS_LEVEL")
+	_ = v.BindEnv("LOGGER_FILE_PATH")
+	_ = v.BindEnv("LOGGER_FILE_FORMAT")
+	_ = v.BindEnv("AWS_LOGGER_DEAD_LETTER_QUEUE_NAME")
+	_ = v.BindEnv("LOG_CONSUMER_DATASET")
+	_ = v.BindEnv("LOG_CONSUMER_FORMAT")
+	_ = v.BindEnv("LOG_CONSUMER_PREFIX")
+	_ = v.BindEnv("LOG_CONSUMER_BATCH_SIZE")
+	_ = v.BindEnv("LOG_CONSUMER_BATCH_WAIT")
+	_ = v.BindEnv("LOGGER_FILE_MAX_SIZE")
+	_ = v.BindEnv("LOGGER_FILE_ROTATE_INTERVAL")
+	_ = v.BindEnv("LOGGER_FILE_COMPRESS")
+	_ = v.BindEnv("LOGGER_FILE_MAX_AGE")
+	_ = v.BindEnv("LOGGER_FILE_MAX_BACKUPS")
+	_ = v.BindEnv("LOGGER_CODEC")
+	_ = v.BindEnv("TRACING_EXPORTER")
+	_ = v.BindEnv("TRACING_OTLP_ENDPOINT")
+	_ = v.BindEnv("TRACING_SERVICE_NAME")
+	_ = v.BindEnv("TRACING_SAMPLE_RATIO")
+	_ = v.BindEnv("LOGGER_LEVELS")
+	_ = v.BindEnv("ADMIN_TOKEN")
+	_ = v.BindEnv("CONFIG_WATCH_INTERVAL")
 }
 
 func setDefaultValues(v *viper.Viper) {
-	v.SetDefault("CONFIG_FILE", "/tmp/.env")
+	v.SetDefault("CONFIG_FILE", defaultConfigFile)
 	v.SetDefault("DATA_FOLDER", "/tmp/data-lake")
 	v.SetDefault("INGEST_PROCESSOR_TYPE", "local")
 	v.SetDefault("AWS_BUCKET_NAME", "ingest-bucket")
@@ -89,6 +325,81 @@ func setDefaultValues(v *viper.Viper) {
 	v.SetDefault("AWS_LOGGER_QUEUE_NAME", "logger-queue")
 	v.SetDefault("LOGGER_TYPE", "CONSOLE")
 	v.SetDefault("LOGGER_LEVEL", "INFO")
+	v.SetDefault("SCHEMA_SAMPLE_SIZE", 1000)
+	v.SetDefault("SCHEMA_REGISTRY_FOLDER", "/tmp/data-lake-registry")
+	v.SetDefault("AWS_SCHEMA_REGISTRY_PREFIX", "_schemas")
+	v.SetDefault("SCHEMA_COMPATIBILITY", "BACKWARD")
+	v.SetDefault("DATASETS_FILE", "")
+	v.SetDefault("DESCRIPTOR_SET_FILE", "")
+	v.SetDefault("RECORD_VIOLATION_LIMIT", 100)
+	v.SetDefault("CURATED_FOLDER", "/tmp/data-lake-curated")
+	v.SetDefault("AWS_CURATED_BUCKET_NAME", "curated-bucket")
+	v.SetDefault("PARQUET_COMPRESSION", "SNAPPY")
+	v.SetDefault("PARQUET_ROW_GROUP_SIZE", 100000)
+	v.SetDefault("CATALOG_FOLDER", "/tmp/data-lake-catalog")
+	v.SetDefault("AWS_CATALOG_PREFIX", "_catalog")
+	v.SetDefault("COMPACTION_SMALL_FILE_SIZE", 32*1024*1024)
+	v.SetDefault("COMPACTION_TARGET_SIZE", 128*1024*1024)
+	v.SetDefault("COMPACTION_MIN_FILES", 10)
+	v.SetDefault("COMPACTION_GRACE_PERIOD", "24h")
+	v.SetDefault("HTTP_ADDRESS", "127.0.0.1:8000")
+	v.SetDefault("QUERY_ROW_LIMIT", 10000)
+	v.SetDefault("PREVIEW_LIMIT", 20)
+	v.SetDefault("PREVIEW_MAX_LIMIT", 1000)
+	v.SetDefault("QUALITY_REPORTS_FOLDER", "/tmp/data-lake-quality")
+	v.SetDefault("AWS_QUALITY_PREFIX", "_quality")
+	v.SetDefault("QUARANTINE_FOLDER", "/tmp/data-lake-quarantine")
+	v.SetDefault("AWS_QUARANTINE_PREFIX", "_quarantine")
+	v.SetDefault("FRESHNESS_CHECK_INTERVAL", "1m")
+	v.SetDefault("FRESHNESS_NOTIFIERS", "log")
+	v.SetDefault("FRESHNESS_WEBHOOK_URL", "")
+	v.SetDefault("AWS_FRESHNESS_QUEUE_NAME", "")
+	v.SetDefault("INGEST_EVENT_SINKS", "")
+	v.SetDefault("AWS_INGEST_EVENT_QUEUE_NAME", "")
+	v.SetDefault("AWS_INGEST_EVENT_TOPIC_ARN", "")
+	v.SetDefault("INGEST_EVENT_WEBHOOK_URL", "")
+	v.SetDefault("INGEST_EVENT_WEBHOOK_SECRET", "")
+	v.SetDefault("INGEST_EVENT_WEBHOOK_RETRIES", 3)
+	v.SetDefault("EVENT_SOURCE", "/data-lake")
+	v.SetDefault("EVENT_MODE", "structured")
+	v.SetDefault("OUTBOX_RELAY_INTERVAL", "5s")
+	v.SetDefault("AWS_INGEST_DEAD_LETTER_QUEUE_NAME", "")
+	v.SetDefault("SQS_VISIBILITY_TIMEOUT", "60s")
+	v.SetDefault("SQS_WAIT_TIME", "20s")
+	v.SetDefault("SQS_MAX_RECEIVES", 5)
+	v.SetDefault("SQS_RETRY_BACKOFF", "30s")
+	v.SetDefault("SQS_MAX_RETRY_BACKOFF", "15m")
+	v.SetDefault("LOGGER_BUFFER_SIZE", 1000)
+	v.SetDefault("LOGGER_FLUSH_INTERVAL", "1s")
+	v.SetDefault("LOGGER_DROP_POLICY", "drop_newest")
+	v.SetDefault("LOGGER_SPOOL_FOLDER", "/tmp/data-lake-log-spool")
+	v.SetDefault("LOGGER_SPOOL_MAX_SIZE", 64<<20)
+	v.SetDefault("LOGGER_SINKS", "")
+	v.SetDefault("LOGGER_CONSOLE_LEVEL", "")
+	v.SetDefault("LOGGER_JSON_LEVEL", "")
+	v.SetDefault("LOGGER_FILE_LEVEL", "")
+	v.SetDefault("LOGGER_SQS_LEVEL", "")
+	v.SetDefault("LOGGER_FILE_PATH", "/tmp/data-lake-logs/data-lake.log")
+	v.SetDefault("LOGGER_FILE_FORMAT", "text")
+	v.SetDefault("AWS_LOGGER_DEAD_LETTER_QUEUE_NAME", "")
+	v.SetDefault("LOG_CONSUMER_DATASET", "logs")
+	v.SetDefault("LOG_CONSUMER_FORMAT", "parquet")
+	v.SetDefault("LOG_CONSUMER_PREFIX", "logs")
+	v.SetDefault("LOG_CONSUMER_BATCH_SIZE", 500)
+	v.SetDefault("LOG_CONSUMER_BATCH_WAIT", "1m")
+	v.SetDefault("LOGGER_FILE_MAX_SIZE", 100<<20)
+	v.SetDefault("LOGGER_FILE_ROTATE_INTERVAL", "24h")
+	v.SetDefault("LOGGER_FILE_COMPRESS", true)
+	v.SetDefault("LOGGER_FILE_MAX_AGE", "168h")
+	v.SetDefault("LOGGER_FILE_MAX_BACKUPS", 10)
+	v.SetDefault("LOGGER_CODEC", "json")
+	v.SetDefault("TRACING_EXPORTER", "")
+	v.SetDefault("TRACING_OTLP_ENDPOINT", "http://localhost:4318")
+	v.SetDefault("TRACING_SERVICE_NAME", "data-lake")
+	v.SetDefault("TRACING_SAMPLE_RATIO", 1.0)
+	v.SetDefault("LOGGER_LEVELS", "")
+	v.SetDefault("ADMIN_TOKEN", "")
+	v.SetDefault("CONFIG_WATCH_INTERVAL", "0s")
 }
 
 func mergeExternalConfig(v *viper.Viper) error {
@@ -2,6 +2,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -38,4 +39,78 @@ func TestConfig_LoadingDefaultValues(t *testing.T) {
 	assert.Equal(t, "logger-queue", config.AwsLoggerQueueName)
 	assert.Equal(t, "CONSOLE", config.LoggerType)
 	assert.Equal(t, "INFO", config.LoggerLevel)
+	assert.Equal(t, 1000, config.SchemaSampleSize)
+	assert.Equal(t, "/tmp/data-lake-registry", config.SchemaRegistryFolder)
+	assert.Equal(t, "BACKWARD", config.SchemaCompatibility)
+	assert.Equal(t, "", config.DatasetsFile)
+	assert.Equal(t, "", config.DescriptorSetFile)
+	assert.Equal(t, 100, config.RecordViolationLimit)
+	assert.Equal(t, "/tmp/data-lake-curated", config.CuratedFolder)
+	assert.Equal(t, "curated-bucket", config.AwsCuratedBucketName)
+	assert.Equal(t, "SNAPPY", config.ParquetCompression)
+	assert.Equal(t, 100000, config.ParquetRowGroupSize)
+	assert.Equal(t, "/tmp/data-lake-catalog", config.CatalogFolder)
+	assert.Equal(t, "_catalog", config.AwsCatalogPrefix)
+	assert.Equal(t, int64(32*1024*1024), config.CompactionSmallFileSize)
+	assert.Equal(t, int64(128*1024*1024), config.CompactionTargetSize)
+	assert.Equal(t, 10, config.CompactionMinFiles)
+	assert.Equal(t, 24*time.Hour, config.CompactionGracePeriod)
+	assert.Equal(t, "127.0.0.1:8000", config.HttpAddress)
+	assert.Equal(t, 10000, config.QueryRowLimit)
+	assert.Equal(t, 20, config.PreviewLimit)
+	assert.Equal(t, 1000, config.PreviewMaxLimit)
+	assert.Equal(t, "/tmp/data-lake-quality", config.QualityReportsFolder)
+	assert.Equal(t, "_quality", config.AwsQualityPrefix)
+	assert.Equal(t, "/tmp/data-lake-quarantine", config.QuarantineFolder)
+	assert.Equal(t, "_quarantine", config.AwsQuarantinePrefix)
+	assert.Equal(t, time.Minute, config.FreshnessCheckInterval)
+	assert.Equal(t, "log", config.FreshnessNotifiers)
+	assert.Equal(t, "", config.FreshnessWebhookUrl)
+	assert.Equal(t, "", config.AwsFreshnessQueueName)
+	assert.Equal(t, "", config.IngestEventSinks)
+	assert.Equal(t, "", config.AwsIngestEventQueueName)
+	assert.Equal(t, "", config.AwsIngestEventTopicArn)
+	assert.Equal(t, "", config.IngestEventWebhookUrl)
+	assert.Equal(t, "", config.IngestEventWebhookSecret)
+	assert.Equal(t, 3, config.IngestEventWebhookRetries)
+	assert.Equal(t, "/data-lake", config.EventSource)
+	assert.Equal(t, "structured", config.EventMode)
+	assert.Equal(t, 5*time.Second, config.OutboxRelayInterval)
+	assert.Equal(t, "", config.AwsIngestDeadLetterQueueName)
+	assert.Equal(t, time.Minute, config.SqsVisibilityTimeout)
+	assert.Equal(t, 20*time.Second, config.SqsWaitTime)
+	assert.Equal(t, 5, config.SqsMaxReceives)
+	assert.Equal(t, 30*time.Second, config.SqsRetryBackoff)
+	assert.Equal(t, 15*time.Minute, config.SqsMaxRetryBackoff)
+	assert.Equal(t, 1000, config.LoggerBufferSize)
+	assert.Equal(t, time.Second, config.LoggerFlushInterval)
+	assert.Equal(t, "drop_newest", config.LoggerDropPolicy)
+	assert.Equal(t, "/tmp/data-lake-log-spool", config.LoggerSpoolFolder)
+	assert.Equal(t, int64(64<<20), config.LoggerSpoolMaxSize)
+	assert.Equal(t, "", config.LoggerSinks)
+	assert.Equal(t, "", config.LoggerConsoleLevel)
+	assert.Equal(t, "", config.LoggerJsonLevel)
+	assert.Equal(t, "", config.LoggerFileLevel)
+	assert.Equal(t, "", config.LoggerSqsLevel)
+	assert.Equal(t, "/tmp/data-lake-logs/data-lake.log", config.LoggerFilePath)
+	assert.Equal(t, "text", config.LoggerFileFormat)
+	assert.Equal(t, "", config.AwsLoggerDeadLetterQueueName)
+	assert.Equal(t, "logs", config.LogConsumerDataset)
+	assert.Equal(t, "parquet", config.LogConsumerFormat)
+	assert.Equal(t, "logs", config.LogConsumerPrefix)
+	assert.Equal(t, 500, config.LogConsumerBatchSize)
+	assert.Equal(t, time.Minute, config.LogConsumerBatchWait)
+	assert.Equal(t, int64(100<<20), config.LoggerFileMaxSize)
+	assert.Equal(t, 24*time.Hour, config.LoggerFileRotateInterval)
+	assert.Equal(t, true, config.LoggerFileCompress)
+	assert.Equal(t, 7*24*time.Hour, config.LoggerFileMaxAge)
+	assert.Equal(t, 10, config.LoggerFileMaxBackups)
+	assert.Equal(t, "json", config.LoggerCodec)
+	assert.Equal(t, "", config.TracingExporter)
+	assert.Equal(t, "http://localhost:4318", config.TracingOtlpEndpoint)
+	assert.Equal(t, "data-lake", config.TracingServiceName)
+	assert.Equal(t, 1.0, config.TracingSampleRatio)
+	assert.Equal(t, "", config.LoggerLevels)
+	assert.Equal(t, "", config.AdminToken)
+	assert.Equal(t, time.Duration(0), config.ConfigWatchInterval)
 }
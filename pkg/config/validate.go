@@ -0,0 +1,295 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/spf13/viper"
+)
+
+// The values the settings naming one of a few things take, in lower case. Settings holding a list take any number of
+// them, comma separated.
+var (
+	processorTypes     = []string{"local", "localstack"}
+	loggerTypes        = []string{"console", "service"}
+	logLevels          = []string{"debug", "info", "warn", "warning", "error"}
+	loggerSinks        = []string{"console", "json", "file", "sqs"}
+	logFormats         = []string{"text", "json"}
+	logCodecs          = []string{"json", "protobuf", "text"}
+	dropPolicies       = []string{"drop_newest", "drop_oldest", "block"}
+	compatibilities    = []string{"backward", "forward", "full", "none"}
+	eventSinks         = []string{"sqs", "sns", "webhook"}
+	eventModes         = []string{"structured", "binary"}
+	freshnessNotifiers = []string{"log", "webhook", "sqs"}
+	logConsumerFormats = []string{"parquet", "ndjson"}
+	tracingExporters   = []string{"none", "stdout", "otlp"}
+)
+
+// Read reads the config from the environment and CONFIG_FILE, a new one every call. Unlike GetConfig it fails when
+// the file can't be read.
+func Read() (*Config, error) {
+	conf, err := newConfig()
+	if err != nil && !missingDefault(conf.ConfigFile) {
+		return nil, fmt.Errorf("couldn't load configuration: %v", err)
+	}
+
+	return conf, nil
+}
+
+// Load reads the config like Read and validates it, failing with every problem Validate finds.
+func Load() (*Config, error) {
+	conf, err := Read()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, err
+	}
+
+	return conf, nil
+}
+
+// Validate checks the keys of CONFIG_FILE are all known, the settings naming one of a few things name one of them, the
+// settings the ingest processor and sinks in use need are there, and the files and folders that have to exist do. It
+// returns every problem it finds.
+func (conf *Config) Validate() error {
+	errs := make([]error, 0)
+
+	if conf.ConfigFile != "" && !missingDefault(conf.ConfigFile) {
+		unknown, err := unknownKeys(conf.ConfigFile)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("CONFIG_FILE: %v", err))
+		}
+		for _, key := range unknown {
+			errs = append(errs, fmt.Errorf("CONFIG_FILE: unknown key %v", key))
+		}
+	}
+
+	errs = append(errs,
+		oneOf("INGEST_PROCESSOR_TYPE", conf.IngestProcessorType, processorTypes),
+		oneOf("LOGGER_TYPE", conf.LoggerType, loggerTypes),
+		oneOf("LOGGER_LEVEL", conf.LoggerLevel, logLevels),
+		oneOf("LOGGER_CONSOLE_LEVEL", conf.LoggerConsoleLevel, logLevels),
+		oneOf("LOGGER_JSON_LEVEL", conf.LoggerJsonLevel, logLevels),
+		oneOf("LOGGER_FILE_LEVEL", conf.LoggerFileLevel, logLevels),
+		oneOf("LOGGER_SQS_LEVEL", conf.LoggerSqsLevel, logLevels),
+		overrides("LOGGER_LEVELS", conf.LoggerLevels),
+		listOf("LOGGER_SINKS", conf.LoggerSinks, loggerSinks),
+		oneOf("LOGGER_FILE_FORMAT", conf.LoggerFileFormat, logFormats),
+		oneOf("LOGGER_CODEC", conf.LoggerCodec, logCodecs),
+		oneOf("LOGGER_DROP_POLICY", conf.LoggerDropPolicy, dropPolicies),
+		oneOf("SCHEMA_COMPATIBILITY", conf.SchemaCompatibility, compatibilities),
+		oneOf("PARQUET_COMPRESSION", conf.ParquetCompression, compressions()),
+		listOf("INGEST_EVENT_SINKS", conf.IngestEventSinks, eventSinks),
+		oneOf("EVENT_MODE", conf.EventMode, eventModes),
+		listOf("FRESHNESS_NOTIFIERS", conf.FreshnessNotifiers, freshnessNotifiers),
+		oneOf("LOG_CONSUMER_FORMAT", conf.LogConsumerFormat, logConsumerFormats),
+		oneOf("TRACING_EXPORTER", conf.TracingExporter, tracingExporters),
+	)
+
+	// the loops waiting these out would spin without them
+	errs = append(errs,
+		positive("SQS_VISIBILITY_TIMEOUT", conf.SqsVisibilityTimeout),
+		positive("FRESHNESS_CHECK_INTERVAL", conf.FreshnessCheckInterval),
+		positive("OUTBOX_RELAY_INTERVAL", conf.OutboxRelayInterval),
+	)
+	if conf.ConfigWatchInterval != 0 {
+		errs = append(errs, positive("CONFIG_WATCH_INTERVAL", conf.ConfigWatchInterval))
+	}
+
+	if conf.TracingSampleRatio < 0 || conf.TracingSampleRatio > 1 {
+		errs = append(errs, fmt.Errorf("TRACING_SAMPLE_RATIO: %v isn't between 0 and 1", conf.TracingSampleRatio))
+	}
+
+	// what the ingest processor, sinks and notifiers in use need
+	switch strings.ToLower(conf.IngestProcessorType) {
+	case "local":
+		errs = append(errs, folder("DATA_FOLDER", conf.DataFolder))
+	case "localstack":
+		errs = append(errs, required("AWS_BUCKET_NAME", conf.AwsBucketName), required("AWS_CURATED_BUCKET_NAME", conf.AwsCuratedBucketName))
+	}
+	if has(conf.LoggerSinks, "sqs") || (strings.TrimSpace(conf.LoggerSinks) == "" && strings.EqualFold(strings.TrimSpace(conf.LoggerType), "service")) {
+		errs = append(errs, required("AWS_LOGGER_QUEUE_NAME", conf.AwsLoggerQueueName))
+	}
+	if has(conf.LoggerSinks, "file") {
+		errs = append(errs, required("LOGGER_FILE_PATH", conf.LoggerFilePath))
+	}
+	if has(conf.IngestEventSinks, "sqs") {
+		errs = append(errs, required("AWS_INGEST_EVENT_QUEUE_NAME", conf.AwsIngestEventQueueName))
+	}
+	if has(conf.IngestEventSinks, "sns") {
+		errs = append(errs, required("AWS_INGEST_EVENT_TOPIC_ARN", conf.AwsIngestEventTopicArn))
+	}
+	if has(conf.IngestEventSinks, "webhook") {
+		errs = append(errs, required("INGEST_EVENT_WEBHOOK_URL", conf.IngestEventWebhookUrl))
+	}
+	if has(conf.FreshnessNotifiers, "webhook") {
+		errs = append(errs, required("FRESHNESS_WEBHOOK_URL", conf.FreshnessWebhookUrl))
+	}
+	if has(conf.FreshnessNotifiers, "sqs") {
+		errs = append(errs, required("AWS_FRESHNESS_QUEUE_NAME", conf.AwsFreshnessQueueName))
+	}
+	if strings.EqualFold(conf.TracingExporter, "otlp") {
+		errs = append(errs, required("TRACING_OTLP_ENDPOINT", conf.TracingOtlpEndpoint))
+	}
+
+	// files that are read rather than written have to be there
+	errs = append(errs, file("DATASETS_FILE", conf.DatasetsFile), file("DESCRIPTOR_SET_FILE", conf.DescriptorSetFile))
+
+	return errors.Join(errs...)
+}
+
+// Keys returns the keys of every setting.
+func Keys() []string {
+	fields := reflect.VisibleFields(reflect.TypeOf(Config{}))
+	keys := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if key := field.Tag.Get("mapstructure"); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// unknownKeys returns the keys of a config file that aren't settings, sorted.
+func unknownKeys(path string) ([]string, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+
+	// viper reads keys in lower case
+	known := make(map[string]bool)
+	for _, key := range Keys() {
+		known[strings.ToLower(key)] = true
+	}
+
+	unknown := make([]string, 0)
+	for _, key := range v.AllKeys() {
+		if !known[key] {
+			unknown = append(unknown, strings.ToUpper(key))
+		}
+	}
+	sort.Strings(unknown)
+
+	return unknown, nil
+}
+
+// missingDefault tells whether a config file is the default one and isn't there.
+func missingDefault(path string) bool {
+	if path != defaultConfigFile {
+		return false
+	}
+	_, err := os.Stat(path)
+	return errors.Is(err, fs.ErrNotExist)
+}
+
+// oneOf checks a setting is empty or one of the values, in any case.
+func oneOf(key string, value string, values []string) error {
+	if value == "" || slices.Contains(values, strings.ToLower(strings.TrimSpace(value))) {
+		return nil
+	}
+	return fmt.Errorf("%v: %q isn't one of %v", key, value, strings.Join(values, ", "))
+}
+
+// listOf checks every element of a comma separated setting is one of the values.
+func listOf(key string, value string, values []string) error {
+	errs := make([]error, 0)
+	for _, element := range strings.Split(value, ",") {
+		errs = append(errs, oneOf(key, strings.TrimSpace(element), values))
+	}
+	return errors.Join(errs...)
+}
+
+// overrides checks a setting is a comma separated list of name=LEVEL.
+func overrides(key string, value string) error {
+	errs := make([]error, 0)
+	for _, override := range strings.Split(value, ",") {
+		if strings.TrimSpace(override) == "" {
+			continue
+		}
+		name, level, ok := strings.Cut(override, "=")
+		if !ok || strings.TrimSpace(name) == "" || strings.TrimSpace(level) == "" {
+			errs = append(errs, fmt.Errorf("%v: %q isn't name=LEVEL", key, strings.TrimSpace(override)))
+			continue
+		}
+		errs = append(errs, oneOf(key, level, logLevels))
+	}
+	return errors.Join(errs...)
+}
+
+// has tells whether a comma separated setting holds a value, in any case.
+func has(value string, element string) bool {
+	for _, candidate := range strings.Split(value, ",") {
+		if strings.EqualFold(strings.TrimSpace(candidate), element) {
+			return true
+		}
+	}
+	return false
+}
+
+// required checks a setting isn't empty.
+func required(key string, value string) error {
+	if strings.TrimSpace(value) == "" {
+		return fmt.Errorf("%v is required", key)
+	}
+	return nil
+}
+
+// positive checks a duration is more than 0.
+func positive(key string, duration time.Duration) error {
+	if duration <= 0 {
+		return fmt.Errorf("%v: %v isn't positive", key, duration)
+	}
+	return nil
+}
+
+// folder checks a setting names a folder that exists.
+func folder(key string, path string) error {
+	if err := required(key, path); err != nil {
+		return err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("%v: %v", key, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%v: %v isn't a folder", key, path)
+	}
+	return nil
+}
+
+// file checks a setting is empty or names a file that exists.
+func file(key string, path string) error {
+	if path == "" {
+		return nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("%v: %v", key, err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%v: %v is a folder", key, path)
+	}
+	return nil
+}
+
+// compressions returns the parquet compressions, in lower case.
+func compressions() []string {
+	names := make([]string, 0, len(models_v1.Conversion_Compression_value))
+	for name := range models_v1.Conversion_Compression_value {
+		names = append(names, strings.ToLower(name))
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeConfig writes a config file in a folder of the test, returning its path.
+func writeConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestRead(t *testing.T) {
+	// reading doesn't validate
+	t.Setenv("CONFIG_FILE", writeConfig(t, "LOGGER_LEVEL: LOUD\n"))
+
+	conf, err := Read()
+	assert.Nil(t, err)
+	assert.Equal(t, "LOUD", conf.LoggerLevel)
+
+	t.Setenv("CONFIG_FILE", "/tmp/should/not/be/there/test.yaml")
+	_, err = Read()
+	assert.Equal(t, "couldn't load configuration: open /tmp/should/not/be/there/test.yaml: no such file or directory", err.Error())
+}
+
+// intervals sets the durations Validate wants positive.
+func intervals(conf *Config) *Config {
+	conf.SqsVisibilityTimeout = time.Minute
+	conf.FreshnessCheckInterval = time.Minute
+	conf.OutboxRelayInterval = 5 * time.Second
+	return conf
+}
+
+func TestLoad(t *testing.T) {
+	t.Setenv("CONFIG_FILE", writeConfig(t, "DATA_FOLDER: "+t.TempDir()+"\nLOGGER_LEVEL: debug\n"))
+
+	conf, err := Load()
+	assert.Nil(t, err)
+	assert.Equal(t, "debug", conf.LoggerLevel)
+}
+
+func TestLoad_Fails(t *testing.T) {
+	t.Setenv("CONFIG_FILE", "/tmp/should/not/be/there/test.yaml")
+
+	_, err := Load()
+	assert.Equal(t, "couldn't load configuration: open /tmp/should/not/be/there/test.yaml: no such file or directory", err.Error())
+}
+
+func TestConfig_Validate(t *testing.T) {
+	path := writeConfig(t, "DATA_FOLDR: /tmp\nLOGGER_LEVEL: INFO\n")
+	folder := t.TempDir()
+
+	conf := intervals(&Config{
+		ConfigFile:          path,
+		DataFolder:          folder,
+		IngestProcessorType: "local",
+		LoggerLevels:        "ingest=LOUD,parquet",
+		LoggerSinks:         "console, sqs",
+		IngestEventSinks:    "webhook",
+		TracingSampleRatio:  2,
+		DatasetsFile:        folder,
+	})
+
+	assert.Equal(t, `CONFIG_FILE: unknown key DATA_FOLDR
+LOGGER_LEVELS: "LOUD" isn't one of debug, info, warn, warning, error
+LOGGER_LEVELS: "parquet" isn't name=LEVEL
+TRACING_SAMPLE_RATIO: 2 isn't between 0 and 1
+AWS_LOGGER_QUEUE_NAME is required
+INGEST_EVENT_WEBHOOK_URL is required
+DATASETS_FILE: `+folder+` is a folder`, conf.Validate().Error())
+}
+
+func TestConfig_Validate_ProcessorType(t *testing.T) {
+	conf := intervals(&Config{IngestProcessorType: "S3"})
+	assert.Equal(t, `INGEST_PROCESSOR_TYPE: "S3" isn't one of local, localstack`, conf.Validate().Error())
+
+	conf = intervals(&Config{IngestProcessorType: "LocalStack", AwsBucketName: "ingest-bucket"})
+	assert.Equal(t, "AWS_CURATED_BUCKET_NAME is required", conf.Validate().Error())
+
+	conf = intervals(&Config{IngestProcessorType: "local", DataFolder: "/tmp/should/not/be/there"})
+	assert.Equal(t, "DATA_FOLDER: stat /tmp/should/not/be/there: no such file or directory", conf.Validate().Error())
+}
+
+func TestConfig_Validate_Enums(t *testing.T) {
+	conf := intervals(&Config{
+		LoggerType:          "Service",
+		LoggerDropPolicy:    "DROP_OLDEST",
+		ParquetCompression:  "zstd",
+		SchemaCompatibility: "strict",
+		FreshnessNotifiers:  "log,pager",
+		AwsLoggerQueueName:  "logger-queue",
+	})
+
+	assert.Equal(t, `SCHEMA_COMPATIBILITY: "strict" isn't one of backward, forward, full, none
+FRESHNESS_NOTIFIERS: "pager" isn't one of log, webhook, sqs`, conf.Validate().Error())
+}
+
+func TestConfig_Validate_Durations(t *testing.T) {
+	conf := &Config{SqsVisibilityTimeout: time.Nanosecond, OutboxRelayInterval: -time.Second, ConfigWatchInterval: -time.Second}
+
+	assert.Equal(t, `FRESHNESS_CHECK_INTERVAL: 0s isn't positive
+OUTBOX_RELAY_INTERVAL: -1s isn't positive
+CONFIG_WATCH_INTERVAL: -1s isn't positive`, conf.Validate().Error())
+
+	// without watching the interval can be 0
+	assert.Nil(t, intervals(&Config{}).Validate())
+}
+
+func TestKeys(t *testing.T) {
+	keys := Keys()
+	assert.Contains(t, keys, "CONFIG_FILE")
+	assert.Contains(t, keys, "CONFIG_WATCH_INTERVAL")
+}
+
+func TestKeys_Documented(t *testing.T) {
+	readme, err := os.ReadFile("../../README.md")
+	assert.Nil(t, err)
+
+	// every setting has a row in the configuration tables of the README
+	for _, key := range Keys() {
+		assert.Contains(t, string(readme), "| `"+key+"` |", key)
+	}
+}
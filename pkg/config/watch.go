@@ -0,0 +1,113 @@
+package config
+
+import (
+	"log"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Watcher reloads the configuration when CONFIG_FILE changes and tells its subscribers about it. A config that doesn't
+// validate is logged and left out, the last valid one stays in use.
+type Watcher struct {
+	path        string
+	interval    time.Duration
+	lock        sync.Mutex
+	current     *Config
+	modified    time.Time
+	size        int64
+	subscribers []func(*Config)
+}
+
+// NewWatcher watches the CONFIG_FILE of conf every CONFIG_WATCH_INTERVAL, starting from conf.
+func NewWatcher(conf *Config) *Watcher {
+	watcher := &Watcher{
+		path:     conf.ConfigFile,
+		interval: conf.ConfigWatchInterval,
+		current:  conf,
+	}
+	watcher.modified, watcher.size = watcher.stat()
+
+	return watcher
+}
+
+// Subscribe has subscriber called with every config reloaded from then on.
+func (w *Watcher) Subscribe(subscriber func(*Config)) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.subscribers = append(w.subscribers, subscriber)
+}
+
+// Check reloads the config when the file was modified since it was last read, telling whether it was.
+func (w *Watcher) Check() (bool, error) {
+	modified, size := w.stat()
+
+	w.lock.Lock()
+	changed := !modified.Equal(w.modified) || size != w.size
+	w.modified, w.size = modified, size
+	w.lock.Unlock()
+
+	if !changed {
+		return false, nil
+	}
+
+	return true, w.Reload()
+}
+
+// Reload loads and validates the config, making it the one GetConfig returns and calling the subscribers with it.
+func (w *Watcher) Reload() error {
+	conf, err := Load()
+	if err != nil {
+		log.Printf("error reloading configuration, keeping the current one: %v", err)
+		return err
+	}
+
+	w.lock.Lock()
+	changed := Changed(w.current, conf)
+	w.current = conf
+	subscribers := append([]func(*Config){}, w.subscribers...)
+	w.lock.Unlock()
+
+	// values can be secrets, only the keys are logged
+	log.Printf("reloaded configuration, changed: %v", changed)
+
+	configInstance.Store(conf)
+	for _, subscriber := range subscribers {
+		subscriber(conf)
+	}
+
+	return nil
+}
+
+// Watch checks the file every CONFIG_WATCH_INTERVAL, never returning.
+func (w *Watcher) Watch() {
+	for {
+		time.Sleep(w.interval)
+		_, _ = w.Check()
+	}
+}
+
+// stat returns when the file was last modified and its size, zero when it can't be read.
+func (w *Watcher) stat() (time.Time, int64) {
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return time.Time{}, 0
+	}
+	return info.ModTime(), info.Size()
+}
+
+// Changed returns the keys of the settings that differ between two configs.
+func Changed(previous *Config, current *Config) []string {
+	changed := make([]string, 0)
+
+	before, after := reflect.ValueOf(*previous), reflect.ValueOf(*current)
+	for i, field := range reflect.VisibleFields(before.Type()) {
+		if !reflect.DeepEqual(before.Field(i).Interface(), after.Field(i).Interface()) {
+			changed = append(changed, field.Tag.Get("mapstructure"))
+		}
+	}
+
+	return changed
+}
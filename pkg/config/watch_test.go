@@ -0,0 +1,56 @@
+package config
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatcher(t *testing.T) {
+	folder := t.TempDir()
+	path := writeConfig(t, "DATA_FOLDER: "+folder+"\nLOGGER_LEVEL: INFO\n")
+	t.Setenv("CONFIG_FILE", path)
+
+	conf, err := Load()
+	assert.Nil(t, err)
+
+	previous := configInstance.Load()
+	defer configInstance.Store(previous)
+
+	watcher := NewWatcher(conf)
+	reloaded := make([]*Config, 0)
+	watcher.Subscribe(func(conf *Config) { reloaded = append(reloaded, conf) })
+
+	changed, err := watcher.Check()
+	assert.False(t, changed)
+	assert.Nil(t, err)
+
+	// the modification time of the file can be the same, its size can't
+	assert.Nil(t, os.WriteFile(path, []byte("DATA_FOLDER: "+folder+"\nLOGGER_LEVEL: DEBUG\n"), 0o644))
+	changed, err = watcher.Check()
+	assert.True(t, changed)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 1, len(reloaded))
+	assert.Equal(t, "DEBUG", reloaded[0].LoggerLevel)
+	assert.Equal(t, reloaded[0], GetConfig())
+
+	// a config that doesn't validate is left out
+	assert.Nil(t, os.WriteFile(path, []byte("DATA_FOLDER: "+folder+"\nLOGGER_LEVEL: LOUD\n"), 0o644))
+	changed, err = watcher.Check()
+	assert.True(t, changed)
+	assert.Equal(t, `LOGGER_LEVEL: "LOUD" isn't one of debug, info, warn, warning, error`, err.Error())
+
+	assert.Equal(t, 1, len(reloaded))
+	assert.Equal(t, "DEBUG", GetConfig().LoggerLevel)
+}
+
+func TestChanged(t *testing.T) {
+	previous := &Config{LoggerLevel: "INFO", AdminToken: "secret", ConfigWatchInterval: time.Second}
+	current := &Config{LoggerLevel: "DEBUG", AdminToken: "rotated", ConfigWatchInterval: time.Second}
+
+	assert.Equal(t, []string{"LOGGER_LEVEL", "ADMIN_TOKEN"}, Changed(previous, current))
+	assert.Empty(t, Changed(previous, previous))
+}
@@ -0,0 +1,265 @@
+package convert
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/records"
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/compress"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// LineageKey is the key the lineage of a converted file is stored under in its footer metadata.
+const LineageKey = "data_lake.lineage"
+
+const ContentType = "application/vnd.apache.parquet"
+
+var codecs = map[models_v1.Conversion_Compression]compress.Codec{
+	models_v1.Conversion_UNCOMPRESSED: &parquet.Uncompressed,
+	models_v1.Conversion_SNAPPY:       &parquet.Snappy,
+	models_v1.Conversion_GZIP:         &parquet.Gzip,
+	models_v1.Conversion_ZSTD:         &parquet.Zstd,
+}
+
+func ParseCompression(name string) (models_v1.Conversion_Compression, error) {
+	compression, ok := models_v1.Conversion_Compression_value[strings.ToUpper(name)]
+	if !ok {
+		return models_v1.Conversion_UNCOMPRESSED, fmt.Errorf("unknown parquet compression %v", name)
+	}
+	return models_v1.Conversion_Compression(compression), nil
+}
+
+// ParquetConverter rewrites the records of CSV and JSON files as parquet.
+type ParquetConverter struct {
+	compression  models_v1.Conversion_Compression
+	rowGroupSize int
+	observers    []func(values map[string]interface{})
+}
+
+func NewParquetConverter(compression string, rowGroupSize int) (*ParquetConverter, error) {
+	codec, err := ParseCompression(compression)
+	if err != nil {
+		return nil, err
+	}
+
+	if rowGroupSize <= 0 {
+		return nil, fmt.Errorf("parquet row group size must be positive, got %d", rowGroupSize)
+	}
+
+	return &ParquetConverter{
+		compression:  codec,
+		rowGroupSize: rowGroupSize,
+	}, nil
+}
+
+// Observe calls fn with the converted values of every record Convert writes, so they can be looked at in the same
+// pass.
+func (converter *ParquetConverter) Observe(fn func(values map[string]interface{})) {
+	converter.observers = append(converter.observers, fn)
+}
+
+// Convert writes every record the reader returns to output, starting a new row group every rowGroupSize records.
+// The lineage is kept in the footer of the file so it travels with it.
+func (converter *ParquetConverter) Convert(schema *models_v1.Schema, reader records.Reader, output io.Writer, lineage *models_v1.Lineage) (*models_v1.Conversion, error) {
+	parquetSchema, err := ParquetSchema("record", schema)
+	if err != nil {
+		return nil, err
+	}
+
+	lineageJson, err := protojson.Marshal(lineage)
+	if err != nil {
+		return nil, err
+	}
+
+	counter := &countingWriter{writer: output}
+	writer := parquet.NewWriter(counter,
+		parquetSchema,
+		parquet.Compression(codecs[converter.compression]),
+		parquet.KeyValueMetadata(LineageKey, string(lineageJson)),
+	)
+
+	conversion := &models_v1.Conversion{
+		Compression: converter.compression,
+		Lineage:     lineage,
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		values, err := row(schema.GetFields(), record.Fields)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", record.Line, err)
+		}
+
+		if err := writer.Write(values); err != nil {
+			return nil, fmt.Errorf("line %d: %v", record.Line, err)
+		}
+
+		for _, observe := range converter.observers {
+			observe(values)
+		}
+
+		conversion.Records++
+		if int(conversion.Records)%converter.rowGroupSize == 0 {
+			if err := writer.Flush(); err != nil {
+				return nil, err
+			}
+			conversion.RowGroups++
+		}
+	}
+
+	// Close flushes whatever is left into a last, smaller row group
+	if int(conversion.Records)%converter.rowGroupSize != 0 {
+		conversion.RowGroups++
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	conversion.ContentSize = counter.size
+
+	return conversion, nil
+}
+
+// Merge copies the rows of parquet files that share a schema into a single file, in order.
+func (converter *ParquetConverter) Merge(files [][]byte, output io.Writer, lineage *models_v1.Lineage) (*models_v1.Conversion, error) {
+	lineageJson, err := protojson.Marshal(lineage)
+	if err != nil {
+		return nil, err
+	}
+
+	counter := &countingWriter{writer: output}
+	conversion := &models_v1.Conversion{
+		Compression: converter.compression,
+		Lineage:     lineage,
+	}
+
+	var writer *parquet.Writer
+	for i, data := range files {
+		file, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, fmt.Errorf("file %d: %v", i, err)
+		}
+
+		if writer == nil {
+			writer = parquet.NewWriter(counter,
+				file.Schema(),
+				parquet.Compression(codecs[converter.compression]),
+				parquet.MaxRowsPerRowGroup(int64(converter.rowGroupSize)),
+				parquet.KeyValueMetadata(LineageKey, string(lineageJson)),
+			)
+		} else if !parquet.EqualNodes(writer.Schema(), file.Schema()) {
+			return nil, fmt.Errorf("file %d: schema differs from the first file", i)
+		}
+
+		reader := parquet.NewReader(file)
+		rows, err := parquet.CopyRows(writer, reader)
+		reader.Close()
+		if err != nil {
+			return nil, fmt.Errorf("file %d: %v", i, err)
+		}
+
+		conversion.Records += int32(rows)
+	}
+
+	if writer == nil {
+		return nil, fmt.Errorf("no files to merge")
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	conversion.RowGroups = (conversion.Records + int32(converter.rowGroupSize) - 1) / int32(converter.rowGroupSize)
+	conversion.ContentSize = counter.size
+
+	return conversion, nil
+}
+
+// ParquetRows calls fn with the rows of a parquet file until it returns false, and returns the columns of the file in
+// order. Only the footer and the pages of the rows read are read from r.
+func ParquetRows(r io.ReaderAt, size int64, fn func(row map[string]interface{}) bool) ([]string, error) {
+	file, err := parquet.OpenFile(r, size, parquet.SkipPageIndex(true), parquet.SkipBloomFilters(true))
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]string, 0, len(file.Schema().Fields()))
+	timestamps := make(map[string]time.Duration)
+	for _, field := range file.Schema().Fields() {
+		columns = append(columns, field.Name())
+		if unit := timestampUnit(field); unit != 0 {
+			timestamps[field.Name()] = unit
+		}
+	}
+
+	reader := parquet.NewReader(file)
+	defer reader.Close()
+
+	for i := int64(0); i < file.NumRows(); i++ {
+		row := map[string]interface{}{}
+		if err := reader.Read(&row); err != nil {
+			return nil, err
+		}
+
+		// maps don't carry logical types, timestamps come back as the integers they're stored as
+		for name, unit := range timestamps {
+			if value, ok := row[name].(int64); ok {
+				row[name] = time.Unix(0, value*int64(unit)).UTC()
+			}
+		}
+
+		if !fn(row) {
+			break
+		}
+	}
+
+	return columns, nil
+}
+
+func timestampUnit(field parquet.Field) time.Duration {
+	logicalType := field.Type().LogicalType()
+	if logicalType == nil || logicalType.Timestamp == nil {
+		return 0
+	}
+
+	switch {
+	case logicalType.Timestamp.Unit.Millis != nil:
+		return time.Millisecond
+	case logicalType.Timestamp.Unit.Nanos != nil:
+		return time.Nanosecond
+	default:
+		return time.Microsecond
+	}
+}
+
+// ParquetLocation swaps the extension of a source location for .parquet, keeping it at the same path in the curated
+// zone.
+func ParquetLocation(location string) string {
+	return strings.TrimSuffix(location, path.Ext(location)) + ".parquet"
+}
+
+type countingWriter struct {
+	writer io.Writer
+	size   int64
+}
+
+func (counter *countingWriter) Write(p []byte) (int, error) {
+	n, err := counter.writer.Write(p)
+	counter.size += int64(n)
+	return n, err
+}
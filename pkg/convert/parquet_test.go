@@ -0,0 +1,191 @@
+package convert
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/records"
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+var ordersSchema = &models_v1.Schema{
+	Format: models_v1.Schema_CSV,
+	Header: true,
+	Fields: []*models_v1.Field{
+		{Name: "id", Type: models_v1.Field_INTEGER},
+		{Name: "amount", Type: models_v1.Field_NUMBER, Nullable: true},
+		{Name: "paid", Type: models_v1.Field_BOOLEAN},
+		{Name: "created", Type: models_v1.Field_TIMESTAMP},
+	},
+}
+
+func readRows(t *testing.T, data []byte) (*parquet.File, []map[string]interface{}) {
+	file, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+	assert.Nil(t, err)
+
+	reader := parquet.NewReader(file)
+	defer reader.Close()
+
+	rows := make([]map[string]interface{}, 0)
+	for i := int64(0); i < file.NumRows(); i++ {
+		row := map[string]interface{}{}
+		assert.Nil(t, reader.Read(&row))
+		rows = append(rows, row)
+	}
+
+	return file, rows
+}
+
+func TestParquetConverter_ConvertCsv(t *testing.T) {
+	converter, err := NewParquetConverter("zstd", 2)
+	assert.Nil(t, err)
+
+	content := "id,amount,paid,created\n1,10.5,true,2024-01-02T03:04:05Z\n2,,false,2024-01-03\n3,7,true,2024-01-04 10:00:00\n"
+	lineage := &models_v1.Lineage{Sources: []string{"orders/01.csv"}, Dataset: "orders", SchemaVersion: 1, Timestamp: 1700000000000}
+	output := &bytes.Buffer{}
+
+	conversion, err := converter.Convert(ordersSchema, records.NewCsvRecordReader(strings.NewReader(content), ordersSchema), output, lineage)
+
+	assert.Nil(t, err)
+	assert.Equal(t, models_v1.Conversion_ZSTD, conversion.Compression)
+	assert.Equal(t, int32(3), conversion.Records)
+	assert.Equal(t, int32(2), conversion.RowGroups)
+	assert.Equal(t, int64(output.Len()), conversion.ContentSize)
+
+	file, rows := readRows(t, output.Bytes())
+	assert.Len(t, file.RowGroups(), 2)
+	assert.Equal(t, "ZSTD", file.Metadata().RowGroups[0].Columns[0].MetaData.Codec.String())
+
+	value, ok := file.Lookup(LineageKey)
+	assert.True(t, ok)
+	storedLineage := &models_v1.Lineage{}
+	assert.Nil(t, protojson.Unmarshal([]byte(value), storedLineage))
+	assert.True(t, proto.Equal(lineage, storedLineage))
+
+	assert.Equal(t, int64(1), rows[0]["id"])
+	assert.Equal(t, 10.5, rows[0]["amount"])
+	assert.Equal(t, true, rows[0]["paid"])
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC).UnixMicro(), rows[0]["created"])
+	assert.Nil(t, rows[1]["amount"])
+	assert.Equal(t, float64(7), rows[2]["amount"])
+}
+
+func TestParquetConverter_ConvertJson(t *testing.T) {
+	converter, _ := NewParquetConverter("SNAPPY", 100)
+
+	schema := &models_v1.Schema{
+		Format: models_v1.Schema_NDJSON,
+		Fields: []*models_v1.Field{
+			{Name: "customer", Type: models_v1.Field_OBJECT, Fields: []*models_v1.Field{
+				{Name: "name", Type: models_v1.Field_STRING},
+			}},
+			{Name: "extra", Type: models_v1.Field_OBJECT},
+			{Name: "id", Type: models_v1.Field_INTEGER},
+			{Name: "tags", Type: models_v1.Field_ARRAY, Items: &models_v1.Field{Type: models_v1.Field_STRING}},
+			{Name: "lines", Type: models_v1.Field_ARRAY, Items: &models_v1.Field{Type: models_v1.Field_OBJECT, Fields: []*models_v1.Field{
+				{Name: "sku", Type: models_v1.Field_STRING},
+				{Name: "quantity", Type: models_v1.Field_INTEGER},
+			}}},
+		},
+	}
+	content := "{\"id\": 1, \"customer\": {\"name\": \"ann\"}, \"tags\": [\"a\", \"b\"], \"extra\": {\"x\": 1}, \"lines\": [{\"sku\": \"x\", \"quantity\": 2}, {\"sku\": \"y\"}]}\n{\"id\": 2, \"unknown\": true}\n"
+	output := &bytes.Buffer{}
+
+	conversion, err := converter.Convert(schema, records.NewJsonReader(strings.NewReader(content)), output, &models_v1.Lineage{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), conversion.Records)
+	assert.Equal(t, int32(1), conversion.RowGroups)
+
+	_, rows := readRows(t, output.Bytes())
+	assert.Equal(t, map[string]interface{}{"name": "ann"}, rows[0]["customer"])
+	assert.Equal(t, map[string]interface{}{"x": float64(1)}, rows[0]["extra"])
+	assert.Equal(t, []interface{}{"a", "b"}, rows[0]["tags"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"sku": "x", "quantity": int64(2)},
+		map[string]interface{}{"sku": "y", "quantity": nil},
+	}, rows[0]["lines"])
+	assert.Equal(t, int64(2), rows[1]["id"])
+	assert.Nil(t, rows[1]["customer"])
+}
+
+func TestParquetConverter_ConvertFailure(t *testing.T) {
+	converter, _ := NewParquetConverter("snappy", 100)
+
+	content := "id,amount,paid,created\n1,10.5,true,2024-01-02\nthree,1,false,2024-01-03\n"
+
+	_, err := converter.Convert(ordersSchema, records.NewCsvRecordReader(strings.NewReader(content), ordersSchema), &bytes.Buffer{}, &models_v1.Lineage{})
+
+	assert.Error(t, err)
+	assert.Equal(t, "line 3: id: strconv.ParseInt: parsing \"three\": invalid syntax", err.Error())
+
+	_, err = converter.Convert(&models_v1.Schema{}, records.NewJsonReader(strings.NewReader("{}")), &bytes.Buffer{}, &models_v1.Lineage{})
+	assert.Error(t, err)
+}
+
+func TestNewParquetConverter_Failure(t *testing.T) {
+	_, err := NewParquetConverter("lzo", 100)
+	assert.Error(t, err)
+
+	_, err = NewParquetConverter("snappy", 0)
+	assert.Error(t, err)
+}
+
+func TestParquetLocation(t *testing.T) {
+	assert.Equal(t, "orders/2024/01.parquet", ParquetLocation("orders/2024/01.csv"))
+	assert.Equal(t, "orders/events.parquet", ParquetLocation("orders/events.ndjson"))
+	assert.Equal(t, "orders/01.parquet", ParquetLocation("orders/01"))
+}
+
+func TestParquetConverter_Merge(t *testing.T) {
+	converter, _ := NewParquetConverter("snappy", 3)
+
+	files := make([][]byte, 0)
+	for _, content := range []string{"id,amount,paid,created\n1,1.5,true,2024-01-01\n2,2,false,2024-01-02\n", "id,amount,paid,created\n3,,true,2024-01-03\n"} {
+		output := &bytes.Buffer{}
+		_, _ = converter.Convert(ordersSchema, records.NewCsvRecordReader(strings.NewReader(content), ordersSchema), output, &models_v1.Lineage{})
+		files = append(files, output.Bytes())
+	}
+
+	lineage := &models_v1.Lineage{Sources: []string{"orders/01.csv", "orders/02.csv"}}
+	output := &bytes.Buffer{}
+
+	conversion, err := converter.Merge(files, output, lineage)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int32(3), conversion.Records)
+	assert.Equal(t, int32(1), conversion.RowGroups)
+
+	file, rows := readRows(t, output.Bytes())
+	value, _ := file.Lookup(LineageKey)
+	assert.Equal(t, "{\"sources\":[\"orders/01.csv\",\"orders/02.csv\"]}", strings.ReplaceAll(value, " ", ""))
+	assert.Equal(t, int64(3), rows[2]["id"])
+	assert.Nil(t, rows[2]["amount"])
+}
+
+func TestParquetConverter_MergeFailure(t *testing.T) {
+	converter, _ := NewParquetConverter("snappy", 3)
+
+	_, err := converter.Merge(nil, &bytes.Buffer{}, &models_v1.Lineage{})
+	assert.Error(t, err)
+
+	_, err = converter.Merge([][]byte{[]byte("not parquet")}, &bytes.Buffer{}, &models_v1.Lineage{})
+	assert.Error(t, err)
+
+	other := &models_v1.Schema{Fields: []*models_v1.Field{{Name: "name", Type: models_v1.Field_STRING}}}
+	files := make([][]byte, 0)
+	for _, schema := range []*models_v1.Schema{ordersSchema, other} {
+		output := &bytes.Buffer{}
+		_, _ = converter.Convert(schema, records.NewJsonReader(strings.NewReader("{}")), output, &models_v1.Lineage{})
+		files = append(files, output.Bytes())
+	}
+
+	_, err = converter.Merge(files, &bytes.Buffer{}, &models_v1.Lineage{})
+	assert.Equal(t, "file 1: schema differs from the first file", err.Error())
+}
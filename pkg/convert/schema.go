@@ -0,0 +1,191 @@
+package convert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/schema"
+	"github.com/parquet-go/parquet-go"
+)
+
+// ParquetSchema maps an inferred or declared schema onto a parquet schema. Every column is optional, inference only
+// sees a sample so a field it never saw empty can still be empty further into the file.
+func ParquetSchema(name string, schema *models_v1.Schema) (*parquet.Schema, error) {
+	if len(schema.GetFields()) == 0 {
+		return nil, fmt.Errorf("schema has no fields")
+	}
+
+	return parquet.NewSchema(name, groupNode(schema.GetFields())), nil
+}
+
+func groupNode(fields []*models_v1.Field) parquet.Group {
+	group := parquet.Group{}
+	for _, field := range fields {
+		group[field.GetName()] = parquet.Optional(fieldNode(field))
+	}
+	return group
+}
+
+func fieldNode(field *models_v1.Field) parquet.Node {
+	var node parquet.Node
+
+	switch field.GetType() {
+	case models_v1.Field_BOOLEAN:
+		node = parquet.Leaf(parquet.BooleanType)
+	case models_v1.Field_INTEGER:
+		node = parquet.Int(64)
+	case models_v1.Field_NUMBER:
+		node = parquet.Leaf(parquet.DoubleType)
+	case models_v1.Field_TIMESTAMP:
+		node = parquet.Timestamp(parquet.Microsecond)
+	case models_v1.Field_OBJECT:
+		// objects we know nothing about, like google.protobuf.Struct, are kept as JSON
+		if len(field.GetFields()) == 0 {
+			node = parquet.JSON()
+		} else {
+			node = groupNode(field.GetFields())
+		}
+	case models_v1.Field_ARRAY:
+		if field.GetItems() == nil {
+			node = parquet.JSON()
+		} else {
+			// parquet-go loses the values of optional list elements, so items are required and can't be null
+			node = parquet.List(fieldNode(field.GetItems()))
+		}
+	default:
+		node = parquet.String()
+	}
+
+	return node
+}
+
+// Values converts the fields of a record to the Go types of the parquet columns schema maps them onto.
+func Values(schema *models_v1.Schema, fields map[string]interface{}) (map[string]interface{}, error) {
+	return row(schema.GetFields(), fields)
+}
+
+// row converts the fields of a record to the Go types the parquet schema of fields expects.
+func row(fields []*models_v1.Field, values map[string]interface{}) (map[string]interface{}, error) {
+	converted := make(map[string]interface{}, len(fields))
+
+	for _, field := range fields {
+		value, err := convertValue(field, values[field.GetName()])
+		if err != nil {
+			return nil, fmt.Errorf("%v: %v", field.GetName(), err)
+		}
+		if value != nil {
+			converted[field.GetName()] = value
+		}
+	}
+
+	return converted, nil
+}
+
+// convertValue reads a value as the type of its field. CSV values are strings and so are nested values in CSV files,
+// which are expected to hold JSON.
+func convertValue(field *models_v1.Field, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	if field.GetType() == models_v1.Field_STRING || field.GetType() == models_v1.Field_NULL {
+		return stringValue(value)
+	}
+
+	if s, ok := value.(string); ok {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			return nil, nil
+		}
+
+		switch field.GetType() {
+		case models_v1.Field_BOOLEAN:
+			return strconv.ParseBool(s)
+		case models_v1.Field_INTEGER:
+			return strconv.ParseInt(s, 10, 64)
+		case models_v1.Field_NUMBER:
+			return strconv.ParseFloat(s, 64)
+		case models_v1.Field_TIMESTAMP:
+			return schema.ParseTimestamp(s)
+		}
+
+		decoder := json.NewDecoder(strings.NewReader(s))
+		decoder.UseNumber()
+		if err := decoder.Decode(&value); err != nil {
+			return nil, fmt.Errorf("value %q is not a valid %v", s, field.GetType())
+		}
+	}
+
+	switch field.GetType() {
+	case models_v1.Field_BOOLEAN:
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+	case models_v1.Field_INTEGER:
+		if n, ok := value.(json.Number); ok {
+			if i, err := n.Int64(); err == nil {
+				return i, nil
+			}
+		}
+	case models_v1.Field_NUMBER:
+		if n, ok := value.(json.Number); ok {
+			return n.Float64()
+		}
+		if f, ok := value.(float64); ok {
+			return f, nil
+		}
+	case models_v1.Field_OBJECT:
+		if len(field.GetFields()) == 0 {
+			return stringValue(value)
+		}
+		if m, ok := value.(map[string]interface{}); ok {
+			return row(field.GetFields(), m)
+		}
+	case models_v1.Field_ARRAY:
+		if field.GetItems() == nil {
+			return stringValue(value)
+		}
+		if items, ok := value.([]interface{}); ok {
+			converted := make([]interface{}, len(items))
+			for i, item := range items {
+				item, err := convertValue(field.GetItems(), item)
+				if err != nil {
+					return nil, fmt.Errorf("item %d: %v", i, err)
+				}
+				if item == nil {
+					return nil, fmt.Errorf("item %d: arrays can't hold null items", i)
+				}
+				converted[i] = item
+			}
+			return converted, nil
+		}
+	}
+
+	return nil, fmt.Errorf("value %v is not a valid %v", describe(value), field.GetType())
+}
+
+// stringValue keeps strings as they are and writes anything else as JSON.
+func stringValue(value interface{}) (interface{}, error) {
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(bytes.TrimSpace(data)), nil
+}
+
+func describe(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
@@ -0,0 +1,50 @@
+package convert
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_convertValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		field    *models_v1.Field
+		value    interface{}
+		expected interface{}
+		err      bool
+	}{
+		{"nil", &models_v1.Field{Type: models_v1.Field_INTEGER}, nil, nil, false},
+		{"empty csv value", &models_v1.Field{Type: models_v1.Field_INTEGER}, " ", nil, false},
+		{"csv integer", &models_v1.Field{Type: models_v1.Field_INTEGER}, "42", int64(42), false},
+		{"json integer", &models_v1.Field{Type: models_v1.Field_INTEGER}, json.Number("42"), int64(42), false},
+		{"json fraction as integer", &models_v1.Field{Type: models_v1.Field_INTEGER}, json.Number("4.2"), nil, true},
+		{"csv number", &models_v1.Field{Type: models_v1.Field_NUMBER}, "4.2", 4.2, false},
+		{"csv boolean", &models_v1.Field{Type: models_v1.Field_BOOLEAN}, "TRUE", true, false},
+		{"json string as boolean", &models_v1.Field{Type: models_v1.Field_BOOLEAN}, json.Number("1"), nil, true},
+		{"csv timestamp", &models_v1.Field{Type: models_v1.Field_TIMESTAMP}, "2024-01-02", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), false},
+		{"string keeps whitespace", &models_v1.Field{Type: models_v1.Field_STRING}, " a ", " a ", false},
+		{"number as string", &models_v1.Field{Type: models_v1.Field_STRING}, json.Number("1.50"), "1.50", false},
+		{"object as string", &models_v1.Field{Type: models_v1.Field_STRING}, map[string]interface{}{"a": true}, "{\"a\":true}", false},
+		{"csv json array", &models_v1.Field{Type: models_v1.Field_ARRAY, Items: &models_v1.Field{Type: models_v1.Field_INTEGER}}, "[1, 2]", []interface{}{int64(1), int64(2)}, false},
+		{"csv invalid json", &models_v1.Field{Type: models_v1.Field_ARRAY, Items: &models_v1.Field{Type: models_v1.Field_INTEGER}}, "[1,", nil, true},
+		{"null array item", &models_v1.Field{Type: models_v1.Field_ARRAY, Items: &models_v1.Field{Type: models_v1.Field_INTEGER}}, []interface{}{nil}, nil, true},
+		{"array item", &models_v1.Field{Type: models_v1.Field_ARRAY, Items: &models_v1.Field{Type: models_v1.Field_INTEGER}}, []interface{}{"x"}, nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			value, err := convertValue(test.field, test.value)
+
+			if test.err {
+				assert.Error(t, err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, test.expected, value)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package dataset
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/codingexplorations/data-lake/pkg/quality"
+	"github.com/robfig/cron/v3"
+	"github.com/spf13/viper"
+)
+
+// DefaultName is the dataset of files that sit directly in the data folder.
+const DefaultName = "default"
+
+// Dataset groups the files under a location prefix that share a schema and its rules.
+type Dataset struct {
+	Name          string `mapstructure:"name"`
+	Prefix        string `mapstructure:"prefix"`
+	Compatibility string `mapstructure:"compatibility"`
+	// DescriptorSet and Message name the protobuf message every record of the dataset must be a valid instance of
+	DescriptorSet string `mapstructure:"descriptor_set"`
+	Message       string `mapstructure:"message"`
+	// Compression and RowGroupSize tune the parquet files the dataset's records are converted to
+	Compression  string `mapstructure:"compression"`
+	RowGroupSize int    `mapstructure:"row_group_size"`
+	// Expectations are checked against every new object, objects that fail them are quarantined rather than curated
+	// when Quarantine is set
+	Expectations []*quality.Expectation `mapstructure:"expectations"`
+	Quarantine   bool                   `mapstructure:"quarantine"`
+	// Arrival is when new objects of the dataset are due, the freshness monitor alerts when none arrive in time
+	Arrival *Arrival `mapstructure:"arrival"`
+}
+
+// Arrival is the schedule upstream delivers a dataset on:
+//
+//	arrival:
+//	  schedule: "0 6 * * *"
+//	  grace: 2h
+type Arrival struct {
+	// Schedule is a five field cron expression of when objects are due, in UTC unless it starts with CRON_TZ=
+	Schedule string `mapstructure:"schedule"`
+	// Grace is how long after it's due an object may still arrive before it's missing
+	Grace time.Duration `mapstructure:"grace"`
+}
+
+// Parse returns the schedule of the arrival.
+func (arrival *Arrival) Parse() (cron.Schedule, error) {
+	schedule, err := cron.ParseStandard(arrival.Schedule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arrival schedule %q: %v", arrival.Schedule, err)
+	}
+	return schedule, nil
+}
+
+// Datasets resolves object locations to the datasets defined in DATASETS_FILE. Locations that no definition
+// matches belong to a dataset named after their first folder, using the configured defaults.
+type Datasets struct {
+	conf     *config.Config
+	datasets []*Dataset
+}
+
+func NewDatasets(conf *config.Config, datasets []*Dataset) *Datasets {
+	for _, dataset := range datasets {
+		applyDefaults(conf, dataset)
+	}
+
+	return &Datasets{
+		conf:     conf,
+		datasets: datasets,
+	}
+}
+
+// Load reads the dataset definitions from the YAML file named by DATASETS_FILE, if one is configured.
+func Load(conf *config.Config) (*Datasets, error) {
+	datasets := make([]*Dataset, 0)
+
+	if conf.DatasetsFile != "" {
+		v := viper.New()
+		v.SetConfigFile(conf.DatasetsFile)
+
+		if err := v.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("error loading datasets file %v: %v", conf.DatasetsFile, err)
+		}
+
+		if err := v.UnmarshalKey("datasets", &datasets); err != nil {
+			return nil, fmt.Errorf("error parsing datasets file %v: %v", conf.DatasetsFile, err)
+		}
+	}
+
+	for _, dataset := range datasets {
+		if dataset.Name == "" {
+			return nil, fmt.Errorf("dataset in %v is missing a name", conf.DatasetsFile)
+		}
+		for _, expectation := range dataset.Expectations {
+			if err := expectation.Validate(); err != nil {
+				return nil, fmt.Errorf("dataset %v in %v: %v", dataset.Name, conf.DatasetsFile, err)
+			}
+		}
+		if dataset.Arrival != nil {
+			if _, err := dataset.Arrival.Parse(); err != nil {
+				return nil, fmt.Errorf("dataset %v in %v: %v", dataset.Name, conf.DatasetsFile, err)
+			}
+			if dataset.Arrival.Grace < 0 {
+				return nil, fmt.Errorf("dataset %v in %v: arrival grace can't be negative", dataset.Name, conf.DatasetsFile)
+			}
+		}
+	}
+
+	return NewDatasets(conf, datasets), nil
+}
+
+// Resolve returns the dataset of a location relative to the data folder or bucket, preferring the definition with
+// the longest matching prefix.
+func (datasets *Datasets) Resolve(location string) *Dataset {
+	location = strings.TrimPrefix(location, "/")
+
+	var resolved *Dataset
+	for _, dataset := range datasets.datasets {
+		if strings.HasPrefix(location, dataset.Prefix) && (resolved == nil || len(dataset.Prefix) > len(resolved.Prefix)) {
+			resolved = dataset
+		}
+	}
+	if resolved != nil {
+		return resolved
+	}
+
+	name := DefaultName
+	if i := strings.Index(location, "/"); i > 0 {
+		name = location[:i]
+	}
+
+	dataset := &Dataset{Name: name}
+	applyDefaults(datasets.conf, dataset)
+
+	return dataset
+}
+
+// Get returns the definition of a dataset by name, or nil when there isn't one.
+func (datasets *Datasets) Get(name string) *Dataset {
+	for _, dataset := range datasets.datasets {
+		if dataset.Name == name {
+			return dataset
+		}
+	}
+	return nil
+}
+
+// All returns the datasets defined in DATASETS_FILE.
+func (datasets *Datasets) All() []*Dataset {
+	return datasets.datasets
+}
+
+// Partition returns the folder of a location below the dataset's prefix, files are only ever compacted with files of
+// the same partition.
+func (dataset *Dataset) Partition(location string) string {
+	location = strings.TrimPrefix(strings.TrimPrefix(location, "/"), dataset.Prefix)
+	if i := strings.LastIndex(location, "/"); i >= 0 {
+		return location[:i]
+	}
+	return ""
+}
+
+func applyDefaults(conf *config.Config, dataset *Dataset) {
+	if dataset.Prefix == "" {
+		dataset.Prefix = dataset.Name + "/"
+	}
+	if dataset.Compatibility == "" {
+		dataset.Compatibility = conf.SchemaCompatibility
+	}
+	dataset.Compatibility = strings.ToUpper(dataset.Compatibility)
+	if dataset.DescriptorSet == "" {
+		dataset.DescriptorSet = conf.DescriptorSetFile
+	}
+	if dataset.Compression == "" {
+		dataset.Compression = conf.ParquetCompression
+	}
+	if dataset.RowGroupSize == 0 {
+		dataset.RowGroupSize = conf.ParquetRowGroupSize
+	}
+}
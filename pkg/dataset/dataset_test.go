@@ -0,0 +1,141 @@
+package dataset
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/codingexplorations/data-lake/pkg/quality"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	fileName := t.TempDir() + "/datasets.yaml"
+	_ = os.WriteFile(fileName, []byte(`
+datasets:
+  - name: orders
+    compatibility: full
+  - name: eu-orders
+    prefix: orders/eu/
+    compression: zstd
+    row_group_size: 500
+`), 0644)
+
+	datasets, err := Load(&config.Config{DatasetsFile: fileName, SchemaCompatibility: "BACKWARD", ParquetCompression: "SNAPPY", ParquetRowGroupSize: 1000})
+
+	assert.Nil(t, err)
+	assert.Equal(t, &Dataset{Name: "orders", Prefix: "orders/", Compatibility: "FULL", Compression: "SNAPPY", RowGroupSize: 1000}, datasets.Get("orders"))
+	assert.Equal(t, &Dataset{Name: "eu-orders", Prefix: "orders/eu/", Compatibility: "BACKWARD", Compression: "zstd", RowGroupSize: 500}, datasets.Get("eu-orders"))
+	assert.Nil(t, datasets.Get("missing"))
+}
+
+func TestLoad_Expectations(t *testing.T) {
+	fileName := t.TempDir() + "/datasets.yaml"
+	_ = os.WriteFile(fileName, []byte(`
+datasets:
+  - name: orders
+    quarantine: true
+    expectations:
+      - type: not_null
+        column: order_id
+      - type: between
+        column: amount
+        min: 0
+      - type: row_count_change
+        max_change: 0.2
+        window: 48h
+`), 0644)
+
+	datasets, err := Load(&config.Config{DatasetsFile: fileName})
+
+	assert.Nil(t, err)
+	orders := datasets.Get("orders")
+	assert.True(t, orders.Quarantine)
+	min := 0.0
+	assert.Equal(t, []*quality.Expectation{
+		{Type: quality.NotNull, Column: "order_id"},
+		{Type: quality.Between, Column: "amount", Min: &min},
+		{Type: quality.RowCountChange, MaxChange: 0.2, Window: 48 * time.Hour},
+	}, orders.Expectations)
+
+	_ = os.WriteFile(fileName, []byte("datasets:\n  - name: orders\n    expectations:\n      - type: not_null\n"), 0644)
+
+	_, err = Load(&config.Config{DatasetsFile: fileName})
+	assert.Equal(t, "dataset orders in "+fileName+": not_null expectation is missing a column", err.Error())
+}
+
+func TestLoad_Arrival(t *testing.T) {
+	fileName := t.TempDir() + "/datasets.yaml"
+	_ = os.WriteFile(fileName, []byte(`
+datasets:
+  - name: orders
+    arrival:
+      schedule: "0 6 * * *"
+      grace: 2h
+  - name: customers
+`), 0644)
+
+	datasets, err := Load(&config.Config{DatasetsFile: fileName})
+
+	assert.Nil(t, err)
+	assert.Equal(t, &Arrival{Schedule: "0 6 * * *", Grace: 2 * time.Hour}, datasets.Get("orders").Arrival)
+	assert.Nil(t, datasets.Get("customers").Arrival)
+	assert.Len(t, datasets.All(), 2)
+
+	schedule, err := datasets.Get("orders").Arrival.Parse()
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 5, 2, 6, 0, 0, 0, time.UTC), schedule.Next(time.Date(2024, 5, 1, 7, 0, 0, 0, time.UTC)))
+
+	_ = os.WriteFile(fileName, []byte("datasets:\n  - name: orders\n    arrival:\n      schedule: every day\n"), 0644)
+
+	_, err = Load(&config.Config{DatasetsFile: fileName})
+	assert.ErrorContains(t, err, "dataset orders in "+fileName+": invalid arrival schedule \"every day\"")
+}
+
+func TestLoad_Failure(t *testing.T) {
+	_, err := Load(&config.Config{DatasetsFile: "/tmp/should/not/be/there/datasets.yaml"})
+	assert.Error(t, err)
+
+	fileName := t.TempDir() + "/datasets.yaml"
+	_ = os.WriteFile(fileName, []byte("datasets:\n  - prefix: orders/\n"), 0644)
+
+	_, err = Load(&config.Config{DatasetsFile: fileName})
+	assert.Error(t, err)
+}
+
+func TestDatasets_Resolve(t *testing.T) {
+	datasets := NewDatasets(&config.Config{SchemaCompatibility: "forward"}, []*Dataset{
+		{Name: "orders"},
+		{Name: "eu-orders", Prefix: "orders/eu/"},
+	})
+
+	tests := []struct {
+		name     string
+		location string
+		expected string
+	}{
+		{name: "defined", location: "/orders/2024/01.csv", expected: "orders"},
+		{name: "longest prefix", location: "orders/eu/01.csv", expected: "eu-orders"},
+		{name: "first folder", location: "customers/01.csv", expected: "customers"},
+		{name: "data folder root", location: "/01.csv", expected: DefaultName},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dataset := datasets.Resolve(tc.location)
+
+			assert.Equal(t, tc.expected, dataset.Name)
+			assert.Equal(t, "FORWARD", dataset.Compatibility)
+		})
+	}
+}
+
+func TestDataset_Partition(t *testing.T) {
+	dataset := &Dataset{Name: "orders", Prefix: "orders/"}
+
+	assert.Equal(t, "2024/01", dataset.Partition("/orders/2024/01/a.csv"))
+	assert.Equal(t, "region=eu", dataset.Partition("orders/region=eu/a.csv"))
+	assert.Equal(t, "", dataset.Partition("orders/a.csv"))
+	assert.Equal(t, "", dataset.Partition("a.csv"))
+}
@@ -0,0 +1,108 @@
+package events
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	cloudevents "github.com/cloudevents/sdk-go/v2/event"
+	"github.com/codingexplorations/data-lake/pkg/aws"
+	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/codingexplorations/data-lake/pkg/tracing"
+)
+
+// SqsPublisher sends every event to the queue named by AWS_INGEST_EVENT_QUEUE_NAME. In binary mode the attributes of
+// an event go in ce- prefixed message attributes and its data, base64 encoded since message bodies are text, in the
+// body. The data is always DataContentType, so it goes without a content-type attribute, keeping events with every
+// attribute and extension within the 10 message attributes SQS takes. In structured mode the trace context of the
+// event goes in traceparent and tracestate message attributes.
+type SqsPublisher struct {
+	sqs      aws.SqsClient
+	queueUrl *string
+	mode     string
+}
+
+func NewSqsPublisher(conf *config.Config, mode string) (*SqsPublisher, error) {
+	if conf.AwsIngestEventQueueName == "" {
+		return nil, fmt.Errorf("sqs sink needs AWS_INGEST_EVENT_QUEUE_NAME")
+	}
+
+	sqs, err := aws.NewSqs()
+	if err != nil {
+		return nil, err
+	}
+
+	queueUrl, err := sqs.GetQueueUrl(conf.AwsIngestEventQueueName)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get the url of queue %v: %v", conf.AwsIngestEventQueueName, err)
+	}
+
+	return &SqsPublisher{sqs: sqs, queueUrl: queueUrl.QueueUrl, mode: mode}, nil
+}
+
+func (publisher *SqsPublisher) Publish(event cloudevents.Event) error {
+	body, attributes, err := publisher.message(event)
+	if err != nil {
+		return err
+	}
+
+	if _, err := publisher.sqs.SendMessage(publisher.queueUrl, body, attributes); err != nil {
+		return fmt.Errorf("couldn't send event %v to queue: %v", event.ID(), err)
+	}
+
+	return nil
+}
+
+func (publisher *SqsPublisher) message(event cloudevents.Event) (string, map[string]types.MessageAttributeValue, error) {
+	if publisher.mode != Binary {
+		body, err := structured(event)
+		return string(body), tracing.Inject(TraceContext(context.Background(), event), nil), err
+	}
+
+	binary, err := binaryAttributes(event)
+	if err != nil {
+		return "", nil, err
+	}
+
+	attributes := make(map[string]types.MessageAttributeValue, len(binary))
+	for name, value := range binary {
+		attributes["ce-"+name] = types.MessageAttributeValue{DataType: awsSdk.String("String"), StringValue: awsSdk.String(value)}
+	}
+
+	return base64.StdEncoding.EncodeToString(event.Data()), attributes, nil
+}
+
+// SnsPublisher publishes every event as a structured CloudEvent to the topic AWS_INGEST_EVENT_TOPIC_ARN, on SNS or any
+// service compatible with it that AWS_ENDPOINT_URL points to.
+type SnsPublisher struct {
+	sns      aws.SnsClient
+	topicArn string
+}
+
+func NewSnsPublisher(conf *config.Config) (*SnsPublisher, error) {
+	if conf.AwsIngestEventTopicArn == "" {
+		return nil, fmt.Errorf("sns sink needs AWS_INGEST_EVENT_TOPIC_ARN")
+	}
+
+	sns, err := aws.NewSns()
+	if err != nil {
+		return nil, err
+	}
+
+	return &SnsPublisher{sns: sns, topicArn: conf.AwsIngestEventTopicArn}, nil
+}
+
+func (publisher *SnsPublisher) Publish(event cloudevents.Event) error {
+	body, err := structured(event)
+	if err != nil {
+		return err
+	}
+
+	if _, err := publisher.sns.Publish(publisher.topicArn, string(body)); err != nil {
+		return fmt.Errorf("couldn't publish event %v to topic: %v", event.ID(), err)
+	}
+
+	return nil
+}
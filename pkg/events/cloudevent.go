@@ -0,0 +1,238 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/types"
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/catalog"
+	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/protobuf/proto"
+)
+
+// TypePrefix namespaces the types of the events the lake emits.
+const TypePrefix = "com.github.codingexplorations.datalake."
+
+// The types of the events the lake emits.
+const (
+	ObjectIngested    = TypePrefix + "object.ingested"
+	ObjectFailed      = TypePrefix + "object.failed"
+	ObjectRejected    = TypePrefix + "object.rejected"
+	ObjectQuarantined = TypePrefix + "object.quarantined"
+	SchemaChanged     = TypePrefix + "schema.changed"
+	FileDeleted       = TypePrefix + "file.deleted"
+)
+
+// DataContentType is the content type of the data of every event, the protobuf encoding of the models_v1 message
+// whose type URL the dataschema attribute holds.
+const DataContentType = "application/protobuf"
+
+// SchemaPrefix is prepended to the full name of the models_v1 message an event carries to make its dataschema, the
+// type URL protobuf uses for Any messages made an absolute URI.
+const SchemaPrefix = "https://type.googleapis.com/"
+
+// DatasetExtension is the extension attribute naming the dataset an event is about, for routers to filter on.
+const DatasetExtension = "dataset"
+
+// The extension attributes of the CloudEvents distributed tracing extension, holding the W3C trace context of what an
+// event tells about.
+const (
+	TraceParentExtension = "traceparent"
+	TraceStateExtension  = "tracestate"
+)
+
+// NewEvent wraps a message in a CloudEvents 1.0 event.
+func NewEvent(source string, id string, eventType string, subject string, dataset string, message proto.Message) (cloudevents.Event, error) {
+	data, err := proto.Marshal(message)
+	if err != nil {
+		return cloudevents.Event{}, fmt.Errorf("couldn't encode %v event %v: %v", eventType, id, err)
+	}
+
+	event := cloudevents.New(cloudevents.CloudEventsVersionV1)
+	event.SetID(id)
+	event.SetSource(source)
+	event.SetType(eventType)
+	event.SetSubject(subject)
+	event.SetTime(time.Now().UTC())
+	event.SetDataSchema(SchemaPrefix + string(message.ProtoReflect().Descriptor().FullName()))
+	if dataset != "" {
+		event.SetExtension(DatasetExtension, dataset)
+	}
+	if err := event.SetData(DataContentType, data); err != nil {
+		return cloudevents.Event{}, err
+	}
+
+	if err := event.Validate(); err != nil {
+		return cloudevents.Event{}, fmt.Errorf("invalid %v event %v: %v", eventType, id, err)
+	}
+
+	return event, nil
+}
+
+// WithTraceContext returns the event carrying the trace context of the span in ctx. It goes along with the event through
+// the outbox, so consumers continue the trace however late the event is delivered.
+func WithTraceContext(ctx context.Context, event cloudevents.Event) cloudevents.Event {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	for _, name := range []string{TraceParentExtension, TraceStateExtension} {
+		if value, ok := carrier[name]; ok {
+			event.SetExtension(name, value)
+		}
+	}
+	return event
+}
+
+// TraceContext returns ctx holding the trace context an event carries.
+func TraceContext(ctx context.Context, event cloudevents.Event) context.Context {
+	carrier := propagation.MapCarrier{}
+	for _, name := range []string{TraceParentExtension, TraceStateExtension} {
+		if value, ok := event.Extensions()[name].(string); ok {
+			carrier[name] = value
+		}
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
+
+// Emitter wraps what happens in the lake in CloudEvents and hands them to a publisher.
+type Emitter struct {
+	source    string
+	publisher Publisher
+}
+
+func NewEmitter(source string, publisher Publisher) *Emitter {
+	return &Emitter{source: source, publisher: publisher}
+}
+
+// GetEmitter returns an emitter of events from EVENT_SOURCE to the sinks named in INGEST_EVENT_SINKS. Given a catalog
+// the events go to the outbox of their dataset in it, for the relay to deliver.
+func GetEmitter(conf *config.Config, cat catalog.Catalog) (*Emitter, error) {
+	publishers, err := GetPublisher(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	// without any sinks there is nothing to relay events to
+	if cat != nil && len(publishers) > 0 {
+		return NewEmitter(conf.EventSource, NewOutbox(cat)), nil
+	}
+
+	return NewEmitter(conf.EventSource, publishers), nil
+}
+
+// NewIngested returns the event of what became of an object, its id is the id of the ingest event so consumers can
+// drop duplicates.
+func (emitter *Emitter) NewIngested(ingest *models_v1.IngestEvent) (cloudevents.Event, error) {
+	eventType := ObjectIngested
+	switch ingest.Status {
+	case models_v1.IngestEvent_FAILED:
+		eventType = ObjectFailed
+	case models_v1.IngestEvent_REJECTED:
+		eventType = ObjectRejected
+	case models_v1.IngestEvent_QUARANTINED:
+		eventType = ObjectQuarantined
+	}
+
+	return NewEvent(emitter.source, ingest.Id, eventType, ingest.Location, ingest.Dataset, ingest)
+}
+
+// NewSchemaChanged returns the event of a dataset registering a new version of its schema.
+func (emitter *Emitter) NewSchemaChanged(change *models_v1.SchemaChange) (cloudevents.Event, error) {
+	return NewEvent(emitter.source, uuid.NewString(), SchemaChanged, change.Dataset, change.Dataset, change)
+}
+
+// NewDeleted returns the event of a file being deleted from the lake.
+func (emitter *Emitter) NewDeleted(deletion *models_v1.Deletion) (cloudevents.Event, error) {
+	return NewEvent(emitter.source, uuid.NewString(), FileDeleted, deletion.Location, deletion.Dataset, deletion)
+}
+
+// Ingested emits what became of an object.
+func (emitter *Emitter) Ingested(ingest *models_v1.IngestEvent) error {
+	event, err := emitter.NewIngested(ingest)
+	if err != nil {
+		return err
+	}
+	return emitter.Emit(event)
+}
+
+// SchemaChanged emits that a dataset registered a new version of its schema.
+func (emitter *Emitter) SchemaChanged(change *models_v1.SchemaChange) error {
+	event, err := emitter.NewSchemaChanged(change)
+	if err != nil {
+		return err
+	}
+	return emitter.Emit(event)
+}
+
+// Deleted emits that a file was deleted from the lake.
+func (emitter *Emitter) Deleted(deletion *models_v1.Deletion) error {
+	event, err := emitter.NewDeleted(deletion)
+	if err != nil {
+		return err
+	}
+	return emitter.Emit(event)
+}
+
+// Emit hands events to the publisher.
+func (emitter *Emitter) Emit(events ...cloudevents.Event) error {
+	errs := make([]error, 0)
+	for _, event := range events {
+		if err := emitter.publisher.Publish(event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Stage returns events as outbox entries to commit to the catalog together with the changes they tell about. Without
+// an outbox it returns nil, and the events are for the caller to emit once the changes are committed.
+func (emitter *Emitter) Stage(events ...cloudevents.Event) ([]*models_v1.OutboxEvent, error) {
+	if _, ok := emitter.publisher.(*Outbox); !ok {
+		return nil, nil
+	}
+
+	outbox := make([]*models_v1.OutboxEvent, 0, len(events))
+	for _, event := range events {
+		entry, err := NewOutboxEvent(event)
+		if err != nil {
+			return nil, err
+		}
+		outbox = append(outbox, entry)
+	}
+
+	return outbox, nil
+}
+
+// binaryAttributes returns the context attributes of an event as they're sent in binary mode, by name without the
+// ce- prefix. The data content type is left out, it's sent as the content type of the message.
+func binaryAttributes(event cloudevents.Event) (map[string]string, error) {
+	attributes := map[string]string{
+		"specversion": event.SpecVersion(),
+		"id":          event.ID(),
+		"source":      event.Source(),
+		"type":        event.Type(),
+		"time":        types.FormatTime(event.Time()),
+	}
+	if event.Subject() != "" {
+		attributes["subject"] = event.Subject()
+	}
+	if event.DataSchema() != "" {
+		attributes["dataschema"] = event.DataSchema()
+	}
+
+	for name, value := range event.Extensions() {
+		formatted, err := types.Format(value)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't format extension %v of event %v: %v", name, event.ID(), err)
+		}
+		attributes[name] = formatted
+	}
+
+	return attributes, nil
+}
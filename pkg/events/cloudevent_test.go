@@ -0,0 +1,170 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2/event"
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	eventsMocks "github.com/codingexplorations/data-lake/test/mocks/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+var ingest = &models_v1.IngestEvent{
+	Id:       "0b7c9f3e-8a51-4c1e-9d0e-2f4a6b8c1d3e",
+	Location: "orders/2024/01.csv",
+	Dataset:  "orders",
+	Status:   models_v1.IngestEvent_SUCCEEDED,
+	Checksum: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+}
+
+func newEvent(t *testing.T) cloudevents.Event {
+	event, err := NewEvent("/data-lake", ingest.Id, ObjectIngested, ingest.Location, ingest.Dataset, ingest)
+	assert.Nil(t, err)
+	return event
+}
+
+// traced returns a context holding a sampled span, with the global propagator set to W3C trace context.
+func traced() (context.Context, trace.SpanContext) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	span := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+	})
+	return trace.ContextWithSpanContext(context.Background(), span), span
+}
+
+func TestNewEvent(t *testing.T) {
+	event := newEvent(t)
+
+	assert.Equal(t, "1.0", event.SpecVersion())
+	assert.Equal(t, ingest.Id, event.ID())
+	assert.Equal(t, "/data-lake", event.Source())
+	assert.Equal(t, "com.github.codingexplorations.datalake.object.ingested", event.Type())
+	assert.Equal(t, "orders/2024/01.csv", event.Subject())
+	assert.Equal(t, "https://type.googleapis.com/models.v1.IngestEvent", event.DataSchema())
+	assert.Equal(t, "application/protobuf", event.DataContentType())
+	assert.Equal(t, "orders", event.Extensions()[DatasetExtension])
+	assert.False(t, event.Time().IsZero())
+
+	decoded := &models_v1.IngestEvent{}
+	assert.Nil(t, proto.Unmarshal(event.Data(), decoded))
+	assert.True(t, proto.Equal(ingest, decoded))
+}
+
+func TestNewEvent_Structured(t *testing.T) {
+	body, err := structured(newEvent(t))
+	assert.Nil(t, err)
+
+	var document map[string]interface{}
+	assert.Nil(t, json.Unmarshal(body, &document))
+	assert.Equal(t, "1.0", document["specversion"])
+	assert.Equal(t, ingest.Id, document["id"])
+	assert.Equal(t, "orders", document["dataset"])
+	assert.NotEmpty(t, document["data_base64"])
+	assert.Nil(t, document["data"])
+
+	// consumers in any language read the event back with a CloudEvents SDK
+	decoded := cloudevents.New()
+	assert.Nil(t, json.Unmarshal(body, &decoded))
+	data := &models_v1.IngestEvent{}
+	assert.Nil(t, proto.Unmarshal(decoded.Data(), data))
+	assert.True(t, proto.Equal(ingest, data))
+}
+
+func TestBinaryAttributes(t *testing.T) {
+	event := newEvent(t)
+
+	attributes, err := binaryAttributes(event)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"specversion": "1.0",
+		"id":          ingest.Id,
+		"source":      "/data-lake",
+		"type":        ObjectIngested,
+		"subject":     "orders/2024/01.csv",
+		"time":        event.Time().Format("2006-01-02T15:04:05.999999999Z07:00"),
+		"dataschema":  "https://type.googleapis.com/models.v1.IngestEvent",
+		"dataset":     "orders",
+	}, attributes)
+}
+
+func TestEmitter(t *testing.T) {
+	tests := []struct {
+		name         string
+		emit         func(emitter *Emitter) error
+		expectedType string
+		subject      string
+	}{
+		{"ingested", func(emitter *Emitter) error { return emitter.Ingested(ingest) }, ObjectIngested, "orders/2024/01.csv"},
+		{"failed", func(emitter *Emitter) error {
+			return emitter.Ingested(&models_v1.IngestEvent{Id: "1", Location: "orders/2024/02.csv", Status: models_v1.IngestEvent_FAILED})
+		}, ObjectFailed, "orders/2024/02.csv"},
+		{"rejected", func(emitter *Emitter) error {
+			return emitter.Ingested(&models_v1.IngestEvent{Id: "2", Location: "orders/2024/03.csv", Status: models_v1.IngestEvent_REJECTED})
+		}, ObjectRejected, "orders/2024/03.csv"},
+		{"quarantined", func(emitter *Emitter) error {
+			return emitter.Ingested(&models_v1.IngestEvent{Id: "3", Location: "orders/2024/04.csv", Status: models_v1.IngestEvent_QUARANTINED})
+		}, ObjectQuarantined, "orders/2024/04.csv"},
+		{"schema changed", func(emitter *Emitter) error {
+			return emitter.SchemaChanged(&models_v1.SchemaChange{Dataset: "orders", Schema: &models_v1.Schema{Version: 2}})
+		}, SchemaChanged, "orders"},
+		{"deleted", func(emitter *Emitter) error {
+			return emitter.Deleted(&models_v1.Deletion{Dataset: "orders", Location: "curated/orders/2024/01.parquet", Reason: "compacted"})
+		}, FileDeleted, "curated/orders/2024/01.parquet"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			publisher := eventsMocks.NewPublisher(t)
+			publisher.On("Publish", mock.MatchedBy(func(event cloudevents.Event) bool {
+				return event.Type() == test.expectedType && event.Subject() == test.subject && event.Source() == "/data-lake"
+			})).Return(nil).Once()
+
+			assert.Nil(t, test.emit(NewEmitter("/data-lake", publisher)))
+		})
+	}
+}
+
+func TestEmitter_Failure(t *testing.T) {
+	publisher := eventsMocks.NewPublisher(t)
+	publisher.On("Publish", mock.Anything).Return(errors.New("queue gone"))
+
+	err := NewEmitter("/data-lake", publisher).Ingested(ingest)
+	assert.Equal(t, "queue gone", err.Error())
+
+	// events without a source aren't valid CloudEvents
+	err = NewEmitter("", publisher).Ingested(ingest)
+	assert.ErrorContains(t, err, "invalid "+ObjectIngested+" event "+ingest.Id)
+}
+
+func TestTraceContext(t *testing.T) {
+	ctx, span := traced()
+
+	event := WithTraceContext(ctx, newEvent(t))
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", event.Extensions()[TraceParentExtension])
+	assert.Nil(t, event.Extensions()[TraceStateExtension])
+
+	// the trace context goes along through the outbox
+	entry, err := NewOutboxEvent(event)
+	assert.Nil(t, err)
+	parsed, err := ParseOutboxEvent(entry)
+	assert.Nil(t, err)
+	extracted := trace.SpanContextFromContext(TraceContext(context.Background(), parsed))
+	assert.Equal(t, span.TraceID(), extracted.TraceID())
+	assert.Equal(t, span.SpanID(), extracted.SpanID())
+	assert.True(t, extracted.IsRemote())
+
+	// events emitted outside of a trace carry none
+	assert.Equal(t, event.Extensions()[DatasetExtension], WithTraceContext(context.Background(), newEvent(t)).Extensions()[DatasetExtension])
+	assert.Nil(t, WithTraceContext(context.Background(), newEvent(t)).Extensions()[TraceParentExtension])
+	assert.False(t, trace.SpanContextFromContext(TraceContext(context.Background(), newEvent(t))).IsValid())
+}
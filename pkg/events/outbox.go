@@ -0,0 +1,59 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/types"
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/catalog"
+)
+
+// Outbox commits events to the outbox of their dataset in the catalog instead of delivering them, the relay delivers
+// them from there. Events that tell about changes of the files of a dataset are better committed together with the
+// changes, see Emitter.Stage.
+type Outbox struct {
+	catalog catalog.Catalog
+}
+
+func NewOutbox(catalog catalog.Catalog) *Outbox {
+	return &Outbox{catalog: catalog}
+}
+
+func (outbox *Outbox) Publish(event cloudevents.Event) error {
+	dataset, err := types.ToString(event.Extensions()[DatasetExtension])
+	if err != nil || dataset == "" {
+		return fmt.Errorf("event %v names no dataset to enqueue it to", event.ID())
+	}
+
+	entry, err := NewOutboxEvent(event)
+	if err != nil {
+		return err
+	}
+
+	if err := outbox.catalog.Enqueue(dataset, entry); err != nil {
+		return fmt.Errorf("couldn't enqueue event %v to the outbox of dataset %v: %v", event.ID(), dataset, err)
+	}
+
+	return nil
+}
+
+// NewOutboxEvent encodes an event for the outbox, keyed by its id.
+func NewOutboxEvent(event cloudevents.Event) (*models_v1.OutboxEvent, error) {
+	body, err := structured(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models_v1.OutboxEvent{Id: event.ID(), Timestamp: event.Time().UnixMilli(), Event: body}, nil
+}
+
+// ParseOutboxEvent decodes the event of an outbox entry.
+func ParseOutboxEvent(entry *models_v1.OutboxEvent) (cloudevents.Event, error) {
+	event := cloudevents.New()
+	if err := json.Unmarshal(entry.Event, &event); err != nil {
+		return cloudevents.Event{}, fmt.Errorf("couldn't decode outbox event %v: %v", entry.Id, err)
+	}
+	return event, nil
+}
@@ -0,0 +1,87 @@
+package events
+
+import (
+	"testing"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/catalog"
+	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/codingexplorations/data-lake/pkg/storage"
+	eventsMocks "github.com/codingexplorations/data-lake/test/mocks/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestOutboxEvent(t *testing.T) {
+	event := newEvent(t)
+
+	entry, err := NewOutboxEvent(event)
+	assert.Nil(t, err)
+	assert.Equal(t, ingest.Id, entry.Id)
+	assert.Equal(t, event.Time().UnixMilli(), entry.Timestamp)
+
+	parsed, err := ParseOutboxEvent(entry)
+	assert.Nil(t, err)
+	assert.Equal(t, event.ID(), parsed.ID())
+	assert.Equal(t, event.Type(), parsed.Type())
+	assert.Equal(t, "orders", parsed.Extensions()[DatasetExtension])
+	decoded := &models_v1.IngestEvent{}
+	assert.Nil(t, proto.Unmarshal(parsed.Data(), decoded))
+	assert.True(t, proto.Equal(ingest, decoded))
+
+	_, err = ParseOutboxEvent(&models_v1.OutboxEvent{Id: "1", Event: []byte("{")})
+	assert.ErrorContains(t, err, "couldn't decode outbox event 1")
+}
+
+func TestOutbox_Publish(t *testing.T) {
+	tableCatalog := catalog.NewTableCatalog(storage.NewLocalStorage(), t.TempDir())
+
+	assert.Nil(t, NewOutbox(tableCatalog).Publish(newEvent(t)))
+
+	outbox, err := tableCatalog.Outbox("orders")
+	assert.Nil(t, err)
+	assert.Len(t, outbox, 1)
+	assert.Equal(t, ingest.Id, outbox[0].Id)
+
+	event, _ := NewEvent("/data-lake", "1", ObjectFailed, "test.txt", "", ingest)
+	err = NewOutbox(tableCatalog).Publish(event)
+	assert.Equal(t, "event 1 names no dataset to enqueue it to", err.Error())
+}
+
+func TestEmitter_Stage(t *testing.T) {
+	event := newEvent(t)
+
+	// without an outbox events are emitted once the changes are committed
+	outbox, err := NewEmitter("/data-lake", eventsMocks.NewPublisher(t)).Stage(event)
+	assert.Nil(t, err)
+	assert.Nil(t, outbox)
+
+	tableCatalog := catalog.NewTableCatalog(storage.NewLocalStorage(), t.TempDir())
+	outbox, err = NewEmitter("/data-lake", NewOutbox(tableCatalog)).Stage(event)
+	assert.Nil(t, err)
+	assert.Len(t, outbox, 1)
+	assert.Equal(t, event.ID(), outbox[0].Id)
+
+	// staging doesn't commit anything, the caller commits the entries with its changes
+	pending, _ := tableCatalog.Outbox("orders")
+	assert.Empty(t, pending)
+}
+
+func TestGetEmitter(t *testing.T) {
+	tableCatalog := catalog.NewTableCatalog(storage.NewLocalStorage(), t.TempDir())
+	conf := &config.Config{EventSource: "/data-lake", IngestEventSinks: "webhook", IngestEventWebhookUrl: "http://localhost/events"}
+
+	emitter, err := GetEmitter(conf, tableCatalog)
+	assert.Nil(t, err)
+	assert.Equal(t, NewEmitter("/data-lake", NewOutbox(tableCatalog)), emitter)
+
+	// without a catalog events are published right away
+	emitter, err = GetEmitter(conf, nil)
+	assert.Nil(t, err)
+	assert.IsType(t, Publishers{}, emitter.publisher)
+
+	// without sinks there is nothing to fill an outbox for
+	emitter, err = GetEmitter(&config.Config{EventSource: "/data-lake"}, tableCatalog)
+	assert.Nil(t, err)
+	assert.Equal(t, NewEmitter("/data-lake", Publishers{}), emitter)
+}
@@ -0,0 +1,85 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2/event"
+	"github.com/codingexplorations/data-lake/pkg/config"
+)
+
+// The content modes events are sent in. Structured events are a CloudEvents JSON document, binary events carry their
+// attributes next to the data, as message attributes or headers.
+const (
+	Structured = "structured"
+	Binary     = "binary"
+)
+
+// Publisher delivers events to a sink downstream systems listen on.
+type Publisher interface {
+	Publish(event cloudevents.Event) error
+}
+
+// GetPublisher returns the publishers named in INGEST_EVENT_SINKS, a comma separated list of sqs, sns and webhook,
+// sending events in the EVENT_MODE content mode. Without any sinks events are dropped.
+func GetPublisher(conf *config.Config) (Publishers, error) {
+	mode := strings.ToLower(conf.EventMode)
+	if mode == "" {
+		mode = Structured
+	}
+	if mode != Structured && mode != Binary {
+		return nil, fmt.Errorf("unknown event mode %q", conf.EventMode)
+	}
+
+	publishers := make(Publishers, 0)
+
+	for _, name := range strings.Split(conf.IngestEventSinks, ",") {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "":
+		case "sqs":
+			sqsPublisher, err := NewSqsPublisher(conf, mode)
+			if err != nil {
+				return nil, err
+			}
+			publishers = append(publishers, sqsPublisher)
+		case "sns":
+			snsPublisher, err := NewSnsPublisher(conf)
+			if err != nil {
+				return nil, err
+			}
+			publishers = append(publishers, snsPublisher)
+		case "webhook":
+			if conf.IngestEventWebhookUrl == "" {
+				return nil, fmt.Errorf("webhook sink needs INGEST_EVENT_WEBHOOK_URL")
+			}
+			publishers = append(publishers, NewWebhookPublisher(conf.IngestEventWebhookUrl, conf.IngestEventWebhookSecret, conf.IngestEventWebhookRetries, mode))
+		default:
+			return nil, fmt.Errorf("unknown ingest event sink %q", name)
+		}
+	}
+
+	return publishers, nil
+}
+
+// Publishers delivers every event to each of its publishers, even when some of them fail.
+type Publishers []Publisher
+
+func (publishers Publishers) Publish(event cloudevents.Event) error {
+	errs := make([]error, 0)
+	for _, publisher := range publishers {
+		if err := publisher.Publish(event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// structured encodes an event as a CloudEvents JSON document, its protobuf data as data_base64.
+func structured(event cloudevents.Event) ([]byte, error) {
+	data, err := event.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't encode event %v: %v", event.ID(), err)
+	}
+	return data, nil
+}
@@ -0,0 +1,130 @@
+package events
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/codingexplorations/data-lake/pkg/config"
+	awsMocks "github.com/codingexplorations/data-lake/test/mocks/pkg/aws"
+	eventsMocks "github.com/codingexplorations/data-lake/test/mocks/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSqsPublisher_Publish(t *testing.T) {
+	event := newEvent(t)
+	sqsClient := awsMocks.NewSqsClient(t)
+	queueUrl := aws.String("http://localhost:4566/000000000000/ingest-events")
+	body, _ := structured(event)
+	sqsClient.On("SendMessage", queueUrl, string(body), map[string]types.MessageAttributeValue(nil)).Return(&sqs.SendMessageOutput{}, nil).Once()
+
+	publisher := &SqsPublisher{sqs: sqsClient, queueUrl: queueUrl, mode: Structured}
+	assert.Nil(t, publisher.Publish(event))
+
+	sqsClient.On("SendMessage", queueUrl, string(body), map[string]types.MessageAttributeValue(nil)).Return(nil, errors.New("queue gone")).Once()
+	assert.Equal(t, "couldn't send event "+event.ID()+" to queue: queue gone", publisher.Publish(event).Error())
+}
+
+func TestSqsPublisher_Publish_Binary(t *testing.T) {
+	event := newEvent(t)
+	sqsClient := awsMocks.NewSqsClient(t)
+	queueUrl := aws.String("http://localhost:4566/000000000000/ingest-events")
+
+	var attributes map[string]types.MessageAttributeValue
+	sqsClient.On("SendMessage", queueUrl, base64.StdEncoding.EncodeToString(event.Data()), mock.Anything).Run(func(args mock.Arguments) {
+		attributes = args.Get(2).(map[string]types.MessageAttributeValue)
+	}).Return(&sqs.SendMessageOutput{}, nil).Once()
+
+	publisher := &SqsPublisher{sqs: sqsClient, queueUrl: queueUrl, mode: Binary}
+	assert.Nil(t, publisher.Publish(event))
+
+	// SQS takes at most 10 message attributes
+	assert.LessOrEqual(t, len(attributes), 10)
+	assert.Equal(t, "1.0", *attributes["ce-specversion"].StringValue)
+	assert.Equal(t, event.ID(), *attributes["ce-id"].StringValue)
+	assert.Equal(t, ObjectIngested, *attributes["ce-type"].StringValue)
+	assert.Equal(t, "orders", *attributes["ce-dataset"].StringValue)
+	assert.Equal(t, "String", *attributes["ce-source"].DataType)
+}
+
+func TestSqsPublisher_Publish_Traced(t *testing.T) {
+	ctx, _ := traced()
+	event := WithTraceContext(ctx, newEvent(t))
+	sqsClient := awsMocks.NewSqsClient(t)
+	queueUrl := aws.String("http://localhost:4566/000000000000/ingest-events")
+
+	var attributes map[string]types.MessageAttributeValue
+	sqsClient.On("SendMessage", queueUrl, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		attributes = args.Get(2).(map[string]types.MessageAttributeValue)
+	}).Return(&sqs.SendMessageOutput{}, nil)
+
+	// structured events carry the trace context in message attributes of their own
+	publisher := &SqsPublisher{sqs: sqsClient, queueUrl: queueUrl, mode: Structured}
+	assert.Nil(t, publisher.Publish(event))
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", *attributes["traceparent"].StringValue)
+
+	// binary events carry it as the extension it is
+	publisher.mode = Binary
+	assert.Nil(t, publisher.Publish(event))
+	assert.LessOrEqual(t, len(attributes), 10)
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", *attributes["ce-traceparent"].StringValue)
+
+	// an event with every attribute and extension set still fits in the 10 message attributes SQS takes
+	event.SetExtension(TraceStateExtension, "vendor=value")
+	assert.NotEmpty(t, event.Subject())
+	assert.NotEmpty(t, event.DataSchema())
+	assert.Nil(t, publisher.Publish(event))
+	assert.Len(t, attributes, 10)
+	assert.Equal(t, "vendor=value", *attributes["ce-tracestate"].StringValue)
+}
+
+func TestSnsPublisher_Publish(t *testing.T) {
+	event := newEvent(t)
+	snsClient := awsMocks.NewSnsClient(t)
+	topicArn := "arn:aws:sns:us-east-1:000000000000:ingest-events"
+	body, _ := structured(event)
+	snsClient.On("Publish", topicArn, string(body)).Return(&sns.PublishOutput{}, nil).Once()
+
+	publisher := &SnsPublisher{sns: snsClient, topicArn: topicArn}
+	assert.Nil(t, publisher.Publish(event))
+
+	snsClient.On("Publish", topicArn, string(body)).Return(nil, errors.New("topic gone")).Once()
+	assert.Equal(t, "couldn't publish event "+event.ID()+" to topic: topic gone", publisher.Publish(event).Error())
+}
+
+func TestPublishers_Publish(t *testing.T) {
+	event := newEvent(t)
+	failing, working := eventsMocks.NewPublisher(t), eventsMocks.NewPublisher(t)
+	failing.On("Publish", event).Return(errors.New("webhook down"))
+	working.On("Publish", event).Return(nil)
+
+	err := Publishers{failing, working}.Publish(event)
+	assert.Equal(t, "webhook down", err.Error())
+}
+
+func TestGetPublisher(t *testing.T) {
+	publisher, err := GetPublisher(&config.Config{})
+	assert.Nil(t, err)
+	assert.Equal(t, Publishers{}, publisher)
+
+	publisher, err = GetPublisher(&config.Config{IngestEventSinks: "webhook", IngestEventWebhookUrl: "http://localhost/events", IngestEventWebhookSecret: "secret", IngestEventWebhookRetries: 2, EventMode: "BINARY"})
+	assert.Nil(t, err)
+	assert.Equal(t, Publishers{NewWebhookPublisher("http://localhost/events", "secret", 2, Binary)}, publisher)
+
+	_, err = GetPublisher(&config.Config{IngestEventSinks: "webhook"})
+	assert.Equal(t, "webhook sink needs INGEST_EVENT_WEBHOOK_URL", err.Error())
+
+	_, err = GetPublisher(&config.Config{IngestEventSinks: "sqs"})
+	assert.Equal(t, "sqs sink needs AWS_INGEST_EVENT_QUEUE_NAME", err.Error())
+
+	_, err = GetPublisher(&config.Config{IngestEventSinks: "kafka"})
+	assert.Equal(t, "unknown ingest event sink \"kafka\"", err.Error())
+
+	_, err = GetPublisher(&config.Config{EventMode: "batched"})
+	assert.Equal(t, "unknown event mode \"batched\"", err.Error())
+}
@@ -0,0 +1,113 @@
+package events
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/codingexplorations/data-lake/pkg/catalog"
+	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/codingexplorations/data-lake/pkg/log"
+)
+
+type Relay interface {
+	// Relay delivers the events waiting in the outbox of every dataset, returning how many it delivered
+	Relay() (int, error)
+}
+
+// RelayImpl delivers the events in the outboxes of the catalog to the sinks named in INGEST_EVENT_SINKS. An event is
+// only taken out of its outbox once it was delivered, so an event whose delivery was cut short by a crash is delivered
+// again on the next run: delivery is at least once, and consumers drop duplicates by the id of the event, which stays
+// the same across deliveries. The events of a dataset are delivered in the order they were committed.
+type RelayImpl struct {
+	logger    log.Logger
+	catalog   catalog.Catalog
+	publisher Publisher
+
+	lock sync.Mutex
+}
+
+// NewRelay returns the relay of the configured catalog, or nil when there are no sinks to deliver events to.
+func NewRelay(conf *config.Config, logger log.Logger) (Relay, error) {
+	publishers, err := GetPublisher(conf)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create event publisher: %v", err)
+	}
+	if len(publishers) == 0 {
+		return nil, nil
+	}
+
+	tableCatalog, err := catalog.GetCatalog(conf)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create catalog: %v", err)
+	}
+
+	return &RelayImpl{
+		logger:    logger,
+		catalog:   tableCatalog,
+		publisher: publishers,
+	}, nil
+}
+
+// Relay relays the outbox of every dataset. A dataset that fails doesn't stop the others from being relayed, the
+// first error is returned once all are done.
+func (relay *RelayImpl) Relay() (int, error) {
+	relay.lock.Lock()
+	defer relay.lock.Unlock()
+
+	datasets, err := relay.catalog.Datasets()
+	if err != nil {
+		relay.logger.Error("couldn't list datasets", log.Err(err))
+		return 0, err
+	}
+
+	delivered := 0
+	var firstErr error
+	for _, dataset := range datasets {
+		count, err := relay.relayDataset(dataset)
+		delivered += count
+		if err != nil {
+			relay.logger.Warn("couldn't relay the outbox of dataset", log.Dataset(dataset), log.Err(err))
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	return delivered, firstErr
+}
+
+// relayDataset delivers the outbox of a dataset up to the first event that can't be delivered, keeping the events
+// after it for the next run so they aren't delivered out of order.
+func (relay *RelayImpl) relayDataset(dataset string) (int, error) {
+	outbox, err := relay.catalog.Outbox(dataset)
+	if err != nil {
+		return 0, err
+	}
+
+	// dropped entries are taken out of the outbox along with the delivered ones
+	delivered, dropped := make([]string, 0, len(outbox)), 0
+	var publishErr error
+	for _, entry := range outbox {
+		event, err := ParseOutboxEvent(entry)
+		if err != nil {
+			// an entry that can't be decoded never will be, it's dropped instead of holding up the ones after it
+			relay.logger.Error("dropping event from the outbox of dataset", log.Dataset(dataset), log.Err(err))
+			delivered = append(delivered, entry.Id)
+			dropped++
+			continue
+		}
+
+		if publishErr = relay.publisher.Publish(event); publishErr != nil {
+			break
+		}
+		delivered = append(delivered, entry.Id)
+	}
+
+	if len(delivered) > 0 {
+		if err := relay.catalog.Delivered(dataset, delivered); err != nil {
+			return 0, fmt.Errorf("couldn't take delivered events out of the outbox: %v", err)
+		}
+	}
+
+	return len(delivered) - dropped, publishErr
+}
@@ -0,0 +1,138 @@
+package events
+
+import (
+	"errors"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2/event"
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/catalog"
+	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/codingexplorations/data-lake/pkg/log"
+	"github.com/codingexplorations/data-lake/pkg/storage"
+	catalogMocks "github.com/codingexplorations/data-lake/test/mocks/pkg/catalog"
+	eventsMocks "github.com/codingexplorations/data-lake/test/mocks/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// enqueue commits an ingest event to the outbox of a dataset.
+func enqueue(t *testing.T, cat catalog.Catalog, id string, dataset string) {
+	event, err := NewEvent("/data-lake", id, ObjectIngested, dataset+"/"+id+".csv", dataset, &models_v1.IngestEvent{Id: id, Dataset: dataset})
+	assert.Nil(t, err)
+	assert.Nil(t, NewOutbox(cat).Publish(event))
+}
+
+func TestNewRelay(t *testing.T) {
+	// without sinks there's nothing to relay to
+	relay, err := NewRelay(&config.Config{}, log.NewConsoleLog())
+	assert.Nil(t, err)
+	assert.True(t, relay == nil)
+
+	relay, err = NewRelay(&config.Config{IngestEventSinks: "webhook", EventMode: "carrier-pigeon"}, log.NewConsoleLog())
+	assert.Equal(t, "couldn't create event publisher: unknown event mode \"carrier-pigeon\"", err.Error())
+	assert.True(t, relay == nil)
+}
+
+func TestRelayImpl_Relay(t *testing.T) {
+	tableCatalog := catalog.NewTableCatalog(storage.NewLocalStorage(), t.TempDir())
+	enqueue(t, tableCatalog, "1", "orders")
+	enqueue(t, tableCatalog, "2", "orders")
+	enqueue(t, tableCatalog, "3", "returns")
+
+	published := make([]string, 0)
+	publisher := eventsMocks.NewPublisher(t)
+	publisher.On("Publish", mock.Anything).Run(func(args mock.Arguments) {
+		published = append(published, args.Get(0).(cloudevents.Event).ID())
+	}).Return(nil)
+
+	relay := &RelayImpl{logger: log.NewConsoleLog(), catalog: tableCatalog, publisher: publisher}
+
+	delivered, err := relay.Relay()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, delivered)
+	assert.Equal(t, []string{"1", "2", "3"}, published)
+
+	// delivered events are out of the outbox
+	delivered, err = relay.Relay()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, delivered)
+	outbox, _ := tableCatalog.Outbox("orders")
+	assert.Empty(t, outbox)
+}
+
+func TestRelayImpl_Relay_Undelivered(t *testing.T) {
+	tableCatalog := catalog.NewTableCatalog(storage.NewLocalStorage(), t.TempDir())
+	enqueue(t, tableCatalog, "1", "orders")
+	enqueue(t, tableCatalog, "2", "orders")
+	enqueue(t, tableCatalog, "3", "orders")
+	enqueue(t, tableCatalog, "4", "returns")
+
+	published := make([]string, 0)
+	publisher := eventsMocks.NewPublisher(t)
+	publisher.On("Publish", mock.MatchedBy(func(event cloudevents.Event) bool { return event.ID() == "2" })).Return(errors.New("queue gone")).Once()
+	publisher.On("Publish", mock.Anything).Run(func(args mock.Arguments) {
+		published = append(published, args.Get(0).(cloudevents.Event).ID())
+	}).Return(nil)
+
+	relay := &RelayImpl{logger: log.NewConsoleLog(), catalog: tableCatalog, publisher: publisher}
+
+	// the events after one that can't be delivered wait for it, other datasets don't
+	delivered, err := relay.Relay()
+	assert.Equal(t, "queue gone", err.Error())
+	assert.Equal(t, 2, delivered)
+	assert.Equal(t, []string{"1", "4"}, published)
+	outbox, _ := tableCatalog.Outbox("orders")
+	assert.Equal(t, []string{"2", "3"}, []string{outbox[0].Id, outbox[1].Id})
+
+	delivered, err = relay.Relay()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, delivered)
+	assert.Equal(t, []string{"1", "4", "2", "3"}, published)
+}
+
+func TestRelayImpl_Relay_Redelivery(t *testing.T) {
+	tableCatalog := catalog.NewTableCatalog(storage.NewLocalStorage(), t.TempDir())
+	enqueue(t, tableCatalog, "1", "orders")
+	outbox, _ := tableCatalog.Outbox("orders")
+
+	// a relay that dies after delivering an event but before taking it out of the outbox
+	mockCatalog := catalogMocks.NewCatalog(t)
+	mockCatalog.On("Datasets").Return([]string{"orders"}, nil)
+	mockCatalog.On("Outbox", "orders").Return(outbox, nil)
+	mockCatalog.On("Delivered", "orders", []string{"1"}).Return(errors.New("disk full"))
+
+	published := make([]cloudevents.Event, 0)
+	publisher := eventsMocks.NewPublisher(t)
+	publisher.On("Publish", mock.Anything).Run(func(args mock.Arguments) {
+		published = append(published, args.Get(0).(cloudevents.Event))
+	}).Return(nil)
+
+	_, err := (&RelayImpl{logger: log.NewConsoleLog(), catalog: mockCatalog, publisher: publisher}).Relay()
+	assert.Equal(t, "couldn't take delivered events out of the outbox: disk full", err.Error())
+
+	// delivers the event again with the same id for consumers to drop the duplicate by
+	delivered, err := (&RelayImpl{logger: log.NewConsoleLog(), catalog: tableCatalog, publisher: publisher}).Relay()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, delivered)
+	assert.Len(t, published, 2)
+	assert.Equal(t, published[0].ID(), published[1].ID())
+	assert.Equal(t, published[0].Time(), published[1].Time())
+}
+
+func TestRelayImpl_Relay_Undecodable(t *testing.T) {
+	tableCatalog := catalog.NewTableCatalog(storage.NewLocalStorage(), t.TempDir())
+	assert.Nil(t, tableCatalog.Enqueue("orders", &models_v1.OutboxEvent{Id: "1", Event: []byte("{")}))
+	enqueue(t, tableCatalog, "2", "orders")
+
+	publisher := eventsMocks.NewPublisher(t)
+	publisher.On("Publish", mock.Anything).Return(nil).Once()
+
+	delivered, err := (&RelayImpl{logger: log.NewConsoleLog(), catalog: tableCatalog, publisher: publisher}).Relay()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, delivered)
+
+	// entries that can't be decoded are dropped instead of holding up the ones after them
+	outbox, _ := tableCatalog.Outbox("orders")
+	assert.Empty(t, outbox)
+}
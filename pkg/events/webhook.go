@@ -0,0 +1,128 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2/event"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const (
+	// SignatureHeader carries the hex HMAC-SHA256 of the body keyed with INGEST_EVENT_WEBHOOK_SECRET, as
+	// sha256=<hex>, for the receiver to check the event came from the lake
+	SignatureHeader = "X-Data-Lake-Signature"
+	// IdempotencyKeyHeader carries the id of the event, the same on every delivery of it, for receivers that drop
+	// duplicates without reading the event
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// StructuredContentType is the content type of structured events sent over HTTP
+	StructuredContentType = "application/cloudevents+json"
+
+	webhookTimeout = 10 * time.Second
+	webhookBackoff = time.Second
+)
+
+// WebhookPublisher posts every event to a URL following the CloudEvents HTTP binding, retrying failed deliveries with
+// a doubling backoff. Answers in the 4xx range other than 429 are the receiver refusing the event and aren't retried.
+type WebhookPublisher struct {
+	url     string
+	secret  []byte
+	retries int
+	mode    string
+	backoff time.Duration
+	client  *http.Client
+}
+
+func NewWebhookPublisher(url string, secret string, retries int, mode string) *WebhookPublisher {
+	return &WebhookPublisher{
+		url:     url,
+		secret:  []byte(secret),
+		retries: max(retries, 0),
+		mode:    mode,
+		backoff: webhookBackoff,
+		client:  &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+func (publisher *WebhookPublisher) Publish(event cloudevents.Event) error {
+	body, header, err := publisher.message(event)
+	if err != nil {
+		return err
+	}
+
+	backoff := publisher.backoff
+	for attempt := 0; ; attempt++ {
+		retry, err := publisher.post(body, header)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt == publisher.retries {
+			return fmt.Errorf("couldn't post event %v to webhook: %v", event.ID(), err)
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func (publisher *WebhookPublisher) message(event cloudevents.Event) ([]byte, http.Header, error) {
+	header := http.Header{}
+	header.Set(IdempotencyKeyHeader, event.ID())
+	otel.GetTextMapPropagator().Inject(TraceContext(context.Background(), event), propagation.HeaderCarrier(header))
+
+	if publisher.mode != Binary {
+		body, err := structured(event)
+		header.Set("Content-Type", StructuredContentType)
+		return body, header, err
+	}
+
+	attributes, err := binaryAttributes(event)
+	if err != nil {
+		return nil, nil, err
+	}
+	for name, value := range attributes {
+		header.Set("ce-"+name, value)
+	}
+	header.Set("Content-Type", event.DataContentType())
+
+	return event.Data(), header, nil
+}
+
+// post delivers the body once, telling whether a failure is worth retrying.
+func (publisher *WebhookPublisher) post(body []byte, header http.Header) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, publisher.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header = header.Clone()
+	if len(publisher.secret) > 0 {
+		req.Header.Set(SignatureHeader, "sha256="+Sign(publisher.secret, body))
+	}
+
+	resp, err := publisher.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return false, nil
+	}
+
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("webhook answered with %v", resp.Status)
+}
+
+// Sign returns the hex HMAC-SHA256 of a body keyed with a secret.
+func Sign(secret []byte, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
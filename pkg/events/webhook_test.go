@@ -0,0 +1,118 @@
+package events
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookPublisher_Publish(t *testing.T) {
+	event := newEvent(t)
+	var body []byte
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		header = r.Header
+	}))
+	defer server.Close()
+
+	assert.Nil(t, NewWebhookPublisher(server.URL, "secret", 3, Structured).Publish(event))
+
+	expected, _ := structured(event)
+	assert.Equal(t, expected, body)
+	assert.Equal(t, "application/cloudevents+json", header.Get("Content-Type"))
+	assert.Equal(t, event.ID(), header.Get(IdempotencyKeyHeader))
+	assert.Equal(t, "sha256="+Sign([]byte("secret"), body), header.Get(SignatureHeader))
+}
+
+func TestWebhookPublisher_Publish_Binary(t *testing.T) {
+	event := newEvent(t)
+	var body []byte
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		header = r.Header
+	}))
+	defer server.Close()
+
+	assert.Nil(t, NewWebhookPublisher(server.URL, "secret", 3, Binary).Publish(event))
+
+	assert.Equal(t, event.Data(), body)
+	assert.Equal(t, "application/protobuf", header.Get("Content-Type"))
+	assert.Equal(t, "1.0", header.Get("ce-specversion"))
+	assert.Equal(t, event.ID(), header.Get("ce-id"))
+	assert.Equal(t, "/data-lake", header.Get("ce-source"))
+	assert.Equal(t, ObjectIngested, header.Get("ce-type"))
+	assert.Equal(t, "orders", header.Get("ce-dataset"))
+	assert.Equal(t, "sha256="+Sign([]byte("secret"), body), header.Get(SignatureHeader))
+}
+
+func TestWebhookPublisher_Publish_Traced(t *testing.T) {
+	ctx, _ := traced()
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+	}))
+	defer server.Close()
+
+	assert.Nil(t, NewWebhookPublisher(server.URL, "secret", 3, Structured).Publish(WithTraceContext(ctx, newEvent(t))))
+
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", header.Get("traceparent"))
+}
+
+func TestWebhookPublisher_Publish_Unsigned(t *testing.T) {
+	event := newEvent(t)
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+	}))
+	defer server.Close()
+
+	assert.Nil(t, NewWebhookPublisher(server.URL, "", 3, Structured).Publish(event))
+	assert.Empty(t, header.Get(SignatureHeader))
+}
+
+func TestWebhookPublisher_Publish_Retries(t *testing.T) {
+	event := newEvent(t)
+	tests := []struct {
+		name          string
+		statuses      []int
+		expectedCalls int
+		expectedError string
+	}{
+		{"recovers", []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK}, 3, ""},
+		{"gives up", []int{http.StatusBadGateway}, 4, "couldn't post event " + ingest.Id + " to webhook: webhook answered with 502 Bad Gateway"},
+		{"refused", []int{http.StatusBadRequest}, 1, "couldn't post event " + ingest.Id + " to webhook: webhook answered with 400 Bad Request"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(test.statuses[min(calls, len(test.statuses)-1)])
+				calls++
+			}))
+			defer server.Close()
+
+			publisher := NewWebhookPublisher(server.URL, "secret", 3, Structured)
+			publisher.backoff = time.Millisecond
+
+			err := publisher.Publish(event)
+			if test.expectedError == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.Equal(t, test.expectedError, err.Error())
+			}
+			assert.Equal(t, test.expectedCalls, calls)
+		})
+	}
+}
+
+func TestSign(t *testing.T) {
+	// RFC 4231 test case 2
+	assert.Equal(t, "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843", Sign([]byte("Jefe"), []byte("what do ya want for nothing?")))
+}
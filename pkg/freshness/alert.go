@@ -0,0 +1,36 @@
+package freshness
+
+import (
+	"fmt"
+	"time"
+)
+
+// The statuses of an alert, a dataset is late until the objects it was due to receive arrive and resolve it.
+const (
+	Late     = "late"
+	Resolved = "resolved"
+)
+
+// Alert tells that the objects a dataset was due to receive didn't arrive in time, or that they arrived after all.
+type Alert struct {
+	Dataset string `json:"dataset"`
+	Status  string `json:"status"`
+	// Due is when the missing objects were scheduled, Deadline is when the grace period after it ended
+	Due      time.Time `json:"due"`
+	Deadline time.Time `json:"deadline"`
+	// LastArrival is when the dataset last received an object, nil when it never has
+	LastArrival *time.Time `json:"last_arrival,omitempty"`
+}
+
+// String describes the alert the way it's logged.
+func (alert *Alert) String() string {
+	last := "never"
+	if alert.LastArrival != nil {
+		last = alert.LastArrival.Format(time.RFC3339)
+	}
+
+	if alert.Status == Resolved {
+		return fmt.Sprintf("dataset %v is no longer late: objects due at %v arrived at %v", alert.Dataset, alert.Due.Format(time.RFC3339), last)
+	}
+	return fmt.Sprintf("dataset %v is late: objects due at %v didn't arrive by %v, last arrival %v", alert.Dataset, alert.Due.Format(time.RFC3339), alert.Deadline.Format(time.RFC3339), last)
+}
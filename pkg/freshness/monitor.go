@@ -0,0 +1,192 @@
+package freshness
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/catalog"
+	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/codingexplorations/data-lake/pkg/dataset"
+	"github.com/codingexplorations/data-lake/pkg/log"
+	"github.com/robfig/cron/v3"
+)
+
+// maxLookback is how far back the last due time of a schedule is searched for, far enough for a schedule that only
+// fires on leap days.
+const maxLookback = 5 * 366 * 24 * time.Hour
+
+type Monitor interface {
+	// Check raises an alert for every dataset whose objects are late, and for every late dataset whose objects
+	// arrived since, returning the alerts it raised
+	Check() ([]*Alert, error)
+}
+
+// MonitorImpl watches the datasets that have an arrival schedule for objects that don't arrive in time. The objects
+// due at a time are expected after the deadline of the due time before it and before their own deadline, a dataset
+// without any is late until they arrive.
+type MonitorImpl struct {
+	logger   log.Logger
+	catalog  catalog.Catalog
+	datasets *dataset.Datasets
+	notifier Notifier
+	now      func() time.Time
+
+	lock sync.Mutex
+	// late holds the due time each late dataset was alerted for, so every due time is only alerted on once
+	late map[string]time.Time
+}
+
+func NewMonitor(conf *config.Config, logger log.Logger) (Monitor, error) {
+	datasets, err := dataset.Load(conf)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't load datasets: %v", err)
+	}
+
+	tableCatalog, err := catalog.GetCatalog(conf)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create catalog: %v", err)
+	}
+
+	notifier, err := GetNotifier(conf, logger)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create freshness notifier: %v", err)
+	}
+
+	return &MonitorImpl{
+		logger:   logger,
+		catalog:  tableCatalog,
+		datasets: datasets,
+		notifier: notifier,
+		now:      func() time.Time { return time.Now().UTC() },
+		late:     make(map[string]time.Time),
+	}, nil
+}
+
+// Check checks every dataset with an arrival schedule. A dataset that fails doesn't stop the others from being
+// checked, and an alert that couldn't be delivered is raised again on the next check. The first error is returned
+// once all are done.
+func (monitor *MonitorImpl) Check() ([]*Alert, error) {
+	monitor.lock.Lock()
+	defer monitor.lock.Unlock()
+
+	now := monitor.now()
+	alerts := make([]*Alert, 0)
+	var firstErr error
+
+	for _, ds := range monitor.datasets.All() {
+		if ds.Arrival == nil {
+			continue
+		}
+
+		alert, err := monitor.check(ds, now)
+		if err == nil && alert != nil {
+			err = monitor.notifier.Notify(alert)
+		}
+		if err != nil {
+			monitor.logger.Error("couldn't check freshness of dataset", log.Dataset(ds.Name), log.Err(err))
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if alert == nil {
+			continue
+		}
+
+		if alert.Status == Late {
+			monitor.late[ds.Name] = alert.Due
+		} else {
+			delete(monitor.late, ds.Name)
+		}
+		alerts = append(alerts, alert)
+	}
+
+	return alerts, firstErr
+}
+
+// check returns the alert a dataset needs, nil when nothing changed since it was last checked.
+func (monitor *MonitorImpl) check(ds *dataset.Dataset, now time.Time) (*Alert, error) {
+	schedule, err := ds.Arrival.Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	lastArrival, err := monitor.lastArrival(ds.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	grace := ds.Arrival.Grace
+	due, ok := previous(schedule, now.Add(-grace))
+	if !ok {
+		return nil, nil
+	}
+	before, ok := previous(schedule, due.Add(-time.Second))
+	arrived := lastArrival != nil && (!ok || lastArrival.After(before.Add(grace)))
+
+	lateDue, late := monitor.late[ds.Name]
+	switch {
+	case !arrived && (!late || !lateDue.Equal(due)):
+		return &Alert{Dataset: ds.Name, Status: Late, Due: due, Deadline: due.Add(grace), LastArrival: lastArrival}, nil
+	case arrived && late:
+		return &Alert{Dataset: ds.Name, Status: Resolved, Due: lateDue, Deadline: lateDue.Add(grace), LastArrival: lastArrival}, nil
+	}
+
+	return nil, nil
+}
+
+// lastArrival returns when objects were last added to a dataset, either listed as curated files or recorded as
+// arrivals without one, compactions and purges don't count.
+func (monitor *MonitorImpl) lastArrival(name string) (*time.Time, error) {
+	snapshots, err := monitor.catalog.Snapshots(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var last int64
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		if snapshots[i].Operation == models_v1.Commit_APPEND {
+			last = snapshots[i].Timestamp
+			break
+		}
+	}
+
+	arrived, err := monitor.catalog.Arrived(name)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, arrival := range arrived {
+		last = max(last, arrival.Timestamp)
+	}
+
+	if last == 0 {
+		return nil, nil
+	}
+
+	arrival := time.UnixMilli(last).UTC()
+	return &arrival, nil
+}
+
+// previous returns the last time of a schedule at or before a time. Schedules only tell the next time after another,
+// so it looks further back each time until it finds one.
+func previous(schedule cron.Schedule, t time.Time) (time.Time, bool) {
+	for back := time.Minute; back <= maxLookback; back *= 2 {
+		next := schedule.Next(t.Add(-back))
+		if next.IsZero() || next.After(t) {
+			continue
+		}
+
+		for {
+			after := schedule.Next(next)
+			if after.IsZero() || after.After(t) {
+				return next, true
+			}
+			next = after
+		}
+	}
+
+	return time.Time{}, false
+}
@@ -0,0 +1,207 @@
+package freshness
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/codingexplorations/data-lake/pkg/dataset"
+	"github.com/codingexplorations/data-lake/pkg/log"
+	catalogMocks "github.com/codingexplorations/data-lake/test/mocks/pkg/catalog"
+	"github.com/robfig/cron/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+// notifier records the alerts it's notified of, failing with err while it's set.
+type notifier struct {
+	alerts []*Alert
+	err    error
+}
+
+func (n *notifier) Notify(alert *Alert) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.alerts = append(n.alerts, alert)
+	return nil
+}
+
+func newMonitor(t *testing.T, now *time.Time, datasets ...*dataset.Dataset) (*MonitorImpl, *catalogMocks.Catalog, *notifier) {
+	mockCatalog := catalogMocks.NewCatalog(t)
+	notifier := &notifier{}
+
+	return &MonitorImpl{
+		logger:   log.NewConsoleLog(),
+		catalog:  mockCatalog,
+		datasets: dataset.NewDatasets(&config.Config{}, datasets),
+		notifier: notifier,
+		now:      func() time.Time { return *now },
+		late:     make(map[string]time.Time),
+	}, mockCatalog, notifier
+}
+
+func snapshot(operation models_v1.Commit_Operation, timestamp time.Time) *models_v1.Snapshot {
+	return &models_v1.Snapshot{Operation: operation, Timestamp: timestamp.UnixMilli()}
+}
+
+func TestMonitorImpl_Check(t *testing.T) {
+	daily := &dataset.Arrival{Schedule: "0 6 * * *", Grace: 2 * time.Hour}
+	now := time.Date(2024, 5, 2, 7, 0, 0, 0, time.UTC)
+	monitor, mockCatalog, notifier := newMonitor(t, &now,
+		&dataset.Dataset{Name: "orders", Arrival: daily},
+		&dataset.Dataset{Name: "customers"},
+	)
+
+	arrived := time.Date(2024, 5, 1, 6, 30, 0, 0, time.UTC)
+	snapshots := []*models_v1.Snapshot{snapshot(models_v1.Commit_APPEND, arrived)}
+	mockCatalog.On("Snapshots", "orders").Return(func(string) []*models_v1.Snapshot { return snapshots }, nil)
+	mockCatalog.On("Arrived", "orders").Return([]*models_v1.Lineage{}, nil)
+
+	// yesterday's objects arrived and today's aren't past their deadline yet
+	alerts, err := monitor.Check()
+	assert.Nil(t, err)
+	assert.Empty(t, alerts)
+
+	// compacting doesn't count as an arrival
+	snapshots = append(snapshots, snapshot(models_v1.Commit_COMPACT, time.Date(2024, 5, 2, 7, 30, 0, 0, time.UTC)))
+	now = time.Date(2024, 5, 2, 8, 30, 0, 0, time.UTC)
+	late := &Alert{
+		Dataset:     "orders",
+		Status:      Late,
+		Due:         time.Date(2024, 5, 2, 6, 0, 0, 0, time.UTC),
+		Deadline:    time.Date(2024, 5, 2, 8, 0, 0, 0, time.UTC),
+		LastArrival: &arrived,
+	}
+	alerts, err = monitor.Check()
+	assert.Nil(t, err)
+	assert.Equal(t, []*Alert{late}, alerts)
+	assert.Equal(t, []*Alert{late}, notifier.alerts)
+
+	// a late dataset is only alerted on once
+	now = time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)
+	alerts, err = monitor.Check()
+	assert.Nil(t, err)
+	assert.Empty(t, alerts)
+
+	arrivedLate := time.Date(2024, 5, 2, 9, 10, 0, 0, time.UTC)
+	snapshots = append(snapshots, snapshot(models_v1.Commit_APPEND, arrivedLate))
+	now = time.Date(2024, 5, 2, 9, 15, 0, 0, time.UTC)
+	resolved := &Alert{
+		Dataset:     "orders",
+		Status:      Resolved,
+		Due:         time.Date(2024, 5, 2, 6, 0, 0, 0, time.UTC),
+		Deadline:    time.Date(2024, 5, 2, 8, 0, 0, 0, time.UTC),
+		LastArrival: &arrivedLate,
+	}
+	alerts, err = monitor.Check()
+	assert.Nil(t, err)
+	assert.Equal(t, []*Alert{resolved}, alerts)
+	assert.Equal(t, []*Alert{late, resolved}, notifier.alerts)
+
+	// objects that arrive early count for the next due time
+	snapshots = append(snapshots, snapshot(models_v1.Commit_APPEND, time.Date(2024, 5, 3, 5, 50, 0, 0, time.UTC)))
+	now = time.Date(2024, 5, 3, 8, 30, 0, 0, time.UTC)
+	alerts, err = monitor.Check()
+	assert.Nil(t, err)
+	assert.Empty(t, alerts)
+}
+
+func TestMonitorImpl_Check_NeverArrived(t *testing.T) {
+	now := time.Date(2024, 5, 2, 7, 0, 0, 0, time.UTC)
+	monitor, mockCatalog, notifier := newMonitor(t, &now, &dataset.Dataset{Name: "orders", Arrival: &dataset.Arrival{Schedule: "0 * * * *"}})
+
+	mockCatalog.On("Snapshots", "orders").Return([]*models_v1.Snapshot{}, nil)
+	mockCatalog.On("Arrived", "orders").Return([]*models_v1.Lineage{}, nil)
+	late := &Alert{Dataset: "orders", Status: Late, Due: now, Deadline: now}
+
+	alerts, err := monitor.Check()
+	assert.Nil(t, err)
+	assert.Equal(t, []*Alert{late}, alerts)
+
+	// every due time that passes without objects is alerted on
+	now = now.Add(time.Hour)
+
+	alerts, err = monitor.Check()
+	assert.Nil(t, err)
+	assert.Len(t, alerts, 1)
+	assert.Equal(t, now, alerts[0].Due)
+	assert.Len(t, notifier.alerts, 2)
+}
+
+func TestMonitorImpl_Check_Arrived(t *testing.T) {
+	now := time.Date(2024, 5, 2, 8, 30, 0, 0, time.UTC)
+	monitor, mockCatalog, _ := newMonitor(t, &now,
+		&dataset.Dataset{Name: "documents", Arrival: &dataset.Arrival{Schedule: "0 6 * * *", Grace: 2 * time.Hour}},
+	)
+
+	// unstructured objects have no curated file listed, only their arrival recorded
+	appended := time.Date(2024, 5, 1, 6, 30, 0, 0, time.UTC)
+	arrived := time.Date(2024, 5, 2, 6, 30, 0, 0, time.UTC)
+	mockCatalog.On("Snapshots", "documents").Return([]*models_v1.Snapshot{snapshot(models_v1.Commit_APPEND, appended)}, nil)
+	mockCatalog.On("Arrived", "documents").Return([]*models_v1.Lineage{
+		{Sources: []string{"documents/report.pdf"}, Dataset: "documents", Timestamp: arrived.UnixMilli()},
+	}, nil)
+
+	alerts, err := monitor.Check()
+	assert.Nil(t, err)
+	assert.Empty(t, alerts)
+
+	lastArrival, err := monitor.lastArrival("documents")
+	assert.Nil(t, err)
+	assert.Equal(t, arrived, *lastArrival)
+}
+
+func TestMonitorImpl_Check_Failure(t *testing.T) {
+	now := time.Date(2024, 5, 2, 7, 0, 0, 0, time.UTC)
+	monitor, mockCatalog, notifier := newMonitor(t, &now,
+		&dataset.Dataset{Name: "orders", Arrival: &dataset.Arrival{Schedule: "0 6 * * *"}},
+		&dataset.Dataset{Name: "customers", Arrival: &dataset.Arrival{Schedule: "0 6 * * *"}},
+	)
+
+	mockCatalog.On("Snapshots", "orders").Return(nil, errors.New("catalog unavailable"))
+	mockCatalog.On("Snapshots", "customers").Return([]*models_v1.Snapshot{}, nil)
+	mockCatalog.On("Arrived", "customers").Return([]*models_v1.Lineage{}, nil)
+	notifier.err = errors.New("webhook down")
+
+	// the customers alert isn't delivered, so it's raised again on the next check
+	alerts, err := monitor.Check()
+	assert.Equal(t, "catalog unavailable", err.Error())
+	assert.Empty(t, alerts)
+
+	notifier.err = nil
+
+	alerts, err = monitor.Check()
+	assert.Equal(t, "catalog unavailable", err.Error())
+	assert.Len(t, alerts, 1)
+	assert.Equal(t, "customers", alerts[0].Dataset)
+}
+
+func TestPrevious(t *testing.T) {
+	at := time.Date(2024, 5, 2, 7, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		schedule string
+		expected time.Time
+	}{
+		{"every minute", "* * * * *", at},
+		{"daily", "0 6 * * *", time.Date(2024, 5, 2, 6, 0, 0, 0, time.UTC)},
+		{"later today", "0 8 * * *", time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)},
+		{"weekly", "30 9 * * MON", time.Date(2024, 4, 29, 9, 30, 0, 0, time.UTC)},
+		{"leap day", "0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"time zone", "CRON_TZ=Europe/Berlin 0 6 * * *", time.Date(2024, 5, 2, 4, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schedule, err := cron.ParseStandard(test.schedule)
+			assert.Nil(t, err)
+
+			previous, ok := previous(schedule, at)
+			assert.True(t, ok)
+			assert.True(t, test.expected.Equal(previous), "got %v", previous)
+		})
+	}
+}
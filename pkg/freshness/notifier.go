@@ -0,0 +1,156 @@
+package freshness
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/codingexplorations/data-lake/pkg/aws"
+	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/codingexplorations/data-lake/pkg/log"
+)
+
+// webhookTimeout bounds how long a webhook may take to accept an alert.
+const webhookTimeout = 10 * time.Second
+
+// Notifier delivers the alerts of the monitor.
+type Notifier interface {
+	Notify(alert *Alert) error
+}
+
+// NotifierFunc lets a function be used as a notifier.
+type NotifierFunc func(alert *Alert) error
+
+func (f NotifierFunc) Notify(alert *Alert) error {
+	return f(alert)
+}
+
+// GetNotifier returns the notifiers named in FRESHNESS_NOTIFIERS, a comma separated list of log, webhook and sqs.
+func GetNotifier(conf *config.Config, logger log.Logger) (Notifier, error) {
+	notifiers := make(Notifiers, 0)
+
+	for _, name := range strings.Split(conf.FreshnessNotifiers, ",") {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "":
+		case "log":
+			notifiers = append(notifiers, NewLogNotifier(logger))
+		case "webhook":
+			if conf.FreshnessWebhookUrl == "" {
+				return nil, fmt.Errorf("webhook notifier needs FRESHNESS_WEBHOOK_URL")
+			}
+			notifiers = append(notifiers, NewWebhookNotifier(conf.FreshnessWebhookUrl))
+		case "sqs":
+			sqsNotifier, err := NewSqsNotifier(conf)
+			if err != nil {
+				return nil, err
+			}
+			notifiers = append(notifiers, sqsNotifier)
+		default:
+			return nil, fmt.Errorf("unknown freshness notifier %q", name)
+		}
+	}
+
+	return notifiers, nil
+}
+
+// Notifiers delivers every alert to each of its notifiers, even when some of them fail.
+type Notifiers []Notifier
+
+func (notifiers Notifiers) Notify(alert *Alert) error {
+	errs := make([]error, 0)
+	for _, notifier := range notifiers {
+		if err := notifier.Notify(alert); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// LogNotifier logs late datasets at ERROR and resolved ones at INFO.
+type LogNotifier struct {
+	logger log.Logger
+}
+
+func NewLogNotifier(logger log.Logger) *LogNotifier {
+	return &LogNotifier{logger: logger}
+}
+
+func (notifier *LogNotifier) Notify(alert *Alert) error {
+	if alert.Status == Late {
+		notifier.logger.Error(alert.String(), log.Dataset(alert.Dataset), slog.String("status", alert.Status))
+	} else {
+		notifier.logger.Info(alert.String(), log.Dataset(alert.Dataset), slog.String("status", alert.Status))
+	}
+	return nil
+}
+
+// WebhookNotifier posts every alert as JSON to a URL.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{url: url, client: &http.Client{Timeout: webhookTimeout}}
+}
+
+func (notifier *WebhookNotifier) Notify(alert *Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	resp, err := notifier.client.Post(notifier.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("couldn't post alert to webhook: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook answered alert with %v", resp.Status)
+	}
+
+	return nil
+}
+
+// SqsNotifier sends every alert as JSON to the queue named by AWS_FRESHNESS_QUEUE_NAME.
+type SqsNotifier struct {
+	sqs      aws.SqsClient
+	queueUrl *string
+}
+
+func NewSqsNotifier(conf *config.Config) (*SqsNotifier, error) {
+	if conf.AwsFreshnessQueueName == "" {
+		return nil, fmt.Errorf("sqs notifier needs AWS_FRESHNESS_QUEUE_NAME")
+	}
+
+	sqs, err := aws.NewSqs()
+	if err != nil {
+		return nil, err
+	}
+
+	queueUrl, err := sqs.GetQueueUrl(conf.AwsFreshnessQueueName)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get the url of queue %v: %v", conf.AwsFreshnessQueueName, err)
+	}
+
+	return &SqsNotifier{sqs: sqs, queueUrl: queueUrl.QueueUrl}, nil
+}
+
+func (notifier *SqsNotifier) Notify(alert *Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	if _, err := notifier.sqs.SendMessage(notifier.queueUrl, string(body), nil); err != nil {
+		return fmt.Errorf("couldn't send alert to queue: %v", err)
+	}
+
+	return nil
+}
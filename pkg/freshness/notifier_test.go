@@ -0,0 +1,106 @@
+package freshness
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/codingexplorations/data-lake/pkg/log"
+	awsMocks "github.com/codingexplorations/data-lake/test/mocks/pkg/aws"
+	logMocks "github.com/codingexplorations/data-lake/test/mocks/pkg/log"
+	"github.com/stretchr/testify/assert"
+)
+
+var lateAlert = &Alert{
+	Dataset:  "orders",
+	Status:   Late,
+	Due:      time.Date(2024, 5, 2, 6, 0, 0, 0, time.UTC),
+	Deadline: time.Date(2024, 5, 2, 8, 0, 0, 0, time.UTC),
+}
+
+func TestLogNotifier_Notify(t *testing.T) {
+	logger := logMocks.NewLogger(t)
+	logger.On("Error", "dataset orders is late: objects due at 2024-05-02T06:00:00Z didn't arrive by 2024-05-02T08:00:00Z, last arrival never", log.Dataset("orders"), slog.String("status", "late")).Once()
+
+	assert.Nil(t, NewLogNotifier(logger).Notify(lateAlert))
+
+	arrived := time.Date(2024, 5, 2, 9, 10, 0, 0, time.UTC)
+	logger.On("Info", "dataset orders is no longer late: objects due at 2024-05-02T06:00:00Z arrived at 2024-05-02T09:10:00Z", log.Dataset("orders"), slog.String("status", "resolved")).Once()
+
+	assert.Nil(t, NewLogNotifier(logger).Notify(&Alert{Dataset: "orders", Status: Resolved, Due: lateAlert.Due, Deadline: lateAlert.Deadline, LastArrival: &arrived}))
+}
+
+func TestWebhookNotifier_Notify(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		_ = json.NewDecoder(r.Body).Decode(&received)
+	}))
+	defer server.Close()
+
+	assert.Nil(t, NewWebhookNotifier(server.URL).Notify(lateAlert))
+	assert.Equal(t, map[string]interface{}{
+		"dataset":  "orders",
+		"status":   "late",
+		"due":      "2024-05-02T06:00:00Z",
+		"deadline": "2024-05-02T08:00:00Z",
+	}, received)
+}
+
+func TestWebhookNotifier_Notify_Failure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	err := NewWebhookNotifier(server.URL).Notify(lateAlert)
+	assert.Equal(t, "webhook answered alert with 502 Bad Gateway", err.Error())
+}
+
+func TestSqsNotifier_Notify(t *testing.T) {
+	sqsClient := awsMocks.NewSqsClient(t)
+	queueUrl := aws.String("http://localhost:4566/000000000000/freshness")
+	body, _ := json.Marshal(lateAlert)
+	sqsClient.On("SendMessage", queueUrl, string(body), map[string]types.MessageAttributeValue(nil)).Return(&sqs.SendMessageOutput{}, nil).Once()
+
+	notifier := &SqsNotifier{sqs: sqsClient, queueUrl: queueUrl}
+	assert.Nil(t, notifier.Notify(lateAlert))
+
+	sqsClient.On("SendMessage", queueUrl, string(body), map[string]types.MessageAttributeValue(nil)).Return(nil, errors.New("queue gone")).Once()
+	assert.Equal(t, "couldn't send alert to queue: queue gone", notifier.Notify(lateAlert).Error())
+}
+
+func TestNotifiers_Notify(t *testing.T) {
+	notified := make([]*Alert, 0)
+	failing := NotifierFunc(func(alert *Alert) error { return errors.New("webhook down") })
+	working := NotifierFunc(func(alert *Alert) error {
+		notified = append(notified, alert)
+		return nil
+	})
+
+	err := Notifiers{failing, working}.Notify(lateAlert)
+	assert.Equal(t, "webhook down", err.Error())
+	assert.Equal(t, []*Alert{lateAlert}, notified)
+}
+
+func TestGetNotifier(t *testing.T) {
+	logger := logMocks.NewLogger(t)
+
+	notifier, err := GetNotifier(&config.Config{FreshnessNotifiers: "log, webhook", FreshnessWebhookUrl: "http://localhost/alerts"}, logger)
+	assert.Nil(t, err)
+	assert.Equal(t, Notifiers{NewLogNotifier(logger), NewWebhookNotifier("http://localhost/alerts")}, notifier)
+
+	_, err = GetNotifier(&config.Config{FreshnessNotifiers: "webhook"}, logger)
+	assert.Equal(t, "webhook notifier needs FRESHNESS_WEBHOOK_URL", err.Error())
+
+	_, err = GetNotifier(&config.Config{FreshnessNotifiers: "pager"}, logger)
+	assert.Equal(t, "unknown freshness notifier \"pager\"", err.Error())
+}
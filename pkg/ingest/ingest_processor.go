@@ -1,36 +1,57 @@
 package ingest
 
 import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"github.com/codingexplorations/data-lake/pkg/log"
-	golog "log"
+	"io"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/bufbuild/protovalidate-go"
 	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/catalog"
 	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/codingexplorations/data-lake/pkg/convert"
+	"github.com/codingexplorations/data-lake/pkg/dataset"
+	"github.com/codingexplorations/data-lake/pkg/events"
+	"github.com/codingexplorations/data-lake/pkg/quality"
+	"github.com/codingexplorations/data-lake/pkg/records"
+	"github.com/codingexplorations/data-lake/pkg/registry"
+	"github.com/codingexplorations/data-lake/pkg/schema"
+	"github.com/codingexplorations/data-lake/pkg/stats"
+	"github.com/codingexplorations/data-lake/pkg/storage"
+	"github.com/codingexplorations/data-lake/pkg/validation"
+	"github.com/google/uuid"
 )
 
+// errCatalogued is returned when processing an object the catalog already has, there's nothing to ingest again.
+var errCatalogued = errors.New("object already catalogued")
+
+// IngestProcessor ingests the files of a folder, or a single one, as part of the span in ctx.
 type IngestProcessor interface {
-	ProcessFolder(folder string) ([]*models_v1.Object, error)
-	ProcessFile(fileName string) (*models_v1.Object, error)
+	ProcessFolder(ctx context.Context, folder string) ([]*models_v1.Object, error)
+	ProcessFile(ctx context.Context, fileName string) (*models_v1.Object, error)
 }
 
-func GetIngestProcessor(conf *config.Config) IngestProcessor {
-	golog.Println("here")
+// GetIngestProcessor returns the processor of INGEST_PROCESSOR_TYPE, failing when it can't be created.
+func GetIngestProcessor(conf *config.Config, logger log.Logger) (IngestProcessor, error) {
 	switch conf.IngestProcessorType {
 	case "local":
-		golog.Println("Using local ingest processor")
-		return NewLocalIngestProcessor()
+		logger.Info("Using local ingest processor")
+		return NewLocalIngestProcessor(conf, logger)
 	case "localstack":
-		golog.Println("Using localstack ingest processor")
-		logger, err := log.NewSqsLog()
-		if err != nil {
-			golog.Fatalf("couldn't create logger: %v\n", err)
-		}
+		logger.Info("Using localstack ingest processor")
 		return NewS3IngestProcessorImpl(conf, logger)
 	default:
-		golog.Println("Using default ingest processor")
-		return NewLocalIngestProcessor()
+		logger.Info("Using default ingest processor")
+		return NewLocalIngestProcessor(conf, logger)
 	}
 }
 
@@ -41,8 +62,442 @@ func validate(object *models_v1.Object) (bool, error) {
 	}
 
 	if err := validator.Validate(object); err != nil {
-		return false, fmt.Errorf("failed to validate object: %v", err)
+		return false, fmt.Errorf("failed to validate object: %w", err)
 	}
 
 	return true, nil
 }
+
+// inferSchema attaches the schema inferred from the first sampleSize records of a structured file to the object, other
+// files are left without one.
+func inferSchema(object *models_v1.Object, format models_v1.Schema_Format, content io.Reader, sampleSize int) error {
+	if format == models_v1.Schema_UNKNOWN {
+		return nil
+	}
+
+	inferred, err := schema.Infer(format, content, sampleSize)
+	if err != nil {
+		return fmt.Errorf("failed to infer schema: %v", err)
+	}
+
+	object.Schema = inferred
+
+	return nil
+}
+
+// declareSchema replaces the inferred fields of the object's schema with the fields of the message its dataset
+// declares, so the declared schema is what gets registered.
+func declareSchema(ds *dataset.Dataset, object *models_v1.Object) error {
+	if ds.Message == "" {
+		return nil
+	}
+
+	if object.Schema == nil {
+		return fmt.Errorf("dataset %v expects %v records but %v is not a CSV or JSON file", ds.Name, ds.Message, object.FileName)
+	}
+
+	descriptor, err := validation.LoadMessageDescriptor(ds.DescriptorSet, ds.Message)
+	if err != nil {
+		return err
+	}
+
+	object.Schema.Fields = schema.FromDescriptor(descriptor)
+
+	return nil
+}
+
+// registerSchema records the object's schema with the registry, failing when it changed in a way the dataset's
+// compatibility mode doesn't allow. A schema that became a new version of the dataset's schema is returned as a change.
+func registerSchema(schemaRegistry registry.SchemaRegistry, ds *dataset.Dataset, object *models_v1.Object) (*models_v1.SchemaChange, error) {
+	if object.Schema == nil {
+		return nil, nil
+	}
+
+	compatibility, err := registry.ParseCompatibility(ds.Compatibility)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := schemaRegistry.Latest(ds.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	registered, err := schemaRegistry.Register(ds.Name, object.Schema, compatibility)
+	if err != nil {
+		return nil, err
+	}
+
+	object.Schema.Version = registered.Version
+
+	if previous != nil && previous.Version == registered.Version {
+		return nil, nil
+	}
+
+	return &models_v1.SchemaChange{
+		Dataset:   ds.Name,
+		Location:  object.FileLocation,
+		Previous:  previous,
+		Schema:    registered,
+		Timestamp: time.Now().UnixMilli(),
+	}, nil
+}
+
+// validateRecords runs protovalidate on every record of the file against the message its dataset declares, attaching
+// the report to the object. The report keeps the first violationLimit violations.
+func validateRecords(ds *dataset.Dataset, object *models_v1.Object, content io.Reader, violationLimit int) error {
+	descriptor, err := validation.LoadMessageDescriptor(ds.DescriptorSet, ds.Message)
+	if err != nil {
+		return err
+	}
+
+	validator, err := validation.NewRecordValidator(descriptor, violationLimit)
+	if err != nil {
+		return err
+	}
+
+	reader, err := records.NewReader(object.Schema, content)
+	if err != nil {
+		return err
+	}
+
+	report, err := validator.Validate(reader)
+	if err != nil {
+		return fmt.Errorf("failed to validate records: %v", err)
+	}
+
+	object.Validation = report
+
+	if report.InvalidRecords > 0 {
+		return &validation.RecordValidationError{Report: report}
+	}
+
+	return nil
+}
+
+// checkQuality checks a structured object against the expectations of its dataset, attaching the report to it and
+// keeping it in reports. A failing object of a dataset that quarantines failures is moved by quarantine, which returns
+// where to, and true is returned.
+func checkQuality(cat catalog.Catalog, reports *quality.Reports, ds *dataset.Dataset, object *models_v1.Object, content io.Reader, quarantine func() (string, error)) (bool, error) {
+	if len(ds.Expectations) == 0 || object.Schema == nil {
+		return false, nil
+	}
+
+	var history quality.History
+	if cat != nil {
+		history = quality.NewCatalogHistory(cat)
+	}
+
+	reader, err := records.NewReader(object.Schema, content)
+	if err != nil {
+		return false, err
+	}
+
+	report, err := quality.Check(ds.Name, ds.Expectations, object, reader, history)
+	if err != nil {
+		return false, fmt.Errorf("failed to check the quality of %v: %v", object.FileLocation, err)
+	}
+	object.Quality = report
+
+	quarantined := !report.Passed && ds.Quarantine
+	if quarantined {
+		location, err := quarantine()
+		if err != nil {
+			return false, fmt.Errorf("failed to quarantine %v: %v", object.FileLocation, err)
+		}
+		report.QuarantineLocation = location
+	}
+
+	if reports != nil {
+		if err := reports.Write(report); err != nil {
+			return false, err
+		}
+	}
+
+	return quarantined, nil
+}
+
+// curate converts a structured object to parquet and writes it to location in the curated zone, returning the file to
+// list in the catalog.
+func curate(cat catalog.Catalog, curated storage.Storage, ds *dataset.Dataset, object *models_v1.Object, content []byte, location string, partition string) (*models_v1.DataFile, error) {
+	output := &bytes.Buffer{}
+	if err := convertObject(ds, object, bytes.NewReader(content), output, location); err != nil {
+		return nil, err
+	}
+
+	if err := curated.Write(location, output, convert.ContentType); err != nil {
+		return nil, err
+	}
+
+	if cat == nil {
+		return nil, nil
+	}
+
+	return &models_v1.DataFile{
+		Location:      location,
+		Dataset:       ds.Name,
+		Partition:     partition,
+		Format:        models_v1.Schema_PARQUET,
+		SchemaVersion: object.Schema.Version,
+		Records:       object.Conversion.Records,
+		ContentSize:   object.Conversion.ContentSize,
+		Lineage:       object.Conversion.Lineage,
+		Statistics:    object.Statistics,
+	}, nil
+}
+
+// record lists the curated file of an object in the catalog and emits how ingesting the object went. Objects without
+// a curated file, unstructured, quarantined or not curated at all, are recorded as arrivals instead, so the freshness
+// of their dataset still shows them. With an outbox the ingest event is committed together with the file or arrival,
+// so the event is neither lost nor sent for an object that wasn't recorded. Objects already catalogued, and ones
+// ending the same way as when their event was emitted, have nothing new to tell and emit nothing. object, file and
+// err are what processing returned.
+func record(ctx context.Context, cat catalog.Catalog, emitter *events.Emitter, sent *emitted, logger log.Logger, location string, dataset string, object *models_v1.Object, file *models_v1.DataFile, started time.Time, err error) (*models_v1.Object, error) {
+	if errors.Is(err, errCatalogued) {
+		return object, nil
+	}
+
+	var commit func(outbox ...*models_v1.OutboxEvent) error
+	switch {
+	case err != nil:
+	case file != nil:
+		commit = func(outbox ...*models_v1.OutboxEvent) error {
+			return cat.Add(file, outbox...)
+		}
+	case cat != nil && object != nil && object.Dataset != "":
+		arrival := &models_v1.Lineage{
+			Sources:       []string{object.FileLocation},
+			Checksums:     []string{object.Checksum},
+			Dataset:       object.Dataset,
+			SchemaVersion: object.GetSchema().GetVersion(),
+			Timestamp:     time.Now().UnixMilli(),
+		}
+		commit = func(outbox ...*models_v1.OutboxEvent) error {
+			return cat.Arrive(arrival, outbox...)
+		}
+	}
+
+	if commit != nil {
+		ingest := ingestEvent(location, dataset, object, started, nil)
+		if err = add(ctx, emitter, logger, commit, ingest); err == nil {
+			sent.add(ingest.Id)
+			return object, nil
+		}
+		logger.Error("error cataloguing object", log.Location(location), log.Err(err))
+		object = nil
+	}
+
+	if ingest := ingestEvent(location, dataset, object, started, err); emitter != nil && !sent.has(ingest.Id) {
+		if emitIngested(ctx, emitter, logger, ingest) {
+			sent.add(ingest.Id)
+		}
+	}
+
+	return object, err
+}
+
+// add commits a file or an arrival to the catalog along with the event of its object being ingested.
+func add(ctx context.Context, emitter *events.Emitter, logger log.Logger, commit func(outbox ...*models_v1.OutboxEvent) error, ingest *models_v1.IngestEvent) error {
+	if emitter == nil {
+		return commit()
+	}
+
+	event, err := emitter.NewIngested(ingest)
+	if err != nil {
+		logger.Warn("couldn't emit ingest event", log.Location(ingest.Location), log.Err(err))
+		return commit()
+	}
+	event = events.WithTraceContext(ctx, event)
+
+	outbox, err := emitter.Stage(event)
+	if err != nil {
+		return err
+	}
+
+	if err := commit(outbox...); err != nil {
+		return err
+	}
+
+	if outbox == nil {
+		if err := emitter.Emit(event); err != nil {
+			logger.Warn("couldn't emit ingest event", log.Location(ingest.Location), log.Err(err))
+		}
+	}
+
+	return nil
+}
+
+// convertObject writes the records of a structured object to output as parquet, attaching the conversion, the
+// lineage back to the object and the statistics of its columns to it. location is where output ends up in the curated zone.
+func convertObject(ds *dataset.Dataset, object *models_v1.Object, content io.Reader, output io.Writer, location string) error {
+	converter, err := convert.NewParquetConverter(ds.Compression, ds.RowGroupSize)
+	if err != nil {
+		return err
+	}
+
+	reader, err := records.NewReader(object.Schema, content)
+	if err != nil {
+		return err
+	}
+
+	// statistics are collected from the values as they are written, the records are only read once
+	collector := stats.NewCollector(object.Schema)
+	converter.Observe(collector.Add)
+
+	lineage := &models_v1.Lineage{
+		Sources:       []string{object.FileLocation},
+		Checksums:     []string{object.Checksum},
+		Dataset:       ds.Name,
+		SchemaVersion: object.Schema.Version,
+		Timestamp:     time.Now().UnixMilli(),
+	}
+
+	conversion, err := converter.Convert(object.Schema, reader, output, lineage)
+	if err != nil {
+		return fmt.Errorf("failed to convert %v to parquet: %v", object.FileLocation, err)
+	}
+
+	conversion.Location = location
+	object.Conversion = conversion
+	object.Statistics = collector.Statistics()
+
+	return nil
+}
+
+// collectStatistics attaches the statistics of the columns of a structured object that wasn't converted to it.
+func collectStatistics(object *models_v1.Object, content io.Reader) error {
+	reader, err := records.NewReader(object.Schema, content)
+	if err != nil {
+		return err
+	}
+
+	statistics, err := stats.Collect(object.Schema, reader)
+	if err != nil {
+		return err
+	}
+
+	object.Statistics = statistics
+
+	return nil
+}
+
+// checksum returns the hex SHA-256 of an object's content.
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// ingestEvent describes how processing an object ended. object and err are what processing returned, the object is
+// nil when it failed or the object was rejected, dataset names the dataset of the location for those.
+func ingestEvent(location string, dataset string, object *models_v1.Object, started time.Time, err error) *models_v1.IngestEvent {
+	event := &models_v1.IngestEvent{
+		Location: location,
+		Dataset:  dataset,
+		Started:  started.UnixMilli(),
+		Duration: time.Since(started).Milliseconds(),
+		Object:   object,
+	}
+
+	var invalid *protovalidate.ValidationError
+	switch {
+	case errors.As(err, &invalid):
+		event.Status = models_v1.IngestEvent_REJECTED
+		event.Error = err.Error()
+	case err != nil:
+		event.Status = models_v1.IngestEvent_FAILED
+		event.Error = err.Error()
+	case object == nil:
+		event.Status = models_v1.IngestEvent_REJECTED
+	case object.GetQuality().GetQuarantineLocation() != "":
+		event.Status = models_v1.IngestEvent_QUARANTINED
+	default:
+		event.Status = models_v1.IngestEvent_SUCCEEDED
+	}
+
+	if object != nil {
+		event.Dataset = object.Dataset
+		event.Checksum = object.Checksum
+	}
+	event.Id = ingestEventId(event)
+
+	return event
+}
+
+// ingestEventId derives the id of an ingest event from the dataset, location and checksum of the object and how
+// ingesting it ended, an object ingested again unchanged has the same id and its event is dropped as a duplicate.
+func ingestEventId(event *models_v1.IngestEvent) string {
+	name := strings.Join([]string{event.Dataset, event.Location, event.Checksum, event.Status.String()}, "\n")
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(name)).String()
+}
+
+// emitIngested emits an ingest event carrying the trace in ctx, telling whether it was. Objects are ingested whether
+// or not it can be delivered.
+func emitIngested(ctx context.Context, emitter *events.Emitter, logger log.Logger, ingest *models_v1.IngestEvent) bool {
+	event, err := emitter.NewIngested(ingest)
+	if err == nil {
+		err = emitter.Emit(events.WithTraceContext(ctx, event))
+	}
+	if err != nil {
+		logger.Warn("couldn't emit ingest event", log.Location(ingest.Location), log.Err(err))
+		return false
+	}
+	return true
+}
+
+// emittedLimit is how many event ids a processor remembers. An object forgotten and processed again unchanged is
+// emitted again with the same id, for the outbox or the receivers to drop as a duplicate.
+const emittedLimit = 100_000
+
+// emitted keeps the ids of the ingest events a processor emitted, so objects processed again unchanged aren't told
+// about again. Past its limit the least recently seen ids are forgotten. A nil one remembers nothing.
+type emitted struct {
+	lock  sync.Mutex
+	limit int
+	order *list.List
+	ids   map[string]*list.Element
+}
+
+func newEmitted(limit int) *emitted {
+	return &emitted{limit: limit, order: list.New(), ids: make(map[string]*list.Element)}
+}
+
+func (e *emitted) has(id string) bool {
+	if e == nil {
+		return false
+	}
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	element, ok := e.ids[id]
+	if ok {
+		e.order.MoveToFront(element)
+	}
+	return ok
+}
+
+func (e *emitted) add(id string) {
+	if e == nil {
+		return
+	}
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if element, ok := e.ids[id]; ok {
+		e.order.MoveToFront(element)
+		return
+	}
+	e.ids[id] = e.order.PushFront(id)
+
+	if e.order.Len() > e.limit {
+		oldest := e.order.Back()
+		e.order.Remove(oldest)
+		delete(e.ids, oldest.Value.(string))
+	}
+}
+
+// emitSchemaChanged emits a change of a dataset's schema, the object is ingested whether or not it can be delivered.
+func emitSchemaChanged(emitter *events.Emitter, logger log.Logger, change *models_v1.SchemaChange) {
+	if err := emitter.SchemaChanged(change); err != nil {
+		logger.Warn("couldn't emit schema change of dataset", log.Dataset(change.Dataset), log.Err(err))
+	}
+}
@@ -1,9 +1,12 @@
 package ingest
 
 import (
+	"strings"
 	"testing"
 
 	modelsv1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/codingexplorations/data-lake/pkg/log"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -89,3 +92,26 @@ func TestFolderIngest_ProcessFile_validateTable(t *testing.T) {
 		})
 	}
 }
+
+func TestGetIngestProcessor_Failure(t *testing.T) {
+	// a processor that can't be created is an error rather than a nil processor
+	processor, err := GetIngestProcessor(&config.Config{IngestProcessorType: "local", DatasetsFile: "/tmp/should/not/be/there/datasets.yaml"}, log.NewConsoleLog())
+
+	assert.Nil(t, processor)
+	assert.True(t, strings.HasPrefix(err.Error(), "couldn't load datasets: "), err.Error())
+}
+
+func Test_emitted_Limit(t *testing.T) {
+	sent := newEmitted(2)
+
+	sent.add("a")
+	sent.add("b")
+	assert.True(t, sent.has("a"))
+
+	// past the limit the least recently seen id is forgotten
+	sent.add("c")
+	assert.True(t, sent.has("a"))
+	assert.False(t, sent.has("b"))
+	assert.True(t, sent.has("c"))
+	assert.Len(t, sent.ids, 2)
+}
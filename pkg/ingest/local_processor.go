@@ -1,60 +1,120 @@
 package ingest
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/catalog"
+	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/codingexplorations/data-lake/pkg/convert"
+	"github.com/codingexplorations/data-lake/pkg/dataset"
+	"github.com/codingexplorations/data-lake/pkg/events"
 	"github.com/codingexplorations/data-lake/pkg/log"
+	"github.com/codingexplorations/data-lake/pkg/quality"
+	"github.com/codingexplorations/data-lake/pkg/registry"
+	"github.com/codingexplorations/data-lake/pkg/schema"
+	"github.com/codingexplorations/data-lake/pkg/storage"
+	"github.com/codingexplorations/data-lake/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type LocalIngestProcessorImpl struct {
-	logger log.Logger
+	conf           *config.Config
+	logger         log.Logger
+	datasets       *dataset.Datasets
+	schemaRegistry registry.SchemaRegistry
+	catalog        catalog.Catalog
+	reports        *quality.Reports
+	emitter        *events.Emitter
+	emitted        *emitted
 }
 
-func NewLocalIngestProcessor() *LocalIngestProcessorImpl {
-	logger := log.NewConsoleLog()
+// NewLocalIngestProcessor returns a processor ingesting the files of DATA_FOLDER, failing when the datasets or the
+// event emitter can't be loaded.
+func NewLocalIngestProcessor(conf *config.Config, logger log.Logger) (IngestProcessor, error) {
+	datasets, err := dataset.Load(conf)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't load datasets: %v", err)
+	}
 
-	return &LocalIngestProcessorImpl{
-		logger: logger,
+	tableCatalog := catalog.NewTableCatalog(storage.NewLocalStorage(), conf.CatalogFolder)
+
+	emitter, err := events.GetEmitter(conf, tableCatalog)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create event emitter: %v", err)
 	}
+
+	return &LocalIngestProcessorImpl{
+		conf:           conf,
+		logger:         logger,
+		datasets:       datasets,
+		schemaRegistry: registry.NewLocalSchemaRegistry(conf.SchemaRegistryFolder),
+		catalog:        tableCatalog,
+		reports:        quality.NewReports(storage.NewLocalStorage(), conf.QualityReportsFolder),
+		emitter:        emitter,
+		emitted:        newEmitted(emittedLimit),
+	}, nil
 }
 
-// ProcessFolder processes the file
-func (processor *LocalIngestProcessorImpl) ProcessFolder(folder string) ([]*models_v1.Object, error) {
+// ProcessFolder processes the files of a folder and its subfolders. A file that fails is recorded as failed and the
+// rest are processed all the same, the errors are returned joined.
+func (processor *LocalIngestProcessorImpl) ProcessFolder(ctx context.Context, folder string) (objects []*models_v1.Object, err error) {
+	ctx, span := tracing.Start(ctx, "ProcessFolder", attribute.String(log.LocationKey, folder))
+	defer func() { tracing.End(span, err) }()
+
 	entries, err := os.ReadDir(folder)
 	if err != nil {
 		return nil, err
 	}
 
 	processedObjects := make([]*models_v1.Object, 0)
+	var errs []error
 
 	for _, entry := range entries {
 		if entry.IsDir() {
-			if processedFolderObjects, err := processor.ProcessFolder(folder + "/" + entry.Name()); err != nil {
-				return nil, err
-			} else {
-				processedObjects = append(processedObjects, processedFolderObjects...)
-			}
+			processedFolderObjects, err := processor.ProcessFolder(ctx, folder+"/"+entry.Name())
+			processedObjects = append(processedObjects, processedFolderObjects...)
+			errs = append(errs, err)
 		} else {
-			if processedFile, err := processor.ProcessFile(folder + "/" + entry.Name()); err != nil {
-				return nil, err
+			if processedFile, err := processor.ProcessFile(ctx, folder+"/"+entry.Name()); err != nil {
+				errs = append(errs, err)
 			} else {
 				processedObjects = append(processedObjects, processedFile)
 			}
 		}
 	}
 
-	return processedObjects, nil
+	return processedObjects, errors.Join(errs...)
+}
+
+// ProcessFile processes the file, catalogs it and publishes how that went
+func (processor *LocalIngestProcessorImpl) ProcessFile(ctx context.Context, fileName string) (processed *models_v1.Object, err error) {
+	dataset := processor.datasetName(fileName)
+	ctx, span := tracing.Start(ctx, "ProcessFile", attribute.String(log.LocationKey, fileName), attribute.String(log.DatasetKey, dataset))
+	defer func() { tracing.End(span, err) }()
+
+	started := time.Now()
+	object, file, err := processor.processFile(ctx, fileName)
+
+	return record(ctx, processor.catalog, processor.emitter, processor.emitted, log.WithTrace(processor.logger, ctx), fileName, dataset, object, file, started, err)
 }
 
-// ProcessFile processes the file
-func (processor *LocalIngestProcessorImpl) ProcessFile(fileName string) (*models_v1.Object, error) {
+func (processor *LocalIngestProcessorImpl) processFile(ctx context.Context, fileName string) (*models_v1.Object, *models_v1.DataFile, error) {
+	// everything logged processing the object carries the trace and span it's processed in
+	logger := log.WithTrace(processor.logger, ctx)
+
 	// read the file
 	data, err := os.ReadFile(fileName)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	fileSize := len(data)
@@ -66,18 +126,120 @@ func (processor *LocalIngestProcessorImpl) ProcessFile(fileName string) (*models
 		FileLocation: fileName,
 		ContentType:  "text/plain",
 		ContentSize:  int32(fileSize),
+		Checksum:     checksum(data),
 	}
 
 	valid, err := validate(object)
 	if err != nil {
-		processor.logger.Error(fmt.Sprintf("error validating object: %v\n", err))
-		return nil, err
+		logger.Error("error validating object", log.Location(fileName), log.Err(err))
+		return nil, nil, err
 	}
 
 	if !valid {
-		processor.logger.Error(fmt.Sprintf("object is not valid: %v\n", object))
-		return nil, nil
+		logger.Error("object is not valid", log.Location(fileName), slog.Any("object", object))
+		return nil, nil, nil
 	}
 
-	return object, nil
+	format := schema.DetectFormat(object.FileName, object.ContentType, data)
+	if err := inferSchema(object, format, bytes.NewReader(data), processor.conf.SchemaSampleSize); err != nil {
+		logger.Warn("couldn't infer schema", log.Location(fileName), log.Err(err))
+	}
+
+	var file *models_v1.DataFile
+	if processor.datasets != nil {
+		ds := processor.datasets.Resolve(strings.TrimPrefix(fileName, processor.conf.DataFolder))
+		object.Dataset = ds.Name
+
+		// objects the catalog already has with the same content, directly or through a compacted file, were registered,
+		// checked and curated when they were first ingested
+		if processor.catalog != nil {
+			catalogued, err := catalog.Catalogued(processor.catalog, ds.Name, object.FileLocation, object.Checksum)
+			if err != nil {
+				logger.Error("couldn't look up object in catalog", log.Location(fileName), log.Err(err))
+				return nil, nil, err
+			}
+			if catalogued {
+				return object, nil, errCatalogued
+			}
+		}
+
+		if err := declareSchema(ds, object); err != nil {
+			logger.Error("error validating object", log.Location(fileName), log.Err(err))
+			return nil, nil, err
+		}
+
+		change, err := registerSchema(processor.schemaRegistry, ds, object)
+		if err != nil {
+			logger.Error("error validating object", log.Location(fileName), log.Err(err))
+			return nil, nil, err
+		}
+		if change != nil && processor.emitter != nil {
+			emitSchemaChanged(processor.emitter, logger, change)
+		}
+
+		if ds.Message != "" {
+			if err := validateRecords(ds, object, bytes.NewReader(data), processor.conf.RecordViolationLimit); err != nil {
+				logger.Error("error validating object", log.Location(fileName), log.Err(err))
+				return nil, nil, err
+			}
+		}
+
+		quarantined, err := checkQuality(processor.catalog, processor.reports, ds, object, bytes.NewReader(data), func() (string, error) {
+			return processor.quarantine(object, data)
+		})
+		if err != nil {
+			logger.Error("error checking quality of object", log.Location(fileName), log.Err(err))
+			return nil, nil, err
+		}
+		if object.Quality != nil && !object.Quality.Passed {
+			logger.Warn("object failed expectations of dataset", log.Location(fileName), log.Dataset(ds.Name), slog.Any("failed", quality.Failed(object.Quality)))
+		}
+		if quarantined {
+			return object, nil, nil
+		}
+
+		if object.Schema != nil && processor.conf.CuratedFolder != "" {
+			if file, err = processor.convert(ds, object, data); err != nil {
+				logger.Error("error converting object", log.Location(fileName), log.Err(err))
+				return nil, nil, err
+			}
+		}
+	}
+
+	if object.Schema != nil && object.Statistics == nil {
+		if err := collectStatistics(object, bytes.NewReader(data)); err != nil {
+			logger.Warn("couldn't collect statistics", log.Location(fileName), log.Err(err))
+		}
+	}
+
+	return object, file, nil
+}
+
+// convert writes the object to the same path under the curated folder as parquet, returning the file to catalog.
+func (processor *LocalIngestProcessorImpl) convert(ds *dataset.Dataset, object *models_v1.Object, data []byte) (*models_v1.DataFile, error) {
+	relative := strings.TrimPrefix(object.FileLocation, processor.conf.DataFolder)
+	location := filepath.Join(processor.conf.CuratedFolder, convert.ParquetLocation(relative))
+
+	return curate(processor.catalog, storage.NewLocalStorage(), ds, object, data, location, ds.Partition(relative))
+}
+
+// quarantine moves a failing object to the same path under the quarantine folder, where the next runs don't pick it up.
+func (processor *LocalIngestProcessorImpl) quarantine(object *models_v1.Object, data []byte) (string, error) {
+	relative := strings.TrimPrefix(object.FileLocation, processor.conf.DataFolder)
+	location := filepath.Join(processor.conf.QuarantineFolder, relative)
+
+	local := storage.NewLocalStorage()
+	if err := local.Write(location, bytes.NewReader(data), object.ContentType); err != nil {
+		return "", err
+	}
+
+	return location, local.Delete(object.FileLocation)
+}
+
+// datasetName returns the dataset of a file, for events about files that weren't processed.
+func (processor *LocalIngestProcessorImpl) datasetName(fileName string) string {
+	if processor.datasets == nil {
+		return ""
+	}
+	return processor.datasets.Resolve(strings.TrimPrefix(fileName, processor.conf.DataFolder)).Name
 }
@@ -1,10 +1,33 @@
 package ingest
 
 import (
+	"context"
+	"errors"
+	"io/fs"
+	"log/slog"
 	"os"
 	"testing"
 
+	cloudevents "github.com/cloudevents/sdk-go/v2/event"
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/catalog"
+	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/codingexplorations/data-lake/pkg/dataset"
+	"github.com/codingexplorations/data-lake/pkg/events"
+	"github.com/codingexplorations/data-lake/pkg/log"
+	"github.com/codingexplorations/data-lake/pkg/quality"
+	"github.com/codingexplorations/data-lake/pkg/registry"
+	"github.com/codingexplorations/data-lake/pkg/storage"
+	"github.com/codingexplorations/data-lake/pkg/validation"
+	catalogMocks "github.com/codingexplorations/data-lake/test/mocks/pkg/catalog"
+	eventsMocks "github.com/codingexplorations/data-lake/test/mocks/pkg/events"
+	logMocks "github.com/codingexplorations/data-lake/test/mocks/pkg/log"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
 )
 
 func TestFolderIngest_ProcessFolder_CheckDepth(t *testing.T) {
@@ -27,11 +50,11 @@ func TestFolderIngest_ProcessFolder_CheckDepth(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			processor := &LocalIngestProcessorImpl{}
+			processor := &LocalIngestProcessorImpl{conf: &config.Config{}}
 
 			pwd, _ := os.Getwd()
 
-			processedObjects, err := processor.ProcessFolder(pwd + tc.folder)
+			processedObjects, err := processor.ProcessFolder(context.Background(), pwd+tc.folder)
 
 			assert.Nil(t, err)
 			assert.Len(t, processedObjects, 1)
@@ -44,13 +67,13 @@ func TestFolderIngest_ProcessFolder_CheckDepth(t *testing.T) {
 }
 
 func TestFolderIngest_ProcessFile_Success(t *testing.T) {
-	processor := &LocalIngestProcessorImpl{}
+	processor := &LocalIngestProcessorImpl{conf: &config.Config{}}
 
 	pwd, _ := os.Getwd()
 
 	fileName := pwd + "/../../test/files/ingest/test.txt"
 
-	processedObject, err := processor.ProcessFile(fileName)
+	processedObject, err := processor.ProcessFile(context.Background(), fileName)
 
 	assert.Nil(t, err)
 	assert.Equal(t, "test.txt", processedObject.FileName)
@@ -60,28 +83,600 @@ func TestFolderIngest_ProcessFile_Success(t *testing.T) {
 }
 
 func TestFolderIngest_ProcessFolder_Failure(t *testing.T) {
-	processor := &LocalIngestProcessorImpl{}
+	processor := &LocalIngestProcessorImpl{conf: &config.Config{}}
 
 	pwd, _ := os.Getwd()
 
 	folder := pwd + "/../../test/files/missing"
 
-	processedObject, err := processor.ProcessFolder(folder)
+	processedObject, err := processor.ProcessFolder(context.Background(), folder)
 
 	assert.Error(t, err)
 	assert.Equal(t, "open /Users/benjaminparrish/Development/CodingExplorations/data-lake/pkg/ingest/../../test/files/missing: no such file or directory", err.Error())
 	assert.Nil(t, processedObject)
 }
 
+func TestFolderIngest_ProcessFolder_ContinuesAfterFailure(t *testing.T) {
+	conf := &config.Config{DataFolder: t.TempDir(), SchemaSampleSize: 1000}
+	processor := &LocalIngestProcessorImpl{
+		conf:           conf,
+		logger:         log.NewConsoleLog(),
+		datasets:       dataset.NewDatasets(conf, []*dataset.Dataset{{Name: "orders", Compatibility: "FULL"}}),
+		schemaRegistry: registry.NewLocalSchemaRegistry(t.TempDir()),
+	}
+
+	_ = os.MkdirAll(conf.DataFolder+"/orders", 0755)
+	_ = os.WriteFile(conf.DataFolder+"/orders/01.csv", []byte("id,amount\n1,10.5\n"), 0644)
+	_ = os.WriteFile(conf.DataFolder+"/orders/02.csv", []byte("id\n2\n"), 0644)
+	_ = os.WriteFile(conf.DataFolder+"/orders/03.csv", []byte("id,amount\n3,4\n"), 0644)
+
+	// the incompatible file doesn't keep the ones after it from being ingested
+	processedObjects, err := processor.ProcessFolder(context.Background(), conf.DataFolder)
+
+	var incompatible *registry.IncompatibleSchemaError
+	assert.ErrorAs(t, err, &incompatible)
+	assert.Len(t, processedObjects, 2)
+	assert.Equal(t, conf.DataFolder+"/orders/01.csv", processedObjects[0].FileLocation)
+	assert.Equal(t, conf.DataFolder+"/orders/03.csv", processedObjects[1].FileLocation)
+}
+
 func TestFolderIngest_ProcessFile_Failure(t *testing.T) {
-	processor := &LocalIngestProcessorImpl{}
+	processor := &LocalIngestProcessorImpl{conf: &config.Config{}}
 
 	pwd, _ := os.Getwd()
 
 	fileName := pwd + "/../../test/files/ingest/missing.txt"
 
-	processedObject, err := processor.ProcessFile(fileName)
+	processedObject, err := processor.ProcessFile(context.Background(), fileName)
+
+	assert.Error(t, err)
+	assert.Nil(t, processedObject)
+}
+
+func TestFolderIngest_ProcessFile_InferSchema(t *testing.T) {
+	processor := &LocalIngestProcessorImpl{conf: &config.Config{SchemaSampleSize: 1000}}
+
+	fileName := t.TempDir() + "/orders.csv"
+	_ = os.WriteFile(fileName, []byte("id,amount\n1,10.5\n2,3\n"), 0644)
+
+	processedObject, err := processor.ProcessFile(context.Background(), fileName)
+
+	assert.Nil(t, err)
+	assert.Equal(t, models_v1.Schema_CSV, processedObject.Schema.Format)
+	assert.True(t, processedObject.Schema.Header)
+	assert.Len(t, processedObject.Schema.Fields, 2)
+	assert.Equal(t, "amount", processedObject.Schema.Fields[1].Name)
+	assert.Equal(t, models_v1.Field_NUMBER, processedObject.Schema.Fields[1].Type)
+
+	// structured objects that aren't converted have their statistics collected on their own
+	assert.Equal(t, int64(2), processedObject.Statistics.Rows)
+	assert.Equal(t, 10.5, processedObject.Statistics.Columns[1].Max.GetNumber())
+}
+
+func TestFolderIngest_ProcessFile_RegisterSchema(t *testing.T) {
+	conf := &config.Config{DataFolder: t.TempDir(), SchemaSampleSize: 1000, SchemaCompatibility: "BACKWARD"}
+	processor := &LocalIngestProcessorImpl{
+		conf:           conf,
+		logger:         log.NewConsoleLog(),
+		datasets:       dataset.NewDatasets(conf, nil),
+		schemaRegistry: registry.NewLocalSchemaRegistry(t.TempDir()),
+	}
+
+	_ = os.MkdirAll(conf.DataFolder+"/orders", 0755)
+	_ = os.WriteFile(conf.DataFolder+"/orders/01.csv", []byte("id,amount\n1,10\n"), 0644)
+	_ = os.WriteFile(conf.DataFolder+"/orders/02.csv", []byte("id,amount,note\n2,3.5,\n"), 0644)
+	_ = os.WriteFile(conf.DataFolder+"/orders/03.csv", []byte("id,amount\nthree,1.5\n"), 0644)
+
+	processedObject, err := processor.ProcessFile(context.Background(), conf.DataFolder+"/orders/01.csv")
+	assert.Nil(t, err)
+	assert.Equal(t, "orders", processedObject.Dataset)
+	assert.Equal(t, int32(1), processedObject.Schema.Version)
+
+	processedObject, err = processor.ProcessFile(context.Background(), conf.DataFolder+"/orders/02.csv")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), processedObject.Schema.Version)
+
+	processedObject, err = processor.ProcessFile(context.Background(), conf.DataFolder+"/orders/03.csv")
+	assert.Nil(t, processedObject)
+	assert.Error(t, err)
+	assert.Equal(t, "schema is not backward compatible with version 2 of dataset orders: field id changed type from INTEGER to STRING", err.Error())
+}
+
+func TestFolderIngest_ProcessFile_ValidateRecords(t *testing.T) {
+	set := &descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{protodesc.ToFileDescriptorProto(models_v1.File_models_v1_schema_proto)},
+	}
+	data, _ := proto.Marshal(set)
+	descriptorSetFile := t.TempDir() + "/descriptors.binpb"
+	_ = os.WriteFile(descriptorSetFile, data, 0644)
+
+	conf := &config.Config{DataFolder: t.TempDir(), SchemaSampleSize: 1000, SchemaCompatibility: "BACKWARD", RecordViolationLimit: 10}
+	processor := &LocalIngestProcessorImpl{
+		conf:   conf,
+		logger: log.NewConsoleLog(),
+		datasets: dataset.NewDatasets(conf, []*dataset.Dataset{
+			{Name: "objects", DescriptorSet: descriptorSetFile, Message: "models.v1.Object"},
+		}),
+		schemaRegistry: registry.NewLocalSchemaRegistry(t.TempDir()),
+	}
+
+	_ = os.MkdirAll(conf.DataFolder+"/objects", 0755)
+	_ = os.WriteFile(conf.DataFolder+"/objects/01.csv", []byte("file_name,file_location,content_type,content_size\na.txt,/a,text/plain,10\n"), 0644)
+	_ = os.WriteFile(conf.DataFolder+"/objects/02.csv", []byte("file_name,file_location,content_type,content_size\nb.txt,/b,text/plain,10\nc.txt,/c,text/plain,0\n"), 0644)
+
+	processedObject, err := processor.ProcessFile(context.Background(), conf.DataFolder+"/objects/01.csv")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), processedObject.Validation.Records)
+	assert.Equal(t, int32(0), processedObject.Validation.InvalidRecords)
+
+	processedObject, err = processor.ProcessFile(context.Background(), conf.DataFolder+"/objects/02.csv")
+	assert.Nil(t, processedObject)
+	var validationErr *validation.RecordValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "1 of 2 records are not valid models.v1.Object messages, first on line 3: content_size: value must be greater than 0 and less than or equal to 1048576", err.Error())
+}
+
+func TestFolderIngest_ProcessFile_Convert(t *testing.T) {
+	conf := &config.Config{DataFolder: t.TempDir(), SchemaSampleSize: 1000, CuratedFolder: t.TempDir(), SchemaCompatibility: "BACKWARD", ParquetCompression: "ZSTD", ParquetRowGroupSize: 1}
+	processor := &LocalIngestProcessorImpl{
+		conf:           conf,
+		logger:         log.NewConsoleLog(),
+		datasets:       dataset.NewDatasets(conf, nil),
+		schemaRegistry: registry.NewLocalSchemaRegistry(t.TempDir()),
+	}
+
+	_ = os.MkdirAll(conf.DataFolder+"/orders", 0755)
+	_ = os.WriteFile(conf.DataFolder+"/orders/01.csv", []byte("id,amount\n1,10.5\n2,3\n"), 0644)
+	_ = os.WriteFile(conf.DataFolder+"/orders/02.csv", []byte("id,amount\n3,1.5\n4,x\n"), 0644)
+
+	processedObject, err := processor.ProcessFile(context.Background(), conf.DataFolder+"/orders/01.csv")
+
+	assert.Nil(t, err)
+	assert.Equal(t, conf.CuratedFolder+"/orders/01.parquet", processedObject.Conversion.Location)
+	assert.Equal(t, models_v1.Conversion_ZSTD, processedObject.Conversion.Compression)
+	assert.Equal(t, int32(2), processedObject.Conversion.Records)
+	assert.Equal(t, int32(2), processedObject.Conversion.RowGroups)
+	assert.True(t, proto.Equal(&models_v1.Lineage{
+		Sources:       []string{conf.DataFolder + "/orders/01.csv"},
+		Checksums:     []string{processedObject.Checksum},
+		Dataset:       "orders",
+		SchemaVersion: 1,
+		Timestamp:     processedObject.Conversion.Lineage.Timestamp,
+	}, processedObject.Conversion.Lineage))
+
+	info, err := os.Stat(processedObject.Conversion.Location)
+	assert.Nil(t, err)
+	assert.Equal(t, processedObject.Conversion.ContentSize, info.Size())
+
+	assert.Equal(t, int64(2), processedObject.Statistics.Rows)
+	amount := processedObject.Statistics.Columns[1]
+	assert.Equal(t, "amount", amount.Name)
+	assert.Equal(t, int64(2), amount.Distinct)
+	assert.Equal(t, 3.0, amount.Min.GetNumber())
+	assert.Equal(t, 10.5, amount.Max.GetNumber())
+
+	// the inferred schema reads amount as a number, the second file only fails once it's converted
+	processedObject, err = processor.ProcessFile(context.Background(), conf.DataFolder+"/orders/02.csv")
+	assert.Nil(t, processedObject)
+	assert.Error(t, err)
+
+	entries, _ := os.ReadDir(conf.CuratedFolder + "/orders")
+	assert.Len(t, entries, 1)
+}
+
+func TestFolderIngest_ProcessFile_Catalog(t *testing.T) {
+	conf := &config.Config{DataFolder: t.TempDir(), SchemaSampleSize: 1000, CuratedFolder: t.TempDir(), SchemaCompatibility: "BACKWARD", ParquetCompression: "SNAPPY", ParquetRowGroupSize: 100}
+	tableCatalog := catalog.NewTableCatalog(storage.NewLocalStorage(), t.TempDir())
+	processor := &LocalIngestProcessorImpl{
+		conf:           conf,
+		logger:         log.NewConsoleLog(),
+		datasets:       dataset.NewDatasets(conf, nil),
+		schemaRegistry: registry.NewLocalSchemaRegistry(t.TempDir()),
+		catalog:        tableCatalog,
+	}
+
+	_ = os.MkdirAll(conf.DataFolder+"/orders/2024", 0755)
+	_ = os.WriteFile(conf.DataFolder+"/orders/2024/01.csv", []byte("id,amount\n1,10.5\n2,3\n"), 0644)
+
+	processedObject, err := processor.ProcessFile(context.Background(), conf.DataFolder+"/orders/2024/01.csv")
+	assert.Nil(t, err)
+	assert.NotNil(t, processedObject.Conversion)
+
+	files, _ := tableCatalog.Files("orders")
+	assert.Len(t, files, 1)
+	assert.Equal(t, conf.CuratedFolder+"/orders/2024/01.parquet", files[0].Location)
+	assert.Equal(t, "2024", files[0].Partition)
+	assert.Equal(t, models_v1.Schema_PARQUET, files[0].Format)
+	assert.Equal(t, int32(1), files[0].SchemaVersion)
+	assert.Equal(t, int32(2), files[0].Records)
+	assert.True(t, proto.Equal(processedObject.Statistics, files[0].Statistics))
+
+	// the runner goes over the data folder again on every run, objects already in the catalog aren't converted again
+	processedObject, err = processor.ProcessFile(context.Background(), conf.DataFolder+"/orders/2024/01.csv")
+	assert.Nil(t, err)
+	assert.Nil(t, processedObject.Conversion)
+
+	files, _ = tableCatalog.Files("orders")
+	assert.Len(t, files, 1)
+}
+
+func TestFolderIngest_ProcessFile_Arrived(t *testing.T) {
+	conf := &config.Config{DataFolder: t.TempDir(), SchemaSampleSize: 1000, SchemaCompatibility: "BACKWARD"}
+	tableCatalog := catalog.NewTableCatalog(storage.NewLocalStorage(), t.TempDir())
+	processor := &LocalIngestProcessorImpl{
+		conf:           conf,
+		logger:         log.NewConsoleLog(),
+		datasets:       dataset.NewDatasets(conf, nil),
+		schemaRegistry: registry.NewLocalSchemaRegistry(t.TempDir()),
+		catalog:        tableCatalog,
+	}
+
+	_ = os.MkdirAll(conf.DataFolder+"/orders", 0755)
+	_ = os.WriteFile(conf.DataFolder+"/orders/01.csv", []byte("id,amount\n1,10.5\n"), 0644)
+	_ = os.WriteFile(conf.DataFolder+"/orders/notes.txt", []byte("some notes"), 0644)
+
+	// without a curated folder, and for unstructured objects, there's no file to list, only the arrival to record
+	for _, file := range []string{"01.csv", "notes.txt"} {
+		processedObject, err := processor.ProcessFile(context.Background(), conf.DataFolder+"/orders/"+file)
+		assert.Nil(t, err)
+		assert.Nil(t, processedObject.Conversion)
+	}
+
+	files, _ := tableCatalog.Files("orders")
+	assert.Empty(t, files)
+
+	arrived, err := tableCatalog.Arrived("orders")
+	assert.Nil(t, err)
+	assert.Len(t, arrived, 2)
+	assert.Equal(t, []string{conf.DataFolder + "/orders/notes.txt"}, arrived[1].Sources)
+	assert.NotEmpty(t, arrived[1].Checksums[0])
+
+	// arrived objects are catalogued, they aren't processed again
+	catalogued, err := catalog.Catalogued(tableCatalog, "orders", conf.DataFolder+"/orders/notes.txt", arrived[1].Checksums[0])
+	assert.Nil(t, err)
+	assert.True(t, catalogued)
+}
+
+func TestFolderIngest_ProcessFile_Quality(t *testing.T) {
+	conf := &config.Config{DataFolder: t.TempDir(), SchemaSampleSize: 1000, CuratedFolder: t.TempDir(), QuarantineFolder: t.TempDir(), SchemaCompatibility: "BACKWARD", ParquetCompression: "SNAPPY", ParquetRowGroupSize: 100}
+	reports := quality.NewReports(storage.NewLocalStorage(), t.TempDir())
+	min := 0.0
+	processor := &LocalIngestProcessorImpl{
+		conf:   conf,
+		logger: log.NewConsoleLog(),
+		datasets: dataset.NewDatasets(conf, []*dataset.Dataset{
+			{Name: "orders", Quarantine: true, Expectations: []*quality.Expectation{{Type: quality.Between, Column: "amount", Min: &min}}},
+			{Name: "returns", Expectations: []*quality.Expectation{{Type: quality.In, Column: "reason", Values: []string{"late", "damaged"}}}},
+		}),
+		schemaRegistry: registry.NewLocalSchemaRegistry(t.TempDir()),
+		catalog:        catalog.NewTableCatalog(storage.NewLocalStorage(), t.TempDir()),
+		reports:        reports,
+	}
+
+	_ = os.MkdirAll(conf.DataFolder+"/orders", 0755)
+	_ = os.MkdirAll(conf.DataFolder+"/returns", 0755)
+	_ = os.WriteFile(conf.DataFolder+"/orders/01.csv", []byte("id,amount\n1,10.5\n2,3\n"), 0644)
+	_ = os.WriteFile(conf.DataFolder+"/orders/02.csv", []byte("id,amount\n3,-1.5\n"), 0644)
+	_ = os.WriteFile(conf.DataFolder+"/returns/01.csv", []byte("id,reason\n1,broken\n2,late\n"), 0644)
+
+	processedObject, err := processor.ProcessFile(context.Background(), conf.DataFolder+"/orders/01.csv")
+	assert.Nil(t, err)
+	assert.True(t, processedObject.Quality.Passed)
+	assert.NotNil(t, processedObject.Conversion)
+
+	// failing objects of a dataset that quarantines them are moved out of the data folder instead of being curated
+	processedObject, err = processor.ProcessFile(context.Background(), conf.DataFolder+"/orders/02.csv")
+	assert.Nil(t, err)
+	assert.False(t, processedObject.Quality.Passed)
+	assert.Equal(t, []int32{2}, processedObject.Quality.Results[0].FailedLines)
+	assert.Equal(t, conf.QuarantineFolder+"/orders/02.csv", processedObject.Quality.QuarantineLocation)
+	assert.Nil(t, processedObject.Conversion)
+	_, err = os.Stat(conf.DataFolder + "/orders/02.csv")
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+	_, err = os.Stat(processedObject.Quality.QuarantineLocation)
+	assert.Nil(t, err)
+
+	report, err := reports.Read("orders", conf.DataFolder+"/orders/02.csv")
+	assert.Nil(t, err)
+	assert.True(t, proto.Equal(processedObject.Quality, report))
+
+	// other datasets only report them
+	processedObject, err = processor.ProcessFile(context.Background(), conf.DataFolder+"/returns/01.csv")
+	assert.Nil(t, err)
+	assert.False(t, processedObject.Quality.Passed)
+	assert.Empty(t, processedObject.Quality.QuarantineLocation)
+	assert.NotNil(t, processedObject.Conversion)
+
+	// objects already in the catalog were checked when they were first ingested
+	processedObject, err = processor.ProcessFile(context.Background(), conf.DataFolder+"/orders/01.csv")
+	assert.Nil(t, err)
+	assert.Nil(t, processedObject.Quality)
+}
+
+func TestFolderIngest_ProcessFile_Events(t *testing.T) {
+	conf := &config.Config{DataFolder: t.TempDir(), SchemaSampleSize: 1000, QuarantineFolder: t.TempDir(), SchemaCompatibility: "BACKWARD"}
+	publisher := eventsMocks.NewPublisher(t)
+	min := 0.0
+	processor := &LocalIngestProcessorImpl{
+		conf:   conf,
+		logger: log.NewConsoleLog(),
+		datasets: dataset.NewDatasets(conf, []*dataset.Dataset{
+			{Name: "orders", Quarantine: true, Expectations: []*quality.Expectation{{Type: quality.Between, Column: "amount", Min: &min}}},
+		}),
+		schemaRegistry: registry.NewLocalSchemaRegistry(t.TempDir()),
+		emitter:        events.NewEmitter("/data-lake", publisher),
+	}
+
+	_ = os.MkdirAll(conf.DataFolder+"/orders", 0755)
+	_ = os.WriteFile(conf.DataFolder+"/orders/01.csv", []byte("id,amount\n1,10.5\n2,3\n"), 0644)
+	_ = os.WriteFile(conf.DataFolder+"/orders/02.csv", []byte("id,amount\n3,-1.5\n4,2\n"), 0644)
+	_ = os.WriteFile(conf.DataFolder+"/orders/03.csv", []byte{}, 0644)
+
+	ingested := make([]*models_v1.IngestEvent, 0)
+	types := make([]string, 0)
+	changes := make([]*models_v1.SchemaChange, 0)
+	publisher.On("Publish", mock.Anything).Run(func(args mock.Arguments) {
+		event := args.Get(0).(cloudevents.Event)
+		if event.Type() == events.SchemaChanged {
+			change := &models_v1.SchemaChange{}
+			assert.Nil(t, proto.Unmarshal(event.Data(), change))
+			changes = append(changes, change)
+			return
+		}
+		ingest := &models_v1.IngestEvent{}
+		assert.Nil(t, proto.Unmarshal(event.Data(), ingest))
+		assert.Equal(t, ingest.Id, event.ID())
+		ingested = append(ingested, ingest)
+		types = append(types, event.Type())
+	}).Return(nil)
 
+	processedObject, err := processor.ProcessFile(context.Background(), conf.DataFolder+"/orders/01.csv")
+	assert.Nil(t, err)
+	_, _ = processor.ProcessFile(context.Background(), conf.DataFolder+"/orders/02.csv")
+	_, _ = processor.ProcessFile(context.Background(), conf.DataFolder+"/orders/03.csv")
+	_, err = processor.ProcessFile(context.Background(), conf.DataFolder+"/orders/04.csv")
 	assert.Error(t, err)
+
+	assert.Len(t, ingested, 4)
+	assert.Equal(t, []string{events.ObjectIngested, events.ObjectQuarantined, events.ObjectRejected, events.ObjectFailed}, types)
+	assert.Equal(t, models_v1.IngestEvent_SUCCEEDED, ingested[0].Status)
+	assert.Equal(t, conf.DataFolder+"/orders/01.csv", ingested[0].Location)
+	assert.Equal(t, "orders", ingested[0].Dataset)
+	assert.Equal(t, "2ca513909a9d0cd97d4c58dc85455f1104ef4b5167b8a71652700a0e45cf6b86", ingested[0].Checksum)
+	assert.Equal(t, processedObject.Checksum, ingested[0].Checksum)
+	assert.True(t, proto.Equal(processedObject, ingested[0].Object))
+	assert.NotEmpty(t, ingested[0].Id)
+	assert.NotZero(t, ingested[0].Started)
+	assert.Equal(t, models_v1.IngestEvent_QUARANTINED, ingested[1].Status)
+	// empty objects are rejected by validation
+	assert.Equal(t, models_v1.IngestEvent_REJECTED, ingested[2].Status)
+	assert.Nil(t, ingested[2].Object)
+	assert.Equal(t, "orders", ingested[2].Dataset)
+	assert.Contains(t, ingested[2].Error, "content_size")
+	assert.Equal(t, models_v1.IngestEvent_FAILED, ingested[3].Status)
+	assert.Equal(t, err.Error(), ingested[3].Error)
+	assert.NotEqual(t, ingested[0].Id, ingested[1].Id)
+
+	// the first object of a dataset registers its schema, the ones after it with the same columns don't change it
+	assert.Len(t, changes, 1)
+	assert.Equal(t, "orders", changes[0].Dataset)
+	assert.Equal(t, conf.DataFolder+"/orders/01.csv", changes[0].Location)
+	assert.Nil(t, changes[0].Previous)
+	assert.Equal(t, int32(1), changes[0].Schema.Version)
+}
+
+func TestFolderIngest_ProcessFile_EventsUndelivered(t *testing.T) {
+	conf := &config.Config{DataFolder: t.TempDir(), SchemaSampleSize: 1000}
+	publisher := eventsMocks.NewPublisher(t)
+	processor := &LocalIngestProcessorImpl{conf: conf, logger: log.NewConsoleLog(), emitter: events.NewEmitter("/data-lake", publisher)}
+
+	_ = os.WriteFile(conf.DataFolder+"/test.txt", []byte("hello data lake"), 0644)
+	publisher.On("Publish", mock.Anything).Return(errors.New("queue gone"))
+
+	// events that can't be delivered don't fail ingest
+	processedObject, err := processor.ProcessFile(context.Background(), conf.DataFolder+"/test.txt")
+	assert.Nil(t, err)
+	assert.Equal(t, "test.txt", processedObject.FileName)
+}
+
+func TestFolderIngest_ProcessFile_Outbox(t *testing.T) {
+	conf := &config.Config{DataFolder: t.TempDir(), SchemaSampleSize: 1000, CuratedFolder: t.TempDir(), SchemaCompatibility: "BACKWARD", ParquetCompression: "SNAPPY", ParquetRowGroupSize: 100}
+	catalogFolder := t.TempDir()
+	tableCatalog := catalog.NewTableCatalog(storage.NewLocalStorage(), catalogFolder)
+	processor := &LocalIngestProcessorImpl{
+		conf:           conf,
+		logger:         log.NewConsoleLog(),
+		datasets:       dataset.NewDatasets(conf, nil),
+		schemaRegistry: registry.NewLocalSchemaRegistry(t.TempDir()),
+		catalog:        tableCatalog,
+		emitter:        events.NewEmitter("/data-lake", events.NewOutbox(tableCatalog)),
+	}
+
+	_ = os.MkdirAll(conf.DataFolder+"/orders", 0755)
+	_ = os.WriteFile(conf.DataFolder+"/orders/01.csv", []byte("id,amount\n1,10.5\n2,3\n"), 0644)
+
+	processedObject, err := processor.ProcessFile(context.Background(), conf.DataFolder+"/orders/01.csv")
+	assert.Nil(t, err)
+
+	outbox, err := tableCatalog.Outbox("orders")
+	assert.Nil(t, err)
+	assert.Len(t, outbox, 2)
+
+	change, err := events.ParseOutboxEvent(outbox[0])
+	assert.Nil(t, err)
+	assert.Equal(t, events.SchemaChanged, change.Type())
+
+	event, err := events.ParseOutboxEvent(outbox[1])
+	assert.Nil(t, err)
+	assert.Equal(t, events.ObjectIngested, event.Type())
+	ingested := &models_v1.IngestEvent{}
+	assert.Nil(t, proto.Unmarshal(event.Data(), ingested))
+	assert.Equal(t, outbox[1].Id, ingested.Id)
+	assert.True(t, proto.Equal(processedObject, ingested.Object))
+
+	// the ingest event is in the same commit as the file it tells about
+	snapshots, _ := tableCatalog.Snapshots("orders")
+	assert.Len(t, snapshots, 1)
+	entries, _ := os.ReadDir(catalogFolder + "/orders/_log")
+	assert.Len(t, entries, 2)
+}
+
+func TestFolderIngest_ProcessFile_OutboxUncatalogued(t *testing.T) {
+	conf := &config.Config{DataFolder: t.TempDir(), SchemaSampleSize: 1000, CuratedFolder: t.TempDir(), SchemaCompatibility: "BACKWARD", ParquetCompression: "SNAPPY", ParquetRowGroupSize: 100}
+	mockCatalog := catalogMocks.NewCatalog(t)
+	processor := &LocalIngestProcessorImpl{
+		conf:           conf,
+		logger:         log.NewConsoleLog(),
+		datasets:       dataset.NewDatasets(conf, nil),
+		schemaRegistry: registry.NewLocalSchemaRegistry(t.TempDir()),
+		catalog:        mockCatalog,
+		emitter:        events.NewEmitter("/data-lake", events.NewOutbox(mockCatalog)),
+	}
+
+	_ = os.MkdirAll(conf.DataFolder+"/orders", 0755)
+	_ = os.WriteFile(conf.DataFolder+"/orders/01.csv", []byte("id,amount\n1,10.5\n2,3\n"), 0644)
+
+	enqueued := make([]string, 0)
+	mockCatalog.On("Files", "orders").Return([]*models_v1.DataFile{}, nil)
+	mockCatalog.On("Arrived", "orders").Return([]*models_v1.Lineage{}, nil)
+	mockCatalog.On("Add", mock.AnythingOfType("*modelsv1.DataFile"), mock.AnythingOfType("*modelsv1.OutboxEvent")).Return(errors.New("disk full"))
+	mockCatalog.On("Enqueue", "orders", mock.AnythingOfType("*modelsv1.OutboxEvent")).Run(func(args mock.Arguments) {
+		event, _ := events.ParseOutboxEvent(args.Get(1).(*models_v1.OutboxEvent))
+		enqueued = append(enqueued, event.Type())
+	}).Return(nil)
+
+	// a file that couldn't be catalogued has its ingest failing, and no event telling it succeeded
+	processedObject, err := processor.ProcessFile(context.Background(), conf.DataFolder+"/orders/01.csv")
 	assert.Nil(t, processedObject)
+	assert.Equal(t, "disk full", err.Error())
+	assert.Equal(t, []string{events.SchemaChanged, events.ObjectFailed}, enqueued)
+}
+
+func TestFolderIngest_ProcessFolder_EventsOnce(t *testing.T) {
+	conf := &config.Config{DataFolder: t.TempDir(), SchemaSampleSize: 1000, CuratedFolder: t.TempDir(), SchemaCompatibility: "BACKWARD", ParquetCompression: "SNAPPY", ParquetRowGroupSize: 100}
+	tableCatalog := catalog.NewTableCatalog(storage.NewLocalStorage(), t.TempDir())
+	processor := &LocalIngestProcessorImpl{
+		conf:           conf,
+		logger:         log.NewConsoleLog(),
+		datasets:       dataset.NewDatasets(conf, nil),
+		schemaRegistry: registry.NewLocalSchemaRegistry(t.TempDir()),
+		catalog:        tableCatalog,
+		emitter:        events.NewEmitter("/data-lake", events.NewOutbox(tableCatalog)),
+		emitted:        newEmitted(emittedLimit),
+	}
+
+	_ = os.MkdirAll(conf.DataFolder+"/orders", 0755)
+	_ = os.WriteFile(conf.DataFolder+"/orders/01.csv", []byte("id,amount\n1,10.5\n2,3\n"), 0644)
+	_ = os.WriteFile(conf.DataFolder+"/orders/notes.txt", []byte("hello data lake"), 0644)
+
+	ingested := func() map[string][]string {
+		outbox, err := tableCatalog.Outbox("orders")
+		assert.Nil(t, err)
+		ids := make(map[string][]string)
+		for _, entry := range outbox {
+			event, err := events.ParseOutboxEvent(entry)
+			assert.Nil(t, err)
+			if event.Type() != events.ObjectIngested {
+				continue
+			}
+			ingest := &models_v1.IngestEvent{}
+			assert.Nil(t, proto.Unmarshal(event.Data(), ingest))
+			ids[ingest.Location] = append(ids[ingest.Location], event.ID())
+		}
+		return ids
+	}
+
+	_, err := processor.ProcessFolder(context.Background(), conf.DataFolder)
+	assert.Nil(t, err)
+	first := ingested()
+
+	// processing the same objects again, already catalogued or unchanged, tells nothing new
+	_, err = processor.ProcessFolder(context.Background(), conf.DataFolder)
+	assert.Nil(t, err)
+	assert.Equal(t, first, ingested())
+	assert.Len(t, first[conf.DataFolder+"/orders/01.csv"], 1)
+	assert.Len(t, first[conf.DataFolder+"/orders/notes.txt"], 1)
+
+	// the id is derived from the object, a restarted processor emitting it again has it dropped as a duplicate
+	processor.emitted = newEmitted(emittedLimit)
+	_, err = processor.ProcessFolder(context.Background(), conf.DataFolder)
+	assert.Nil(t, err)
+	assert.Equal(t, first, ingested())
+}
+
+func TestFolderIngest_ProcessFile_CataloguedNotRegisteredAgain(t *testing.T) {
+	conf := &config.Config{DataFolder: t.TempDir(), SchemaSampleSize: 1000, CuratedFolder: t.TempDir(), ParquetCompression: "SNAPPY", ParquetRowGroupSize: 100}
+	schemaRegistry := registry.NewLocalSchemaRegistry(t.TempDir())
+	processor := &LocalIngestProcessorImpl{
+		conf:           conf,
+		logger:         log.NewConsoleLog(),
+		datasets:       dataset.NewDatasets(conf, []*dataset.Dataset{{Name: "orders", Compatibility: "NONE"}}),
+		schemaRegistry: schemaRegistry,
+		catalog:        catalog.NewTableCatalog(storage.NewLocalStorage(), t.TempDir()),
+	}
+
+	_ = os.MkdirAll(conf.DataFolder+"/orders", 0755)
+	_ = os.WriteFile(conf.DataFolder+"/orders/01.csv", []byte("id,amount\n1,10.5\n"), 0644)
+	_ = os.WriteFile(conf.DataFolder+"/orders/02.csv", []byte("id,amount,note\n2,3,late\n"), 0644)
+
+	_, err := processor.ProcessFolder(context.Background(), conf.DataFolder)
+	assert.Nil(t, err)
+
+	// the first file is catalogued, its older schema isn't registered over the one the second file added
+	_, err = processor.ProcessFile(context.Background(), conf.DataFolder+"/orders/01.csv")
+	assert.Nil(t, err)
+
+	latest, err := schemaRegistry.Latest("orders")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), latest.Version)
+}
+
+func TestFolderIngest_ProcessFile_LogsTrace(t *testing.T) {
+	logger := logMocks.NewLogger(t)
+	traced := logMocks.NewLogger(t)
+	processor := &LocalIngestProcessorImpl{conf: &config.Config{}, logger: logger}
+
+	fileName := t.TempDir() + "/empty.txt"
+	_ = os.WriteFile(fileName, []byte{}, 0644)
+
+	span := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:  trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), span)
+
+	// what's logged processing the object carries the trace it's processed in
+	logger.On("With", slog.String(log.TraceIdKey, "4bf92f3577b34da6a3ce929d0e0e4736"), mock.Anything).Return(traced)
+	traced.On("Error", "error validating object", log.Location(fileName), mock.Anything).Once()
+
+	_, err := processor.ProcessFile(ctx, fileName)
+	assert.Error(t, err)
+}
+
+func TestFolderIngest_ProcessFile_Overwritten(t *testing.T) {
+	conf := &config.Config{DataFolder: t.TempDir(), SchemaSampleSize: 1000, CuratedFolder: t.TempDir(), SchemaCompatibility: "BACKWARD", ParquetCompression: "SNAPPY", ParquetRowGroupSize: 100}
+	tableCatalog := catalog.NewTableCatalog(storage.NewLocalStorage(), t.TempDir())
+	processor := &LocalIngestProcessorImpl{
+		conf:           conf,
+		logger:         log.NewConsoleLog(),
+		datasets:       dataset.NewDatasets(conf, nil),
+		schemaRegistry: registry.NewLocalSchemaRegistry(t.TempDir()),
+		catalog:        tableCatalog,
+	}
+
+	fileName := conf.DataFolder + "/orders/01.csv"
+	_ = os.MkdirAll(conf.DataFolder+"/orders", 0755)
+	_ = os.WriteFile(fileName, []byte("id,amount\n1,10.5\n"), 0644)
+	_, err := processor.ProcessFile(context.Background(), fileName)
+	assert.Nil(t, err)
+
+	// a source overwritten with other content is curated again, replacing the file curated from it
+	_ = os.WriteFile(fileName, []byte("id,amount\n1,10.5\n2,3\n"), 0644)
+	processedObject, err := processor.ProcessFile(context.Background(), fileName)
+	assert.Nil(t, err)
+
+	files, _ := tableCatalog.Files("orders")
+	assert.Len(t, files, 1)
+	assert.Equal(t, int32(2), files[0].Records)
+	assert.Equal(t, []string{processedObject.Checksum}, files[0].Lineage.Checksums)
 }
@@ -1,67 +1,138 @@
 package ingest
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	golog "log"
+	"log/slog"
+	"path"
 	"strings"
+	"time"
 
 	models_v1 "github.com/codingexplorations/data-lake/models/v1"
 	"github.com/codingexplorations/data-lake/pkg/aws"
+	"github.com/codingexplorations/data-lake/pkg/catalog"
 	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/codingexplorations/data-lake/pkg/convert"
+	"github.com/codingexplorations/data-lake/pkg/dataset"
+	"github.com/codingexplorations/data-lake/pkg/events"
 	"github.com/codingexplorations/data-lake/pkg/log"
+	"github.com/codingexplorations/data-lake/pkg/quality"
+	"github.com/codingexplorations/data-lake/pkg/registry"
+	"github.com/codingexplorations/data-lake/pkg/schema"
+	"github.com/codingexplorations/data-lake/pkg/storage"
+	"github.com/codingexplorations/data-lake/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type S3IngestProcessorImpl struct {
-	conf     *config.Config
-	logger   log.Logger
-	s3Client aws.S3Client
+	conf           *config.Config
+	logger         log.Logger
+	s3Client       aws.S3Client
+	datasets       *dataset.Datasets
+	schemaRegistry registry.SchemaRegistry
+	catalog        catalog.Catalog
+	reports        *quality.Reports
+	emitter        *events.Emitter
+	emitted        *emitted
 }
 
-func NewS3IngestProcessorImpl(conf *config.Config, logger log.Logger) *S3IngestProcessorImpl {
+// NewS3IngestProcessorImpl returns a processor ingesting the objects of AWS_BUCKET_NAME, failing when the S3 client,
+// the datasets or the event emitter can't be created.
+func NewS3IngestProcessorImpl(conf *config.Config, logger log.Logger) (IngestProcessor, error) {
 	logger.Info("Using S3 ingest processor")
 
 	s3Client, err := aws.NewS3()
 	if err != nil {
-		logger.Error(fmt.Sprintf("couldn't create s3 client: %v\n", err))
-		return nil
+		return nil, fmt.Errorf("couldn't create s3 client: %v", err)
 	}
 
-	return &S3IngestProcessorImpl{
-		conf:     conf,
-		logger:   logger,
-		s3Client: &s3Client,
+	datasets, err := dataset.Load(conf)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't load datasets: %v", err)
 	}
+
+	// the catalog and the schema registry live in the curated bucket, where every replica sees the same history
+	curated := storage.NewS3Storage(&s3Client, conf.AwsCuratedBucketName)
+	tableCatalog := catalog.NewTableCatalog(curated, conf.AwsCatalogPrefix)
+
+	emitter, err := events.GetEmitter(conf, tableCatalog)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create event emitter: %v", err)
+	}
+
+	return &S3IngestProcessorImpl{
+		conf:           conf,
+		logger:         logger,
+		s3Client:       &s3Client,
+		datasets:       datasets,
+		schemaRegistry: registry.NewStorageSchemaRegistry(curated, conf.AwsSchemaRegistryPrefix),
+		catalog:        tableCatalog,
+		reports:        quality.NewReports(curated, conf.AwsQualityPrefix),
+		emitter:        emitter,
+		emitted:        newEmitted(emittedLimit),
+	}, nil
 }
 
-// ProcessFolder processes the file
-func (processor *S3IngestProcessorImpl) ProcessFolder(prefix string) ([]*models_v1.Object, error) {
+// ProcessFolder processes the objects under a prefix. An object that fails is recorded as failed and the rest are
+// processed all the same, the errors are returned joined.
+func (processor *S3IngestProcessorImpl) ProcessFolder(ctx context.Context, prefix string) (processed []*models_v1.Object, err error) {
+	ctx, span := tracing.Start(ctx, "ProcessFolder", attribute.String(log.LocationKey, prefix))
+	defer func() { tracing.End(span, err) }()
+
 	golog.Println("Processing folder: ", prefix)
-	objects, err := processor.s3Client.ListObjects(processor.conf.AwsBucketName, &prefix)
+	objects, err := aws.S3WithContext(processor.s3Client, ctx).ListObjects(processor.conf.AwsBucketName, &prefix)
 	if err != nil {
-		processor.logger.Error(fmt.Sprintf("couldn't list objects in bucket %v.\n", processor.conf.AwsBucketName))
+		processor.logger.Error("couldn't list objects in bucket", slog.String("bucket", processor.conf.AwsBucketName), log.Location(prefix), log.Err(err))
 		return nil, err
 	}
 
 	processedObjects := make([]*models_v1.Object, 0)
+	var errs []error
 
 	for _, object := range objects {
-		if processedFile, err := processor.ProcessFile(*object.Key); err != nil {
-			return nil, err
+		if processedFile, err := processor.ProcessFile(ctx, *object.Key); err != nil {
+			errs = append(errs, err)
 		} else {
-			processor.logger.Info(fmt.Sprintf("processed file: %v\n", processedFile))
+			processor.logger.Info("processed file", log.Location(*object.Key), slog.Any("object", processedFile))
 			processedObjects = append(processedObjects, processedFile)
 		}
 	}
 
-	return processedObjects, nil
+	return processedObjects, errors.Join(errs...)
+}
+
+// ProcessFile processes the file, catalogs it and publishes how that went
+func (processor *S3IngestProcessorImpl) ProcessFile(ctx context.Context, key string) (processed *models_v1.Object, err error) {
+	dataset := ""
+	if processor.datasets != nil {
+		dataset = processor.datasets.Resolve(key).Name
+	}
+
+	ctx, span := tracing.Start(ctx, "ProcessFile", attribute.String(log.LocationKey, key), attribute.String(log.DatasetKey, dataset))
+	defer func() { tracing.End(span, err) }()
+
+	// the calls to S3 made processing the object are traced as part of it
+	bound := *processor
+	bound.s3Client = aws.S3WithContext(processor.s3Client, ctx)
+
+	started := time.Now()
+	object, file, err := bound.processFile(ctx, key)
+
+	return record(ctx, processor.catalog, processor.emitter, processor.emitted, log.WithTrace(processor.logger, ctx), key, dataset, object, file, started, err)
 }
 
-// ProcessFile processes the file
-func (processor *S3IngestProcessorImpl) ProcessFile(key string) (*models_v1.Object, error) {
+func (processor *S3IngestProcessorImpl) processFile(ctx context.Context, key string) (*models_v1.Object, *models_v1.DataFile, error) {
+	// everything logged processing the object carries the trace and span it's processed in
+	logger := log.WithTrace(processor.logger, ctx)
+
 	headObject, err := processor.s3Client.HeadObject(processor.conf.AwsBucketName, key)
 	if err != nil {
-		processor.logger.Error(fmt.Sprintf("couldn't get object %v in bucket %v.\n", key, processor.conf.AwsBucketName))
-		return nil, err
+		logger.Error("couldn't get object in bucket", slog.String("bucket", processor.conf.AwsBucketName), log.Location(key), log.Err(err))
+		return nil, nil, err
 	}
 
 	pathSplit := strings.Split(key, "/")
@@ -75,14 +146,122 @@ func (processor *S3IngestProcessorImpl) ProcessFile(key string) (*models_v1.Obje
 
 	valid, err := validate(object)
 	if err != nil {
-		processor.logger.Error(fmt.Sprintf("error validating object: %v\n", err))
-		return nil, err
+		logger.Error("error validating object", log.Location(key), log.Err(err))
+		return nil, nil, err
 	}
 
 	if !valid {
-		processor.logger.Error(fmt.Sprintf("object is invalid: %v\n", object))
-		return nil, nil
+		logger.Error("object is invalid", log.Location(key), slog.Any("object", object))
+		return nil, nil, nil
+	}
+
+	// validation caps objects at 1MB so they are read into memory once, every object is checksummed so one uploaded
+	// again with new content under the same key is told apart
+	getObject, err := processor.s3Client.GetObject(processor.conf.AwsBucketName, key)
+	if err != nil {
+		logger.Error("couldn't get object in bucket", slog.String("bucket", processor.conf.AwsBucketName), log.Location(key), log.Err(err))
+		return nil, nil, err
+	}
+	defer getObject.Body.Close()
+
+	data, err := io.ReadAll(getObject.Body)
+	if err != nil {
+		logger.Error("couldn't read object in bucket", slog.String("bucket", processor.conf.AwsBucketName), log.Location(key), log.Err(err))
+		return nil, nil, err
+	}
+	object.Checksum = checksum(data)
+
+	if format := schema.DetectFormat(key, object.ContentType, nil); format != models_v1.Schema_UNKNOWN {
+		if err := inferSchema(object, format, bytes.NewReader(data), processor.conf.SchemaSampleSize); err != nil {
+			logger.Warn("couldn't infer schema", log.Location(key), log.Err(err))
+		}
+	}
+
+	var file *models_v1.DataFile
+	if processor.datasets != nil {
+		ds := processor.datasets.Resolve(key)
+		object.Dataset = ds.Name
+
+		// objects the catalog already has with the same content, directly or through a compacted file, were registered,
+		// checked and curated when they were first ingested
+		if processor.catalog != nil {
+			catalogued, err := catalog.Catalogued(processor.catalog, ds.Name, object.FileLocation, object.Checksum)
+			if err != nil {
+				logger.Error("couldn't look up object in catalog", log.Location(key), log.Err(err))
+				return nil, nil, err
+			}
+			if catalogued {
+				return object, nil, errCatalogued
+			}
+		}
+
+		if err := declareSchema(ds, object); err != nil {
+			logger.Error("error validating object", log.Location(key), log.Err(err))
+			return nil, nil, err
+		}
+
+		change, err := registerSchema(processor.schemaRegistry, ds, object)
+		if err != nil {
+			logger.Error("error validating object", log.Location(key), log.Err(err))
+			return nil, nil, err
+		}
+		if change != nil && processor.emitter != nil {
+			emitSchemaChanged(processor.emitter, logger, change)
+		}
+
+		if ds.Message != "" {
+			if err := validateRecords(ds, object, bytes.NewReader(data), processor.conf.RecordViolationLimit); err != nil {
+				logger.Error("error validating object", log.Location(key), log.Err(err))
+				return nil, nil, err
+			}
+		}
+
+		quarantined, err := checkQuality(processor.catalog, processor.reports, ds, object, bytes.NewReader(data), func() (string, error) {
+			return processor.quarantine(object, data)
+		})
+		if err != nil {
+			logger.Error("error checking quality of object", log.Location(key), log.Err(err))
+			return nil, nil, err
+		}
+		if object.Quality != nil && !object.Quality.Passed {
+			logger.Warn("object failed expectations of dataset", log.Location(key), log.Dataset(ds.Name), slog.Any("failed", quality.Failed(object.Quality)))
+		}
+		if quarantined {
+			return object, nil, nil
+		}
+
+		if object.Schema != nil && processor.conf.AwsCuratedBucketName != "" {
+			if file, err = processor.convert(ds, object, data); err != nil {
+				logger.Error("error converting object", log.Location(key), log.Err(err))
+				return nil, nil, err
+			}
+		}
+	}
+
+	if object.Schema != nil && object.Statistics == nil {
+		if err := collectStatistics(object, bytes.NewReader(data)); err != nil {
+			logger.Warn("couldn't collect statistics", log.Location(key), log.Err(err))
+		}
+	}
+
+	return object, file, nil
+}
+
+// convert uploads the object as parquet to the same key in the curated bucket, returning the file to catalog.
+func (processor *S3IngestProcessorImpl) convert(ds *dataset.Dataset, object *models_v1.Object, data []byte) (*models_v1.DataFile, error) {
+	curated := storage.NewS3Storage(processor.s3Client, processor.conf.AwsCuratedBucketName)
+
+	return curate(processor.catalog, curated, ds, object, data, convert.ParquetLocation(object.FileLocation), ds.Partition(object.FileLocation))
+}
+
+// quarantine moves a failing object to the same key below AWS_QUARANTINE_PREFIX in the curated bucket.
+func (processor *S3IngestProcessorImpl) quarantine(object *models_v1.Object, data []byte) (string, error) {
+	location := path.Join(processor.conf.AwsQuarantinePrefix, object.FileLocation)
+
+	curated := storage.NewS3Storage(processor.s3Client, processor.conf.AwsCuratedBucketName)
+	if err := curated.Write(location, bytes.NewReader(data), object.ContentType); err != nil {
+		return "", err
 	}
 
-	return object, nil
+	return location, storage.NewS3Storage(processor.s3Client, processor.conf.AwsBucketName).Delete(object.FileLocation)
 }
@@ -1,23 +1,33 @@
 package ingest
 
 import (
+	"context"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"io"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go/aws"
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
 	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/codingexplorations/data-lake/pkg/convert"
+	"github.com/codingexplorations/data-lake/pkg/dataset"
 	"github.com/codingexplorations/data-lake/pkg/log"
+	"github.com/codingexplorations/data-lake/pkg/registry"
 	mocks "github.com/codingexplorations/data-lake/test/mocks/pkg/aws"
+	registryMocks "github.com/codingexplorations/data-lake/test/mocks/pkg/registry"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func Test_S3Processor_NewS3IngestProcessorImpl(t *testing.T) {
 	conf := config.GetConfig()
 	logger := log.NewConsoleLog()
 
-	processor := NewS3IngestProcessorImpl(conf, logger)
+	processor, err := NewS3IngestProcessorImpl(conf, logger)
 
+	assert.Nil(t, err)
 	assert.NotNil(t, processor)
 }
 
@@ -42,6 +52,8 @@ func Test_S3Processor_ProcessFolder(t *testing.T) {
 	}
 	s3Client.On("HeadObject", conf.AwsBucketName, "test/test1.txt").Return(headObjectOutput, nil)
 	s3Client.On("HeadObject", conf.AwsBucketName, "test/test2.txt").Return(headObjectOutput, nil)
+	s3Client.On("GetObject", conf.AwsBucketName, "test/test1.txt").Return(&s3.GetObjectOutput{Body: io.NopCloser(strings.NewReader("hello data lake"))}, nil)
+	s3Client.On("GetObject", conf.AwsBucketName, "test/test2.txt").Return(&s3.GetObjectOutput{Body: io.NopCloser(strings.NewReader("hello data lake"))}, nil)
 
 	processor := &S3IngestProcessorImpl{
 		conf:     conf,
@@ -49,7 +61,7 @@ func Test_S3Processor_ProcessFolder(t *testing.T) {
 		s3Client: s3Client,
 	}
 
-	processedObjects, err := processor.ProcessFolder("test/")
+	processedObjects, err := processor.ProcessFolder(context.Background(), "test/")
 
 	assert.Nil(t, err)
 	assert.Equal(t, 2, len(processedObjects))
@@ -73,6 +85,7 @@ func Test_S3Processor_ProcessFile(t *testing.T) {
 		ContentLength: aws.Int64(15),
 	}
 	s3Client.On("HeadObject", conf.AwsBucketName, "test/test.txt").Return(headObjectOutput, nil)
+	s3Client.On("GetObject", conf.AwsBucketName, "test/test.txt").Return(&s3.GetObjectOutput{Body: io.NopCloser(strings.NewReader("hello data lake"))}, nil)
 
 	processor := &S3IngestProcessorImpl{
 		conf:     conf,
@@ -80,11 +93,117 @@ func Test_S3Processor_ProcessFile(t *testing.T) {
 		s3Client: s3Client,
 	}
 
-	processedObject, err := processor.ProcessFile("test/test.txt")
+	processedObject, err := processor.ProcessFile(context.Background(), "test/test.txt")
 
 	assert.Nil(t, err)
 	assert.Equal(t, "test.txt", processedObject.FileName)
 	assert.Equal(t, "test/test.txt", processedObject.FileLocation)
 	assert.Equal(t, "text/plain", processedObject.ContentType)
 	assert.Equal(t, int32(15), processedObject.ContentSize)
+	// objects that aren't structured are checksummed too
+	assert.Equal(t, checksum([]byte("hello data lake")), processedObject.Checksum)
+}
+
+func Test_S3Processor_ProcessFile_InferSchema(t *testing.T) {
+	conf := config.GetConfig()
+
+	s3Client := mocks.NewS3Client(t)
+
+	headObjectOutput := &s3.HeadObjectOutput{
+		ContentType:   aws.String("application/octet-stream"),
+		ContentLength: aws.Int64(27),
+	}
+	s3Client.On("HeadObject", conf.AwsBucketName, "test/events.ndjson").Return(headObjectOutput, nil)
+
+	getObjectOutput := &s3.GetObjectOutput{
+		Body: io.NopCloser(strings.NewReader("{\"id\": 1}\n{\"id\": 2.5}\n")),
+	}
+	s3Client.On("GetObject", conf.AwsBucketName, "test/events.ndjson").Return(getObjectOutput, nil)
+
+	processor := &S3IngestProcessorImpl{
+		conf:     conf,
+		logger:   log.NewConsoleLog(),
+		s3Client: s3Client,
+	}
+
+	processedObject, err := processor.ProcessFile(context.Background(), "test/events.ndjson")
+
+	assert.Nil(t, err)
+	assert.Equal(t, models_v1.Schema_NDJSON, processedObject.Schema.Format)
+	assert.Equal(t, int32(2), processedObject.Schema.SampledRecords)
+	assert.Equal(t, models_v1.Field_NUMBER, processedObject.Schema.Fields[0].Type)
+}
+
+func Test_S3Processor_ProcessFile_IncompatibleSchema(t *testing.T) {
+	conf := config.GetConfig()
+
+	s3Client := mocks.NewS3Client(t)
+	schemaRegistry := registryMocks.NewSchemaRegistry(t)
+
+	headObjectOutput := &s3.HeadObjectOutput{
+		ContentType:   aws.String("text/csv"),
+		ContentLength: aws.Int64(12),
+	}
+	s3Client.On("HeadObject", conf.AwsBucketName, "orders/01").Return(headObjectOutput, nil)
+
+	getObjectOutput := &s3.GetObjectOutput{
+		Body: io.NopCloser(strings.NewReader("id\n1\n")),
+	}
+	s3Client.On("GetObject", conf.AwsBucketName, "orders/01").Return(getObjectOutput, nil)
+
+	incompatible := &registry.IncompatibleSchemaError{Dataset: "orders", Version: 1, Compatibility: registry.Full, Reasons: []string{"field amount is required but missing"}}
+	schemaRegistry.On("Latest", "orders").Return(&models_v1.Schema{Version: 1}, nil)
+	schemaRegistry.On("Register", "orders", mock.AnythingOfType("*modelsv1.Schema"), registry.Full).Return(nil, incompatible)
+
+	processor := &S3IngestProcessorImpl{
+		conf:           conf,
+		logger:         log.NewConsoleLog(),
+		s3Client:       s3Client,
+		datasets:       dataset.NewDatasets(conf, []*dataset.Dataset{{Name: "orders", Compatibility: "FULL"}}),
+		schemaRegistry: schemaRegistry,
+	}
+
+	processedObject, err := processor.ProcessFile(context.Background(), "orders/01")
+
+	assert.Nil(t, processedObject)
+	assert.Equal(t, incompatible, err)
+}
+
+func Test_S3Processor_ProcessFile_Convert(t *testing.T) {
+	conf := config.GetConfig()
+
+	s3Client := mocks.NewS3Client(t)
+	schemaRegistry := registryMocks.NewSchemaRegistry(t)
+
+	headObjectOutput := &s3.HeadObjectOutput{
+		ContentType:   aws.String("text/csv"),
+		ContentLength: aws.Int64(19),
+	}
+	s3Client.On("HeadObject", conf.AwsBucketName, "orders/01.csv").Return(headObjectOutput, nil)
+
+	getObjectOutput := &s3.GetObjectOutput{
+		Body: io.NopCloser(strings.NewReader("id,amount\n1,10.5\n2,3\n")),
+	}
+	s3Client.On("GetObject", conf.AwsBucketName, "orders/01.csv").Return(getObjectOutput, nil)
+	s3Client.On("PutObject", conf.AwsCuratedBucketName, "orders/01.parquet", mock.Anything, convert.ContentType).Return(&s3.PutObjectOutput{}, nil)
+
+	schemaRegistry.On("Latest", "orders").Return(&models_v1.Schema{Version: 2}, nil)
+	schemaRegistry.On("Register", "orders", mock.AnythingOfType("*modelsv1.Schema"), registry.Backward).Return(&models_v1.Schema{Version: 3}, nil)
+
+	processor := &S3IngestProcessorImpl{
+		conf:           conf,
+		logger:         log.NewConsoleLog(),
+		s3Client:       s3Client,
+		datasets:       dataset.NewDatasets(conf, nil),
+		schemaRegistry: schemaRegistry,
+	}
+
+	processedObject, err := processor.ProcessFile(context.Background(), "orders/01.csv")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "orders/01.parquet", processedObject.Conversion.Location)
+	assert.Equal(t, models_v1.Conversion_SNAPPY, processedObject.Conversion.Compression)
+	assert.Equal(t, int32(2), processedObject.Conversion.Records)
+	assert.Equal(t, []string{"orders/01.csv"}, processedObject.Conversion.Lineage.Sources)
+	assert.Equal(t, int32(3), processedObject.Conversion.Lineage.SchemaVersion)
 }
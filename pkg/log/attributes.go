@@ -0,0 +1,198 @@
+package log
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	modelsv1 "github.com/codingexplorations/data-lake/models/v1"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// The keys of the attributes logged across the lake, so entries about the same thing can be searched by them.
+const (
+	ErrorKey     = "error"
+	LocationKey  = "location"
+	DatasetKey   = "dataset"
+	SourceKey    = "source"
+	RunIdKey     = "run_id"
+	RequestIdKey = "request_id"
+	TraceIdKey   = "trace_id"
+	SpanIdKey    = "span_id"
+)
+
+// Err returns the attribute of an error.
+func Err(err error) slog.Attr {
+	return slog.Any(ErrorKey, err)
+}
+
+// Location returns the attribute of the location of an object or file.
+func Location(location string) slog.Attr {
+	return slog.String(LocationKey, location)
+}
+
+// Dataset returns the attribute of a dataset name.
+func Dataset(name string) slog.Attr {
+	return slog.String(DatasetKey, name)
+}
+
+// Source returns the attribute naming the component or library an entry comes from.
+func Source(name string) slog.Attr {
+	return slog.String(SourceKey, name)
+}
+
+// RunId returns the attribute of the id of a run of the runner.
+func RunId(id string) slog.Attr {
+	return slog.String(RunIdKey, id)
+}
+
+// RequestId returns the attribute of the id of an HTTP request.
+func RequestId(id string) slog.Attr {
+	return slog.String(RequestIdKey, id)
+}
+
+// Trace returns the attributes of the trace and span in ctx, none when there is no span in it.
+func Trace(ctx context.Context) []slog.Attr {
+	span := trace.SpanContextFromContext(ctx)
+	if !span.IsValid() {
+		return nil
+	}
+	return []slog.Attr{slog.String(TraceIdKey, span.TraceID().String()), slog.String(SpanIdKey, span.SpanID().String())}
+}
+
+// contextKey is the key of the attributes a context carries for WithTrace.
+type contextKey struct{}
+
+// ContextWith returns ctx carrying attributes, like the id of the run or request it's part of, that WithTrace adds
+// to what's logged with it.
+func ContextWith(ctx context.Context, attrs ...slog.Attr) context.Context {
+	// sized exactly, so appending to what Context returns never writes to the slice of another context
+	existing := Context(ctx)
+	combined := make([]slog.Attr, 0, len(existing)+len(attrs))
+	return context.WithValue(ctx, contextKey{}, append(append(combined, existing...), attrs...))
+}
+
+// Context returns the attributes ctx carries, see ContextWith.
+func Context(ctx context.Context) []slog.Attr {
+	attrs, _ := ctx.Value(contextKey{}).([]slog.Attr)
+	return attrs
+}
+
+// WithTrace returns a logger adding the attributes ctx carries and the trace and span in it to everything it logs,
+// the logger itself when there are none.
+func WithTrace(logger Logger, ctx context.Context) Logger {
+	attrs := append(Context(ctx), Trace(ctx)...)
+	if len(attrs) == 0 {
+		return logger
+	}
+
+	args := make([]any, 0, len(attrs))
+	for _, attr := range attrs {
+		args = append(args, attr)
+	}
+	return logger.With(args...)
+}
+
+// attrs parses the args of a log call the way slog does, a key without a value is logged under !BADKEY.
+func attrs(args []any) []slog.Attr {
+	if len(args) == 0 {
+		return nil
+	}
+
+	record := slog.NewRecord(time.Time{}, slog.LevelInfo, "", 0)
+	record.Add(args...)
+
+	parsed := make([]slog.Attr, 0, record.NumAttrs())
+	record.Attrs(func(attr slog.Attr) bool {
+		parsed = append(parsed, attr)
+		return true
+	})
+
+	return parsed
+}
+
+// flatten calls f with every attribute, the keys of grouped attributes joined to the group's key with dots.
+func flatten(prefix string, attrs []slog.Attr, f func(key string, value slog.Value)) {
+	for _, attr := range attrs {
+		value := attr.Value.Resolve()
+		key := attr.Key
+		if prefix != "" && key != "" {
+			key = prefix + "." + key
+		} else if key == "" {
+			key = prefix
+		}
+
+		if value.Kind() == slog.KindGroup {
+			flatten(key, value.Group(), f)
+			continue
+		}
+		if key == "" {
+			continue
+		}
+
+		f(key, value)
+	}
+}
+
+// text formats attributes as space separated key=value pairs, quoting values that need it.
+func text(attrs []slog.Attr) string {
+	var builder strings.Builder
+	flatten("", attrs, func(key string, value slog.Value) {
+		formatted := format(value)
+		if formatted == "" || strings.ContainsAny(formatted, " =\"\n") {
+			formatted = strconv.Quote(formatted)
+		}
+		builder.WriteString(" " + key + "=" + formatted)
+	})
+	return builder.String()
+}
+
+func format(value slog.Value) string {
+	switch value.Kind() {
+	case slog.KindTime:
+		return value.Time().Format(time.RFC3339Nano)
+	case slog.KindAny:
+		if err, ok := value.Any().(error); ok && err != nil {
+			return err.Error()
+		}
+	}
+	return value.String()
+}
+
+// values converts attributes to the typed values of the Log message.
+func values(attrs []slog.Attr) map[string]*modelsv1.LogValue {
+	if len(attrs) == 0 {
+		return nil
+	}
+
+	converted := make(map[string]*modelsv1.LogValue)
+	flatten("", attrs, func(key string, value slog.Value) {
+		switch value.Kind() {
+		case slog.KindBool:
+			converted[key] = &modelsv1.LogValue{Value: &modelsv1.LogValue_Bool{Bool: value.Bool()}}
+		case slog.KindInt64:
+			converted[key] = &modelsv1.LogValue{Value: &modelsv1.LogValue_Int{Int: value.Int64()}}
+		case slog.KindUint64:
+			converted[key] = &modelsv1.LogValue{Value: &modelsv1.LogValue_Int{Int: int64(value.Uint64())}}
+		case slog.KindFloat64:
+			converted[key] = &modelsv1.LogValue{Value: &modelsv1.LogValue_Float{Float: value.Float64()}}
+		case slog.KindTime:
+			converted[key] = &modelsv1.LogValue{Value: &modelsv1.LogValue_Time{Time: value.Time().UnixMilli()}}
+		case slog.KindDuration:
+			converted[key] = &modelsv1.LogValue{Value: &modelsv1.LogValue_Duration{Duration: int64(value.Duration())}}
+		default:
+			converted[key] = &modelsv1.LogValue{Value: &modelsv1.LogValue_String_{String_: format(value)}}
+		}
+	})
+
+	return converted
+}
+
+// with returns the attributes of a logger with the parsed args added.
+func with(existing []slog.Attr, args []any) []slog.Attr {
+	added := attrs(args)
+	combined := make([]slog.Attr, 0, len(existing)+len(added))
+	return append(append(combined, existing...), added...)
+}
@@ -0,0 +1,103 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	modelsv1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestText(t *testing.T) {
+	attributes := attrs([]any{
+		Location("data/orders/2024.csv"),
+		Err(errors.New("file not found")),
+		"rows", 12,
+		slog.Group("batch", slog.String("id", "b-1"), slog.Bool("retried", false)),
+		"note", "",
+	})
+
+	assert.Equal(t, ` location=data/orders/2024.csv error="file not found" rows=12 batch.id=b-1 batch.retried=false note=""`, text(attributes))
+}
+
+func TestText_BadKey(t *testing.T) {
+	assert.Equal(t, " !BADKEY=dangling", text(attrs([]any{"dangling"})))
+}
+
+func TestValues(t *testing.T) {
+	at := time.Date(2024, 5, 2, 6, 0, 0, 0, time.UTC)
+
+	converted := values(attrs([]any{
+		Dataset("orders"),
+		Err(errors.New("timeout")),
+		"rows", 12,
+		"ratio", 0.5,
+		"late", true,
+		"due", at,
+		"took", 3 * time.Second,
+		slog.Group("request", RequestId("r-1")),
+	}))
+
+	expected := map[string]*modelsv1.LogValue{
+		"dataset":            {Value: &modelsv1.LogValue_String_{String_: "orders"}},
+		"error":              {Value: &modelsv1.LogValue_String_{String_: "timeout"}},
+		"rows":               {Value: &modelsv1.LogValue_Int{Int: 12}},
+		"ratio":              {Value: &modelsv1.LogValue_Float{Float: 0.5}},
+		"late":               {Value: &modelsv1.LogValue_Bool{Bool: true}},
+		"due":                {Value: &modelsv1.LogValue_Time{Time: at.UnixMilli()}},
+		"took":               {Value: &modelsv1.LogValue_Duration{Duration: int64(3 * time.Second)}},
+		"request.request_id": {Value: &modelsv1.LogValue_String_{String_: "r-1"}},
+	}
+
+	assert.Equal(t, len(expected), len(converted))
+	for key, value := range expected {
+		assert.True(t, proto.Equal(value, converted[key]), key)
+	}
+
+	assert.Nil(t, values(nil))
+}
+
+func TestWith(t *testing.T) {
+	existing := []slog.Attr{Source("compactor")}
+
+	combined := with(existing, []any{Dataset("orders")})
+
+	assert.Equal(t, []slog.Attr{Source("compactor"), Dataset("orders")}, combined)
+	assert.Equal(t, []slog.Attr{Source("compactor")}, existing)
+}
+
+func TestWithTrace(t *testing.T) {
+	logger := NewConsoleLog()
+
+	// without a span the logger is left as it is
+	assert.Nil(t, Trace(context.Background()))
+	assert.Same(t, logger, WithTrace(logger, context.Background()))
+
+	span := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:  trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), span)
+
+	assert.Equal(t, []slog.Attr{slog.String(TraceIdKey, "4bf92f3577b34da6a3ce929d0e0e4736"), slog.String(SpanIdKey, "00f067aa0ba902b7")}, Trace(ctx))
+	assert.Equal(t, Trace(ctx), WithTrace(logger, ctx).(*ConsoleLog).attrs)
+}
+
+func TestContextWith(t *testing.T) {
+	logger := NewConsoleLog()
+
+	run := ContextWith(context.Background(), RunId("run-1"))
+	first := ContextWith(run, Dataset("orders"))
+	second := ContextWith(run, Dataset("customers"))
+
+	// a context carries the attributes of the ones it's derived from, without the ones of its siblings
+	assert.Equal(t, []slog.Attr{RunId("run-1")}, Context(run))
+	assert.Equal(t, []slog.Attr{RunId("run-1"), Dataset("orders")}, Context(first))
+	assert.Equal(t, []slog.Attr{RunId("run-1"), Dataset("customers")}, Context(second))
+	assert.Equal(t, Context(first), WithTrace(logger, first).(*ConsoleLog).attrs)
+}
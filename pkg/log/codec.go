@@ -0,0 +1,288 @@
+package log
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	modelsv1 "github.com/codingexplorations/data-lake/models/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// The codecs logs can be shipped to the logger queue in, LOGGER_CODEC names one.
+const (
+	// JsonCodec is the protojson of the Log message, levels by name
+	JsonCodec = "json"
+	// ProtobufCodec is the binary protobuf of the Log message, base64 encoded since message bodies are text
+	ProtobufCodec = "protobuf"
+	// TextCodec is the timestamped text line of the file logger, attribute values come back as strings
+	TextCodec = "text"
+)
+
+// The content types of shipped logs, in the ContentTypeAttribute of their messages.
+const (
+	JsonContentType     = "application/json"
+	ProtobufContentType = "application/x-protobuf"
+	TextContentType     = "text/plain"
+)
+
+// ContentTypeAttribute is the message attribute holding the content type of a shipped log. Messages without it hold
+// json, as every shipped log did before there were codecs.
+const ContentTypeAttribute = "content-type"
+
+// Codec encodes Log messages as the bodies of the messages they're shipped in, and decodes them back.
+type Codec interface {
+	ContentType() string
+	Encode(message *modelsv1.Log) (string, error)
+	Decode(body string) (*modelsv1.Log, error)
+}
+
+// GetCodec returns the codec with a name, json when there is none.
+func GetCodec(name string) (Codec, error) {
+	switch strings.ToLower(name) {
+	case "", JsonCodec:
+		return jsonCodec{}, nil
+	case ProtobufCodec:
+		return protobufCodec{}, nil
+	case TextCodec:
+		return textCodec{}, nil
+	default:
+		return nil, fmt.Errorf("unknown log codec %q", name)
+	}
+}
+
+// GetCodecOf returns the codec of a content type, json when there is none.
+func GetCodecOf(contentType string) (Codec, error) {
+	switch contentType {
+	case "", JsonContentType:
+		return jsonCodec{}, nil
+	case ProtobufContentType:
+		return protobufCodec{}, nil
+	case TextContentType:
+		return textCodec{}, nil
+	default:
+		return nil, fmt.Errorf("unknown log content type %q", contentType)
+	}
+}
+
+// Decode decodes the body of a shipped log by the content type it was shipped with.
+func Decode(body string, contentType string) (*modelsv1.Log, error) {
+	codec, err := GetCodecOf(contentType)
+	if err != nil {
+		return nil, err
+	}
+	return codec.Decode(body)
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) ContentType() string {
+	return JsonContentType
+}
+
+func (jsonCodec) Encode(message *modelsv1.Log) (string, error) {
+	encoded, err := protojson.Marshal(message)
+	return string(encoded), err
+}
+
+func (jsonCodec) Decode(body string) (*modelsv1.Log, error) {
+	message := &modelsv1.Log{}
+	// fields added to Log later don't keep older consumers from reading it
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal([]byte(body), message); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+type protobufCodec struct{}
+
+func (protobufCodec) ContentType() string {
+	return ProtobufContentType
+}
+
+func (protobufCodec) Encode(message *modelsv1.Log) (string, error) {
+	encoded, err := proto.Marshal(message)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(encoded), nil
+}
+
+func (protobufCodec) Decode(body string) (*modelsv1.Log, error) {
+	decoded, err := base64.StdEncoding.DecodeString(body)
+	if err != nil {
+		return nil, err
+	}
+
+	message := &modelsv1.Log{}
+	if err := proto.Unmarshal(decoded, message); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// textCodec writes a Log as `<RFC 3339 time> [LEVEL] file#line - message key=value ...`. Messages holding a = or a
+// quote and values holding a space, = or quote are quoted, and line breaks always are, so every log is a single line
+// that reads back unambiguously.
+type textCodec struct{}
+
+func (textCodec) ContentType() string {
+	return TextContentType
+}
+
+func (textCodec) Encode(message *modelsv1.Log) (string, error) {
+	var builder strings.Builder
+	builder.WriteString(time.UnixMilli(message.Timestamp).UTC().Format(time.RFC3339Nano))
+	builder.WriteString(" [" + textLevels[message.Level] + "] ")
+	builder.WriteString(message.File + "#" + strconv.Itoa(int(message.Line)) + " - ")
+	builder.WriteString(quoteText(message.Message, "=\"\n\r"))
+
+	keys := make([]string, 0, len(message.Attributes))
+	for key := range message.Attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		builder.WriteString(" " + key + "=" + quoteText(textValue(message.Attributes[key]), " =\"\n\r"))
+	}
+
+	return builder.String(), nil
+}
+
+func (textCodec) Decode(body string) (*modelsv1.Log, error) {
+	stamp, rest, ok := strings.Cut(body, " [")
+	if !ok {
+		return nil, fmt.Errorf("log line %q has no level", body)
+	}
+	timestamp, err := time.Parse(time.RFC3339Nano, stamp)
+	if err != nil {
+		return nil, fmt.Errorf("log line %q has no timestamp", body)
+	}
+
+	name, rest, ok := strings.Cut(rest, "] ")
+	if !ok {
+		return nil, fmt.Errorf("log line %q has no level", body)
+	}
+	level, ok := modelsv1.Log_LogLevel_value[name]
+	if !ok && name == "WARN" {
+		level, ok = int32(modelsv1.Log_WARNING), true
+	}
+	if !ok {
+		return nil, fmt.Errorf("log line %q has unknown level %q", body, name)
+	}
+
+	caller, rest, ok := strings.Cut(rest, " - ")
+	if !ok {
+		return nil, fmt.Errorf("log line %q has no caller", body)
+	}
+	file, number, _ := strings.Cut(caller, "#")
+	line, err := strconv.ParseInt(number, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("log line %q has no caller line", body)
+	}
+
+	message := &modelsv1.Log{
+		Timestamp: timestamp.UnixMilli(),
+		Level:     modelsv1.Log_LogLevel(level),
+		File:      file,
+		Line:      int32(line),
+	}
+
+	if message.Message, rest, err = textMessage(rest); err != nil {
+		return nil, fmt.Errorf("log line %q: %v", body, err)
+	}
+
+	for rest != "" {
+		var key, value string
+		key, rest, ok = strings.Cut(strings.TrimPrefix(rest, " "), "=")
+		if !ok {
+			return nil, fmt.Errorf("log line %q has an attribute without a value", body)
+		}
+		if value, rest, err = textToken(rest); err != nil {
+			return nil, fmt.Errorf("log line %q: attribute %v: %v", body, key, err)
+		}
+		if message.Attributes == nil {
+			message.Attributes = make(map[string]*modelsv1.LogValue)
+		}
+		message.Attributes[key] = &modelsv1.LogValue{Value: &modelsv1.LogValue_String_{String_: value}}
+	}
+
+	return message, nil
+}
+
+// textLevels are the names text logs give the levels of Log messages, the ones of the console logger.
+var textLevels = map[modelsv1.Log_LogLevel]string{
+	modelsv1.Log_NONE:    "NONE",
+	modelsv1.Log_DEBUG:   "DEBUG",
+	modelsv1.Log_INFO:    "INFO",
+	modelsv1.Log_WARNING: "WARN",
+	modelsv1.Log_ERROR:   "ERROR",
+}
+
+// textMessage reads the message at the start of the rest of a text log, up to its first attribute.
+func textMessage(rest string) (string, string, error) {
+	if strings.HasPrefix(rest, "\"") {
+		return textToken(rest)
+	}
+
+	// unquoted messages hold no =, the message ends with the last word before the first one
+	equals := strings.Index(rest, "=")
+	if equals < 0 {
+		return rest, "", nil
+	}
+	space := strings.LastIndex(rest[:equals], " ")
+	if space < 0 {
+		return "", "", fmt.Errorf("no message")
+	}
+	return rest[:space], rest[space:], nil
+}
+
+// textToken reads a value, quoted or up to the next space, at the start of s and returns it and what follows it.
+func textToken(s string) (string, string, error) {
+	if strings.HasPrefix(s, "\"") {
+		quoted, err := strconv.QuotedPrefix(s)
+		if err != nil {
+			return "", "", err
+		}
+		value, err := strconv.Unquote(quoted)
+		return value, s[len(quoted):], err
+	}
+
+	value, rest, _ := strings.Cut(s, " ")
+	if rest != "" {
+		rest = " " + rest
+	}
+	return value, rest, nil
+}
+
+// quoteText quotes s when it holds any of the characters or is empty.
+func quoteText(s string, chars string) string {
+	if s == "" || strings.ContainsAny(s, chars) {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// textValue returns the value of an attribute the way the text of the console logger writes it.
+func textValue(value *modelsv1.LogValue) string {
+	switch v := value.GetValue().(type) {
+	case *modelsv1.LogValue_String_:
+		return v.String_
+	case *modelsv1.LogValue_Int:
+		return strconv.FormatInt(v.Int, 10)
+	case *modelsv1.LogValue_Float:
+		return strconv.FormatFloat(v.Float, 'g', -1, 64)
+	case *modelsv1.LogValue_Bool:
+		return strconv.FormatBool(v.Bool)
+	case *modelsv1.LogValue_Time:
+		return time.UnixMilli(v.Time).UTC().Format(time.RFC3339Nano)
+	case *modelsv1.LogValue_Duration:
+		return time.Duration(v.Duration).String()
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,122 @@
+package log
+
+import (
+	"testing"
+	"time"
+
+	modelsv1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+var codecLog = &modelsv1.Log{
+	Timestamp: time.Date(2024, 5, 2, 9, 10, 0, 123000000, time.UTC).UnixMilli(),
+	Level:     modelsv1.Log_WARNING,
+	File:      "ingest/s3_processor.go",
+	Line:      217,
+	Message:   "couldn't remove handled message",
+	Attributes: map[string]*modelsv1.LogValue{
+		"location": {Value: &modelsv1.LogValue_String_{String_: "app/data/sample 2.txt"}},
+		"rows":     {Value: &modelsv1.LogValue_Int{Int: 12}},
+		"took":     {Value: &modelsv1.LogValue_Duration{Duration: int64(1500 * time.Millisecond)}},
+	},
+}
+
+func TestCodecs(t *testing.T) {
+	for _, name := range []string{JsonCodec, ProtobufCodec} {
+		codec, err := GetCodec(name)
+		assert.Nil(t, err)
+
+		body, err := codec.Encode(codecLog)
+		assert.Nil(t, err)
+
+		decoded, err := Decode(body, codec.ContentType())
+		assert.Nil(t, err)
+		assert.True(t, proto.Equal(codecLog, decoded), name)
+	}
+}
+
+func TestJsonCodec(t *testing.T) {
+	body, err := jsonCodec{}.Encode(&modelsv1.Log{Level: modelsv1.Log_ERROR, Message: "failed"})
+	assert.Nil(t, err)
+	// levels go by name, the way other languages read protobuf JSON
+	assert.JSONEq(t, `{"level":"ERROR","message":"failed"}`, body)
+
+	// logs shipped without a content type are json
+	decoded, err := Decode(`{"level":"INFO","message":"started","added":"later"}`, "")
+	assert.Nil(t, err)
+	assert.Equal(t, modelsv1.Log_INFO, decoded.Level)
+	assert.Equal(t, "started", decoded.Message)
+}
+
+func TestTextCodec(t *testing.T) {
+	body, err := textCodec{}.Encode(codecLog)
+	assert.Nil(t, err)
+	assert.Equal(t, `2024-05-02T09:10:00.123Z [WARN] ingest/s3_processor.go#217 - couldn't remove handled message location="app/data/sample 2.txt" rows=12 took=1.5s`, body)
+
+	decoded, err := Decode(body, TextContentType)
+	assert.Nil(t, err)
+	assert.True(t, proto.Equal(&modelsv1.Log{
+		Timestamp: codecLog.Timestamp,
+		Level:     modelsv1.Log_WARNING,
+		File:      "ingest/s3_processor.go",
+		Line:      217,
+		Message:   "couldn't remove handled message",
+		Attributes: map[string]*modelsv1.LogValue{
+			"location": {Value: &modelsv1.LogValue_String_{String_: "app/data/sample 2.txt"}},
+			"rows":     {Value: &modelsv1.LogValue_String_{String_: "12"}},
+			"took":     {Value: &modelsv1.LogValue_String_{String_: "1.5s"}},
+		},
+	}, decoded), decoded.String())
+}
+
+func TestTextCodec_Quoted(t *testing.T) {
+	original := &modelsv1.Log{
+		Timestamp: codecLog.Timestamp,
+		Level:     modelsv1.Log_ERROR,
+		File:      "main.go",
+		Line:      1,
+		Message:   "query failed:\nSELECT a = 1",
+		Attributes: map[string]*modelsv1.LogValue{
+			"error": {Value: &modelsv1.LogValue_String_{String_: `column "a" unknown`}},
+			"empty": {Value: &modelsv1.LogValue_String_{String_: ""}},
+		},
+	}
+
+	body, err := textCodec{}.Encode(original)
+	assert.Nil(t, err)
+	assert.Equal(t, `2024-05-02T09:10:00.123Z [ERROR] main.go#1 - "query failed:\nSELECT a = 1" empty="" error="column \"a\" unknown"`, body)
+
+	decoded, err := textCodec{}.Decode(body)
+	assert.Nil(t, err)
+	assert.True(t, proto.Equal(original, decoded), decoded.String())
+
+	decoded, err = textCodec{}.Decode("2024-05-02T09:10:00Z [INFO] main.go#1 - started")
+	assert.Nil(t, err)
+	assert.Equal(t, "started", decoded.Message)
+	assert.Nil(t, decoded.Attributes)
+}
+
+func TestTextCodec_Invalid(t *testing.T) {
+	_, err := textCodec{}.Decode("started")
+	assert.Equal(t, `log line "started" has no level`, err.Error())
+
+	_, err = textCodec{}.Decode("2024-05-02T09:10:00Z [LOUD] main.go#1 - started")
+	assert.Equal(t, `log line "2024-05-02T09:10:00Z [LOUD] main.go#1 - started" has unknown level "LOUD"`, err.Error())
+}
+
+func TestGetCodec(t *testing.T) {
+	codec, err := GetCodec("")
+	assert.Nil(t, err)
+	assert.Equal(t, JsonContentType, codec.ContentType())
+
+	codec, err = GetCodec("Protobuf")
+	assert.Nil(t, err)
+	assert.Equal(t, ProtobufContentType, codec.ContentType())
+
+	_, err = GetCodec("avro")
+	assert.Equal(t, "unknown log codec \"avro\"", err.Error())
+
+	_, err = Decode("{}", "application/avro")
+	assert.Equal(t, "unknown log content type \"application/avro\"", err.Error())
+}
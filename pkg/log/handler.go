@@ -0,0 +1,120 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"runtime"
+
+	"golang.org/x/exp/slices"
+)
+
+// CallerKey is the key of the file and line a record bridged from log/slog was logged at, for loggers that only see
+// the handler calling them.
+const CallerKey = "caller"
+
+// Handler is a slog.Handler logging records through a Logger, so libraries logging with log/slog end up in the same
+// place as the lake's own logs.
+type Handler struct {
+	logger Logger
+	groups []string
+}
+
+func NewHandler(logger Logger) *Handler {
+	return &Handler{logger: logger}
+}
+
+// Enabled tells whether the logger logs records of a level, from INFO for loggers that don't tell.
+func (handler *Handler) Enabled(_ context.Context, level slog.Level) bool {
+	if s, ok := handler.logger.(sink); ok {
+		return s.enabled(level)
+	}
+	return leveled{}.enabled(level)
+}
+
+// Handle logs a record, along with the trace and span of the context it was logged in.
+func (handler *Handler) Handle(ctx context.Context, record slog.Record) error {
+	attrs := make([]slog.Attr, 0, record.NumAttrs())
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs = append(attrs, attr)
+		return true
+	})
+
+	args := make([]any, 0, len(attrs)+3)
+	for _, attr := range handler.group(attrs) {
+		args = append(args, attr)
+	}
+	for _, attr := range Trace(ctx) {
+		args = append(args, attr)
+	}
+
+	file, line := "", int32(0)
+	if record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		file, line = shorten(frame.File), int32(frame.Line)
+	}
+
+	// sinks write the record as logged where slog was called
+	if s, ok := handler.logger.(sink); ok {
+		entry := newEntry(record.Level, file, line, record.Message, args)
+		entry.time = record.Time
+		if s.enabled(record.Level) {
+			s.write(entry)
+		}
+		return nil
+	}
+
+	if file != "" {
+		args = append(args, slog.String(CallerKey, fmt.Sprintf("%s#%d", file, line)))
+	}
+
+	switch {
+	case record.Level >= slog.LevelError:
+		handler.logger.Error(record.Message, args...)
+	case record.Level >= slog.LevelWarn:
+		handler.logger.Warn(record.Message, args...)
+	case record.Level >= slog.LevelInfo:
+		handler.logger.Info(record.Message, args...)
+	default:
+		handler.logger.Debug(record.Message, args...)
+	}
+
+	return nil
+}
+
+func (handler *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	args := make([]any, 0, len(attrs))
+	for _, attr := range handler.group(attrs) {
+		args = append(args, attr)
+	}
+	return &Handler{logger: handler.logger.With(args...), groups: handler.groups}
+}
+
+func (handler *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return handler
+	}
+	return &Handler{logger: handler.logger, groups: append(slices.Clip(handler.groups), name)}
+}
+
+// group nests attributes in the groups opened on the handler.
+func (handler *Handler) group(attrs []slog.Attr) []slog.Attr {
+	if len(attrs) == 0 {
+		return nil
+	}
+	for i := len(handler.groups) - 1; i >= 0; i-- {
+		attrs = []slog.Attr{{Key: handler.groups[i], Value: slog.GroupValue(attrs...)}}
+	}
+	return attrs
+}
+
+// SetSlogDefault makes the logger the default of log/slog. slog.SetDefault also sends the standard log package to the
+// handler, which ConsoleLog writes with, so its output and flags are put back to stop every line going around in
+// circles.
+func SetSlogDefault(logger Logger) {
+	writer, flags := log.Writer(), log.Flags()
+	slog.SetDefault(slog.New(NewHandler(logger)))
+	log.SetOutput(writer)
+	log.SetFlags(flags)
+}
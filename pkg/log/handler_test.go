@@ -0,0 +1,112 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestHandler(t *testing.T) {
+	var buf bytes.Buffer
+
+	log.SetOutput(&buf)
+	defer func() {
+		log.SetOutput(os.Stderr)
+	}()
+
+	logger := slog.New(NewHandler(NewConsoleLog())).With(Source("parquet")).WithGroup("writer")
+
+	logger.Warn("flushing row group", "rows", 100, Err(errors.New("slow disk")))
+
+	output := buf.String()
+	assert.True(t, strings.Contains(output, `[WARN] log/handler_test.go#27 - flushing row group source=parquet writer.rows=100 writer.error="slow disk"`), output)
+}
+
+// messages records what's logged to it, like loggers that aren't sinks.
+type messages []string
+
+func (logged *messages) Error(msg string, args ...any) {
+	logged.log(msg, args)
+}
+
+func (logged *messages) Warn(msg string, args ...any) {
+	logged.log(msg, args)
+}
+
+func (logged *messages) Info(msg string, args ...any) {
+	logged.log(msg, args)
+}
+
+func (logged *messages) Debug(msg string, args ...any) {
+	logged.log(msg, args)
+}
+
+func (logged *messages) With(args ...any) Logger {
+	return logged
+}
+
+func (logged *messages) log(msg string, args []any) {
+	*logged = append(*logged, msg+text(attrs(args)))
+}
+
+func TestHandler_Caller(t *testing.T) {
+	logged := &messages{}
+
+	slog.New(NewHandler(logged)).Info("connected", "host", "localhost")
+
+	assert.Equal(t, 1, len(*logged))
+	assert.True(t, strings.HasPrefix((*logged)[0], "connected host=localhost caller=log/handler_test.go#"), (*logged)[0])
+}
+
+func TestHandler_Trace(t *testing.T) {
+	logged := &messages{}
+	span := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:  trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+	})
+
+	slog.New(NewHandler(logged)).InfoContext(trace.ContextWithSpanContext(context.Background(), span), "connected")
+
+	assert.Equal(t, 1, len(*logged))
+	assert.True(t, strings.HasPrefix((*logged)[0], "connected trace_id=4bf92f3577b34da6a3ce929d0e0e4736 span_id=00f067aa0ba902b7 caller="), (*logged)[0])
+}
+
+func TestHandler_Enabled(t *testing.T) {
+	levels, _ := NewLevels("DEBUG", nil)
+	handler := NewHandler(NewLeveledConsoleLog(levels))
+	assert.True(t, handler.Enabled(context.Background(), slog.LevelDebug))
+	assert.True(t, handler.Enabled(context.Background(), slog.LevelError))
+
+	// loggers without levels log from INFO
+	handler = NewHandler(NewConsoleLog())
+	assert.False(t, handler.Enabled(context.Background(), slog.LevelDebug))
+	assert.True(t, handler.Enabled(context.Background(), slog.LevelInfo))
+}
+
+func TestSetSlogDefault(t *testing.T) {
+	var buf bytes.Buffer
+
+	log.SetOutput(&buf)
+	defaultLogger := slog.Default()
+	defer func() {
+		slog.SetDefault(defaultLogger)
+		log.SetOutput(os.Stderr)
+	}()
+
+	SetSlogDefault(NewConsoleLog())
+
+	slog.Info("from a library")
+	log.Println("from the standard logger")
+
+	output := buf.String()
+	assert.True(t, strings.Contains(output, "[INFO]") && strings.Contains(output, "from a library"), output)
+	assert.True(t, strings.Contains(output, "from the standard logger"), output)
+}
@@ -0,0 +1,289 @@
+package log
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+	"path"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	modelsv1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/config"
+)
+
+// ParseLevel returns the level named DEBUG, INFO, WARN or WARNING, or ERROR, in any case.
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToUpper(strings.TrimSpace(name)) {
+	case "DEBUG":
+		return slog.LevelDebug, nil
+	case "INFO":
+		return slog.LevelInfo, nil
+	case "WARN", "WARNING":
+		return slog.LevelWarn, nil
+	case "ERROR":
+		return slog.LevelError, nil
+	}
+	return 0, fmt.Errorf("unknown log level %q", name)
+}
+
+// Levels holds the level logs are written from, LOGGER_LEVEL, and the levels of the packages and sources that log
+// from another one, LOGGER_LEVELS. An override names a package by its folder, like ingest, or the source attribute of
+// a logger, like parquet, the source winning when both have one. Levels are parsed when they're set, so they can be
+// changed while the lake runs without every log parsing them again.
+type Levels struct {
+	set atomic.Pointer[levelSet]
+	// lock keeps changes from overwriting each other, reading the levels takes no lock
+	lock sync.Mutex
+}
+
+// levelSet is the levels as parsed, replaced as a whole whenever they change.
+type levelSet struct {
+	// level is nil when LOGGER_LEVEL names no level, only the overrides log then
+	level     *slog.Level
+	overrides map[string]slog.Level
+	// lowest is the lowest of the levels, nothing below it is logged whatever it's about
+	lowest *slog.Level
+}
+
+// NewLevels returns the levels logging from level, and from the level of its override for the packages and sources
+// named in overrides.
+func NewLevels(level string, overrides map[string]string) (*Levels, error) {
+	levels := &Levels{}
+	levels.set.Store(&levelSet{})
+	return levels, levels.Set(level, overrides)
+}
+
+// Set replaces the levels. Levels that don't parse leave them as they were.
+func (levels *Levels) Set(level string, overrides map[string]string) error {
+	levels.lock.Lock()
+	defer levels.lock.Unlock()
+
+	return levels.store(level, overrides)
+}
+
+// SetOverride sets the level of a package or source, an empty level removes its override.
+func (levels *Levels) SetOverride(name string, level string) error {
+	levels.lock.Lock()
+	defer levels.lock.Unlock()
+
+	current, overrides := levels.Get()
+	if level == "" {
+		delete(overrides, name)
+	} else {
+		overrides[name] = level
+	}
+	return levels.store(current, overrides)
+}
+
+func (levels *Levels) store(level string, overrides map[string]string) error {
+	set := &levelSet{overrides: make(map[string]slog.Level, len(overrides))}
+
+	if level != "" {
+		parsed, err := ParseLevel(level)
+		if err != nil {
+			return err
+		}
+		set.level = &parsed
+	}
+
+	for name, override := range overrides {
+		parsed, err := ParseLevel(override)
+		if err != nil {
+			return fmt.Errorf("invalid level of %v: %v", name, err)
+		}
+		set.overrides[strings.TrimSpace(name)] = parsed
+	}
+
+	set.lowest = set.level
+	for _, override := range set.overrides {
+		if set.lowest == nil || override < *set.lowest {
+			lowest := override
+			set.lowest = &lowest
+		}
+	}
+
+	levels.set.Store(set)
+	return nil
+}
+
+// Get returns the level logs are written from, empty when there is none, and the levels of the overrides.
+func (levels *Levels) Get() (string, map[string]string) {
+	set := levels.set.Load()
+
+	level := ""
+	if set.level != nil {
+		level = levelName(*set.level)
+	}
+
+	overrides := make(map[string]string, len(set.overrides))
+	for name, override := range set.overrides {
+		overrides[name] = levelName(override)
+	}
+
+	return level, overrides
+}
+
+// Configure sets the levels from LOGGER_LEVEL and LOGGER_LEVELS.
+func (levels *Levels) Configure(conf *config.Config) error {
+	overrides, err := ParseOverrides(conf.LoggerLevels)
+	if err != nil {
+		return fmt.Errorf("invalid LOGGER_LEVELS: %v", err)
+	}
+	if err := levels.Set(conf.LoggerLevel, overrides); err != nil {
+		return fmt.Errorf("invalid LOGGER_LEVEL: %v", err)
+	}
+	return nil
+}
+
+// enabled tells whether anything is logged at a level.
+func (levels *Levels) enabled(level slog.Level) bool {
+	set := levels.set.Load()
+	return set.lowest != nil && level >= *set.lowest
+}
+
+// logs tells whether an entry is logged, by the level of its source or package when they have one.
+func (levels *Levels) logs(entry *entry, attrs []slog.Attr) bool {
+	set := levels.set.Load()
+
+	if len(set.overrides) > 0 {
+		for _, attr := range attrs {
+			if attr.Key == SourceKey {
+				if override, ok := set.overrides[attr.Value.String()]; ok {
+					return entry.level >= override
+				}
+			}
+		}
+		if override, ok := set.overrides[path.Dir(entry.file)]; ok {
+			return entry.level >= override
+		}
+	}
+
+	return set.level != nil && entry.level >= *set.level
+}
+
+// ParseOverrides parses the overrides of LOGGER_LEVELS, a comma separated list of name=LEVEL.
+func ParseOverrides(value string) (map[string]string, error) {
+	overrides := make(map[string]string)
+	for _, override := range strings.Split(value, ",") {
+		if strings.TrimSpace(override) == "" {
+			continue
+		}
+		name, level, ok := strings.Cut(override, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("override %q isn't name=LEVEL", strings.TrimSpace(override))
+		}
+		overrides[strings.TrimSpace(name)] = strings.TrimSpace(level)
+	}
+	return overrides, nil
+}
+
+var levelsLock = &sync.Mutex{}
+
+var levelsInstance atomic.Pointer[Levels]
+
+// GetLevels returns the levels the sinks of GetLogger follow, from the LOGGER_LEVEL and LOGGER_LEVELS of GetConfig
+// until they're changed. When those don't parse everything is logged from INFO until they are. It's a shim for code that isn't
+// handed the levels of its logger, NewLevelsOf makes levels of a config.
+func GetLevels() *Levels {
+	if levels := levelsInstance.Load(); levels != nil {
+		return levels
+	}
+
+	levelsLock.Lock()
+	defer levelsLock.Unlock()
+	if levels := levelsInstance.Load(); levels != nil {
+		return levels
+	}
+
+	levels := NewLevelsOf(config.GetConfig())
+	levelsInstance.Store(levels)
+
+	return levels
+}
+
+// NewLevelsOf returns the levels of LOGGER_LEVEL and LOGGER_LEVELS. When those don't parse everything is logged from
+// INFO until the levels are set.
+func NewLevelsOf(conf *config.Config) *Levels {
+	levels, _ := NewLevels("", nil)
+	if err := levels.Configure(conf); err != nil {
+		log.Printf("logging from INFO until the levels are set: %v", err)
+		_ = levels.Set("INFO", nil)
+	}
+	return levels
+}
+
+// leveled holds the minimum level of a sink. Sinks without one of their own follow the levels they were made with,
+// along with their overrides, or else log from INFO.
+type leveled struct {
+	level  *slog.Level
+	levels *Levels
+}
+
+// Follow has a sink without a level of its own follow the levels, before it logs anything.
+func (sink *leveled) Follow(levels *Levels) {
+	sink.levels = levels
+}
+
+func (sink leveled) enabled(level slog.Level) bool {
+	if sink.level != nil {
+		return level >= *sink.level
+	}
+	if sink.levels != nil {
+		return sink.levels.enabled(level)
+	}
+	return level >= slog.LevelInfo
+}
+
+// logs tells whether the sink writes an entry, attrs are the attributes it's written with.
+func (sink leveled) logs(entry *entry, attrs []slog.Attr) bool {
+	if sink.level != nil {
+		return entry.level >= *sink.level
+	}
+	if sink.levels != nil {
+		return sink.levels.logs(entry, attrs)
+	}
+	return entry.level >= slog.LevelInfo
+}
+
+// sinkLevel returns the minimum level of a sink from its config key, nil for LOGGER_LEVEL when it's empty.
+func sinkLevel(key string, name string) (*slog.Level, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	level, err := ParseLevel(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %v: %v", key, err)
+	}
+	return &level, nil
+}
+
+// levelName returns the name logs of a level are written with.
+func levelName(level slog.Level) string {
+	switch {
+	case level >= slog.LevelError:
+		return "ERROR"
+	case level >= slog.LevelWarn:
+		return "WARN"
+	case level >= slog.LevelInfo:
+		return "INFO"
+	default:
+		return "DEBUG"
+	}
+}
+
+// logLevel returns the level of the Log message for a level.
+func logLevel(level slog.Level) modelsv1.Log_LogLevel {
+	switch {
+	case level >= slog.LevelError:
+		return modelsv1.Log_ERROR
+	case level >= slog.LevelWarn:
+		return modelsv1.Log_WARNING
+	case level >= slog.LevelInfo:
+		return modelsv1.Log_INFO
+	default:
+		return modelsv1.Log_DEBUG
+	}
+}
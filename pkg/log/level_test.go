@@ -0,0 +1,108 @@
+package log
+
+import (
+	"bytes"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevels(t *testing.T) {
+	levels, err := NewLevels("INFO", map[string]string{"ingest": "debug", "parquet": "ERROR"})
+	assert.Nil(t, err)
+
+	// debug is logged somewhere, so it has to be looked at
+	assert.True(t, levels.enabled(slog.LevelDebug))
+
+	assert.True(t, levels.logs(&entry{level: slog.LevelDebug, file: "ingest/s3_processor.go"}, nil))
+	assert.False(t, levels.logs(&entry{level: slog.LevelDebug, file: "compaction/compactor.go"}, nil))
+	assert.True(t, levels.logs(&entry{level: slog.LevelInfo, file: "compaction/compactor.go"}, nil))
+
+	// the source wins over the package
+	parquet := []slog.Attr{Source("parquet")}
+	assert.False(t, levels.logs(&entry{level: slog.LevelWarn, file: "ingest/s3_processor.go"}, parquet))
+	assert.True(t, levels.logs(&entry{level: slog.LevelError, file: "ingest/s3_processor.go"}, parquet))
+
+	level, overrides := levels.Get()
+	assert.Equal(t, "INFO", level)
+	assert.Equal(t, map[string]string{"ingest": "DEBUG", "parquet": "ERROR"}, overrides)
+}
+
+func TestLevels_Set(t *testing.T) {
+	levels, err := NewLevels("WARN", nil)
+	assert.Nil(t, err)
+	assert.False(t, levels.enabled(slog.LevelInfo))
+
+	assert.Nil(t, levels.SetOverride("compaction", "INFO"))
+	assert.True(t, levels.enabled(slog.LevelInfo))
+	assert.True(t, levels.logs(&entry{level: slog.LevelInfo, file: "compaction/compactor.go"}, nil))
+
+	assert.Nil(t, levels.SetOverride("compaction", ""))
+	assert.False(t, levels.enabled(slog.LevelInfo))
+
+	// levels that don't parse change nothing
+	assert.Equal(t, "invalid level of ingest: unknown log level \"LOUD\"", levels.Set("DEBUG", map[string]string{"ingest": "LOUD"}).Error())
+	assert.Equal(t, "invalid level of ingest: unknown log level \"LOUD\"", levels.SetOverride("ingest", "LOUD").Error())
+	level, overrides := levels.Get()
+	assert.Equal(t, "WARN", level)
+	assert.Empty(t, overrides)
+
+	// without a level only the overrides log
+	assert.Nil(t, levels.Set("", map[string]string{"ingest": "ERROR"}))
+	assert.False(t, levels.logs(&entry{level: slog.LevelError, file: "compaction/compactor.go"}, nil))
+	assert.True(t, levels.logs(&entry{level: slog.LevelError, file: "ingest/s3_processor.go"}, nil))
+}
+
+func TestLevels_Configure(t *testing.T) {
+	levels, _ := NewLevels("", nil)
+
+	assert.Nil(t, levels.Configure(&config.Config{LoggerLevel: "ERROR", LoggerLevels: "ingest=DEBUG, parquet = warn,"}))
+	level, overrides := levels.Get()
+	assert.Equal(t, "ERROR", level)
+	assert.Equal(t, map[string]string{"ingest": "DEBUG", "parquet": "WARN"}, overrides)
+
+	assert.Equal(t, "invalid LOGGER_LEVELS: override \"ingest\" isn't name=LEVEL", levels.Configure(&config.Config{LoggerLevels: "ingest"}).Error())
+	assert.Equal(t, "invalid LOGGER_LEVEL: unknown log level \"LOUD\"", levels.Configure(&config.Config{LoggerLevel: "LOUD"}).Error())
+}
+
+func TestConsoleLog_Overrides(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	levels, _ := NewLevels("INFO", map[string]string{"parquet": "ERROR", "log": "DEBUG"})
+
+	logger := NewLeveledConsoleLog(levels)
+	logger.Debug("logged from this package")
+	logger.With(Source("parquet")).Warn("flushing row group")
+
+	output := buf.String()
+	assert.True(t, strings.Contains(output, "logged from this package"), output)
+	assert.False(t, strings.Contains(output, "flushing row group"), output)
+
+	// sinks with a level of their own keep it
+	warn := slog.LevelWarn
+	buf.Reset()
+	(&ConsoleLog{leveled: leveled{level: &warn, levels: levels}, format: Text}).Debug("logged from this package")
+	assert.Empty(t, buf.String())
+
+	// without levels only INFO and above are logged
+	NewConsoleLog().Debug("logged from this package")
+	assert.Empty(t, buf.String())
+}
+
+func TestNewLevelsOf(t *testing.T) {
+	level, overrides := NewLevelsOf(&config.Config{LoggerLevel: "WARN", LoggerLevels: "ingest=DEBUG"}).Get()
+	assert.Equal(t, "WARN", level)
+	assert.Equal(t, map[string]string{"ingest": "DEBUG"}, overrides)
+
+	// levels that don't parse log from INFO
+	levels := NewLevelsOf(&config.Config{LoggerLevel: "LOUD"})
+	assert.True(t, levels.enabled(slog.LevelInfo))
+	assert.False(t, levels.enabled(slog.LevelDebug))
+}
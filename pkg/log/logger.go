@@ -1,12 +1,17 @@
 package log
 
 import (
+	"fmt"
 	"log"
+	"log/slog"
 	"runtime"
 	"strings"
 	"sync"
+	"time"
 
+	modelsv1 "github.com/codingexplorations/data-lake/models/v1"
 	"github.com/codingexplorations/data-lake/pkg/config"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
 var Reset = "\033[0m"
@@ -14,54 +19,205 @@ var Red = "\033[31m"
 var Yellow = "\033[33m"
 var Cyan = "\033[36m"
 
+// The formats sinks write logs in.
+const (
+	// Text writes a line of [LEVEL] file#line - message key=value
+	Text = "text"
+	// Json writes a line of the Log message as protojson
+	Json = "json"
+)
+
+// Logger logs messages with structured attributes. The args of a call are slog.Attr values, like the ones Err and
+// Location return, or alternating keys and values, the same as log/slog takes them.
 type Logger interface {
-	Error(msg string)
-	Warn(msg string)
-	Info(msg string)
-	Debug(msg string)
+	Error(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Info(msg string, args ...any)
+	Debug(msg string, args ...any)
+	// With returns a logger that adds the attributes to everything it logs
+	With(args ...any) Logger
+}
+
+// entry is a log as sinks write it.
+type entry struct {
+	time    time.Time
+	level   slog.Level
+	file    string
+	line    int32
+	message string
+	args    []any
+}
+
+// sink is a Logger that can also write entries logged through another logger, like the Loggers fanning out to it,
+// keeping the file and line they were logged at. It's an alias so mockery leaves it out, mocks of its unexported
+// methods wouldn't compile.
+type sink = interface {
+	Logger
+	enabled(level slog.Level) bool
+	write(entry *entry)
 }
 
 var loggerLock = &sync.Mutex{}
 
 var loggerInstance Logger
 
+// GetLogger returns the logger of GetConfig, following GetLevels. It's a shim for code that isn't handed a logger,
+// NewLogger makes one of a config.
 func GetLogger() (Logger, error) {
 	if loggerInstance == nil {
 		loggerLock.Lock()
 		defer loggerLock.Unlock()
 		if loggerInstance == nil {
-			config := config.GetConfig()
-			switch config.LoggerType {
-			case "SERVICE":
-				serviceLog, err := NewSqsLog()
-				if err != nil {
-					log.Println("failed to create service log instance")
-					return nil, err
-				}
-				loggerInstance = serviceLog
-			case "CONSOLE":
-			default:
-				loggerInstance = NewConsoleLog()
+			logger, err := NewLogger(config.GetConfig(), GetLevels())
+			if err != nil {
+				log.Println("failed to create logger instance")
+				return nil, err
 			}
+			loggerInstance = logger
 		}
 	}
 
 	return loggerInstance, nil
 }
 
+// GetLoggerOrConsole returns the configured logger, or a ConsoleLog when it can't be created so there is always
+// somewhere to log to.
+func GetLoggerOrConsole() Logger {
+	logger, err := GetLogger()
+	if err != nil {
+		return console(GetLevels(), err)
+	}
+	return logger
+}
+
+// NewLoggerOrConsole returns the logger of a config, or a ConsoleLog following the levels when it can't be created so
+// there is always somewhere to log to.
+func NewLoggerOrConsole(conf *config.Config, levels *Levels) Logger {
+	logger, err := NewLogger(conf, levels)
+	if err != nil {
+		return console(levels, err)
+	}
+	return logger
+}
+
+// console returns the ConsoleLog logged to when the configured logger couldn't be created, saying why.
+func console(levels *Levels, err error) Logger {
+	console := NewLeveledConsoleLog(levels)
+	console.Error("couldn't create the configured logger, logging to the console", Err(err))
+	return console
+}
+
+// NewLogger returns a logger writing to the sinks named in LOGGER_SINKS, a comma separated list of console, json, file
+// and sqs, each logging from the level in its LOGGER_<SINK>_LEVEL or else following the levels, the ones of
+// NewLevelsOf unless they're changed. Without any sinks LOGGER_TYPE picks one, sqs for SERVICE and console otherwise.
+func NewLogger(conf *config.Config, levels *Levels) (Logger, error) {
+	loggers := make(Loggers, 0)
+
+	for _, name := range strings.Split(sinks(conf), ",") {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "":
+		case "console":
+			level, err := sinkLevel("LOGGER_CONSOLE_LEVEL", conf.LoggerConsoleLevel)
+			if err != nil {
+				return nil, err
+			}
+			loggers = append(loggers, &ConsoleLog{leveled: leveled{level, levels}, format: Text})
+		case "json":
+			level, err := sinkLevel("LOGGER_JSON_LEVEL", conf.LoggerJsonLevel)
+			if err != nil {
+				return nil, err
+			}
+			loggers = append(loggers, &ConsoleLog{leveled: leveled{level, levels}, format: Json})
+		case "file":
+			level, err := sinkLevel("LOGGER_FILE_LEVEL", conf.LoggerFileLevel)
+			if err != nil {
+				return nil, err
+			}
+			fileLog, err := NewFileLog(conf.LoggerFilePath, conf.LoggerFileFormat, FileRotation(conf))
+			if err != nil {
+				return nil, err
+			}
+			fileLog.leveled = leveled{level, levels}
+			loggers = append(loggers, fileLog)
+		case "sqs":
+			level, err := sinkLevel("LOGGER_SQS_LEVEL", conf.LoggerSqsLevel)
+			if err != nil {
+				return nil, err
+			}
+			sqsLog, err := NewSqsLog(conf)
+			if err != nil {
+				return nil, err
+			}
+			sqsLog.leveled = leveled{level, levels}
+			loggers = append(loggers, sqsLog)
+		default:
+			return nil, fmt.Errorf("unknown logger sink %q", name)
+		}
+	}
+
+	if len(loggers) == 1 {
+		return loggers[0], nil
+	}
+	return loggers, nil
+}
+
+// sinks returns the LOGGER_SINKS, or the one LOGGER_TYPE picks without them, whatever its case.
+func sinks(conf *config.Config) string {
+	if strings.TrimSpace(conf.LoggerSinks) != "" {
+		return conf.LoggerSinks
+	}
+	if strings.EqualFold(strings.TrimSpace(conf.LoggerType), "SERVICE") {
+		return "sqs"
+	}
+	return "console"
+}
+
+// textLine formats an entry as [LEVEL] file#line - message key=value.
+func textLine(entry *entry, attrs []slog.Attr) string {
+	return fmt.Sprintf("[%s] %s#%d - %s%s", levelName(entry.level), entry.file, entry.line, entry.message, text(attrs))
+}
+
+// jsonLine encodes an entry as the protojson of its Log message.
+func jsonLine(entry *entry, attrs []slog.Attr) ([]byte, error) {
+	return protojson.Marshal(record(entry, attrs))
+}
+
+// record returns the Log message of an entry.
+func record(entry *entry, attrs []slog.Attr) *modelsv1.Log {
+	return &modelsv1.Log{
+		Timestamp:  entry.time.UnixMilli(),
+		Level:      logLevel(entry.level),
+		File:       entry.file,
+		Line:       entry.line,
+		Message:    entry.message,
+		Attributes: values(attrs),
+	}
+}
+
+// newEntry returns the entry of a log call made at a file and line.
+func newEntry(level slog.Level, file string, line int32, msg string, args []any) *entry {
+	return &entry{time: time.Now(), level: level, file: file, line: line, message: msg, args: args}
+}
+
 func getCaller(skip int) (string, int32) {
 	_, file, line, ok := runtime.Caller(skip)
 
 	if ok {
-		callerSplit := strings.Split(file, "/")
-
-		// get the last two elements in the file path on the / split - Go splice range functionality
-		lastTwoFilePaths := callerSplit[len(callerSplit)-2:]
+		return shorten(file), int32(line)
+	}
 
-		shortFile := strings.Join(lastTwoFilePaths, "/")
+	return "", int32(line)
+}
 
-		return shortFile, int32(line)
+// shorten keeps the last two elements of a file path, the package directory and the file.
+func shorten(file string) string {
+	callerSplit := strings.Split(file, "/")
+	if len(callerSplit) < 2 {
+		return file
 	}
 
-	return "", int32(line)
+	// get the last two elements in the file path on the / split - Go splice range functionality
+	lastTwoFilePaths := callerSplit[len(callerSplit)-2:]
+
+	return strings.Join(lastTwoFilePaths, "/")
 }
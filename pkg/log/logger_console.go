@@ -3,45 +3,81 @@ package log
 import (
 	"fmt"
 	"log"
-
-	"github.com/codingexplorations/data-lake/pkg/config"
-	"golang.org/x/exp/slices"
+	"log/slog"
 )
 
-type ConsoleLog struct{}
+// ConsoleLog writes logs through the standard logger, as text lines with the attributes after the message as
+// key=value pairs, or as JSON lines for collectors to parse.
+type ConsoleLog struct {
+	leveled
+
+	format string
+	attrs  []slog.Attr
+}
 
+// NewConsoleLog returns a ConsoleLog writing text lines from INFO.
 func NewConsoleLog() *ConsoleLog {
-	return &ConsoleLog{}
+	return &ConsoleLog{format: Text}
 }
 
-func (logger *ConsoleLog) Error(msg string) {
-	if slices.Contains([]string{"ERROR", "WARN", "INFO", "DEBUG"}, config.GetConfig().LoggerLevel) {
-		file, line := getCaller(2)
-		msg = fmt.Sprintf("[ERROR] %s#%d - %s", file, line, msg)
-		log.Println(Red + msg + Reset)
-	}
+// NewLeveledConsoleLog returns a ConsoleLog writing text lines from the levels.
+func NewLeveledConsoleLog(levels *Levels) *ConsoleLog {
+	return &ConsoleLog{leveled: leveled{levels: levels}, format: Text}
 }
 
-func (logger *ConsoleLog) Warn(msg string) {
-	if slices.Contains([]string{"WARN", "INFO", "DEBUG"}, config.GetConfig().LoggerLevel) {
-		file, line := getCaller(2)
-		msg = fmt.Sprintf("[WARN] %s#%d - %s", file, line, msg)
-		log.Println(Yellow + msg + Reset)
-	}
+func (logger *ConsoleLog) Error(msg string, args ...any) {
+	logger.log(slog.LevelError, msg, args)
+}
+
+func (logger *ConsoleLog) Warn(msg string, args ...any) {
+	logger.log(slog.LevelWarn, msg, args)
+}
+
+func (logger *ConsoleLog) Info(msg string, args ...any) {
+	logger.log(slog.LevelInfo, msg, args)
 }
 
-func (logger *ConsoleLog) Info(msg string) {
-	if slices.Contains([]string{"INFO", "DEBUG"}, config.GetConfig().LoggerLevel) {
-		file, line := getCaller(2)
-		msg = fmt.Sprintf("[INFO] %s#%d - %s", file, line, msg)
-		log.Println(msg)
+func (logger *ConsoleLog) Debug(msg string, args ...any) {
+	logger.log(slog.LevelDebug, msg, args)
+}
+
+func (logger *ConsoleLog) With(args ...any) Logger {
+	return &ConsoleLog{leveled: logger.leveled, format: logger.format, attrs: with(logger.attrs, args)}
+}
+
+func (logger *ConsoleLog) log(level slog.Level, msg string, args []any) {
+	if logger.enabled(level) {
+		file, line := getCaller(3)
+		logger.write(newEntry(level, file, line, msg, args))
 	}
 }
 
-func (logger *ConsoleLog) Debug(msg string) {
-	if slices.Contains([]string{"DEBUG"}, config.GetConfig().LoggerLevel) {
-		file, line := getCaller(2)
-		msg = fmt.Sprintf("[DEBUG] %s#%d - %s", file, line, msg)
-		log.Println(Cyan + msg + Reset)
+func (logger *ConsoleLog) write(entry *entry) {
+	attrs := with(logger.attrs, entry.args)
+	if !logger.logs(entry, attrs) {
+		return
+	}
+
+	if logger.format == Json {
+		line, err := jsonLine(entry, attrs)
+		if err != nil {
+			log.Printf("failed to encode log - %v: %v", entry.message, err)
+			return
+		}
+		// the standard logger's prefix would make the line invalid JSON
+		fmt.Fprintln(log.Writer(), string(line))
+		return
+	}
+
+	line := textLine(entry, attrs)
+	switch {
+	case entry.level >= slog.LevelError:
+		log.Println(Red + line + Reset)
+	case entry.level >= slog.LevelWarn:
+		log.Println(Yellow + line + Reset)
+	case entry.level >= slog.LevelInfo:
+		log.Println(line)
+	default:
+		log.Println(Cyan + line + Reset)
 	}
 }
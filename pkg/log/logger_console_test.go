@@ -2,10 +2,14 @@ package log
 
 import (
 	"bytes"
+	"errors"
 	"log"
 	"os"
 	"strings"
 	"testing"
+
+	modelsv1 "github.com/codingexplorations/data-lake/models/v1"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
 func TestConsoleLog_Error(t *testing.T) {
@@ -67,7 +71,8 @@ func TestConsoleLog_Debug(t *testing.T) {
 		log.SetOutput(os.Stderr)
 	}()
 
-	logger := NewConsoleLog()
+	levels, _ := NewLevels("DEBUG", nil)
+	logger := NewLeveledConsoleLog(levels)
 
 	logger.Debug("testing DEBUG")
 
@@ -75,3 +80,41 @@ func TestConsoleLog_Debug(t *testing.T) {
 		t.Error("Failed to output DEBUG log.")
 	}
 }
+
+func TestConsoleLog_Attributes(t *testing.T) {
+	var buf bytes.Buffer
+
+	log.SetOutput(&buf)
+	defer func() {
+		log.SetOutput(os.Stderr)
+	}()
+
+	logger := NewConsoleLog().With(Source("compactor"))
+
+	logger.Error("error compacting dataset", Dataset("orders"), Err(errors.New("disk full")))
+
+	if !strings.Contains(buf.String(), `error compacting dataset source=compactor dataset=orders error="disk full"`) {
+		t.Errorf("Failed to output attributes: %v", buf.String())
+	}
+}
+
+func TestConsoleLog_Json(t *testing.T) {
+	var buf bytes.Buffer
+
+	log.SetOutput(&buf)
+	defer func() {
+		log.SetOutput(os.Stderr)
+	}()
+
+	logger := &ConsoleLog{format: Json}
+
+	logger.Warn("skipped compacting partition", Dataset("orders"))
+
+	logged := &modelsv1.Log{}
+	if err := protojson.Unmarshal(buf.Bytes(), logged); err != nil {
+		t.Fatalf("Failed to output a JSON log: %v", buf.String())
+	}
+	if logged.Message != "skipped compacting partition" || logged.Level != modelsv1.Log_WARNING || logged.Attributes["dataset"].GetString_() != "orders" {
+		t.Errorf("Failed to output the log as JSON: %v", buf.String())
+	}
+}
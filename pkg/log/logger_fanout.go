@@ -0,0 +1,71 @@
+package log
+
+import (
+	"log/slog"
+)
+
+// Loggers logs to each of its loggers, every one of them logging from its own level.
+type Loggers []Logger
+
+func (loggers Loggers) Error(msg string, args ...any) {
+	loggers.log(slog.LevelError, msg, args)
+}
+
+func (loggers Loggers) Warn(msg string, args ...any) {
+	loggers.log(slog.LevelWarn, msg, args)
+}
+
+func (loggers Loggers) Info(msg string, args ...any) {
+	loggers.log(slog.LevelInfo, msg, args)
+}
+
+func (loggers Loggers) Debug(msg string, args ...any) {
+	loggers.log(slog.LevelDebug, msg, args)
+}
+
+func (loggers Loggers) With(args ...any) Logger {
+	withs := make(Loggers, 0, len(loggers))
+	for _, logger := range loggers {
+		withs = append(withs, logger.With(args...))
+	}
+	return withs
+}
+
+func (loggers Loggers) enabled(level slog.Level) bool {
+	for _, logger := range loggers {
+		if s, ok := logger.(sink); !ok || s.enabled(level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (loggers Loggers) write(entry *entry) {
+	for _, logger := range loggers {
+		if s, ok := logger.(sink); ok {
+			if s.enabled(entry.level) {
+				s.write(entry)
+			}
+			continue
+		}
+
+		// loggers that aren't sinks log where they're called from, here
+		switch {
+		case entry.level >= slog.LevelError:
+			logger.Error(entry.message, entry.args...)
+		case entry.level >= slog.LevelWarn:
+			logger.Warn(entry.message, entry.args...)
+		case entry.level >= slog.LevelInfo:
+			logger.Info(entry.message, entry.args...)
+		default:
+			logger.Debug(entry.message, entry.args...)
+		}
+	}
+}
+
+func (loggers Loggers) log(level slog.Level, msg string, args []any) {
+	if loggers.enabled(level) {
+		file, line := getCaller(3)
+		loggers.write(newEntry(level, file, line, msg, args))
+	}
+}
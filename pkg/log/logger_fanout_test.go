@@ -0,0 +1,56 @@
+package log
+
+import (
+	"bytes"
+	"log"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoggers(t *testing.T) {
+	var buf bytes.Buffer
+
+	log.SetOutput(&buf)
+	defer func() {
+		log.SetOutput(os.Stderr)
+	}()
+
+	warn, debug := slog.LevelWarn, slog.LevelDebug
+	path := filepath.Join(t.TempDir(), "data-lake.log")
+	fileLog, err := NewFileLog(path, Text, Rotation{})
+	assert.Nil(t, err)
+	fileLog.level = &debug
+	defer fileLog.Close()
+
+	logged := &messages{}
+	loggers := Loggers{&ConsoleLog{leveled: leveled{level: &warn}, format: Text}, fileLog, logged}.With(Source("runner"))
+
+	loggers.Info("run finished", "objects", 3)
+	loggers.Warn("run slow")
+
+	console := buf.String()
+	assert.False(t, strings.Contains(console, "run finished"), console)
+	assert.True(t, strings.Contains(console, "[WARN] log/logger_fanout_test.go#34 - run slow source=runner"), console)
+
+	file, _ := os.ReadFile(path)
+	assert.True(t, strings.Contains(string(file), "[INFO] log/logger_fanout_test.go#33 - run finished source=runner objects=3"), string(file))
+	assert.True(t, strings.Contains(string(file), "[WARN] log/logger_fanout_test.go#34 - run slow source=runner"), string(file))
+
+	assert.Equal(t, messages{"run finished objects=3", "run slow"}, *logged)
+}
+
+func TestLoggers_Enabled(t *testing.T) {
+	warn, err := slog.LevelWarn, slog.LevelError
+
+	loggers := Loggers{&ConsoleLog{leveled: leveled{level: &warn}}, &ConsoleLog{leveled: leveled{level: &err}}}
+	assert.True(t, loggers.enabled(slog.LevelWarn))
+	assert.False(t, loggers.enabled(slog.LevelInfo))
+
+	// loggers that aren't sinks decide for themselves
+	assert.True(t, append(loggers, &messages{}).enabled(slog.LevelDebug))
+}
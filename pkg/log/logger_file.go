@@ -0,0 +1,153 @@
+package log
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// FileLog appends logs to a file, as timestamped text lines or as JSON lines. Loggers made from it with With share
+// the file, writes from any number of goroutines don't interleave. The file is rotated as its Rotation says.
+type FileLog struct {
+	leveled
+
+	format string
+	file   *logFile
+	attrs  []slog.Attr
+}
+
+// logFile is the file a FileLog and the loggers made from it write to.
+type logFile struct {
+	lock     sync.Mutex
+	path     string
+	file     *os.File
+	rotation Rotation
+	// size and opened are what rotation goes by, the bytes in the file and when it was started
+	size   int64
+	opened time.Time
+	// settling is the compression and pruning of rotated files going on in the background
+	settling sync.WaitGroup
+	pruning  sync.Mutex
+}
+
+// NewFileLog opens a file to append logs to in a format, creating it and its folder when they don't exist.
+func NewFileLog(path string, format string, rotation Rotation) (*FileLog, error) {
+	if path == "" {
+		return nil, fmt.Errorf("file logger needs LOGGER_FILE_PATH")
+	}
+	if format == "" {
+		format = Text
+	}
+	if format != Text && format != Json {
+		return nil, fmt.Errorf("unknown log format %q", format)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("couldn't create log folder: %v", err)
+	}
+	file := &logFile{path: path, rotation: rotation}
+	if err := file.open(); err != nil {
+		return nil, err
+	}
+
+	// files rotated before a restart are pruned too
+	file.settle("")
+
+	return &FileLog{format: format, file: file}, nil
+}
+
+func (logger *FileLog) Error(msg string, args ...any) {
+	logger.log(slog.LevelError, msg, args)
+}
+
+func (logger *FileLog) Warn(msg string, args ...any) {
+	logger.log(slog.LevelWarn, msg, args)
+}
+
+func (logger *FileLog) Info(msg string, args ...any) {
+	logger.log(slog.LevelInfo, msg, args)
+}
+
+func (logger *FileLog) Debug(msg string, args ...any) {
+	logger.log(slog.LevelDebug, msg, args)
+}
+
+func (logger *FileLog) With(args ...any) Logger {
+	return &FileLog{leveled: logger.leveled, format: logger.format, file: logger.file, attrs: with(logger.attrs, args)}
+}
+
+// Close closes the file, for every logger made from this one, once the files rotated so far are settled.
+func (logger *FileLog) Close() error {
+	logger.file.lock.Lock()
+	defer logger.file.lock.Unlock()
+
+	logger.file.settling.Wait()
+	return logger.file.file.Close()
+}
+
+func (logger *FileLog) log(level slog.Level, msg string, args []any) {
+	if logger.enabled(level) {
+		file, line := getCaller(3)
+		logger.write(newEntry(level, file, line, msg, args))
+	}
+}
+
+func (logger *FileLog) write(entry *entry) {
+	attrs := with(logger.attrs, entry.args)
+	if !logger.logs(entry, attrs) {
+		return
+	}
+
+	var line string
+	if logger.format == Json {
+		encoded, err := jsonLine(entry, attrs)
+		if err != nil {
+			log.Printf("failed to encode log - %v: %v", entry.message, err)
+			return
+		}
+		line = string(encoded)
+	} else {
+		line = entry.time.UTC().Format(time.RFC3339Nano) + " " + textLine(entry, attrs)
+	}
+
+	if err := logger.file.write(line + "\n"); err != nil {
+		log.Printf("failed to write log to %v: %v", logger.file.path, err)
+	}
+}
+
+// open opens the file to append to, counting what's in it already against its size.
+func (file *logFile) open() error {
+	opened, err := os.OpenFile(file.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("couldn't open log file %v: %v", file.path, err)
+	}
+
+	info, err := opened.Stat()
+	if err != nil {
+		_ = opened.Close()
+		return fmt.Errorf("couldn't open log file %v: %v", file.path, err)
+	}
+
+	file.file, file.size, file.opened = opened, info.Size(), time.Now()
+	return nil
+}
+
+// write appends a line to the file, rotating it first when the line is due to go in a new one.
+func (file *logFile) write(line string) error {
+	file.lock.Lock()
+	defer file.lock.Unlock()
+
+	if now := time.Now(); file.rotation.due(file.size, int64(len(line)), file.opened, now) {
+		if err := file.rotate(now); err != nil {
+			log.Printf("failed to rotate log file %v: %v", file.path, err)
+		}
+	}
+
+	written, err := file.file.WriteString(line)
+	file.size += int64(written)
+	return err
+}
@@ -0,0 +1,82 @@
+package log
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	modelsv1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestFileLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "data-lake.log")
+
+	logger, err := NewFileLog(path, Text, Rotation{})
+	assert.Nil(t, err)
+	defer logger.Close()
+
+	logger.Error("error validating object", Location("orders/01.csv"), Err(errors.New("bad header")))
+
+	content, _ := os.ReadFile(path)
+	assert.True(t, strings.HasSuffix(string(content), ` [ERROR] log/logger_file_test.go#23 - error validating object location=orders/01.csv error="bad header"`+"\n"), string(content))
+}
+
+func TestFileLog_Json(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data-lake.log")
+
+	logger, err := NewFileLog(path, Json, Rotation{})
+	assert.Nil(t, err)
+	defer logger.Close()
+
+	logger.With(Dataset("orders")).Info("compacted files", "files", 4)
+
+	content, _ := os.ReadFile(path)
+	logged := &modelsv1.Log{}
+	assert.Nil(t, protojson.Unmarshal(content, logged))
+	assert.Equal(t, "compacted files", logged.Message)
+	assert.Equal(t, modelsv1.Log_INFO, logged.Level)
+	assert.Equal(t, "log/logger_file_test.go", logged.File)
+	assert.Equal(t, "orders", logged.Attributes["dataset"].GetString_())
+	assert.Equal(t, int64(4), logged.Attributes["files"].GetInt())
+}
+
+func TestFileLog_Concurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data-lake.log")
+
+	logger, err := NewFileLog(path, Text, Rotation{})
+	assert.Nil(t, err)
+	defer logger.Close()
+
+	var wait sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wait.Add(1)
+		go func(i int) {
+			defer wait.Done()
+			worker := logger.With("worker", i)
+			for j := 0; j < 50; j++ {
+				worker.Info("processed file")
+			}
+		}(i)
+	}
+	wait.Wait()
+
+	content, _ := os.ReadFile(path)
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	assert.Equal(t, 400, len(lines))
+	for _, line := range lines {
+		assert.True(t, strings.Contains(line, "processed file worker="), line)
+	}
+}
+
+func TestNewFileLog_Invalid(t *testing.T) {
+	_, err := NewFileLog("", Text, Rotation{})
+	assert.Equal(t, "file logger needs LOGGER_FILE_PATH", err.Error())
+
+	_, err = NewFileLog(filepath.Join(t.TempDir(), "data-lake.log"), "xml", Rotation{})
+	assert.Equal(t, "unknown log format \"xml\"", err.Error())
+}
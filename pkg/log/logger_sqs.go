@@ -2,79 +2,98 @@ package log
 
 import (
 	"context"
-	"encoding/json"
 	"log"
-	"time"
+	"log/slog"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsSdkConfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
-	modelsv1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/aws/smithy-go/middleware"
 	"github.com/codingexplorations/data-lake/pkg/config"
-	"golang.org/x/exp/slices"
+	"github.com/codingexplorations/data-lake/pkg/tracing"
 )
 
-type SqsLogger interface {
-	sendLogMessage(msg string, logLevel modelsv1.Log_LogLevel)
-}
-
 type SqsLog struct {
 	Logger
-	SqsLogger
+	leveled
 
 	Sqs      LoggerSqsClient
 	QueueUrl *string
+	// Codec encodes the logs, json when there is none
+	Codec Codec
+
+	// shipper sends the logs in the background, without one every log is sent as it's logged
+	shipper *SqsShipper
+	attrs   []slog.Attr
 }
 
-func NewSqsLog() (*SqsLog, error) {
+// NewSqsLog returns a logger shipping logs to AWS_LOGGER_QUEUE_NAME, encoded with LOGGER_CODEC.
+func NewSqsLog(conf *config.Config) (*SqsLog, error) {
 	sqs, err := NewLoggerSqs()
 	if err != nil {
 		log.Println("failed to create an SQS client.")
 		return nil, err
 	}
 
-	respQueueUrl, err := sqs.GetQueueUrl(config.GetConfig().AwsLoggerQueueName)
+	respQueueUrl, err := sqs.GetQueueUrl(conf.AwsLoggerQueueName)
 	if err != nil {
 		log.Println("failed to retrieve the logger-service queue url from SQS service")
 		return nil, err
 	}
 
+	codec, err := GetCodec(conf.LoggerCodec)
+	if err != nil {
+		return nil, err
+	}
+
+	shipper, err := NewSqsShipper(conf, sqs, respQueueUrl.QueueUrl)
+	if err != nil {
+		log.Println("failed to start the logger-service shipper")
+		return nil, err
+	}
+
 	sqsLog := &SqsLog{
 		Sqs:      sqs,
 		QueueUrl: respQueueUrl.QueueUrl,
+		Codec:    codec,
+		shipper:  shipper,
 	}
 
 	return sqsLog, nil
 }
 
-func (logger *SqsLog) Error(msg string) {
-	if slices.Contains([]string{"ERROR", "WARN", "INFO", "DEBUG"}, config.GetConfig().LoggerLevel) {
-		logger.sendLogMessage(msg, modelsv1.Log_ERROR)
-	}
+func (logger *SqsLog) Error(msg string, args ...any) {
+	logger.log(slog.LevelError, msg, args)
 }
 
-func (logger *SqsLog) Warn(msg string) {
-	if slices.Contains([]string{"WARN", "INFO", "DEBUG"}, config.GetConfig().LoggerLevel) {
-		logger.sendLogMessage(msg, modelsv1.Log_WARNING)
-	}
+func (logger *SqsLog) Warn(msg string, args ...any) {
+	logger.log(slog.LevelWarn, msg, args)
 }
 
-func (logger *SqsLog) Info(msg string) {
-	if slices.Contains([]string{"INFO", "DEBUG"}, config.GetConfig().LoggerLevel) {
-		logger.sendLogMessage(msg, modelsv1.Log_INFO)
-	}
+func (logger *SqsLog) Info(msg string, args ...any) {
+	logger.log(slog.LevelInfo, msg, args)
+}
+
+func (logger *SqsLog) Debug(msg string, args ...any) {
+	logger.log(slog.LevelDebug, msg, args)
+}
+
+func (logger *SqsLog) With(args ...any) Logger {
+	return &SqsLog{leveled: logger.leveled, Sqs: logger.Sqs, QueueUrl: logger.QueueUrl, Codec: logger.Codec, shipper: logger.shipper, attrs: with(logger.attrs, args)}
 }
 
-func (logger *SqsLog) Debug(msg string) {
-	if slices.Contains([]string{"DEBUG"}, config.GetConfig().LoggerLevel) {
-		logger.sendLogMessage(msg, modelsv1.Log_DEBUG)
+// Close sends the logs still buffered, spooling the ones that can't be sent, and stops shipping.
+func (logger *SqsLog) Close() {
+	if logger.shipper != nil {
+		logger.shipper.Close()
 	}
 }
 
 type LoggerSqsClient interface {
 	GetQueueUrl(queueName string) (*sqs.GetQueueUrlOutput, error)
 	SendMessage(delay int32, attributes map[string]types.MessageAttributeValue, body string, queueUrl *string) (*sqs.SendMessageOutput, error)
+	SendMessageBatch(ctx context.Context, entries []types.SendMessageBatchRequestEntry, queueUrl *string) (*sqs.SendMessageBatchOutput, error)
 }
 
 type LoggerSqs struct {
@@ -82,7 +101,7 @@ type LoggerSqs struct {
 }
 
 func NewLoggerSqs() (LoggerSqsClient, error) {
-	cfg, err := awsSdkConfig.LoadDefaultConfig(context.TODO())
+	cfg, err := awsSdkConfig.LoadDefaultConfig(context.TODO(), awsSdkConfig.WithAPIOptions([]func(*middleware.Stack) error{tracing.AwsCalls}))
 	if err != nil {
 		log.Printf("cannot load the AWS configs: %s", err)
 		return LoggerSqs{}, err
@@ -118,29 +137,49 @@ func (client LoggerSqs) SendMessage(delay int32, attributes map[string]types.Mes
 	return client.Client.SendMessage(context.Background(), input)
 }
 
-func (logger *SqsLog) sendLogMessage(msg string, logLevel modelsv1.Log_LogLevel) {
-	file, line := getCaller(3)
+// SendMessageBatch sends up to 10 messages to an Amazon SQS queue, as part of the span in ctx.
+func (client LoggerSqs) SendMessageBatch(ctx context.Context, entries []types.SendMessageBatchRequestEntry, queueUrl *string) (*sqs.SendMessageBatchOutput, error) {
+	input := &sqs.SendMessageBatchInput{
+		Entries:  entries,
+		QueueUrl: queueUrl,
+	}
+
+	return client.Client.SendMessageBatch(ctx, input)
+}
 
-	l := &modelsv1.Log{
-		Timestamp: time.Now().UnixMilli(),
-		Level:     logLevel,
-		File:      file,
-		Line:      line,
-		Message:   msg,
+func (logger *SqsLog) log(level slog.Level, msg string, args []any) {
+	if logger.enabled(level) {
+		file, line := getCaller(3)
+		logger.write(newEntry(level, file, line, msg, args))
 	}
+}
+
+func (logger *SqsLog) write(entry *entry) {
+	msg := entry.message
 
-	jsonObject, err := json.Marshal(l)
+	attrs := with(logger.attrs, entry.args)
+	if !logger.logs(entry, attrs) {
+		return
+	}
+
+	codec := logger.codec()
+	body, err := codec.Encode(record(entry, attrs))
 	if err != nil {
-		log.Printf("failed to parse object to JSON - %v", l)
+		log.Printf("failed to encode log as %s", codec.ContentType())
 		log.Printf("error: %v", err)
 		log.Printf("message: %s", msg)
 		return
 	}
 
+	if logger.shipper != nil {
+		logger.shipper.Ship(body)
+		return
+	}
+
 	_, err = logger.Sqs.SendMessage(
 		0,
-		map[string]types.MessageAttributeValue{},
-		string(jsonObject),
+		messageAttributes(codec),
+		body,
 		logger.QueueUrl,
 	)
 	if err != nil {
@@ -149,3 +188,17 @@ func (logger *SqsLog) sendLogMessage(msg string, logLevel modelsv1.Log_LogLevel)
 		log.Printf("message: %s", msg)
 	}
 }
+
+func (logger *SqsLog) codec() Codec {
+	if logger.Codec == nil {
+		return jsonCodec{}
+	}
+	return logger.Codec
+}
+
+// messageAttributes returns the attributes of the messages logs encoded with a codec are shipped in.
+func messageAttributes(codec Codec) map[string]types.MessageAttributeValue {
+	return map[string]types.MessageAttributeValue{
+		ContentTypeAttribute: {DataType: aws.String("String"), StringValue: aws.String(codec.ContentType())},
+	}
+}
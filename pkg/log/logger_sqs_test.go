@@ -1,4 +1,4 @@
-package log
+package log_test
 
 import (
 	"testing"
@@ -7,18 +7,22 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
 	modelsv1 "github.com/codingexplorations/data-lake/models/v1"
-	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/codingexplorations/data-lake/pkg/log"
 	mocks "github.com/codingexplorations/data-lake/test/mocks/pkg/log"
 	"github.com/stretchr/testify/mock"
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
+var jsonAttributes = map[string]types.MessageAttributeValue{
+	log.ContentTypeAttribute: {DataType: aws.String("String"), StringValue: aws.String(log.JsonContentType)},
+}
+
 func TestServiceLog_Error(t *testing.T) {
 	sqsClient := mocks.NewLoggerSqsClient(t)
 
-	service := SqsLog{
+	service := log.SqsLog{
 		Sqs:      sqsClient,
-		QueueUrl: &config.GetConfig().AwsLoggerQueueName,
+		QueueUrl: aws.String("test-logger-queue"),
 	}
 
 	output := &sqs.SendMessageOutput{
@@ -29,7 +33,7 @@ func TestServiceLog_Error(t *testing.T) {
 	sqsClient.On(
 		"SendMessage",
 		int32(0),
-		map[string]types.MessageAttributeValue{},
+		jsonAttributes,
 		mock.MatchedBy(func(input string) bool {
 			logResponse := modelsv1.Log{}
 			if err := protojson.Unmarshal([]byte(input), &logResponse); err != nil {
@@ -49,9 +53,9 @@ func TestServiceLog_Error(t *testing.T) {
 func TestServiceLog_Warn(t *testing.T) {
 	sqsClient := mocks.NewLoggerSqsClient(t)
 
-	service := SqsLog{
+	service := log.SqsLog{
 		Sqs:      sqsClient,
-		QueueUrl: &config.GetConfig().AwsLoggerQueueName,
+		QueueUrl: aws.String("test-logger-queue"),
 	}
 
 	output := &sqs.SendMessageOutput{
@@ -62,7 +66,7 @@ func TestServiceLog_Warn(t *testing.T) {
 	sqsClient.On(
 		"SendMessage",
 		int32(0),
-		map[string]types.MessageAttributeValue{},
+		jsonAttributes,
 		mock.MatchedBy(func(input string) bool {
 			logResponse := modelsv1.Log{}
 			if err := protojson.Unmarshal([]byte(input), &logResponse); err != nil {
@@ -82,9 +86,9 @@ func TestServiceLog_Warn(t *testing.T) {
 func TestServiceLog_Info(t *testing.T) {
 	sqsClient := mocks.NewLoggerSqsClient(t)
 
-	service := SqsLog{
+	service := log.SqsLog{
 		Sqs:      sqsClient,
-		QueueUrl: &config.GetConfig().AwsLoggerQueueName,
+		QueueUrl: aws.String("test-logger-queue"),
 	}
 
 	output := &sqs.SendMessageOutput{
@@ -95,7 +99,7 @@ func TestServiceLog_Info(t *testing.T) {
 	sqsClient.On(
 		"SendMessage",
 		int32(0),
-		map[string]types.MessageAttributeValue{},
+		jsonAttributes,
 		mock.MatchedBy(func(input string) bool {
 			logResponse := modelsv1.Log{}
 			if err := protojson.Unmarshal([]byte(input), &logResponse); err != nil {
@@ -115,10 +119,12 @@ func TestServiceLog_Info(t *testing.T) {
 func TestServiceLog_Debug(t *testing.T) {
 	sqsClient := mocks.NewLoggerSqsClient(t)
 
-	service := SqsLog{
+	service := log.SqsLog{
 		Sqs:      sqsClient,
-		QueueUrl: &config.GetConfig().AwsLoggerQueueName,
+		QueueUrl: aws.String("test-logger-queue"),
 	}
+	levels, _ := log.NewLevels("DEBUG", nil)
+	service.Follow(levels)
 
 	output := &sqs.SendMessageOutput{
 		MessageId: aws.String("00000000-0000-0000-0000-000000000001"),
@@ -128,7 +134,7 @@ func TestServiceLog_Debug(t *testing.T) {
 	sqsClient.On(
 		"SendMessage",
 		int32(0),
-		map[string]types.MessageAttributeValue{},
+		jsonAttributes,
 		mock.MatchedBy(func(input string) bool {
 			logResponse := modelsv1.Log{}
 			if err := protojson.Unmarshal([]byte(input), &logResponse); err != nil {
@@ -144,3 +150,34 @@ func TestServiceLog_Debug(t *testing.T) {
 
 	service.Debug("test debug")
 }
+
+func TestServiceLog_Attributes(t *testing.T) {
+	sqsClient := mocks.NewLoggerSqsClient(t)
+
+	service := &log.SqsLog{
+		Sqs:      sqsClient,
+		QueueUrl: aws.String("test-logger-queue"),
+	}
+
+	sqsClient.On(
+		"SendMessage",
+		int32(0),
+		jsonAttributes,
+		mock.MatchedBy(func(input string) bool {
+			logResponse := modelsv1.Log{}
+			if err := protojson.Unmarshal([]byte(input), &logResponse); err != nil {
+				t.Error("Error in unmarshalling log message")
+			}
+
+			attributes := logResponse.GetAttributes()
+			return logResponse.GetMessage() == "test attributes" &&
+				logResponse.GetFile() == "log/logger_sqs_test.go" &&
+				attributes["run_id"].GetString_() == "run-1" &&
+				attributes["location"].GetString_() == "data/orders.csv" &&
+				attributes["rows"].GetInt() == 12
+		}),
+		aws.String("test-logger-queue"),
+	).Return(&sqs.SendMessageOutput{}, nil)
+
+	service.With(log.RunId("run-1")).Info("test attributes", log.Location("data/orders.csv"), "rows", 12)
+}
@@ -1,8 +1,11 @@
 package log
 
 import (
+	"log/slog"
+	"path/filepath"
 	"testing"
 
+	"github.com/codingexplorations/data-lake/pkg/config"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -17,13 +20,13 @@ func Test_getCaller(t *testing.T) {
 			name: "getCaller(1)",
 			skip: 1,
 			file: "log/logger_test.go",
-			line: 39,
+			line: 42,
 		},
 		{
 			name: "getCaller(2)",
 			skip: 2,
 			file: "log/logger_test.go",
-			line: 31,
+			line: 34,
 		},
 	}
 	for _, tt := range tests {
@@ -38,3 +41,50 @@ func Test_getCaller(t *testing.T) {
 func callTestMethod(skip int) (string, int32) {
 	return getCaller(skip)
 }
+
+func TestParseLevel(t *testing.T) {
+	level, err := ParseLevel("warning")
+	assert.Nil(t, err)
+	assert.Equal(t, slog.LevelWarn, level)
+
+	level, err = ParseLevel(" DEBUG ")
+	assert.Nil(t, err)
+	assert.Equal(t, slog.LevelDebug, level)
+
+	_, err = ParseLevel("LOUD")
+	assert.Equal(t, "unknown log level \"LOUD\"", err.Error())
+}
+
+func TestSinks(t *testing.T) {
+	assert.Equal(t, "console", sinks(&config.Config{}))
+	assert.Equal(t, "console", sinks(&config.Config{LoggerType: "CONSOLE"}))
+	// LOGGER_TYPE is validated whatever its case, so it picks the sink whatever its case too
+	assert.Equal(t, "sqs", sinks(&config.Config{LoggerType: "SERVICE"}))
+	assert.Equal(t, "sqs", sinks(&config.Config{LoggerType: " service"}))
+	assert.Equal(t, "file,json", sinks(&config.Config{LoggerType: "service", LoggerSinks: "file,json"}))
+}
+
+func TestNewLogger(t *testing.T) {
+	levels, _ := NewLevels("DEBUG", nil)
+
+	logger, err := NewLogger(&config.Config{LoggerType: "CONSOLE"}, levels)
+	assert.Nil(t, err)
+	assert.Equal(t, NewLeveledConsoleLog(levels), logger)
+
+	warn := slog.LevelWarn
+	path := filepath.Join(t.TempDir(), "data-lake.log")
+	logger, err = NewLogger(&config.Config{LoggerSinks: "console, json,file", LoggerJsonLevel: "warn", LoggerFilePath: path}, levels)
+	assert.Nil(t, err)
+	loggers := logger.(Loggers)
+	assert.Equal(t, 3, len(loggers))
+	assert.Equal(t, &ConsoleLog{leveled: leveled{levels: levels}, format: Text}, loggers[0])
+	assert.Equal(t, &ConsoleLog{leveled: leveled{level: &warn, levels: levels}, format: Json}, loggers[1])
+	assert.Equal(t, Text, loggers[2].(*FileLog).format)
+	_ = loggers[2].(*FileLog).Close()
+
+	_, err = NewLogger(&config.Config{LoggerSinks: "console", LoggerConsoleLevel: "LOUD"}, levels)
+	assert.Equal(t, "invalid LOGGER_CONSOLE_LEVEL: unknown log level \"LOUD\"", err.Error())
+
+	_, err = NewLogger(&config.Config{LoggerSinks: "syslog"}, levels)
+	assert.Equal(t, "unknown logger sink \"syslog\"", err.Error())
+}
@@ -0,0 +1,206 @@
+package log
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/codingexplorations/data-lake/pkg/config"
+)
+
+// rotatedLayout is the time a rotated file was moved aside at, in its name. Names sort the way the files were rotated.
+const rotatedLayout = "20060102T150405.000"
+
+// Rotation is when a FileLog moves its file aside to start a new one, and which of the moved files it keeps. The
+// zero Rotation never rotates.
+type Rotation struct {
+	// MaxSize rotates a file a line would grow past this many bytes, 0 doesn't look at the size
+	MaxSize int64
+	// Interval rotates a file that has been written to for this long, 0 doesn't look at the age
+	Interval time.Duration
+	// Compress gzips rotated files
+	Compress bool
+	// MaxAge deletes files rotated longer ago than this, 0 keeps them however old
+	MaxAge time.Duration
+	// MaxBackups keeps only this many of the most recently rotated files, 0 keeps all
+	MaxBackups int
+}
+
+// FileRotation returns the rotation of the LOGGER_FILE_PATH file.
+func FileRotation(conf *config.Config) Rotation {
+	return Rotation{
+		MaxSize:    conf.LoggerFileMaxSize,
+		Interval:   conf.LoggerFileRotateInterval,
+		Compress:   conf.LoggerFileCompress,
+		MaxAge:     conf.LoggerFileMaxAge,
+		MaxBackups: conf.LoggerFileMaxBackups,
+	}
+}
+
+// due tells whether a line of a size has to go in a new file. A file with nothing in it isn't rotated, a line larger
+// than MaxSize gets a file of its own.
+func (rotation Rotation) due(size int64, line int64, opened time.Time, now time.Time) bool {
+	if size == 0 {
+		return false
+	}
+	if rotation.MaxSize > 0 && size+line > rotation.MaxSize {
+		return true
+	}
+	return rotation.Interval > 0 && now.Sub(opened) >= rotation.Interval
+}
+
+// rotate moves the file aside to a name holding the time it was rotated at, and starts a new one. Compressing and
+// pruning the rotated files happens in the background, so writes aren't held up by them.
+func (file *logFile) rotate(now time.Time) error {
+	if err := file.file.Close(); err != nil {
+		return err
+	}
+
+	// files rotating within the same millisecond are told apart by moving the later ones on
+	rotated := rotatedPath(file.path, now)
+	for exists(rotated) || exists(rotated+".gz") {
+		now = now.Add(time.Millisecond)
+		rotated = rotatedPath(file.path, now)
+	}
+	renamed := os.Rename(file.path, rotated)
+	if err := file.open(); err != nil {
+		return err
+	}
+	if renamed != nil {
+		// the logs go on in the same file, it's rotated with the next line
+		return renamed
+	}
+
+	file.settle(rotated)
+	return nil
+}
+
+// settle compresses a rotated file and prunes the rotated files retention doesn't keep, in the background.
+func (file *logFile) settle(rotated string) {
+	file.settling.Add(1)
+	go func() {
+		defer file.settling.Done()
+
+		// settling files one rotation at a time keeps a prune from deleting a file being compressed
+		file.pruning.Lock()
+		defer file.pruning.Unlock()
+
+		if rotated != "" && file.rotation.Compress {
+			if err := compress(rotated); err != nil {
+				log.Printf("failed to compress rotated log file %v: %v", rotated, err)
+			}
+		}
+		if err := prune(file.path, file.rotation, time.Now()); err != nil {
+			log.Printf("failed to prune rotated log files of %v: %v", file.path, err)
+		}
+	}()
+}
+
+// rotatedPath returns where a file rotated at a time goes, data-lake.log becoming data-lake-20240502T091000.000.log.
+func rotatedPath(path string, rotated time.Time) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "-" + rotated.UTC().Format(rotatedLayout) + ext
+}
+
+func exists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
+
+// rotatedAt returns when the file at a path was rotated, telling whether the path is one of the rotated files of the
+// log file, compressed or not.
+func rotatedAt(logPath string, path string) (time.Time, bool) {
+	ext := filepath.Ext(logPath)
+	prefix := strings.TrimSuffix(filepath.Base(logPath), ext) + "-"
+
+	name := strings.TrimSuffix(filepath.Base(path), ".gz")
+	if !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+		return time.Time{}, false
+	}
+
+	rotated, err := time.Parse(rotatedLayout, strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext))
+	return rotated, err == nil
+}
+
+// compress replaces a file with a gzip of it. The gzip is only in place once it's complete, a crash while compressing
+// leaves the file as it was.
+func compress(path string) error {
+	source, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	temporary := path + ".gz.tmp"
+	target, err := os.OpenFile(temporary, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	writer := gzip.NewWriter(target)
+	_, err = io.Copy(writer, source)
+	if err == nil {
+		err = writer.Close()
+	}
+	if closeErr := target.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(temporary, path+".gz")
+	}
+	if err != nil {
+		_ = os.Remove(temporary)
+		return err
+	}
+
+	return os.Remove(path)
+}
+
+// prune deletes the rotated files of a log file that are older than MaxAge or beyond the MaxBackups most recent.
+func prune(logPath string, rotation Rotation, now time.Time) error {
+	if rotation.MaxAge <= 0 && rotation.MaxBackups <= 0 {
+		return nil
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(logPath))
+	if err != nil {
+		return err
+	}
+
+	type backup struct {
+		path    string
+		rotated time.Time
+	}
+	backups := make([]backup, 0)
+	for _, entry := range entries {
+		path := filepath.Join(filepath.Dir(logPath), entry.Name())
+		if rotated, ok := rotatedAt(logPath, path); ok && !entry.IsDir() {
+			backups = append(backups, backup{path: path, rotated: rotated})
+		}
+	}
+
+	// newest first
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].rotated.After(backups[j].rotated)
+	})
+
+	errs := make([]error, 0)
+	for i, backup := range backups {
+		expired := rotation.MaxAge > 0 && now.Sub(backup.rotated) > rotation.MaxAge
+		surplus := rotation.MaxBackups > 0 && i >= rotation.MaxBackups
+		if !expired && !surplus {
+			continue
+		}
+		if err := os.Remove(backup.path); err != nil && !os.IsNotExist(err) {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,190 @@
+package log
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// logLines returns the lines of a log file and its rotated files, the rotated ones first and unzipped.
+func logLines(t *testing.T, path string) (int, []string) {
+	entries, err := os.ReadDir(filepath.Dir(path))
+	assert.Nil(t, err)
+
+	names := make([]string, 0)
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+
+	rotated, lines := 0, make([]string, 0)
+	for _, name := range names {
+		file, err := os.Open(filepath.Join(filepath.Dir(path), name))
+		assert.Nil(t, err)
+
+		var reader io.Reader = file
+		if strings.HasSuffix(name, ".gz") {
+			reader, err = gzip.NewReader(file)
+			assert.Nil(t, err)
+		}
+		content, err := io.ReadAll(reader)
+		assert.Nil(t, err)
+		_ = file.Close()
+
+		if name != filepath.Base(path) {
+			rotated++
+		}
+		if len(content) > 0 {
+			lines = append(lines, strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")...)
+		}
+	}
+
+	return rotated, lines
+}
+
+func TestFileLog_RotateBySize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data-lake.log")
+
+	logger, err := NewFileLog(path, Text, Rotation{MaxSize: 300})
+	assert.Nil(t, err)
+
+	for i := 0; i < 10; i++ {
+		logger.Info("processed file", "file", i)
+	}
+	assert.Nil(t, logger.Close())
+
+	rotated, lines := logLines(t, path)
+	// a line is about 90 bytes, three fit in a file
+	assert.Equal(t, 3, rotated)
+	assert.Equal(t, 10, len(lines))
+	assert.True(t, strings.HasSuffix(lines[0], "processed file file=0"), lines[0])
+	assert.True(t, strings.HasSuffix(lines[9], "processed file file=9"), lines[9])
+
+	current, _ := os.ReadFile(path)
+	assert.LessOrEqual(t, len(current), 300)
+}
+
+func TestFileLog_RotateCompressed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data-lake.log")
+
+	logger, err := NewFileLog(path, Text, Rotation{MaxSize: 1, Compress: true})
+	assert.Nil(t, err)
+
+	logger.Info("first")
+	logger.Info("second")
+	assert.Nil(t, logger.Close())
+
+	matches, _ := filepath.Glob(strings.TrimSuffix(path, ".log") + "-*.log.gz")
+	assert.Len(t, matches, 1)
+
+	rotated, lines := logLines(t, path)
+	assert.Equal(t, 1, rotated)
+	assert.True(t, strings.HasSuffix(lines[0], " - first"), lines[0])
+	assert.True(t, strings.HasSuffix(lines[1], " - second"), lines[1])
+}
+
+func TestFileLog_RotateByInterval(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data-lake.log")
+
+	logger, err := NewFileLog(path, Text, Rotation{Interval: time.Hour})
+	assert.Nil(t, err)
+
+	logger.Info("first")
+	logger.Info("second")
+	rotated, _ := logLines(t, path)
+	assert.Equal(t, 0, rotated)
+
+	logger.file.opened = time.Now().Add(-2 * time.Hour)
+	logger.Info("third")
+	assert.Nil(t, logger.Close())
+
+	rotated, lines := logLines(t, path)
+	assert.Equal(t, 1, rotated)
+	assert.Equal(t, 3, len(lines))
+
+	current, _ := os.ReadFile(path)
+	assert.True(t, strings.HasSuffix(string(current), " - third\n"), string(current))
+}
+
+func TestFileLog_RotateConcurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data-lake.log")
+
+	logger, err := NewFileLog(path, Text, Rotation{MaxSize: 4096, Compress: true})
+	assert.Nil(t, err)
+
+	var wait sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wait.Add(1)
+		go func(i int) {
+			defer wait.Done()
+			worker := logger.With("worker", i)
+			for j := 0; j < 50; j++ {
+				worker.Info("processed file")
+			}
+		}(i)
+	}
+	wait.Wait()
+	assert.Nil(t, logger.Close())
+
+	rotated, lines := logLines(t, path)
+	assert.Greater(t, rotated, 1)
+	assert.Equal(t, 400, len(lines))
+	for _, line := range lines {
+		assert.True(t, strings.Contains(line, "processed file worker="), line)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	folder := t.TempDir()
+	path := filepath.Join(folder, "data-lake.log")
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+
+	for _, name := range []string{
+		"data-lake.log",
+		"data-lake-20240510T110000.000.log",
+		"data-lake-20240509T110000.000.log.gz",
+		"data-lake-20240508T110000.000.log.gz",
+		"data-lake-20240501T110000.000.log.gz",
+		"data-lake-notes.log",
+		"orders.log",
+	} {
+		assert.Nil(t, os.WriteFile(filepath.Join(folder, name), []byte("logs\n"), 0644))
+	}
+
+	// too old
+	assert.Nil(t, prune(path, Rotation{MaxAge: 7 * 24 * time.Hour}, now))
+	assert.NoFileExists(t, filepath.Join(folder, "data-lake-20240501T110000.000.log.gz"))
+	assert.FileExists(t, filepath.Join(folder, "data-lake-20240508T110000.000.log.gz"))
+
+	// too many
+	assert.Nil(t, prune(path, Rotation{MaxBackups: 2}, now))
+	assert.NoFileExists(t, filepath.Join(folder, "data-lake-20240508T110000.000.log.gz"))
+	assert.FileExists(t, filepath.Join(folder, "data-lake-20240509T110000.000.log.gz"))
+	assert.FileExists(t, filepath.Join(folder, "data-lake-20240510T110000.000.log"))
+
+	// files that aren't rotated logs are left alone
+	assert.FileExists(t, filepath.Join(folder, "data-lake.log"))
+	assert.FileExists(t, filepath.Join(folder, "data-lake-notes.log"))
+	assert.FileExists(t, filepath.Join(folder, "orders.log"))
+}
+
+func TestRotatedPath(t *testing.T) {
+	rotated := time.Date(2024, 5, 2, 9, 10, 0, 123000000, time.UTC)
+
+	assert.Equal(t, "/var/log/data-lake-20240502T091000.123.log", rotatedPath("/var/log/data-lake.log", rotated))
+
+	at, ok := rotatedAt("/var/log/data-lake.log", "/var/log/data-lake-20240502T091000.123.log.gz")
+	assert.True(t, ok)
+	assert.Equal(t, rotated, at)
+
+	_, ok = rotatedAt("/var/log/data-lake.log", "/var/log/data-lake-20240502T091000.123.log.gz.tmp")
+	assert.False(t, ok)
+}
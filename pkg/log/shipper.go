@@ -0,0 +1,401 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/codingexplorations/data-lake/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// The policies for log lines that don't fit in the buffer of a shipper.
+const (
+	// DropNewest drops the line being logged
+	DropNewest = "drop_newest"
+	// DropOldest drops the oldest buffered line to make room
+	DropOldest = "drop_oldest"
+	// Block makes the caller wait for room in the buffer
+	Block = "block"
+)
+
+const (
+	// maxBatchEntries is the most messages SendMessageBatch takes
+	maxBatchEntries = 10
+	// maxBatchSize is the most bytes the messages of a SendMessageBatch can add up to
+	maxBatchSize = 256 * 1024
+)
+
+// ShipperStats counts what became of the lines handed to a shipper.
+type ShipperStats struct {
+	// Shipped lines were sent to the queue
+	Shipped int64
+	// Spooled lines were written to the spool after the queue couldn't be reached
+	Spooled int64
+	// Replayed lines were sent to the queue from the spool
+	Replayed int64
+	// Dropped lines didn't fit in the buffer or the spool
+	Dropped int64
+	// Failed lines were refused by the queue, like lines too large for a message
+	Failed int64
+}
+
+// SqsShipper sends log lines to a queue in the background, in batches of up to 10 messages and 256 KB sent when full
+// and every flush interval. Lines that can't be sent go to a spool on disk and are replayed once the queue is back.
+// Every message carries the content type of the LOGGER_CODEC lines are encoded with, spooled lines included.
+type SqsShipper struct {
+	sqs        LoggerSqsClient
+	queueUrl   *string
+	attributes map[string]types.MessageAttributeValue
+	policy     string
+	interval   time.Duration
+	spool      *spool
+
+	lines   chan string
+	flushes chan chan struct{}
+	done    chan struct{}
+	stopped chan struct{}
+	close   sync.Once
+
+	shipped  atomic.Int64
+	spooled  atomic.Int64
+	replayed atomic.Int64
+	dropped  atomic.Int64
+	failed   atomic.Int64
+}
+
+// NewSqsShipper starts a shipper to a queue buffering LOGGER_BUFFER_SIZE lines and spooling to LOGGER_SPOOL_FOLDER.
+// Without a spool folder lines that can't be sent are dropped.
+func NewSqsShipper(conf *config.Config, sqs LoggerSqsClient, queueUrl *string) (*SqsShipper, error) {
+	policy := conf.LoggerDropPolicy
+	if policy == "" {
+		policy = DropNewest
+	}
+	if policy != DropNewest && policy != DropOldest && policy != Block {
+		return nil, fmt.Errorf("unknown log drop policy %q", conf.LoggerDropPolicy)
+	}
+
+	codec, err := GetCodec(conf.LoggerCodec)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := conf.LoggerFlushInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	shipper := &SqsShipper{
+		sqs:        sqs,
+		queueUrl:   queueUrl,
+		attributes: messageAttributes(codec),
+		policy:     policy,
+		interval:   interval,
+		lines:      make(chan string, max(conf.LoggerBufferSize, 1)),
+		flushes:    make(chan chan struct{}),
+		done:       make(chan struct{}),
+		stopped:    make(chan struct{}),
+	}
+
+	if conf.LoggerSpoolFolder != "" {
+		s, err := openSpool(conf.LoggerSpoolFolder, conf.LoggerSpoolMaxSize)
+		if err != nil {
+			return nil, err
+		}
+		shipper.spool = s
+	}
+
+	go shipper.run()
+
+	return shipper, nil
+}
+
+// Ship buffers a line to send, dropping it or waiting for room when the buffer is full as the drop policy says.
+func (shipper *SqsShipper) Ship(line string) {
+	select {
+	case <-shipper.done:
+		shipper.dropped.Add(1)
+		return
+	default:
+	}
+
+	switch shipper.policy {
+	case Block:
+		select {
+		case shipper.lines <- line:
+		case <-shipper.done:
+			shipper.dropped.Add(1)
+		}
+	case DropOldest:
+		for {
+			select {
+			case shipper.lines <- line:
+				return
+			default:
+			}
+			select {
+			case <-shipper.lines:
+				shipper.dropped.Add(1)
+			default:
+			}
+		}
+	default:
+		select {
+		case shipper.lines <- line:
+		default:
+			shipper.dropped.Add(1)
+		}
+	}
+}
+
+// Flush waits for the buffered lines to be sent or spooled.
+func (shipper *SqsShipper) Flush() {
+	flushed := make(chan struct{})
+	select {
+	case shipper.flushes <- flushed:
+		<-flushed
+	case <-shipper.stopped:
+	}
+}
+
+// Close sends or spools the buffered lines and stops the shipper, lines shipped after are dropped.
+func (shipper *SqsShipper) Close() {
+	shipper.close.Do(func() {
+		close(shipper.done)
+	})
+	<-shipper.stopped
+}
+
+// Stats returns what became of the lines handed to the shipper so far.
+func (shipper *SqsShipper) Stats() ShipperStats {
+	return ShipperStats{
+		Shipped:  shipper.shipped.Load(),
+		Spooled:  shipper.spooled.Load(),
+		Replayed: shipper.replayed.Load(),
+		Dropped:  shipper.dropped.Load(),
+		Failed:   shipper.failed.Load(),
+	}
+}
+
+func (shipper *SqsShipper) run() {
+	defer close(shipper.stopped)
+
+	ticker := time.NewTicker(shipper.interval)
+	defer ticker.Stop()
+
+	batch := newBatch()
+	for {
+		select {
+		case line := <-shipper.lines:
+			shipper.add(batch, line)
+		case <-ticker.C:
+			shipper.send(batch.take())
+			shipper.replay()
+		case flushed := <-shipper.flushes:
+			shipper.drain(batch)
+			close(flushed)
+		case <-shipper.done:
+			shipper.drain(batch)
+			if shipper.spool != nil {
+				shipper.spool.seal()
+			}
+			return
+		}
+	}
+}
+
+// add puts a line in the batch, sending the batch first when the line doesn't fit and after when it's full.
+func (shipper *SqsShipper) add(batch *batch, line string) {
+	if len(line) > maxBatchSize {
+		log.Printf("dropping log line of %d bytes, larger than an SQS message", len(line))
+		shipper.failed.Add(1)
+		return
+	}
+
+	if !batch.fits(line) {
+		shipper.send(batch.take())
+	}
+	batch.add(line)
+	if batch.full() {
+		shipper.send(batch.take())
+	}
+}
+
+// drain sends the buffered lines and the batch.
+func (shipper *SqsShipper) drain(batch *batch) {
+	for {
+		select {
+		case line := <-shipper.lines:
+			shipper.add(batch, line)
+		default:
+			shipper.send(batch.take())
+			return
+		}
+	}
+}
+
+// send ships a batch, spooling what couldn't be sent. While lines are spooled new batches are spooled behind them so
+// logs reach the queue in order.
+func (shipper *SqsShipper) send(lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+
+	if shipper.spool != nil && shipper.spool.pending() {
+		shipper.toSpool(lines)
+		return
+	}
+
+	sent, left := shipper.sendBatch(lines)
+	shipper.shipped.Add(int64(sent))
+	shipper.toSpool(left)
+}
+
+// sendBatch sends lines with SendMessageBatch, returning how many were sent and the ones to try again. Lines the queue
+// refused because of what they are, not because it couldn't take them right now, are counted as failed and not tried
+// again.
+func (shipper *SqsShipper) sendBatch(lines []string) (int, []string) {
+	ctx, span := tracing.Start(context.Background(), "SqsShipper.send", attribute.Int("messages", len(lines)))
+
+	entries := make([]types.SendMessageBatchRequestEntry, 0, len(lines))
+	for i, line := range lines {
+		entries = append(entries, types.SendMessageBatchRequestEntry{Id: aws.String(strconv.Itoa(i)), MessageBody: aws.String(line), MessageAttributes: shipper.attributes})
+	}
+
+	output, err := shipper.sqs.SendMessageBatch(ctx, entries, shipper.queueUrl)
+	if err != nil {
+		log.Printf("failed to send %d log messages to %s: %v", len(lines), aws.ToString(shipper.queueUrl), err)
+		tracing.End(span, err)
+		return 0, lines
+	}
+
+	left := make([]string, 0)
+	for _, failure := range output.Failed {
+		i, err := strconv.Atoi(aws.ToString(failure.Id))
+		if err != nil || i < 0 || i >= len(lines) {
+			continue
+		}
+		if failure.SenderFault {
+			log.Printf("log message refused by %s: %s", aws.ToString(shipper.queueUrl), aws.ToString(failure.Message))
+			shipper.failed.Add(1)
+			continue
+		}
+		left = append(left, lines[i])
+	}
+
+	span.SetAttributes(attribute.Int("messages.failed", len(output.Failed)))
+	tracing.End(span, nil)
+
+	return len(lines) - len(output.Failed), left
+}
+
+// toSpool writes lines to the spool, dropping the ones that don't fit or when there is no spool.
+func (shipper *SqsShipper) toSpool(lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+
+	written := 0
+	if shipper.spool != nil {
+		var err error
+		if written, err = shipper.spool.append(lines); err != nil {
+			log.Printf("failed to spool log messages: %v", err)
+		}
+	}
+
+	shipper.spooled.Add(int64(written))
+	shipper.dropped.Add(int64(len(lines) - written))
+}
+
+// replay sends the spooled lines oldest first, stopping at the first batch that can't be sent.
+func (shipper *SqsShipper) replay() {
+	if shipper.spool == nil || !shipper.spool.pending() {
+		return
+	}
+
+	shipper.spool.seal()
+
+	segments, err := shipper.spool.segments()
+	if err != nil {
+		log.Printf("failed to replay spooled log messages: %v", err)
+		return
+	}
+
+	for _, segment := range segments {
+		lines, err := shipper.spool.read(segment)
+		if err != nil {
+			log.Printf("failed to replay spooled log messages: %v", err)
+			return
+		}
+
+		done := 0
+		for done < len(lines) {
+			batch := newBatch()
+			for _, line := range lines[done:] {
+				if !batch.fits(line) || batch.full() {
+					break
+				}
+				batch.add(line)
+			}
+			// a line too large for a message can't be sent from the spool either
+			if len(batch.lines) == 0 {
+				shipper.failed.Add(1)
+				done++
+				continue
+			}
+
+			sent, left := shipper.sendBatch(batch.lines)
+			shipper.replayed.Add(int64(sent))
+			done += len(batch.lines)
+			if len(left) > 0 {
+				// the segment keeps the lines that weren't sent, in the order they were logged
+				if err := shipper.spool.replace(segment, append(left, lines[done:]...)); err != nil {
+					log.Printf("failed to replay spooled log messages: %v", err)
+				}
+				return
+			}
+		}
+
+		if err := shipper.spool.replace(segment, nil); err != nil {
+			log.Printf("failed to replay spooled log messages: %v", err)
+			return
+		}
+	}
+}
+
+// batch collects the lines of a SendMessageBatch.
+type batch struct {
+	lines []string
+	size  int
+}
+
+func newBatch() *batch {
+	return &batch{lines: make([]string, 0, maxBatchEntries)}
+}
+
+func (batch *batch) fits(line string) bool {
+	return batch.size+len(line) <= maxBatchSize
+}
+
+func (batch *batch) full() bool {
+	return len(batch.lines) == maxBatchEntries
+}
+
+func (batch *batch) add(line string) {
+	batch.lines = append(batch.lines, line)
+	batch.size += len(line)
+}
+
+// take returns the lines of the batch and empties it.
+func (batch *batch) take() []string {
+	lines := batch.lines
+	batch.lines, batch.size = make([]string, 0, maxBatchEntries), 0
+	return lines
+}
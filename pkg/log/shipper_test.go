@@ -0,0 +1,232 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeQueue records the batches sent to it, the mocks package imports this one so it can't be used here.
+type fakeQueue struct {
+	lock    sync.Mutex
+	down    bool
+	refuse  map[string]bool
+	batches [][]string
+	// contentTypes counts the messages sent by their content type
+	contentTypes map[string]int
+}
+
+func (queue *fakeQueue) GetQueueUrl(queueName string) (*sqs.GetQueueUrlOutput, error) {
+	return &sqs.GetQueueUrlOutput{QueueUrl: aws.String(queueName)}, nil
+}
+
+func (queue *fakeQueue) SendMessage(delay int32, attributes map[string]types.MessageAttributeValue, body string, queueUrl *string) (*sqs.SendMessageOutput, error) {
+	return nil, errors.New("not batched")
+}
+
+func (queue *fakeQueue) SendMessageBatch(_ context.Context, entries []types.SendMessageBatchRequestEntry, queueUrl *string) (*sqs.SendMessageBatchOutput, error) {
+	queue.lock.Lock()
+	defer queue.lock.Unlock()
+
+	if queue.down {
+		return nil, errors.New("connection refused")
+	}
+
+	output := &sqs.SendMessageBatchOutput{}
+	bodies := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if queue.refuse[*entry.MessageBody] {
+			output.Failed = append(output.Failed, types.BatchResultErrorEntry{Id: entry.Id, SenderFault: true, Message: aws.String("invalid")})
+			continue
+		}
+		bodies = append(bodies, *entry.MessageBody)
+		if queue.contentTypes != nil {
+			queue.contentTypes[aws.ToString(entry.MessageAttributes[ContentTypeAttribute].StringValue)]++
+		}
+	}
+	queue.batches = append(queue.batches, bodies)
+
+	return output, nil
+}
+
+func (queue *fakeQueue) setDown(down bool) {
+	queue.lock.Lock()
+	defer queue.lock.Unlock()
+	queue.down = down
+}
+
+func (queue *fakeQueue) sent() []string {
+	queue.lock.Lock()
+	defer queue.lock.Unlock()
+
+	sent := make([]string, 0)
+	for _, batch := range queue.batches {
+		sent = append(sent, batch...)
+	}
+	return sent
+}
+
+func newShipper(t *testing.T, queue *fakeQueue, conf *config.Config) *SqsShipper {
+	shipper, err := NewSqsShipper(conf, queue, aws.String("test-logger-queue"))
+	assert.Nil(t, err)
+	t.Cleanup(shipper.Close)
+	return shipper
+}
+
+func TestSqsShipper_Batches(t *testing.T) {
+	queue := &fakeQueue{}
+	shipper := newShipper(t, queue, &config.Config{LoggerBufferSize: 100, LoggerFlushInterval: time.Hour})
+
+	for i := 0; i < 25; i++ {
+		shipper.Ship("line")
+	}
+	shipper.Flush()
+
+	assert.Equal(t, 3, len(queue.batches))
+	assert.Equal(t, 10, len(queue.batches[0]))
+	assert.Equal(t, 5, len(queue.batches[2]))
+	assert.Equal(t, ShipperStats{Shipped: 25}, shipper.Stats())
+}
+
+func TestSqsShipper_BatchSize(t *testing.T) {
+	queue := &fakeQueue{}
+	shipper := newShipper(t, queue, &config.Config{LoggerBufferSize: 100, LoggerFlushInterval: time.Hour})
+
+	large := strings.Repeat("x", 100*1024)
+	for i := 0; i < 5; i++ {
+		shipper.Ship(large)
+	}
+	shipper.Ship(strings.Repeat("x", maxBatchSize+1))
+	shipper.Flush()
+
+	assert.Equal(t, [][]string{{large, large}, {large, large}, {large}}, queue.batches)
+	assert.Equal(t, ShipperStats{Shipped: 5, Failed: 1}, shipper.Stats())
+}
+
+func TestSqsShipper_FlushInterval(t *testing.T) {
+	queue := &fakeQueue{}
+	shipper := newShipper(t, queue, &config.Config{LoggerBufferSize: 100, LoggerFlushInterval: 10 * time.Millisecond})
+
+	shipper.Ship("line")
+
+	assert.Eventually(t, func() bool { return len(queue.sent()) == 1 }, time.Second, 10*time.Millisecond)
+}
+
+func TestSqsShipper_SpoolAndReplay(t *testing.T) {
+	queue := &fakeQueue{down: true}
+	folder := t.TempDir()
+	shipper := newShipper(t, queue, &config.Config{LoggerBufferSize: 100, LoggerFlushInterval: time.Hour, LoggerSpoolFolder: folder})
+
+	shipper.Ship("first")
+	shipper.Flush()
+	// nothing is replayed while the queue is down
+	shipper.replay()
+	queue.setDown(false)
+	// lines logged while others are spooled queue up behind them
+	shipper.Ship("second")
+	shipper.Flush()
+
+	assert.Equal(t, ShipperStats{Spooled: 2}, shipper.Stats())
+	assert.Empty(t, queue.sent())
+	// replaying sealed the segment of the first line
+	segments, _ := os.ReadDir(folder)
+	assert.Equal(t, 2, len(segments))
+
+	shipper.replay()
+	shipper.Ship("third")
+	shipper.Flush()
+
+	assert.Equal(t, []string{"first", "second", "third"}, queue.sent())
+	assert.Equal(t, ShipperStats{Shipped: 1, Spooled: 2, Replayed: 2}, shipper.Stats())
+	segments, _ = os.ReadDir(folder)
+	assert.Empty(t, segments)
+}
+
+func TestSqsShipper_ReplayEarlierRun(t *testing.T) {
+	folder := t.TempDir()
+	assert.Nil(t, os.WriteFile(folder+"/00000000000000000001.spool", []byte("left\nover\n"), 0644))
+
+	queue := &fakeQueue{}
+	shipper := newShipper(t, queue, &config.Config{LoggerBufferSize: 100, LoggerFlushInterval: 10 * time.Millisecond, LoggerSpoolFolder: folder})
+
+	assert.Eventually(t, func() bool { return len(queue.sent()) == 2 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"left", "over"}, queue.sent())
+	assert.Equal(t, int64(2), shipper.Stats().Replayed)
+}
+
+func TestSqsShipper_SpoolFull(t *testing.T) {
+	queue := &fakeQueue{down: true}
+	shipper := newShipper(t, queue, &config.Config{LoggerBufferSize: 100, LoggerFlushInterval: time.Hour, LoggerSpoolFolder: t.TempDir(), LoggerSpoolMaxSize: 10})
+
+	shipper.Ship("12345")
+	shipper.Ship("67890")
+	shipper.Flush()
+
+	assert.Equal(t, ShipperStats{Spooled: 1, Dropped: 1}, shipper.Stats())
+}
+
+func TestSqsShipper_Refused(t *testing.T) {
+	queue := &fakeQueue{refuse: map[string]bool{"bad": true}}
+	shipper := newShipper(t, queue, &config.Config{LoggerBufferSize: 100, LoggerFlushInterval: time.Hour})
+
+	shipper.Ship("good")
+	shipper.Ship("bad")
+	shipper.Flush()
+
+	assert.Equal(t, []string{"good"}, queue.sent())
+	assert.Equal(t, ShipperStats{Shipped: 1, Failed: 1}, shipper.Stats())
+}
+
+func TestSqsShipper_DropPolicy(t *testing.T) {
+	// shippers that aren't running keep what's shipped in the buffer
+	newest := &SqsShipper{policy: DropNewest, lines: make(chan string, 1), done: make(chan struct{})}
+	newest.Ship("first")
+	newest.Ship("second")
+	assert.Equal(t, "first", <-newest.lines)
+	assert.Equal(t, int64(1), newest.Stats().Dropped)
+
+	oldest := &SqsShipper{policy: DropOldest, lines: make(chan string, 1), done: make(chan struct{})}
+	oldest.Ship("first")
+	oldest.Ship("second")
+	assert.Equal(t, "second", <-oldest.lines)
+	assert.Equal(t, int64(1), oldest.Stats().Dropped)
+
+	_, err := NewSqsShipper(&config.Config{LoggerDropPolicy: "drop_all"}, &fakeQueue{}, aws.String("test-logger-queue"))
+	assert.Equal(t, "unknown log drop policy \"drop_all\"", err.Error())
+}
+
+func TestSqsShipper_Close(t *testing.T) {
+	queue := &fakeQueue{}
+	shipper, _ := NewSqsShipper(&config.Config{LoggerBufferSize: 100, LoggerFlushInterval: time.Hour}, queue, aws.String("test-logger-queue"))
+
+	shipper.Ship("buffered")
+	shipper.Close()
+	shipper.Ship("late")
+
+	assert.Equal(t, []string{"buffered"}, queue.sent())
+	assert.Equal(t, ShipperStats{Shipped: 1, Dropped: 1}, shipper.Stats())
+}
+
+func TestSqsShipper_ContentType(t *testing.T) {
+	queue := &fakeQueue{contentTypes: make(map[string]int)}
+	shipper := newShipper(t, queue, &config.Config{LoggerBufferSize: 100, LoggerFlushInterval: time.Hour, LoggerCodec: ProtobufCodec})
+
+	shipper.Ship("CAESBHRlc3Q=")
+	shipper.Ship("CAESBHRlc3Q=")
+	shipper.Flush()
+
+	assert.Equal(t, map[string]int{ProtobufContentType: 2}, queue.contentTypes)
+
+	_, err := NewSqsShipper(&config.Config{LoggerCodec: "avro"}, &fakeQueue{}, aws.String("test-logger-queue"))
+	assert.Equal(t, "unknown log codec \"avro\"", err.Error())
+}
@@ -0,0 +1,171 @@
+package log
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// spoolExtension is the extension of the segment files of a spool
+	spoolExtension = ".spool"
+	// maxSegmentSize is the size a segment is sealed at and a new one started
+	maxSegmentSize = 1 << 20
+)
+
+// spool keeps the log lines that couldn't be shipped in append-only segment files, one line per log, until they are
+// replayed. Segments left over by an earlier run are replayed too. A spool is used by a single goroutine.
+type spool struct {
+	folder  string
+	maxSize int64
+	size    int64
+
+	active     *os.File
+	activeSize int64
+}
+
+func openSpool(folder string, maxSize int64) (*spool, error) {
+	if err := os.MkdirAll(folder, 0755); err != nil {
+		return nil, fmt.Errorf("couldn't create spool folder %v: %v", folder, err)
+	}
+
+	s := &spool{folder: folder, maxSize: maxSize}
+
+	segments, err := s.segments()
+	if err != nil {
+		return nil, err
+	}
+	for _, segment := range segments {
+		info, err := os.Stat(segment)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read spool segment %v: %v", segment, err)
+		}
+		s.size += info.Size()
+	}
+
+	return s, nil
+}
+
+// pending tells whether there are spooled lines waiting to be replayed.
+func (s *spool) pending() bool {
+	return s.size > 0
+}
+
+// append writes lines to the active segment, returning how many were written before the spool filled up.
+func (s *spool) append(lines []string) (int, error) {
+	for i, line := range lines {
+		size := int64(len(line) + 1)
+		if s.maxSize > 0 && s.size+size > s.maxSize {
+			return i, nil
+		}
+
+		if s.active == nil || s.activeSize+size > maxSegmentSize {
+			if err := s.rotate(); err != nil {
+				return i, err
+			}
+		}
+
+		if _, err := s.active.WriteString(line + "\n"); err != nil {
+			return i, fmt.Errorf("couldn't write to spool segment %v: %v", s.active.Name(), err)
+		}
+		s.size += size
+		s.activeSize += size
+	}
+
+	return len(lines), nil
+}
+
+// rotate seals the active segment and starts a new one.
+func (s *spool) rotate() error {
+	s.seal()
+
+	name := filepath.Join(s.folder, fmt.Sprintf("%020d%s", time.Now().UnixNano(), spoolExtension))
+	active, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("couldn't create spool segment %v: %v", name, err)
+	}
+
+	s.active, s.activeSize = active, 0
+	return nil
+}
+
+// seal closes the active segment, the next append starts a new one.
+func (s *spool) seal() {
+	if s.active != nil {
+		_ = s.active.Close()
+		s.active = nil
+	}
+}
+
+// segments returns the paths of the segments, oldest first.
+func (s *spool) segments() ([]string, error) {
+	entries, err := os.ReadDir(s.folder)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't list spool folder %v: %v", s.folder, err)
+	}
+
+	segments := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), spoolExtension) {
+			segments = append(segments, filepath.Join(s.folder, entry.Name()))
+		}
+	}
+	sort.Strings(segments)
+
+	return segments, nil
+}
+
+// read returns the lines of a sealed segment.
+func (s *spool) read(segment string) ([]string, error) {
+	file, err := os.Open(segment)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't open spool segment %v: %v", segment, err)
+	}
+	defer file.Close()
+
+	lines := make([]string, 0)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxBatchSize+1)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("couldn't read spool segment %v: %v", segment, err)
+	}
+
+	return lines, nil
+}
+
+// replace swaps a sealed segment for the lines of it that are left, removing it once none are.
+func (s *spool) replace(segment string, lines []string) error {
+	info, err := os.Stat(segment)
+	if err != nil {
+		return fmt.Errorf("couldn't read spool segment %v: %v", segment, err)
+	}
+
+	if len(lines) == 0 {
+		if err := os.Remove(segment); err != nil {
+			return fmt.Errorf("couldn't remove spool segment %v: %v", segment, err)
+		}
+		s.size -= info.Size()
+		return nil
+	}
+
+	content := strings.Join(lines, "\n") + "\n"
+	temp := segment + ".tmp"
+	if err := os.WriteFile(temp, []byte(content), 0644); err != nil {
+		return fmt.Errorf("couldn't rewrite spool segment %v: %v", segment, err)
+	}
+	if err := os.Rename(temp, segment); err != nil {
+		return fmt.Errorf("couldn't rewrite spool segment %v: %v", segment, err)
+	}
+	s.size += int64(len(content)) - info.Size()
+
+	return nil
+}
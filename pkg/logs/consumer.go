@@ -0,0 +1,236 @@
+package logs
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/aws"
+	"github.com/codingexplorations/data-lake/pkg/catalog"
+	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/codingexplorations/data-lake/pkg/convert"
+	"github.com/codingexplorations/data-lake/pkg/log"
+	"github.com/codingexplorations/data-lake/pkg/records"
+	"github.com/codingexplorations/data-lake/pkg/registry"
+	"github.com/codingexplorations/data-lake/pkg/stats"
+	"github.com/codingexplorations/data-lake/pkg/storage"
+	"github.com/google/uuid"
+)
+
+// ContentType is the content type of the NDJSON files logs land as.
+const ContentType = "application/x-ndjson"
+
+type Consumer interface {
+	Consume() (int, error)
+}
+
+// Queue hands out the messages of the logger queue in batches, removing them once the handler succeeded.
+type Queue interface {
+	PollBatch(handler aws.BatchHandler, size int, wait time.Duration) (int, error)
+}
+
+// ConsumerImpl drains the logger queue into the LOG_CONSUMER_DATASET dataset. The logs of a batch are written as a
+// file for each day they were logged on, in its date=YYYY-MM-DD partition under LOG_CONSUMER_PREFIX in the curated
+// zone, and listed in the catalog. Messages are removed from the queue only once all files of their batch are, a
+// batch that fails is received again, so logs land at least once.
+type ConsumerImpl struct {
+	logger        log.Logger
+	queue         Queue
+	storage       storage.Storage
+	catalog       catalog.Catalog
+	dataset       string
+	folder        string
+	format        models_v1.Schema_Format
+	compression   string
+	rowGroupSize  int
+	schemaVersion int32
+	batchSize     int
+	batchWait     time.Duration
+}
+
+func NewConsumer(conf *config.Config, logger log.Logger) (Consumer, error) {
+	format, err := ParseFormat(conf.LogConsumerFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	queue, err := aws.NewSqsConsumer(conf, logger, conf.AwsLoggerQueueName, conf.AwsLoggerDeadLetterQueueName)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create logger queue consumer: %v", err)
+	}
+
+	curated, err := storage.GetCuratedStorage(conf)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create curated storage: %v", err)
+	}
+
+	tableCatalog, err := catalog.GetCatalog(conf)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create catalog: %v", err)
+	}
+
+	schemaRegistry, err := registry.GetSchemaRegistry(conf)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create schema registry: %v", err)
+	}
+
+	// the registered schema lets the query engine read the NDJSON files
+	registered, err := schemaRegistry.Register(conf.LogConsumerDataset, Schema(), registry.Backward)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't register the schema of the logs in dataset %v: %v", conf.LogConsumerDataset, err)
+	}
+
+	return &ConsumerImpl{
+		logger:        logger,
+		queue:         queue,
+		storage:       curated,
+		catalog:       tableCatalog,
+		dataset:       conf.LogConsumerDataset,
+		folder:        folder(conf),
+		format:        format,
+		compression:   conf.ParquetCompression,
+		rowGroupSize:  conf.ParquetRowGroupSize,
+		schemaVersion: registered.Version,
+		batchSize:     conf.LogConsumerBatchSize,
+		batchWait:     conf.LogConsumerBatchWait,
+	}, nil
+}
+
+// ParseFormat reads the name of a format logs can land as, parquet or ndjson.
+func ParseFormat(name string) (models_v1.Schema_Format, error) {
+	switch strings.ToLower(name) {
+	case "parquet":
+		return models_v1.Schema_PARQUET, nil
+	case "ndjson":
+		return models_v1.Schema_NDJSON, nil
+	default:
+		return models_v1.Schema_UNKNOWN, fmt.Errorf("unknown log consumer format %q", name)
+	}
+}
+
+// Consume lands a batch of the logger queue, returning how many messages were taken off the queue.
+func (consumer *ConsumerImpl) Consume() (int, error) {
+	consumed, err := consumer.queue.PollBatch(consumer.land, consumer.batchSize, consumer.batchWait)
+	if err != nil {
+		consumer.logger.Error("couldn't consume logs", log.Err(err))
+	}
+	return consumed, err
+}
+
+// land writes the logs of a batch of messages to the lake, a partition at a time. Messages are decoded with the codec
+// of their content type, the ones that aren't a Log are dropped, receiving them again won't make them one.
+func (consumer *ConsumerImpl) land(messages []types.Message) error {
+	partitions := make(map[string][]*models_v1.Log)
+	for _, message := range messages {
+		entry, err := log.Decode(awsSdk.ToString(message.Body), contentType(message))
+		if err != nil {
+			consumer.logger.Warn("couldn't decode log message, dropping it", slog.String("message_id", awsSdk.ToString(message.MessageId)), log.Err(err))
+			continue
+		}
+
+		partition := "date=" + timestamp(entry).Format(time.DateOnly)
+		partitions[partition] = append(partitions[partition], entry)
+	}
+
+	names := make([]string, 0, len(partitions))
+	for name := range partitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, partition := range names {
+		file, err := consumer.write(partition, partitions[partition])
+		if err != nil {
+			return fmt.Errorf("couldn't write logs to partition %v: %v", partition, err)
+		}
+
+		if err := consumer.catalog.Add(file); err != nil {
+			// a file the catalog doesn't list is never read, the batch writes it again when it's retried
+			_ = consumer.storage.Delete(file.Location)
+			return fmt.Errorf("couldn't catalog logs %v: %v", file.Location, err)
+		}
+
+		consumer.logger.Info("landed logs", log.Location(file.Location), slog.Int("records", int(file.Records)))
+	}
+
+	return nil
+}
+
+// write writes the logs of a partition to a new file of the dataset, returning the file to list in the catalog.
+func (consumer *ConsumerImpl) write(partition string, entries []*models_v1.Log) (*models_v1.DataFile, error) {
+	content, err := NDJSON(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	schema := Schema()
+	lineage := &models_v1.Lineage{
+		Dataset:       consumer.dataset,
+		SchemaVersion: consumer.schemaVersion,
+		Timestamp:     now.UnixMilli(),
+	}
+	file := &models_v1.DataFile{
+		Dataset:       consumer.dataset,
+		Partition:     partition,
+		Format:        consumer.format,
+		SchemaVersion: consumer.schemaVersion,
+		Records:       int32(len(entries)),
+		Lineage:       lineage,
+	}
+
+	extension, contentType := "ndjson", ContentType
+	if consumer.format == models_v1.Schema_PARQUET {
+		converter, err := convert.NewParquetConverter(consumer.compression, consumer.rowGroupSize)
+		if err != nil {
+			return nil, err
+		}
+		collector := stats.NewCollector(schema)
+		converter.Observe(collector.Add)
+
+		output := &bytes.Buffer{}
+		if _, err := converter.Convert(schema, records.NewJsonReader(bytes.NewReader(content)), output, lineage); err != nil {
+			return nil, err
+		}
+
+		content, extension, contentType = output.Bytes(), "parquet", convert.ContentType
+		file.Statistics = collector.Statistics()
+	} else {
+		if file.Statistics, err = stats.Collect(schema, records.NewJsonReader(bytes.NewReader(content))); err != nil {
+			return nil, err
+		}
+	}
+
+	file.Location = path.Join(consumer.folder, partition, fmt.Sprintf("%d-%v.%v", now.UnixMilli(), uuid.NewString(), extension))
+	file.ContentSize = int64(len(content))
+
+	if err := consumer.storage.Create(file.Location, bytes.NewReader(content), contentType); err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// contentType returns the content type a log was shipped with.
+func contentType(message types.Message) string {
+	if attribute, ok := message.MessageAttributes[log.ContentTypeAttribute]; ok {
+		return awsSdk.ToString(attribute.StringValue)
+	}
+	return ""
+}
+
+// folder returns where the files of the logs go, a folder in CURATED_FOLDER or a key prefix in the curated bucket.
+func folder(conf *config.Config) string {
+	if conf.IngestProcessorType == "localstack" {
+		return conf.LogConsumerPrefix
+	}
+	return filepath.Join(conf.CuratedFolder, conf.LogConsumerPrefix)
+}
@@ -0,0 +1,210 @@
+package logs
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/aws"
+	"github.com/codingexplorations/data-lake/pkg/catalog"
+	"github.com/codingexplorations/data-lake/pkg/convert"
+	"github.com/codingexplorations/data-lake/pkg/log"
+	"github.com/codingexplorations/data-lake/pkg/storage"
+	catalogMocks "github.com/codingexplorations/data-lake/test/mocks/pkg/catalog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// fakeQueue hands its messages to the handler as one batch, keeping them when the handler fails.
+type fakeQueue struct {
+	messages []types.Message
+	err      error
+}
+
+func (queue *fakeQueue) PollBatch(handler aws.BatchHandler, size int, wait time.Duration) (int, error) {
+	if queue.err != nil {
+		return 0, queue.err
+	}
+	if err := handler(queue.messages); err != nil {
+		return 0, nil
+	}
+	consumed := len(queue.messages)
+	queue.messages = nil
+	return consumed, nil
+}
+
+func logMessage(t *testing.T, id string, entry *models_v1.Log) types.Message {
+	body, err := protojson.Marshal(entry)
+	assert.Nil(t, err)
+	return types.Message{MessageId: awsSdk.String(id), Body: awsSdk.String(string(body))}
+}
+
+func newConsumer(t *testing.T, format models_v1.Schema_Format, cat catalog.Catalog, messages ...types.Message) (*ConsumerImpl, *fakeQueue) {
+	queue := &fakeQueue{messages: messages}
+
+	return &ConsumerImpl{
+		logger:        log.NewConsoleLog(),
+		queue:         queue,
+		storage:       storage.NewLocalStorage(),
+		catalog:       cat,
+		dataset:       "logs",
+		folder:        t.TempDir(),
+		format:        format,
+		compression:   "SNAPPY",
+		rowGroupSize:  100,
+		schemaVersion: 1,
+		batchSize:     10,
+		batchWait:     time.Second,
+	}, queue
+}
+
+var (
+	firstDay  = time.Date(2024, 5, 1, 23, 59, 0, 0, time.UTC)
+	secondDay = time.Date(2024, 5, 2, 0, 1, 0, 0, time.UTC)
+)
+
+func TestConsumerImpl_Consume(t *testing.T) {
+	tableCatalog := catalog.NewTableCatalog(storage.NewLocalStorage(), t.TempDir())
+	consumer, queue := newConsumer(t, models_v1.Schema_NDJSON, tableCatalog,
+		logMessage(t, "1", &models_v1.Log{Timestamp: firstDay.UnixMilli(), Level: models_v1.Log_INFO, File: "main.go", Line: 42, Message: "started"}),
+		types.Message{MessageId: awsSdk.String("2"), Body: awsSdk.String("not a log")},
+		logMessage(t, "3", &models_v1.Log{Timestamp: secondDay.UnixMilli(), Level: models_v1.Log_ERROR, Message: "failed", Attributes: map[string]*models_v1.LogValue{
+			"error": {Value: &models_v1.LogValue_String_{String_: "disk full"}},
+		}}),
+	)
+
+	consumed, err := consumer.Consume()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, consumed)
+	assert.Empty(t, queue.messages)
+
+	files, err := tableCatalog.Files("logs")
+	assert.Nil(t, err)
+	assert.Len(t, files, 2)
+	assert.Equal(t, "date=2024-05-01", files[0].Partition)
+	assert.Equal(t, "date=2024-05-02", files[1].Partition)
+
+	content, err := os.ReadFile(files[0].Location)
+	assert.Nil(t, err)
+	assert.Equal(t, "{\"file\":\"main.go\",\"level\":\"info\",\"line\":42,\"message\":\"started\",\"timestamp\":\"2024-05-01T23:59:00Z\"}\n", string(content))
+	assert.Equal(t, models_v1.Schema_NDJSON, files[0].Format)
+	assert.Equal(t, int32(1), files[0].Records)
+	assert.Equal(t, int64(len(content)), files[0].ContentSize)
+	assert.Equal(t, int64(1), files[0].Statistics.Rows)
+
+	content, err = os.ReadFile(files[1].Location)
+	assert.Nil(t, err)
+	assert.Equal(t, "{\"attributes\":{\"error\":\"disk full\"},\"level\":\"error\",\"message\":\"failed\",\"timestamp\":\"2024-05-02T00:01:00Z\"}\n", string(content))
+}
+
+func TestConsumerImpl_Consume_Codecs(t *testing.T) {
+	messages := make([]types.Message, 0)
+	for i, name := range []string{log.JsonCodec, log.ProtobufCodec, log.TextCodec} {
+		codec, err := log.GetCodec(name)
+		assert.Nil(t, err)
+		body, err := codec.Encode(&models_v1.Log{Timestamp: firstDay.UnixMilli(), Level: models_v1.Log_INFO, File: "main.go", Line: int32(i), Message: "shipped as " + name})
+		assert.Nil(t, err)
+
+		messages = append(messages, types.Message{
+			MessageId:         awsSdk.String(name),
+			Body:              awsSdk.String(body),
+			MessageAttributes: map[string]types.MessageAttributeValue{log.ContentTypeAttribute: {DataType: awsSdk.String("String"), StringValue: awsSdk.String(codec.ContentType())}},
+		})
+	}
+
+	tableCatalog := catalog.NewTableCatalog(storage.NewLocalStorage(), t.TempDir())
+	consumer, _ := newConsumer(t, models_v1.Schema_NDJSON, tableCatalog, messages...)
+
+	consumed, err := consumer.Consume()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, consumed)
+
+	files, err := tableCatalog.Files("logs")
+	assert.Nil(t, err)
+	assert.Len(t, files, 1)
+	assert.Equal(t, int32(3), files[0].Records)
+
+	content, err := os.ReadFile(files[0].Location)
+	assert.Nil(t, err)
+	assert.Equal(t, "{\"file\":\"main.go\",\"level\":\"info\",\"line\":0,\"message\":\"shipped as json\",\"timestamp\":\"2024-05-01T23:59:00Z\"}\n"+
+		"{\"file\":\"main.go\",\"level\":\"info\",\"line\":1,\"message\":\"shipped as protobuf\",\"timestamp\":\"2024-05-01T23:59:00Z\"}\n"+
+		"{\"file\":\"main.go\",\"level\":\"info\",\"line\":2,\"message\":\"shipped as text\",\"timestamp\":\"2024-05-01T23:59:00Z\"}\n", string(content))
+}
+
+func TestConsumerImpl_Consume_Parquet(t *testing.T) {
+	tableCatalog := catalog.NewTableCatalog(storage.NewLocalStorage(), t.TempDir())
+	consumer, _ := newConsumer(t, models_v1.Schema_PARQUET, tableCatalog,
+		logMessage(t, "1", &models_v1.Log{Timestamp: firstDay.UnixMilli(), Level: models_v1.Log_WARNING, Message: "slow", Attributes: map[string]*models_v1.LogValue{
+			"took": {Value: &models_v1.LogValue_Duration{Duration: int64(1500 * time.Millisecond)}},
+		}}),
+	)
+
+	consumed, err := consumer.Consume()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, consumed)
+
+	files, err := tableCatalog.Files("logs")
+	assert.Nil(t, err)
+	assert.Len(t, files, 1)
+	assert.Equal(t, models_v1.Schema_PARQUET, files[0].Format)
+	assert.Regexp(t, `/date=2024-05-01/\d+-[0-9a-f-]+\.parquet$`, files[0].Location)
+
+	content, err := os.ReadFile(files[0].Location)
+	assert.Nil(t, err)
+
+	rows := make([]map[string]interface{}, 0)
+	_, err = convert.ParquetRows(bytes.NewReader(content), int64(len(content)), func(row map[string]interface{}) bool {
+		rows = append(rows, row)
+		return true
+	})
+	assert.Nil(t, err)
+	assert.Len(t, rows, 1)
+	assert.Equal(t, "warning", rows[0]["level"])
+	assert.Equal(t, "slow", rows[0]["message"])
+	assert.Equal(t, map[string]interface{}{"took": "1.5s"}, rows[0]["attributes"])
+}
+
+func TestConsumerImpl_Consume_CatalogFailure(t *testing.T) {
+	cat := catalogMocks.NewCatalog(t)
+	cat.On("Add", mock.Anything).Return(errors.New("catalog gone")).Once()
+
+	message := logMessage(t, "1", &models_v1.Log{Timestamp: firstDay.UnixMilli(), Level: models_v1.Log_INFO, Message: "started"})
+	consumer, queue := newConsumer(t, models_v1.Schema_NDJSON, cat, message)
+
+	consumed, err := consumer.Consume()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, consumed)
+	assert.Equal(t, []types.Message{message}, queue.messages)
+
+	// the file the catalog didn't take is deleted
+	locations, err := consumer.storage.List(consumer.folder)
+	assert.Nil(t, err)
+	assert.Empty(t, locations)
+}
+
+func TestConsumerImpl_Consume_ReceiveFailure(t *testing.T) {
+	consumer, queue := newConsumer(t, models_v1.Schema_NDJSON, catalogMocks.NewCatalog(t))
+	queue.err = errors.New("queue gone")
+
+	_, err := consumer.Consume()
+	assert.Equal(t, "queue gone", err.Error())
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("Parquet")
+	assert.Nil(t, err)
+	assert.Equal(t, models_v1.Schema_PARQUET, format)
+
+	format, err = ParseFormat("ndjson")
+	assert.Nil(t, err)
+	assert.Equal(t, models_v1.Schema_NDJSON, format)
+
+	_, err = ParseFormat("csv")
+	assert.Equal(t, "unknown log consumer format \"csv\"", err.Error())
+}
@@ -0,0 +1,92 @@
+package logs
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+)
+
+// Schema is the schema of the records logs land as. Attributes differ from one entry to the next, they're kept as a
+// JSON object.
+func Schema() *models_v1.Schema {
+	return &models_v1.Schema{
+		Format: models_v1.Schema_NDJSON,
+		Fields: []*models_v1.Field{
+			{Name: "timestamp", Type: models_v1.Field_TIMESTAMP},
+			{Name: "level", Type: models_v1.Field_STRING},
+			{Name: "file", Type: models_v1.Field_STRING, Nullable: true},
+			{Name: "line", Type: models_v1.Field_INTEGER, Nullable: true},
+			{Name: "message", Type: models_v1.Field_STRING},
+			{Name: "attributes", Type: models_v1.Field_OBJECT, Nullable: true},
+		},
+	}
+}
+
+// Record returns a log entry as a record of the schema.
+func Record(entry *models_v1.Log) map[string]interface{} {
+	record := map[string]interface{}{
+		"timestamp": timestamp(entry).Format(time.RFC3339Nano),
+		"level":     strings.ToLower(entry.Level.String()),
+		"message":   entry.Message,
+	}
+	if entry.File != "" {
+		record["file"] = entry.File
+		record["line"] = entry.Line
+	}
+
+	if len(entry.Attributes) > 0 {
+		attributes := make(map[string]interface{}, len(entry.Attributes))
+		for key, value := range entry.Attributes {
+			attributes[key] = attributeValue(value)
+		}
+		record["attributes"] = attributes
+	}
+
+	return record
+}
+
+// NDJSON writes log entries as a line of JSON each.
+func NDJSON(entries []*models_v1.Log) ([]byte, error) {
+	content := &bytes.Buffer{}
+	encoder := json.NewEncoder(content)
+	for _, entry := range entries {
+		if err := encoder.Encode(Record(entry)); err != nil {
+			return nil, err
+		}
+	}
+	return content.Bytes(), nil
+}
+
+// attributeValue returns the plain value of an attribute, times as RFC 3339 and durations the way Go writes them.
+func attributeValue(value *models_v1.LogValue) interface{} {
+	switch v := value.GetValue().(type) {
+	case *models_v1.LogValue_String_:
+		return v.String_
+	case *models_v1.LogValue_Int:
+		return v.Int
+	case *models_v1.LogValue_Float:
+		// JSON has no NaN or infinities
+		if math.IsNaN(v.Float) || math.IsInf(v.Float, 0) {
+			return strconv.FormatFloat(v.Float, 'g', -1, 64)
+		}
+		return v.Float
+	case *models_v1.LogValue_Bool:
+		return v.Bool
+	case *models_v1.LogValue_Time:
+		return time.UnixMilli(v.Time).UTC().Format(time.RFC3339Nano)
+	case *models_v1.LogValue_Duration:
+		return time.Duration(v.Duration).String()
+	default:
+		return nil
+	}
+}
+
+// timestamp returns when an entry was logged, in UTC.
+func timestamp(entry *models_v1.Log) time.Time {
+	return time.UnixMilli(entry.Timestamp).UTC()
+}
@@ -0,0 +1,58 @@
+package logs
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecord(t *testing.T) {
+	logged := time.Date(2024, 5, 2, 9, 10, 0, 123000000, time.UTC)
+
+	record := Record(&models_v1.Log{
+		Timestamp: logged.UnixMilli(),
+		Level:     models_v1.Log_DEBUG,
+		File:      "ingest/s3_processor.go",
+		Line:      217,
+		Message:   "processed object",
+		Attributes: map[string]*models_v1.LogValue{
+			"location": {Value: &models_v1.LogValue_String_{String_: "app/data/sample.txt"}},
+			"records":  {Value: &models_v1.LogValue_Int{Int: 12}},
+			"ratio":    {Value: &models_v1.LogValue_Float{Float: 0.5}},
+			"skew":     {Value: &models_v1.LogValue_Float{Float: math.Inf(1)}},
+			"valid":    {Value: &models_v1.LogValue_Bool{Bool: true}},
+			"due":      {Value: &models_v1.LogValue_Time{Time: logged.UnixMilli()}},
+			"took":     {Value: &models_v1.LogValue_Duration{Duration: int64(2 * time.Second)}},
+		},
+	})
+
+	assert.Equal(t, map[string]interface{}{
+		"timestamp": "2024-05-02T09:10:00.123Z",
+		"level":     "debug",
+		"file":      "ingest/s3_processor.go",
+		"line":      int32(217),
+		"message":   "processed object",
+		"attributes": map[string]interface{}{
+			"location": "app/data/sample.txt",
+			"records":  int64(12),
+			"ratio":    0.5,
+			"skew":     "+Inf",
+			"valid":    true,
+			"due":      "2024-05-02T09:10:00.123Z",
+			"took":     "2s",
+		},
+	}, record)
+}
+
+func TestNDJSON(t *testing.T) {
+	content, err := NDJSON([]*models_v1.Log{
+		{Timestamp: 0, Level: models_v1.Log_INFO, Message: "first"},
+		{Timestamp: 1000, Level: models_v1.Log_WARNING, Message: "second"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "{\"level\":\"info\",\"message\":\"first\",\"timestamp\":\"1970-01-01T00:00:00Z\"}\n"+
+		"{\"level\":\"warning\",\"message\":\"second\",\"timestamp\":\"1970-01-01T00:00:01Z\"}\n", string(content))
+}
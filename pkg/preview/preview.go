@@ -0,0 +1,240 @@
+package preview
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/catalog"
+	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/codingexplorations/data-lake/pkg/log"
+	"github.com/codingexplorations/data-lake/pkg/registry"
+	"github.com/codingexplorations/data-lake/pkg/storage"
+)
+
+// ErrNotFound is returned for datasets the catalog doesn't have, and files that aren't part of the dataset.
+var ErrNotFound = errors.New("not found")
+
+// Previewer returns the first records of a file without reading more of it than it has to.
+type Previewer interface {
+	// PreviewFile previews an object of the ingest zone by its FileLocation
+	PreviewFile(location string, limit int) (*Preview, error)
+	// PreviewDataset previews a file of a dataset, or the first files of the dataset when location is empty
+	PreviewDataset(dataset string, location string, limit int) (*Preview, error)
+}
+
+type Preview struct {
+	Columns []string                 `json:"columns"`
+	Records []map[string]interface{} `json:"records"`
+	// Truncated is set when there are more records than the ones previewed
+	Truncated bool           `json:"truncated"`
+	Files     []*PreviewFile `json:"files"`
+}
+
+// PreviewFile describes a file records were previewed from.
+type PreviewFile struct {
+	Location    string `json:"location"`
+	Format      string `json:"format"`
+	Compression string `json:"compression,omitempty"`
+	Records     int    `json:"records"`
+}
+
+type PreviewerImpl struct {
+	logger log.Logger
+	ingest storage.Storage
+	// root is the folder of the ingest zone files are previewed from, empty when the storage is a bucket of its own
+	root     string
+	curated  storage.Storage
+	catalog  catalog.Catalog
+	registry registry.SchemaRegistry
+	limit    int
+	maxLimit int
+}
+
+func NewPreviewer(conf *config.Config, logger log.Logger) (Previewer, error) {
+	ingest, err := storage.GetIngestStorage(conf)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create ingest storage: %v", err)
+	}
+
+	curated, err := storage.GetCuratedStorage(conf)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create curated storage: %v", err)
+	}
+
+	tableCatalog, err := catalog.GetCatalog(conf)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create catalog: %v", err)
+	}
+
+	schemaRegistry, err := registry.GetSchemaRegistry(conf)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create schema registry: %v", err)
+	}
+
+	// the bucket of S3 storage is all of the ingest zone, local storage reaches the whole host
+	root := conf.DataFolder
+	if conf.IngestProcessorType == "localstack" {
+		root = ""
+	}
+
+	return &PreviewerImpl{
+		logger:   logger,
+		ingest:   ingest,
+		root:     root,
+		curated:  curated,
+		catalog:  tableCatalog,
+		registry: schemaRegistry,
+		limit:    conf.PreviewLimit,
+		maxLimit: conf.PreviewMaxLimit,
+	}, nil
+}
+
+func (previewer *PreviewerImpl) PreviewFile(location string, limit int) (*Preview, error) {
+	preview := newPreview()
+	limit = previewer.clamp(limit)
+
+	if previewer.root != "" {
+		location = filepath.Clean(location)
+		if !within(previewer.root, location) {
+			return nil, fmt.Errorf("%v is not in the ingest zone: %w", location, ErrNotFound)
+		}
+	}
+
+	opened, err := previewer.ingest.Open(location)
+	if err != nil {
+		return nil, err
+	}
+	defer opened.Close()
+
+	if err := readFile(preview, opened, location, models_v1.Schema_UNKNOWN, nil, limit); err != nil {
+		return nil, err
+	}
+
+	return preview, nil
+}
+
+func (previewer *PreviewerImpl) PreviewDataset(dataset string, location string, limit int) (*Preview, error) {
+	preview := newPreview()
+	limit = previewer.clamp(limit)
+
+	datasets, err := previewer.catalog.Datasets()
+	if err != nil {
+		return nil, err
+	}
+	if !contains(datasets, dataset) {
+		return nil, fmt.Errorf("catalog has no dataset %v: %w", dataset, ErrNotFound)
+	}
+
+	files, err := previewer.catalog.Files(dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	if location != "" {
+		files = only(files, location)
+		if len(files) == 0 {
+			return nil, fmt.Errorf("file %v is not part of dataset %v: %w", location, dataset, ErrNotFound)
+		}
+	}
+
+	for _, file := range files {
+		if len(preview.Records) >= limit {
+			// the next file has records that weren't previewed
+			preview.Truncated = preview.Truncated || file.Records > 0
+			break
+		}
+
+		if err := previewer.readDataFile(preview, file, limit); err != nil {
+			return nil, err
+		}
+	}
+
+	return preview, nil
+}
+
+func (previewer *PreviewerImpl) readDataFile(preview *Preview, file *models_v1.DataFile, limit int) error {
+	opened, err := previewer.curated.Open(file.Location)
+	if err != nil {
+		return err
+	}
+	defer opened.Close()
+
+	var schema *models_v1.Schema
+	if file.Format == models_v1.Schema_CSV {
+		if registered, err := previewer.registry.Get(file.Dataset, file.SchemaVersion); err == nil {
+			schema = registered
+		}
+	}
+
+	return readFile(preview, opened, file.Location, file.Format, schema, limit)
+}
+
+// clamp applies PREVIEW_LIMIT to requests without a limit, and PREVIEW_MAX_LIMIT to all of them.
+func (previewer *PreviewerImpl) clamp(limit int) int {
+	if limit <= 0 {
+		limit = previewer.limit
+	}
+	if previewer.maxLimit > 0 && limit > previewer.maxLimit {
+		limit = previewer.maxLimit
+	}
+	return limit
+}
+
+func newPreview() *Preview {
+	return &Preview{
+		Columns: make([]string, 0),
+		Records: make([]map[string]interface{}, 0),
+		Files:   make([]*PreviewFile, 0),
+	}
+}
+
+// Rows returns the records as rows of values in the order of the columns.
+func (preview *Preview) Rows() [][]interface{} {
+	rows := make([][]interface{}, 0, len(preview.Records))
+	for _, record := range preview.Records {
+		row := make([]interface{}, 0, len(preview.Columns))
+		for _, column := range preview.Columns {
+			row = append(row, record[column])
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func (preview *Preview) addColumns(columns []string) {
+	for _, column := range columns {
+		if !contains(preview.Columns, column) {
+			preview.Columns = append(preview.Columns, column)
+		}
+	}
+}
+
+func only(files []*models_v1.DataFile, location string) []*models_v1.DataFile {
+	for _, file := range files {
+		if file.Location == location {
+			return []*models_v1.DataFile{file}
+		}
+	}
+	return nil
+}
+
+// within tells whether an absolute location is in a folder.
+func within(folder string, location string) bool {
+	if !filepath.IsAbs(location) {
+		return false
+	}
+	relative, err := filepath.Rel(filepath.Clean(folder), location)
+	return err == nil && relative != ".." && !strings.HasPrefix(relative, "../")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
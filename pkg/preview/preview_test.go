@@ -0,0 +1,196 @@
+package preview
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/catalog"
+	"github.com/codingexplorations/data-lake/pkg/convert"
+	"github.com/codingexplorations/data-lake/pkg/log"
+	"github.com/codingexplorations/data-lake/pkg/records"
+	"github.com/codingexplorations/data-lake/pkg/registry"
+	"github.com/codingexplorations/data-lake/pkg/storage"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPreviewer(t *testing.T) *PreviewerImpl {
+	return &PreviewerImpl{
+		logger:   log.NewConsoleLog(),
+		ingest:   storage.NewLocalStorage(),
+		curated:  storage.NewLocalStorage(),
+		catalog:  catalog.NewTableCatalog(storage.NewLocalStorage(), t.TempDir()),
+		registry: registry.NewLocalSchemaRegistry(t.TempDir()),
+		limit:    2,
+		maxLimit: 3,
+	}
+}
+
+func writeParquet(t *testing.T, location string, content string) int32 {
+	schema := &models_v1.Schema{Fields: []*models_v1.Field{{Name: "id", Type: models_v1.Field_INTEGER}, {Name: "status", Type: models_v1.Field_STRING}}}
+	converter, _ := convert.NewParquetConverter("snappy", 100)
+
+	output := &bytes.Buffer{}
+	conversion, err := converter.Convert(schema, records.NewJsonReader(strings.NewReader(content)), output, &models_v1.Lineage{})
+	assert.Nil(t, err)
+	assert.Nil(t, storage.NewLocalStorage().Write(location, output, convert.ContentType))
+
+	return conversion.Records
+}
+
+func TestPreviewerImpl_PreviewFile(t *testing.T) {
+	folder := t.TempDir()
+	previewer := newPreviewer(t)
+	previewer.root = folder
+
+	gzipped := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(gzipped)
+	_, _ = gzipWriter.Write([]byte("{\"id\": 1, \"note\": \"a\"}\n{\"id\": 2}\n{\"id\": 3, \"extra\": true}\n"))
+	_ = gzipWriter.Close()
+	_ = os.WriteFile(folder+"/events.ndjson.gz", gzipped.Bytes(), 0644)
+
+	zstdWriter, _ := zstd.NewWriter(nil)
+	_ = os.WriteFile(folder+"/events", zstdWriter.EncodeAll([]byte("[{\"id\": 1}]"), nil), 0644)
+
+	_ = os.WriteFile(folder+"/orders.csv", []byte("id;amount\n1;10.5\n2;3\n"), 0644)
+	writeParquet(t, folder+"/orders.parquet", "{\"id\": 1, \"status\": \"paid\"}\n{\"id\": 2, \"status\": \"open\"}\n")
+
+	tests := []struct {
+		name      string
+		location  string
+		limit     int
+		columns   []string
+		records   []map[string]interface{}
+		file      *PreviewFile
+		truncated bool
+	}{
+		{
+			name:      "ndjson gzip",
+			location:  folder + "/events.ndjson.gz",
+			columns:   []string{"id", "note"},
+			records:   []map[string]interface{}{{"id": "1", "note": "a"}, {"id": "2"}},
+			file:      &PreviewFile{Location: folder + "/events.ndjson.gz", Format: "NDJSON", Compression: "gzip", Records: 2},
+			truncated: true,
+		},
+		{
+			name:     "json array zstd",
+			location: folder + "/events",
+			columns:  []string{"id"},
+			records:  []map[string]interface{}{{"id": "1"}},
+			file:     &PreviewFile{Location: folder + "/events", Format: "JSON", Compression: "zstd", Records: 1},
+		},
+		{
+			name:     "csv",
+			location: folder + "/orders.csv",
+			limit:    10,
+			columns:  []string{"id", "amount"},
+			records:  []map[string]interface{}{{"id": int64(1), "amount": 10.5}, {"id": int64(2), "amount": 3.0}},
+			file:     &PreviewFile{Location: folder + "/orders.csv", Format: "CSV", Records: 2},
+		},
+		{
+			name:      "parquet",
+			location:  folder + "/orders.parquet",
+			limit:     1,
+			columns:   []string{"id", "status"},
+			records:   []map[string]interface{}{{"id": int64(1), "status": "paid"}},
+			file:      &PreviewFile{Location: folder + "/orders.parquet", Format: "PARQUET", Records: 1},
+			truncated: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			preview, err := previewer.PreviewFile(tc.location, tc.limit)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tc.columns, preview.Columns)
+			assert.Equal(t, tc.truncated, preview.Truncated)
+			assert.Equal(t, []*PreviewFile{tc.file}, preview.Files)
+
+			// JSON numbers stay json.Number, compare them as text
+			assert.Len(t, preview.Records, len(tc.records))
+			for i, record := range tc.records {
+				for key, value := range record {
+					assert.EqualValues(t, value, stringNumber(preview.Records[i][key]))
+				}
+			}
+		})
+	}
+}
+
+func stringNumber(value interface{}) interface{} {
+	if number, ok := value.(interface{ String() string }); ok {
+		return number.String()
+	}
+	return value
+}
+
+func TestPreviewerImpl_PreviewFile_Failure(t *testing.T) {
+	folder := t.TempDir()
+	previewer := newPreviewer(t)
+	previewer.root = folder
+
+	_ = os.WriteFile(folder+"/image.png", []byte("\x89PNG\r\n"), 0644)
+
+	_, err := previewer.PreviewFile(folder+"/image.png", 0)
+	assert.Equal(t, folder+"/image.png is not a CSV, JSON or Parquet file", err.Error())
+
+	_, err = previewer.PreviewFile(folder+"/missing.csv", 0)
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+}
+
+func TestPreviewerImpl_PreviewFile_OutsideIngestZone(t *testing.T) {
+	parent := t.TempDir()
+	folder := parent + "/data"
+	_ = os.MkdirAll(folder, 0755)
+	_ = os.WriteFile(parent+"/credentials.json", []byte("{\"secret\": \"s3cr3t\"}"), 0644)
+
+	previewer := newPreviewer(t)
+	previewer.root = folder
+
+	for _, location := range []string{"/etc/hosts", parent + "/credentials.json", folder + "/../credentials.json", "credentials.json", folder + "-other/x.csv"} {
+		_, err := previewer.PreviewFile(location, 0)
+		assert.True(t, errors.Is(err, ErrNotFound), location)
+	}
+}
+
+func TestPreviewerImpl_PreviewDataset(t *testing.T) {
+	folder := t.TempDir()
+	previewer := newPreviewer(t)
+
+	for i, content := range []string{"{\"id\": 1, \"status\": \"paid\"}\n{\"id\": 2, \"status\": \"open\"}\n", "{\"id\": 3, \"status\": \"sent\"}\n"} {
+		location := folder + "/orders/0" + string(rune('1'+i)) + ".parquet"
+		records := writeParquet(t, location, content)
+		assert.Nil(t, previewer.catalog.Add(&models_v1.DataFile{Location: location, Dataset: "orders", Format: models_v1.Schema_PARQUET, Records: records}))
+	}
+
+	// the preview carries on into the next file, up to PREVIEW_MAX_LIMIT records
+	preview, err := previewer.PreviewDataset("orders", "", 10)
+	assert.Nil(t, err)
+	assert.Len(t, preview.Records, 3)
+	assert.False(t, preview.Truncated)
+	assert.Len(t, preview.Files, 2)
+
+	preview, err = previewer.PreviewDataset("orders", "", 0)
+	assert.Nil(t, err)
+	assert.Len(t, preview.Records, 2)
+	assert.True(t, preview.Truncated)
+	assert.Len(t, preview.Files, 1)
+
+	preview, err = previewer.PreviewDataset("orders", folder+"/orders/02.parquet", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, [][]interface{}{{int64(3), "sent"}}, preview.Rows())
+
+	_, err = previewer.PreviewDataset("orders", folder+"/orders/03.parquet", 0)
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.Equal(t, "file "+folder+"/orders/03.parquet is not part of dataset orders: not found", err.Error())
+
+	_, err = previewer.PreviewDataset("customers", "", 0)
+	assert.Equal(t, "catalog has no dataset customers: not found", err.Error())
+}
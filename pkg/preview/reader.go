@@ -0,0 +1,179 @@
+package preview
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/convert"
+	"github.com/codingexplorations/data-lake/pkg/records"
+	"github.com/codingexplorations/data-lake/pkg/schema"
+	"github.com/codingexplorations/data-lake/pkg/storage"
+	"github.com/klauspost/compress/zstd"
+)
+
+// sniffSize is how much of the start of a file is looked at to tell its format, and to infer the dialect of CSV files
+const sniffSize = 64 * 1024
+
+var parquetMagic = []byte("PAR1")
+
+// readFile adds up to limit records of a file to the preview. An UNKNOWN format is detected from the name and start
+// of the file, and CSV files without a schema have one inferred from their start.
+func readFile(preview *Preview, file storage.File, location string, format models_v1.Schema_Format, csvSchema *models_v1.Schema, limit int) error {
+	head := make([]byte, 4)
+	n, err := file.ReadAt(head, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	head = head[:n]
+
+	previewed := &PreviewFile{Location: location}
+	preview.Files = append(preview.Files, previewed)
+
+	add := func(row map[string]interface{}) bool {
+		if len(preview.Records) >= limit {
+			preview.Truncated = true
+			return false
+		}
+		preview.Records = append(preview.Records, row)
+		previewed.Records++
+		return true
+	}
+
+	// parquet files are read from their footer, only the pages of the previewed rows are fetched
+	if format == models_v1.Schema_PARQUET || (format == models_v1.Schema_UNKNOWN && bytes.Equal(head, parquetMagic)) {
+		previewed.Format = models_v1.Schema_PARQUET.String()
+		columns, err := convert.ParquetRows(file, file.Size(), add)
+		if err != nil {
+			return fmt.Errorf("%v: %v", location, err)
+		}
+		preview.addColumns(columns)
+		return nil
+	}
+
+	stream := bufio.NewReaderSize(io.NewSectionReader(file, 0, file.Size()), storage.ReadChunkSize)
+	compression, decompressed, err := decompress(head, stream)
+	if err != nil {
+		return fmt.Errorf("%v: %v", location, err)
+	}
+	previewed.Compression = compression
+	if closer, ok := decompressed.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	name := location
+	if compression != "" {
+		name = name[:len(name)-len(path.Ext(name))]
+	}
+
+	body := bufio.NewReaderSize(decompressed, sniffSize)
+	sample, _ := body.Peek(sniffSize)
+
+	if format == models_v1.Schema_UNKNOWN {
+		if bytes.HasPrefix(sample, parquetMagic) {
+			return fmt.Errorf("%v: compressed parquet files can't be previewed", location)
+		}
+		format = schema.DetectFormat(name, "", sample)
+		if format == models_v1.Schema_UNKNOWN {
+			return fmt.Errorf("%v is not a CSV, JSON or Parquet file", location)
+		}
+	}
+
+	if format == models_v1.Schema_CSV && csvSchema == nil {
+		// every row of the sample is used, a handful of rows isn't enough to tell a header apart
+		csvSchema, err = schema.Infer(format, bytes.NewReader(completeLines(sample, sniffSize)), sniffSize)
+		if err != nil {
+			return fmt.Errorf("%v: %v", location, err)
+		}
+	}
+
+	if format == models_v1.Schema_CSV {
+		previewed.Format = format.String()
+		columns := make([]string, 0, len(csvSchema.GetFields()))
+		for _, field := range csvSchema.GetFields() {
+			columns = append(columns, field.GetName())
+		}
+		preview.addColumns(columns)
+		reader := records.NewCsvRecordReader(body, csvSchema)
+		return readRecords(reader, csvSchema, location, add)
+	}
+
+	reader := records.NewJsonReader(body)
+	err = readRecords(reader, nil, location, func(row map[string]interface{}) bool {
+		keys := make([]string, 0, len(row))
+		for key := range row {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		if !add(row) {
+			return false
+		}
+		preview.addColumns(keys)
+		return true
+	})
+	previewed.Format = reader.Format().String()
+
+	return err
+}
+
+func readRecords(reader records.Reader, recordSchema *models_v1.Schema, location string, add func(row map[string]interface{}) bool) error {
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%v: %v", location, err)
+		}
+
+		row := record.Fields
+		if len(recordSchema.GetFields()) > 0 {
+			if values, err := convert.Values(recordSchema, record.Fields); err == nil {
+				row = values
+			}
+		}
+
+		if !add(row) {
+			return nil
+		}
+	}
+}
+
+// decompress returns a reader of the decompressed content of gzip, zstd and bzip2 files, and the compression they
+// use. Other files are returned as they are.
+func decompress(head []byte, r io.Reader) (string, io.Reader, error) {
+	switch {
+	case bytes.HasPrefix(head, []byte{0x1f, 0x8b}):
+		reader, err := gzip.NewReader(r)
+		return "gzip", reader, err
+	case bytes.HasPrefix(head, []byte{0x28, 0xb5, 0x2f, 0xfd}):
+		decoder, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return "", nil, err
+		}
+		return "zstd", decoder.IOReadCloser(), nil
+	case bytes.HasPrefix(head, []byte("BZh")):
+		return "bzip2", bzip2.NewReader(r), nil
+	default:
+		return "", r, nil
+	}
+}
+
+// completeLines drops the last line of a sample that filled the whole buffer, it's most likely cut short.
+func completeLines(sample []byte, size int) []byte {
+	if len(sample) < size {
+		return sample
+	}
+	if i := bytes.LastIndexByte(sample, '\n'); i >= 0 {
+		return sample[:i+1]
+	}
+	return sample
+}
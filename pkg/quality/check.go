@@ -0,0 +1,233 @@
+package quality
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/convert"
+	"github.com/codingexplorations/data-lake/pkg/records"
+)
+
+// maxFailedLines is how many of the lines that failed an expectation a report keeps
+const maxFailedLines = 10
+
+// History tells how many rows the objects of a dataset had, to compare the row count of a new object with.
+type History interface {
+	// RowsPerObject returns the average number of rows of the objects ingested since a time, and false when there
+	// weren't any
+	RowsPerObject(dataset string, since time.Time) (float64, bool, error)
+}
+
+// Check reads every record of an object and returns how it held up to the expectations of its dataset. History may
+// be nil, row_count_change expectations then pass for lack of anything to compare with.
+func Check(dataset string, expectations []*Expectation, object *models_v1.Object, reader records.Reader, history History) (*models_v1.QualityReport, error) {
+	checkers := make([]*checker, 0, len(expectations))
+	for _, expectation := range expectations {
+		c, err := newChecker(expectation)
+		if err != nil {
+			return nil, err
+		}
+		checkers = append(checkers, c)
+	}
+
+	now := time.Now()
+	report := &models_v1.QualityReport{
+		Dataset:   dataset,
+		Location:  object.FileLocation,
+		Timestamp: now.UnixMilli(),
+		Passed:    true,
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		values, err := convert.Values(object.Schema, record.Fields)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", record.Line, err)
+		}
+
+		report.Rows++
+		for _, c := range checkers {
+			c.check(values, int32(record.Line))
+		}
+	}
+
+	for _, c := range checkers {
+		result, err := c.result(dataset, report.Rows, now, history)
+		if err != nil {
+			return nil, err
+		}
+		report.Passed = report.Passed && result.Passed
+		report.Results = append(report.Results, result)
+	}
+
+	return report, nil
+}
+
+// Failed returns the expectations a report didn't pass.
+func Failed(report *models_v1.QualityReport) []string {
+	failed := make([]string, 0)
+	for _, result := range report.GetResults() {
+		if !result.Passed {
+			failed = append(failed, result.Expectation)
+		}
+	}
+	return failed
+}
+
+type checker struct {
+	expectation *Expectation
+	path        []string
+	pattern     *regexp.Regexp
+	values      map[string]bool
+	seen        map[string]bool
+	failedRows  int64
+	failedLines []int32
+}
+
+func newChecker(expectation *Expectation) (*checker, error) {
+	if err := expectation.Validate(); err != nil {
+		return nil, err
+	}
+
+	c := &checker{expectation: expectation, path: strings.Split(expectation.Column, ".")}
+	switch expectation.Type {
+	case Match:
+		c.pattern = regexp.MustCompile(expectation.Pattern)
+	case In:
+		c.values = make(map[string]bool, len(expectation.Values))
+		for _, value := range expectation.Values {
+			c.values[value] = true
+		}
+	case Unique:
+		c.seen = make(map[string]bool)
+	}
+
+	return c, nil
+}
+
+func (c *checker) check(values map[string]interface{}, line int32) {
+	if c.expectation.Type == RowCount || c.expectation.Type == RowCountChange {
+		return
+	}
+
+	value := lookup(values, c.path)
+	if c.expectation.Type == NotNull {
+		c.fail(value == nil, line)
+		return
+	}
+	if value == nil {
+		return
+	}
+
+	switch c.expectation.Type {
+	case Unique:
+		key := text(value)
+		c.fail(c.seen[key], line)
+		c.seen[key] = true
+	case Between:
+		n, ok := number(value)
+		c.fail(!ok || (c.expectation.Min != nil && n < *c.expectation.Min) || (c.expectation.Max != nil && n > *c.expectation.Max), line)
+	case In:
+		c.fail(!c.values[text(value)], line)
+	case Match:
+		c.fail(!c.pattern.MatchString(text(value)), line)
+	}
+}
+
+func (c *checker) fail(failed bool, line int32) {
+	if !failed {
+		return
+	}
+	c.failedRows++
+	if len(c.failedLines) < maxFailedLines {
+		c.failedLines = append(c.failedLines, line)
+	}
+}
+
+func (c *checker) result(dataset string, rows int64, now time.Time, history History) (*models_v1.ExpectationResult, error) {
+	result := &models_v1.ExpectationResult{Expectation: c.expectation.String()}
+
+	switch c.expectation.Type {
+	case RowCount:
+		min, max := c.expectation.Min, c.expectation.Max
+		result.Passed = (min == nil || float64(rows) >= *min) && (max == nil || float64(rows) <= *max)
+		result.Message = fmt.Sprintf("%d rows", rows)
+	case RowCountChange:
+		if history == nil {
+			result.Passed = true
+			result.Message = "no history to compare with"
+			break
+		}
+		average, ok, err := history.RowsPerObject(dataset, now.Add(-c.expectation.window()))
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get the row counts of dataset %v: %v", dataset, err)
+		}
+		if !ok {
+			result.Passed = true
+			result.Message = fmt.Sprintf("no objects in the previous %v to compare with", c.expectation.window())
+			break
+		}
+		change := math.Abs(float64(rows)-average) / math.Max(average, 1)
+		result.Passed = change <= c.expectation.MaxChange
+		result.Message = fmt.Sprintf("%d rows, %v on average before", rows, formatFloat(math.Round(average*10)/10))
+	default:
+		result.FailedRows = c.failedRows
+		result.FailedLines = c.failedLines
+		result.Passed = rows == 0 || float64(rows-c.failedRows)/float64(rows) >= c.expectation.mostly()
+		if c.failedRows > 0 {
+			result.Message = fmt.Sprintf("%d of %d rows failed", c.failedRows, rows)
+		}
+	}
+
+	return result, nil
+}
+
+// lookup returns the value at a path of nested records, nil when any of them is missing.
+func lookup(values map[string]interface{}, path []string) interface{} {
+	var value interface{} = values
+	for _, name := range path {
+		record, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = record[name]
+	}
+	return value
+}
+
+func number(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, !math.IsNaN(v)
+	}
+	return 0, false
+}
+
+// text is how values are compared with the values of in expectations, and matched against patterns.
+func text(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,125 @@
+package quality
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/catalog"
+	"github.com/codingexplorations/data-lake/pkg/records"
+	"github.com/codingexplorations/data-lake/pkg/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+var orders = &models_v1.Object{
+	FileLocation: "orders/2024/01.csv",
+	Schema: &models_v1.Schema{
+		Format:    models_v1.Schema_CSV,
+		Header:    true,
+		Delimiter: ",",
+		Fields: []*models_v1.Field{
+			{Name: "order_id", Type: models_v1.Field_INTEGER, Nullable: true},
+			{Name: "status", Type: models_v1.Field_STRING},
+			{Name: "amount", Type: models_v1.Field_NUMBER},
+		},
+	},
+}
+
+const ordersCsv = "order_id,status,amount\n1,paid,10\n2,open,-5\n,paid,3\n2,lost,4\n"
+
+type history map[string]float64
+
+func (h history) RowsPerObject(dataset string, since time.Time) (float64, bool, error) {
+	rows, ok := h[dataset]
+	return rows, ok, nil
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		expectation *Expectation
+		passed      bool
+		failedRows  int64
+		failedLines []int32
+		message     string
+	}{
+		{expectation: &Expectation{Type: NotNull, Column: "order_id"}, failedRows: 1, failedLines: []int32{4}, message: "1 of 4 rows failed"},
+		{expectation: &Expectation{Type: NotNull, Column: "order_id", Mostly: 0.75}, passed: true, failedRows: 1, failedLines: []int32{4}, message: "1 of 4 rows failed"},
+		// nulls are left to not_null
+		{expectation: &Expectation{Type: Unique, Column: "order_id"}, failedRows: 1, failedLines: []int32{5}, message: "1 of 4 rows failed"},
+		{expectation: &Expectation{Type: Between, Column: "amount", Min: float(0)}, failedRows: 1, failedLines: []int32{3}, message: "1 of 4 rows failed"},
+		{expectation: &Expectation{Type: Between, Column: "amount", Min: float(-10), Max: float(10)}, passed: true},
+		{expectation: &Expectation{Type: In, Column: "status", Values: []string{"open", "paid"}}, failedRows: 1, failedLines: []int32{5}, message: "1 of 4 rows failed"},
+		{expectation: &Expectation{Type: In, Column: "order_id", Values: []string{"1", "2"}}, passed: true},
+		{expectation: &Expectation{Type: Match, Column: "status", Pattern: "^[a-z]{4}$"}, passed: true},
+		{expectation: &Expectation{Type: RowCount, Min: float(1), Max: float(3)}, message: "4 rows"},
+		{expectation: &Expectation{Type: RowCountChange, MaxChange: 0.2}, passed: true, message: "4 rows, 3.5 on average before"},
+		{expectation: &Expectation{Type: RowCountChange, MaxChange: 0.1}, message: "4 rows, 3.5 on average before"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expectation.String(), func(t *testing.T) {
+			reader, _ := records.NewReader(orders.Schema, strings.NewReader(ordersCsv))
+
+			report, err := Check("orders", []*Expectation{test.expectation}, orders, reader, history{"orders": 3.5})
+
+			assert.Nil(t, err)
+			assert.Equal(t, "orders/2024/01.csv", report.Location)
+			assert.Equal(t, int64(4), report.Rows)
+			assert.Equal(t, test.passed, report.Passed)
+			assert.Equal(t, test.expectation.String(), report.Results[0].Expectation)
+			assert.Equal(t, test.passed, report.Results[0].Passed)
+			assert.Equal(t, test.failedRows, report.Results[0].FailedRows)
+			assert.Equal(t, test.failedLines, report.Results[0].FailedLines)
+			assert.Equal(t, test.message, report.Results[0].Message)
+		})
+	}
+}
+
+func TestCheck_NoHistory(t *testing.T) {
+	expectations := []*Expectation{{Type: RowCountChange, MaxChange: 0.2}}
+
+	reader, _ := records.NewReader(orders.Schema, strings.NewReader(ordersCsv))
+	report, err := Check("orders", expectations, orders, reader, nil)
+	assert.Nil(t, err)
+	assert.True(t, report.Passed)
+	assert.Equal(t, "no history to compare with", report.Results[0].Message)
+
+	reader, _ = records.NewReader(orders.Schema, strings.NewReader(ordersCsv))
+	report, err = Check("orders", expectations, orders, reader, history{})
+	assert.Nil(t, err)
+	assert.True(t, report.Passed)
+	assert.Equal(t, "no objects in the previous 24h0m0s to compare with", report.Results[0].Message)
+}
+
+func TestCheck_Failure(t *testing.T) {
+	reader, _ := records.NewReader(orders.Schema, strings.NewReader("order_id,status,amount\nx,paid,1\n"))
+	_, err := Check("orders", []*Expectation{{Type: NotNull, Column: "order_id"}}, orders, reader, nil)
+	assert.Equal(t, "line 2: order_id: strconv.ParseInt: parsing \"x\": invalid syntax", err.Error())
+
+	reader, _ = records.NewReader(orders.Schema, strings.NewReader(ordersCsv))
+	_, err = Check("orders", []*Expectation{{Type: "positive"}}, orders, reader, nil)
+	assert.Error(t, err)
+}
+
+func TestCatalogHistory_RowsPerObject(t *testing.T) {
+	tableCatalog := catalog.NewTableCatalog(storage.NewLocalStorage(), t.TempDir())
+	history := NewCatalogHistory(tableCatalog)
+
+	_, ok, err := history.RowsPerObject("orders", time.Now().Add(-time.Hour))
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	_ = tableCatalog.Add(&models_v1.DataFile{Location: "orders/01.parquet", Dataset: "orders", Records: 10, Lineage: &models_v1.Lineage{Sources: []string{"orders/01.csv"}}})
+	// a compacted file counts for all of its sources
+	_ = tableCatalog.Add(&models_v1.DataFile{Location: "orders/compacted.parquet", Dataset: "orders", Records: 40, Lineage: &models_v1.Lineage{Sources: []string{"orders/02.csv", "orders/03.csv"}}})
+
+	rows, ok, err := history.RowsPerObject("orders", time.Now().Add(-time.Hour))
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 50.0/3, rows)
+
+	_, ok, err = history.RowsPerObject("orders", time.Now().Add(time.Hour))
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
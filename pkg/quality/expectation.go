@@ -0,0 +1,147 @@
+package quality
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The types of expectations. Row expectations are checked against every row and ignore nulls unless they're about
+// them, the others against the object as a whole.
+const (
+	NotNull        = "not_null"
+	Unique         = "unique"
+	Between        = "between"
+	In             = "in"
+	Match          = "match"
+	RowCount       = "row_count"
+	RowCountChange = "row_count_change"
+)
+
+// DefaultWindow is how far back the row count of an object is compared by default, so it's compared with yesterday's.
+const DefaultWindow = 24 * time.Hour
+
+// Expectation is a rule every object of a dataset is expected to follow, declared with the dataset in DATASETS_FILE:
+//
+//	expectations:
+//	  - type: not_null
+//	    column: order_id
+//	  - type: between
+//	    column: amount
+//	    min: 0
+//	  - type: row_count_change
+//	    max_change: 0.2
+type Expectation struct {
+	Type   string `mapstructure:"type"`
+	Column string `mapstructure:"column"`
+	// Min and Max bound the values of between and the number of rows of row_count, either can be left out
+	Min     *float64 `mapstructure:"min"`
+	Max     *float64 `mapstructure:"max"`
+	Values  []string `mapstructure:"values"`
+	Pattern string   `mapstructure:"pattern"`
+	// MaxChange is how far the row count may be from the average of the objects ingested in the Window before, as a
+	// fraction of it
+	MaxChange float64       `mapstructure:"max_change"`
+	Window    time.Duration `mapstructure:"window"`
+	// Mostly is the share of rows that have to meet a row expectation, all of them when it isn't set
+	Mostly float64 `mapstructure:"mostly"`
+}
+
+// Validate checks that the expectation has what its type needs.
+func (expectation *Expectation) Validate() error {
+	switch expectation.Type {
+	case NotNull, Unique, Between, In, Match:
+		if expectation.Column == "" {
+			return fmt.Errorf("%v expectation is missing a column", expectation.Type)
+		}
+	case RowCount, RowCountChange:
+	default:
+		return fmt.Errorf("unknown expectation type %q", expectation.Type)
+	}
+
+	switch expectation.Type {
+	case Between, RowCount:
+		if expectation.Min == nil && expectation.Max == nil {
+			return fmt.Errorf("%v expectation needs a min or a max", expectation.Type)
+		}
+	case In:
+		if len(expectation.Values) == 0 {
+			return fmt.Errorf("in expectation of %v has no values", expectation.Column)
+		}
+	case Match:
+		if _, err := regexp.Compile(expectation.Pattern); err != nil || expectation.Pattern == "" {
+			return fmt.Errorf("match expectation of %v needs a valid pattern: %v", expectation.Column, err)
+		}
+	case RowCountChange:
+		if expectation.MaxChange <= 0 {
+			return fmt.Errorf("row_count_change expectation needs a positive max_change")
+		}
+	}
+
+	if expectation.Mostly < 0 || expectation.Mostly > 1 {
+		return fmt.Errorf("mostly must be between 0 and 1, got %v", expectation.Mostly)
+	}
+
+	return nil
+}
+
+// String describes the expectation the way reports name it.
+func (expectation *Expectation) String() string {
+	var description string
+
+	switch expectation.Type {
+	case NotNull:
+		description = fmt.Sprintf("%v is not null", expectation.Column)
+	case Unique:
+		description = fmt.Sprintf("%v is unique", expectation.Column)
+	case Between:
+		description = fmt.Sprintf("%v is %v", expectation.Column, bounds(expectation.Min, expectation.Max))
+	case In:
+		description = fmt.Sprintf("%v is one of %v", expectation.Column, strings.Join(expectation.Values, ", "))
+	case Match:
+		description = fmt.Sprintf("%v matches %v", expectation.Column, expectation.Pattern)
+	case RowCount:
+		description = fmt.Sprintf("row count is %v", bounds(expectation.Min, expectation.Max))
+	case RowCountChange:
+		description = fmt.Sprintf("row count is within %v%% of the previous %v", formatFloat(expectation.MaxChange*100), expectation.window())
+	default:
+		description = expectation.Type
+	}
+
+	if expectation.Mostly > 0 && expectation.Mostly < 1 {
+		description += fmt.Sprintf(" for %v%% of rows", formatFloat(expectation.Mostly*100))
+	}
+
+	return description
+}
+
+func (expectation *Expectation) window() time.Duration {
+	if expectation.Window <= 0 {
+		return DefaultWindow
+	}
+	return expectation.Window
+}
+
+func (expectation *Expectation) mostly() float64 {
+	if expectation.Mostly <= 0 {
+		return 1
+	}
+	return expectation.Mostly
+}
+
+func bounds(min, max *float64) string {
+	switch {
+	case min != nil && max != nil:
+		return fmt.Sprintf("between %v and %v", formatFloat(*min), formatFloat(*max))
+	case min != nil:
+		return fmt.Sprintf("at least %v", formatFloat(*min))
+	default:
+		return fmt.Sprintf("at most %v", formatFloat(*max))
+	}
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
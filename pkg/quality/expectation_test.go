@@ -0,0 +1,61 @@
+package quality
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func float(f float64) *float64 {
+	return &f
+}
+
+func TestExpectation_String(t *testing.T) {
+	tests := []struct {
+		expectation *Expectation
+		expected    string
+	}{
+		{&Expectation{Type: NotNull, Column: "order_id"}, "order_id is not null"},
+		{&Expectation{Type: Unique, Column: "order_id", Mostly: 0.995}, "order_id is unique for 99.5% of rows"},
+		{&Expectation{Type: Between, Column: "amount", Min: float(0)}, "amount is at least 0"},
+		{&Expectation{Type: Between, Column: "amount", Min: float(0), Max: float(99.5)}, "amount is between 0 and 99.5"},
+		{&Expectation{Type: In, Column: "status", Values: []string{"open", "paid"}}, "status is one of open, paid"},
+		{&Expectation{Type: Match, Column: "email", Pattern: ".+@.+"}, "email matches .+@.+"},
+		{&Expectation{Type: RowCount, Max: float(1000)}, "row count is at most 1000"},
+		{&Expectation{Type: RowCountChange, MaxChange: 0.2}, "row count is within 20% of the previous 24h0m0s"},
+		{&Expectation{Type: RowCountChange, MaxChange: 0.5, Window: time.Hour}, "row count is within 50% of the previous 1h0m0s"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			assert.Nil(t, test.expectation.Validate())
+			assert.Equal(t, test.expected, test.expectation.String())
+		})
+	}
+}
+
+func TestExpectation_Validate(t *testing.T) {
+	tests := []struct {
+		expectation *Expectation
+		expected    string
+	}{
+		{&Expectation{Type: "positive", Column: "amount"}, "unknown expectation type \"positive\""},
+		{&Expectation{Type: NotNull}, "not_null expectation is missing a column"},
+		{&Expectation{Type: Between, Column: "amount"}, "between expectation needs a min or a max"},
+		{&Expectation{Type: RowCount}, "row_count expectation needs a min or a max"},
+		{&Expectation{Type: In, Column: "status"}, "in expectation of status has no values"},
+		{&Expectation{Type: Match, Column: "email", Pattern: "("}, "match expectation of email needs a valid pattern: error parsing regexp: missing closing ): `(`"},
+		{&Expectation{Type: RowCountChange}, "row_count_change expectation needs a positive max_change"},
+		{&Expectation{Type: NotNull, Column: "id", Mostly: 2}, "mostly must be between 0 and 1, got 2"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			err := test.expectation.Validate()
+
+			assert.Error(t, err)
+			assert.Equal(t, test.expected, err.Error())
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package quality
+
+import (
+	"time"
+
+	"github.com/codingexplorations/data-lake/pkg/catalog"
+)
+
+// CatalogHistory takes the row counts of earlier objects from the files the catalog lists for their dataset.
+type CatalogHistory struct {
+	catalog catalog.Catalog
+}
+
+func NewCatalogHistory(cat catalog.Catalog) *CatalogHistory {
+	return &CatalogHistory{catalog: cat}
+}
+
+// RowsPerObject averages the records of the files added since a time over the objects they were converted from, a
+// compacted file counts for all of its sources.
+func (history *CatalogHistory) RowsPerObject(dataset string, since time.Time) (float64, bool, error) {
+	files, err := history.catalog.Files(dataset)
+	if err != nil {
+		return 0, false, err
+	}
+
+	rows, objects := int64(0), 0
+	for _, file := range files {
+		if file.Timestamp < since.UnixMilli() {
+			continue
+		}
+		rows += int64(file.Records)
+		objects += max(len(file.GetLineage().GetSources()), 1)
+	}
+
+	if objects == 0 {
+		return 0, false, nil
+	}
+	return float64(rows) / float64(objects), true, nil
+}
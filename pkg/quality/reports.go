@@ -0,0 +1,63 @@
+package quality
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/storage"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Reports keeps the latest quality report of every object as JSON, at the location of the object below
+// <root>/<dataset>/.
+type Reports struct {
+	storage storage.Storage
+	root    string
+}
+
+func NewReports(storage storage.Storage, root string) *Reports {
+	return &Reports{storage: storage, root: root}
+}
+
+func (reports *Reports) Write(report *models_v1.QualityReport) error {
+	data, err := protojson.MarshalOptions{Multiline: true}.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	location := reports.location(report.Dataset, report.Location)
+	if err := reports.storage.Write(location, bytes.NewReader(data), "application/json"); err != nil {
+		return fmt.Errorf("couldn't write quality report %v: %v", location, err)
+	}
+
+	return nil
+}
+
+// Read returns the latest report of an object, missing reports are reported as fs.ErrNotExist.
+func (reports *Reports) Read(dataset string, location string) (*models_v1.QualityReport, error) {
+	body, err := reports.storage.Read(reports.location(dataset, location))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models_v1.QualityReport{}
+	if err := protojson.Unmarshal(data, report); err != nil {
+		return nil, fmt.Errorf("couldn't read quality report of %v: %v", location, err)
+	}
+
+	return report, nil
+}
+
+func (reports *Reports) location(dataset string, location string) string {
+	return path.Join(reports.root, dataset, strings.TrimPrefix(location, "/")+".json")
+}
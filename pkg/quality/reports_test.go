@@ -0,0 +1,35 @@
+package quality
+
+import (
+	"io/fs"
+	"os"
+	"testing"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestReports(t *testing.T) {
+	root := t.TempDir()
+	reports := NewReports(storage.NewLocalStorage(), root)
+	report := &models_v1.QualityReport{
+		Dataset:  "orders",
+		Location: "/data/orders/01.csv",
+		Rows:     2,
+		Results:  []*models_v1.ExpectationResult{{Expectation: "order_id is not null", FailedRows: 1, FailedLines: []int32{3}}},
+	}
+
+	assert.Nil(t, reports.Write(report))
+
+	_, err := os.Stat(root + "/orders/data/orders/01.csv.json")
+	assert.Nil(t, err)
+
+	read, err := reports.Read("orders", "/data/orders/01.csv")
+	assert.Nil(t, err)
+	assert.True(t, proto.Equal(report, read))
+
+	_, err = reports.Read("orders", "/data/orders/02.csv")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
@@ -0,0 +1,172 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var aggregateFunctions = map[string]bool{"COUNT": true, "SUM": true, "AVG": true, "MIN": true, "MAX": true}
+
+// Aggregate is COUNT, SUM, AVG, MIN or MAX over the rows of a group. Arg is nil for COUNT(*). Aggregates are
+// computed while scanning, evaluating one reads its result from the row the group is projected from.
+type Aggregate struct {
+	Func string
+	Arg  Expr
+}
+
+func (a *Aggregate) Eval(row map[string]interface{}) (interface{}, error) {
+	value, ok := row[a.key()]
+	if !ok {
+		return nil, fmt.Errorf("%v can only be used in the select list", a)
+	}
+	return value, nil
+}
+
+func (a *Aggregate) String() string {
+	if a.Arg == nil {
+		return strings.ToLower(a.Func) + "(*)"
+	}
+	return fmt.Sprintf("%v(%v)", strings.ToLower(a.Func), a.Arg)
+}
+
+// key can't clash with a column name, column names can't hold NUL.
+func (a *Aggregate) key() string {
+	return "\x00" + a.String()
+}
+
+type accumulator struct {
+	aggregate *Aggregate
+	count     int64
+	sumInt    int64
+	sumFloat  float64
+	floats    bool
+	value     interface{}
+}
+
+func (acc *accumulator) add(row map[string]interface{}) error {
+	if acc.aggregate.Arg == nil {
+		acc.count++
+		return nil
+	}
+
+	value, err := acc.aggregate.Arg.Eval(row)
+	if err != nil || value == nil {
+		return err
+	}
+
+	switch acc.aggregate.Func {
+	case "SUM", "AVG":
+		if i, ok := integer(value); ok && !acc.floats {
+			acc.sumInt += i
+		} else if f, ok := number(normalize(value)); ok {
+			if !acc.floats {
+				acc.floats = true
+				acc.sumFloat = float64(acc.sumInt)
+			}
+			acc.sumFloat += f
+		} else {
+			return fmt.Errorf("%v: %v is not a number", acc.aggregate, describe(value))
+		}
+	case "MIN", "MAX":
+		if acc.value == nil {
+			acc.value = value
+			break
+		}
+		result, ok := compare(value, acc.value)
+		if !ok {
+			return fmt.Errorf("%v: can't compare %v with %v", acc.aggregate, describe(value), describe(acc.value))
+		}
+		if (acc.aggregate.Func == "MIN" && result < 0) || (acc.aggregate.Func == "MAX" && result > 0) {
+			acc.value = value
+		}
+	}
+
+	acc.count++
+	return nil
+}
+
+func (acc *accumulator) result() interface{} {
+	switch acc.aggregate.Func {
+	case "COUNT":
+		return acc.count
+	case "SUM":
+		if acc.count == 0 {
+			return nil
+		}
+		if acc.floats {
+			return acc.sumFloat
+		}
+		return acc.sumInt
+	case "AVG":
+		if acc.count == 0 {
+			return nil
+		}
+		if acc.floats {
+			return acc.sumFloat / float64(acc.count)
+		}
+		return float64(acc.sumInt) / float64(acc.count)
+	default:
+		return acc.value
+	}
+}
+
+func integer(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case json.Number:
+		i, err := v.Int64()
+		return i, err == nil
+	case string:
+		i, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		return i, err == nil
+	}
+	return 0, false
+}
+
+// aggregates returns the aggregates an expression uses.
+func aggregates(expr Expr) []*Aggregate {
+	found := make([]*Aggregate, 0)
+	walk(expr, func(e Expr) {
+		if a, ok := e.(*Aggregate); ok {
+			found = append(found, a)
+		}
+	})
+	return found
+}
+
+// walk calls fn for an expression and everything below it.
+func walk(expr Expr, fn func(Expr)) {
+	if expr == nil {
+		return
+	}
+	fn(expr)
+
+	switch e := expr.(type) {
+	case *Aggregate:
+		walk(e.Arg, fn)
+	case *Comparison:
+		walk(e.Left, fn)
+		walk(e.Right, fn)
+	case *Logical:
+		walk(e.Left, fn)
+		walk(e.Right, fn)
+	case *Not:
+		walk(e.Expr, fn)
+	case *IsNull:
+		walk(e.Expr, fn)
+	case *In:
+		walk(e.Expr, fn)
+		for _, value := range e.Values {
+			walk(value, fn)
+		}
+	case *Like:
+		walk(e.Expr, fn)
+	}
+}
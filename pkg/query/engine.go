@@ -0,0 +1,351 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/catalog"
+	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/codingexplorations/data-lake/pkg/log"
+	"github.com/codingexplorations/data-lake/pkg/registry"
+	"github.com/codingexplorations/data-lake/pkg/storage"
+)
+
+// Every row has these columns on top of its own, conditions on them only are checked against the catalog before a
+// file is read.
+const (
+	FileColumn      = "_file"
+	PartitionColumn = "_partition"
+)
+
+var ErrDatasetNotFound = errors.New("dataset not found")
+
+// Engine runs SQL statements over the files the catalog lists for a dataset.
+type Engine interface {
+	Query(sql string) (*Result, error)
+	// Profile merges the column statistics of the files of a dataset
+	Profile(dataset string) (*Profile, error)
+}
+
+type Result struct {
+	Columns []string        `json:"columns"`
+	Rows    [][]interface{} `json:"rows"`
+	// Truncated is set when QUERY_ROW_LIMIT cut the rows short
+	Truncated    bool `json:"truncated"`
+	FilesScanned int  `json:"files_scanned"`
+	FilesPruned  int  `json:"files_pruned"`
+}
+
+// EngineImpl scans files one at a time, keeping only the rows it returns or the groups it aggregates in memory.
+type EngineImpl struct {
+	logger   log.Logger
+	catalog  catalog.Catalog
+	storage  storage.Storage
+	registry registry.SchemaRegistry
+	rowLimit int
+}
+
+func NewEngine(conf *config.Config, logger log.Logger) (Engine, error) {
+	tableCatalog, err := catalog.GetCatalog(conf)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create catalog: %v", err)
+	}
+
+	curated, err := storage.GetCuratedStorage(conf)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create curated storage: %v", err)
+	}
+
+	schemaRegistry, err := registry.GetSchemaRegistry(conf)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create schema registry: %v", err)
+	}
+
+	return &EngineImpl{
+		logger:   logger,
+		catalog:  tableCatalog,
+		storage:  curated,
+		registry: schemaRegistry,
+		rowLimit: conf.QueryRowLimit,
+	}, nil
+}
+
+func (engine *EngineImpl) Query(sql string) (*Result, error) {
+	statement, err := Parse(sql)
+	if err != nil {
+		return nil, err
+	}
+
+	return engine.Execute(statement)
+}
+
+func (engine *EngineImpl) Execute(statement *Statement) (*Result, error) {
+	datasets, err := engine.catalog.Datasets()
+	if err != nil {
+		return nil, err
+	}
+	if !contains(datasets, statement.Dataset) {
+		return nil, fmt.Errorf("catalog has no dataset %v: %w", statement.Dataset, ErrDatasetNotFound)
+	}
+
+	files, err := engine.catalog.Files(statement.Dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Columns: make([]string, 0), Rows: make([][]interface{}, 0)}
+	aggregated := statement.Aggregated()
+	filters := pruningFilters(statement.Where)
+
+	// when the row limit applies one more row is read to know whether there were more
+	limit, capped := engine.limit(statement)
+	take := limit
+	if capped {
+		take++
+	}
+
+	rows := make([]map[string]interface{}, 0)
+	groups := newGroups(statement)
+	columns := make([]string, 0)
+	var rowErr error
+
+	for _, file := range files {
+		if !aggregated && limit >= 0 && len(rows) >= take {
+			break
+		}
+
+		keep, err := matches(filters, map[string]interface{}{FileColumn: file.Location, PartitionColumn: file.Partition})
+		if err != nil {
+			return nil, err
+		}
+		if !keep || excluded(statement.Where, file.Statistics) {
+			result.FilesPruned++
+			continue
+		}
+		result.FilesScanned++
+
+		fileColumns, err := engine.scan(file, func(row map[string]interface{}) bool {
+			addMetadata(row, file)
+
+			if statement.Where != nil {
+				ok, err := condition(statement.Where, row)
+				if err != nil {
+					rowErr = err
+					return false
+				}
+				if ok == nil || !*ok {
+					return true
+				}
+			}
+
+			if aggregated {
+				rowErr = groups.add(row)
+				return rowErr == nil
+			}
+
+			rows = append(rows, row)
+			return limit < 0 || len(rows) < take
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %v: %v", file.Location, err)
+		}
+		if rowErr != nil {
+			return nil, fmt.Errorf("%v: %v", file.Location, rowErr)
+		}
+
+		for _, column := range fileColumns {
+			if !contains(columns, column) {
+				columns = append(columns, column)
+			}
+		}
+	}
+
+	if aggregated {
+		rows = groups.rows()
+	}
+	if limit >= 0 && len(rows) > limit {
+		rows = rows[:limit]
+		result.Truncated = capped
+	}
+
+	for _, item := range statement.Items {
+		if item.Star {
+			result.Columns = append(result.Columns, columns...)
+		} else {
+			result.Columns = append(result.Columns, item.Name())
+		}
+	}
+
+	for _, row := range rows {
+		values := make([]interface{}, 0, len(result.Columns))
+		for _, item := range statement.Items {
+			if item.Star {
+				for _, column := range columns {
+					values = append(values, row[column])
+				}
+				continue
+			}
+
+			value, err := item.Expr.Eval(row)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		result.Rows = append(result.Rows, values)
+	}
+
+	return result, nil
+}
+
+// limit returns how many rows the statement returns, -1 for all of them, and whether QUERY_ROW_LIMIT lowered it.
+func (engine *EngineImpl) limit(statement *Statement) (int, bool) {
+	if engine.rowLimit > 0 && (statement.Limit < 0 || statement.Limit > engine.rowLimit) {
+		return engine.rowLimit, true
+	}
+	return statement.Limit, false
+}
+
+// addMetadata adds the file and partition columns, unless the file has columns of that name itself.
+func addMetadata(row map[string]interface{}, file *models_v1.DataFile) {
+	if _, ok := row[FileColumn]; !ok {
+		row[FileColumn] = file.Location
+	}
+	if _, ok := row[PartitionColumn]; !ok {
+		row[PartitionColumn] = file.Partition
+	}
+}
+
+// pruningFilters returns the conditions ANDed together in where that only use the file and partition columns, a file
+// they don't hold for can't have rows the statement returns.
+func pruningFilters(where Expr) []Expr {
+	filters := make([]Expr, 0)
+	for _, conjunct := range conjuncts(where) {
+		metadataOnly, columns := true, 0
+		walk(conjunct, func(e Expr) {
+			if column, ok := e.(*Column); ok {
+				columns++
+				metadataOnly = metadataOnly && len(column.Path) == 1 &&
+					(column.Path[0] == FileColumn || column.Path[0] == PartitionColumn)
+			}
+		})
+		if metadataOnly && columns > 0 {
+			filters = append(filters, conjunct)
+		}
+	}
+	return filters
+}
+
+func conjuncts(expr Expr) []Expr {
+	if expr == nil {
+		return nil
+	}
+	if logical, ok := expr.(*Logical); ok && logical.Op == "AND" {
+		return append(conjuncts(logical.Left), conjuncts(logical.Right)...)
+	}
+	return []Expr{expr}
+}
+
+func matches(filters []Expr, row map[string]interface{}) (bool, error) {
+	for _, filter := range filters {
+		ok, err := condition(filter, row)
+		if err != nil {
+			return false, err
+		}
+		if ok == nil || !*ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// groups keeps the aggregates of every group in the order the groups were first seen.
+type groups struct {
+	statement *Statement
+	order     []*group
+	byKey     map[string]*group
+}
+
+type group struct {
+	// row is the first row of the group, the grouped by values of every row of the group are the same
+	row          map[string]interface{}
+	accumulators []*accumulator
+}
+
+func newGroups(statement *Statement) *groups {
+	return &groups{statement: statement, byKey: make(map[string]*group)}
+}
+
+func (g *groups) add(row map[string]interface{}) error {
+	values := make([]interface{}, 0, len(g.statement.GroupBy))
+	for _, expr := range g.statement.GroupBy {
+		value, err := expr.Eval(row)
+		if err != nil {
+			return err
+		}
+		values = append(values, normalize(value))
+	}
+	key := describe(values)
+
+	current, ok := g.byKey[key]
+	if !ok {
+		current = g.newGroup(row)
+		g.byKey[key] = current
+		g.order = append(g.order, current)
+	}
+
+	for _, acc := range current.accumulators {
+		if err := acc.add(row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g *groups) newGroup(row map[string]interface{}) *group {
+	current := &group{row: row}
+	seen := make(map[string]bool)
+	for _, item := range g.statement.Items {
+		if item.Star {
+			continue
+		}
+		for _, aggregate := range aggregates(item.Expr) {
+			if !seen[aggregate.key()] {
+				seen[aggregate.key()] = true
+				current.accumulators = append(current.accumulators, &accumulator{aggregate: aggregate})
+			}
+		}
+	}
+	return current
+}
+
+func (g *groups) rows() []map[string]interface{} {
+	// without GROUP BY there's always one group, even when no rows matched
+	if len(g.order) == 0 && len(g.statement.GroupBy) == 0 {
+		g.order = append(g.order, g.newGroup(map[string]interface{}{}))
+	}
+
+	rows := make([]map[string]interface{}, 0, len(g.order))
+	for _, current := range g.order {
+		row := make(map[string]interface{}, len(current.row)+len(current.accumulators))
+		for name, value := range current.row {
+			row[name] = value
+		}
+		for _, acc := range current.accumulators {
+			row[acc.aggregate.key()] = acc.result()
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,252 @@
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/catalog"
+	"github.com/codingexplorations/data-lake/pkg/convert"
+	"github.com/codingexplorations/data-lake/pkg/log"
+	"github.com/codingexplorations/data-lake/pkg/records"
+	"github.com/codingexplorations/data-lake/pkg/registry"
+	"github.com/codingexplorations/data-lake/pkg/stats"
+	"github.com/codingexplorations/data-lake/pkg/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+var ordersSchema = &models_v1.Schema{
+	Format: models_v1.Schema_NDJSON,
+	Fields: []*models_v1.Field{
+		{Name: "id", Type: models_v1.Field_INTEGER},
+		{Name: "status", Type: models_v1.Field_STRING},
+		{Name: "amount", Type: models_v1.Field_NUMBER, Nullable: true},
+		{Name: "created", Type: models_v1.Field_TIMESTAMP},
+	},
+}
+
+// newEngine catalogs a parquet file per partition of the orders dataset, and a CSV and an NDJSON file in the
+// returns dataset.
+func newEngine(t *testing.T) *EngineImpl {
+	folder := t.TempDir()
+	tableCatalog := catalog.NewTableCatalog(storage.NewLocalStorage(), t.TempDir())
+	schemaRegistry := registry.NewLocalSchemaRegistry(t.TempDir())
+
+	partitions := map[string]string{
+		"2023": "{\"id\": 1, \"status\": \"paid\", \"amount\": 10.5, \"created\": \"2023-12-31T10:00:00Z\"}\n" +
+			"{\"id\": 2, \"status\": \"open\", \"amount\": null, \"created\": \"2023-12-31T11:00:00Z\"}\n",
+		"2024": "{\"id\": 3, \"status\": \"paid\", \"amount\": 4, \"created\": \"2024-01-01T09:00:00Z\"}\n" +
+			"{\"id\": 4, \"status\": \"sent\", \"amount\": 5.5, \"created\": \"2024-01-02T09:00:00Z\"}\n" +
+			"{\"id\": 5, \"status\": \"paid\", \"amount\": 1, \"created\": \"2024-01-03T09:00:00Z\"}\n",
+	}
+	for _, partition := range []string{"2023", "2024"} {
+		converter, _ := convert.NewParquetConverter("snappy", 100)
+		collector := stats.NewCollector(ordersSchema)
+		converter.Observe(collector.Add)
+
+		output := &bytes.Buffer{}
+		conversion, err := converter.Convert(ordersSchema, records.NewJsonReader(strings.NewReader(partitions[partition])), output, &models_v1.Lineage{Dataset: "orders"})
+		assert.Nil(t, err)
+
+		location := folder + "/orders/" + partition + "/01.parquet"
+		assert.Nil(t, storage.NewLocalStorage().Write(location, output, convert.ContentType))
+		assert.Nil(t, tableCatalog.Add(&models_v1.DataFile{Location: location, Dataset: "orders", Partition: partition, Format: models_v1.Schema_PARQUET, Records: conversion.Records, Statistics: collector.Statistics()}))
+	}
+
+	csvSchema, err := schemaRegistry.Register("returns", &models_v1.Schema{
+		Format: models_v1.Schema_CSV,
+		Header: true,
+		Fields: []*models_v1.Field{{Name: "order_id", Type: models_v1.Field_INTEGER}, {Name: "reason", Type: models_v1.Field_STRING}},
+	}, registry.Backward)
+	assert.Nil(t, err)
+
+	_ = os.WriteFile(folder+"/returns.csv", []byte("order_id,reason\n3,damaged\n4,late\n"), 0644)
+	_ = os.WriteFile(folder+"/returns.ndjson", []byte("{\"order_id\": 1, \"reason\": \"late\"}\n"), 0644)
+	_ = tableCatalog.Add(&models_v1.DataFile{Location: folder + "/returns.csv", Dataset: "returns", Format: models_v1.Schema_CSV, SchemaVersion: csvSchema.Version})
+	_ = tableCatalog.Add(&models_v1.DataFile{Location: folder + "/returns.ndjson", Dataset: "returns", Format: models_v1.Schema_NDJSON})
+
+	return &EngineImpl{
+		logger:   log.NewConsoleLog(),
+		catalog:  tableCatalog,
+		storage:  storage.NewLocalStorage(),
+		registry: schemaRegistry,
+		rowLimit: 100,
+	}
+}
+
+func TestEngineImpl_Query(t *testing.T) {
+	engine := newEngine(t)
+
+	result, err := engine.Query("SELECT id, amount, created FROM orders WHERE status = 'paid' AND amount > 2")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"id", "amount", "created"}, result.Columns)
+	assert.Equal(t, [][]interface{}{
+		{int64(1), 10.5, time.Date(2023, 12, 31, 10, 0, 0, 0, time.UTC)},
+		{int64(3), 4.0, time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)},
+	}, result.Rows)
+	assert.Equal(t, 2, result.FilesScanned)
+	assert.False(t, result.Truncated)
+
+	// * selects the columns in the order of the files, the curated parquet files sort them by name
+	result, err = engine.Query("SELECT * FROM orders WHERE created >= '2024-01-02' LIMIT 1")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"amount", "created", "id", "status"}, result.Columns)
+	assert.Equal(t, [][]interface{}{{5.5, time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC), int64(4), "sent"}}, result.Rows)
+}
+
+func TestEngineImpl_Query_Aggregates(t *testing.T) {
+	engine := newEngine(t)
+
+	result, err := engine.Query("SELECT status, count(*) orders, count(amount), sum(amount), avg(id), min(created), max(amount) FROM orders GROUP BY status")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"status", "orders", "count(amount)", "sum(amount)", "avg(id)", "min(created)", "max(amount)"}, result.Columns)
+	assert.Equal(t, [][]interface{}{
+		{"paid", int64(3), int64(3), 15.5, 3.0, time.Date(2023, 12, 31, 10, 0, 0, 0, time.UTC), 10.5},
+		{"open", int64(1), int64(0), nil, 2.0, time.Date(2023, 12, 31, 11, 0, 0, 0, time.UTC), nil},
+		{"sent", int64(1), int64(1), 5.5, 4.0, time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC), 5.5},
+	}, result.Rows)
+
+	// without GROUP BY there's a single row, even when nothing matched
+	result, err = engine.Query("SELECT count(*), sum(id) FROM orders WHERE status = 'lost'")
+	assert.Nil(t, err)
+	assert.Equal(t, [][]interface{}{{int64(0), nil}}, result.Rows)
+}
+
+func TestEngineImpl_Query_Pruning(t *testing.T) {
+	engine := newEngine(t)
+
+	result, err := engine.Query("SELECT id, _partition FROM orders WHERE _partition = '2024' AND amount < 5")
+
+	assert.Nil(t, err)
+	assert.Equal(t, [][]interface{}{{int64(3), "2024"}, {int64(5), "2024"}}, result.Rows)
+	assert.Equal(t, 1, result.FilesScanned)
+	assert.Equal(t, 1, result.FilesPruned)
+
+	// conditions ORed with other columns can't prune anything
+	result, err = engine.Query("SELECT id FROM orders WHERE _partition = '2024' OR amount > 10")
+	assert.Nil(t, err)
+	assert.Len(t, result.Rows, 4)
+	assert.Equal(t, 0, result.FilesPruned)
+
+	// files after the one that filled the limit aren't read
+	result, err = engine.Query("SELECT id FROM orders LIMIT 2")
+	assert.Nil(t, err)
+	assert.Len(t, result.Rows, 2)
+	assert.Equal(t, 1, result.FilesScanned)
+}
+
+func TestEngineImpl_Query_StatisticsPruning(t *testing.T) {
+	engine := newEngine(t)
+
+	tests := []struct {
+		sql     string
+		ids     []int64
+		scanned int
+	}{
+		{sql: "SELECT id FROM orders WHERE amount > 6", ids: []int64{1}, scanned: 1},
+		{sql: "SELECT id FROM orders WHERE 6 < amount", ids: []int64{1}, scanned: 1},
+		{sql: "SELECT id FROM orders WHERE created < '2024-01-01'", ids: []int64{1, 2}, scanned: 1},
+		{sql: "SELECT id FROM orders WHERE id BETWEEN 4 AND 10", ids: []int64{4, 5}, scanned: 1},
+		{sql: "SELECT id FROM orders WHERE id IN (7, 8)", ids: []int64{}, scanned: 0},
+		{sql: "SELECT id FROM orders WHERE amount IS NULL", ids: []int64{2}, scanned: 1},
+		{sql: "SELECT id FROM orders WHERE status = 'ordered' OR id = 0", ids: []int64{}, scanned: 1},
+		{sql: "SELECT id FROM orders WHERE status = NULL", ids: []int64{}, scanned: 0},
+		// <> only rules out files where every value is the one compared with
+		{sql: "SELECT id FROM orders WHERE status <> 'paid'", ids: []int64{2, 4}, scanned: 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.sql, func(t *testing.T) {
+			result, err := engine.Query(test.sql)
+
+			assert.Nil(t, err)
+			ids := make([]int64, 0)
+			for _, row := range result.Rows {
+				ids = append(ids, row[0].(int64))
+			}
+			assert.Equal(t, test.ids, ids)
+			assert.Equal(t, test.scanned, result.FilesScanned)
+			assert.Equal(t, 2-test.scanned, result.FilesPruned)
+		})
+	}
+}
+
+func TestEngineImpl_Profile(t *testing.T) {
+	engine := newEngine(t)
+
+	profile, err := engine.Profile("orders")
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), profile.Rows)
+	assert.Equal(t, 2, profile.Files)
+	assert.Equal(t, []*ColumnProfile{
+		{Name: "id", Type: "INTEGER", Values: 5, Distinct: 5, Min: int64(1), Max: int64(5)},
+		{Name: "status", Type: "STRING", Values: 5, Distinct: 3, Min: "open", Max: "sent"},
+		{Name: "amount", Type: "NUMBER", Values: 4, Nulls: 1, Distinct: 4, Min: 1.0, Max: 10.5},
+		{Name: "created", Type: "TIMESTAMP", Values: 5, Distinct: 5, Min: time.Date(2023, 12, 31, 10, 0, 0, 0, time.UTC), Max: time.Date(2024, 1, 3, 9, 0, 0, 0, time.UTC)},
+	}, profile.Columns)
+
+	// files catalogued without statistics are counted, but can't be profiled
+	profile, err = engine.Profile("returns")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, profile.FilesWithoutStatistics)
+	assert.Empty(t, profile.Columns)
+
+	_, err = engine.Profile("customers")
+	assert.True(t, errors.Is(err, ErrDatasetNotFound))
+}
+
+func TestEngineImpl_Query_RowLimit(t *testing.T) {
+	engine := newEngine(t)
+	engine.rowLimit = 3
+
+	result, err := engine.Query("SELECT id FROM orders")
+	assert.Nil(t, err)
+	assert.Len(t, result.Rows, 3)
+	assert.True(t, result.Truncated)
+
+	result, err = engine.Query("SELECT id FROM orders WHERE id > 2")
+	assert.Nil(t, err)
+	assert.Len(t, result.Rows, 3)
+	assert.False(t, result.Truncated)
+}
+
+func TestEngineImpl_Query_Records(t *testing.T) {
+	engine := newEngine(t)
+
+	result, err := engine.Query("SELECT order_id, reason FROM returns WHERE order_id IN (1, 3) OR reason LIKE 'l_t%'")
+
+	assert.Nil(t, err)
+	// CSV values are typed by the registered schema, the NDJSON file has none and keeps its JSON numbers
+	assert.Equal(t, [][]interface{}{{int64(3), "damaged"}, {int64(4), "late"}, {json.Number("1"), "late"}}, result.Rows)
+
+	result, err = engine.Query("SELECT sum(order_id) FROM returns")
+	assert.Nil(t, err)
+	assert.Equal(t, [][]interface{}{{int64(8)}}, result.Rows)
+}
+
+func TestEngineImpl_Query_Failure(t *testing.T) {
+	engine := newEngine(t)
+
+	_, err := engine.Query("SELECT id FROM customers")
+	assert.True(t, errors.Is(err, ErrDatasetNotFound))
+	assert.Equal(t, "catalog has no dataset customers: dataset not found", err.Error())
+
+	_, err = engine.Query("SELECT id FROM orders WHERE status > 1")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "can't compare \"paid\" with 1 in status > 1")
+
+	_, err = engine.Query("SELECT sum(status) FROM orders")
+	assert.Contains(t, err.Error(), "sum(status): \"paid\" is not a number")
+
+	var syntaxErr *SyntaxError
+	_, err = engine.Query("SELECT FROM orders")
+	assert.ErrorAs(t, err, &syntaxErr)
+}
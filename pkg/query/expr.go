@@ -0,0 +1,381 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codingexplorations/data-lake/pkg/schema"
+)
+
+// Expr is a scalar expression evaluated against a single row. Rows map column names to values, nested objects are
+// maps themselves. A nil value is SQL NULL.
+type Expr interface {
+	Eval(row map[string]interface{}) (interface{}, error)
+	String() string
+}
+
+// Column reads a field of the row, Path has more than one element for fields of nested objects.
+type Column struct {
+	Path []string
+}
+
+func (c *Column) Eval(row map[string]interface{}) (interface{}, error) {
+	var value interface{} = row
+	for _, name := range c.Path {
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, nil
+		}
+		value = fields[name]
+	}
+	return value, nil
+}
+
+func (c *Column) String() string {
+	return strings.Join(c.Path, ".")
+}
+
+type Literal struct {
+	Value interface{}
+}
+
+func (l *Literal) Eval(map[string]interface{}) (interface{}, error) {
+	return l.Value, nil
+}
+
+func (l *Literal) String() string {
+	switch v := l.Value.(type) {
+	case nil:
+		return "NULL"
+	case string:
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	case bool:
+		return strings.ToUpper(strconv.FormatBool(v))
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// Comparison compares two values with =, <>, <, <=, > or >=. Comparing with NULL is NULL.
+type Comparison struct {
+	Op          string
+	Left, Right Expr
+}
+
+func (c *Comparison) Eval(row map[string]interface{}) (interface{}, error) {
+	left, err := c.Left.Eval(row)
+	if err != nil {
+		return nil, err
+	}
+	right, err := c.Right.Eval(row)
+	if err != nil {
+		return nil, err
+	}
+	if left == nil || right == nil {
+		return nil, nil
+	}
+
+	result, ok := compare(left, right)
+	if !ok {
+		return nil, fmt.Errorf("can't compare %v with %v in %v", describe(left), describe(right), c)
+	}
+
+	switch c.Op {
+	case "=":
+		return result == 0, nil
+	case "<>", "!=":
+		return result != 0, nil
+	case "<":
+		return result < 0, nil
+	case "<=":
+		return result <= 0, nil
+	case ">":
+		return result > 0, nil
+	default:
+		return result >= 0, nil
+	}
+}
+
+func (c *Comparison) String() string {
+	return fmt.Sprintf("%v %v %v", c.Left, c.Op, c.Right)
+}
+
+// Logical combines conditions with AND or OR using SQL's three valued logic.
+type Logical struct {
+	Op          string
+	Left, Right Expr
+}
+
+func (l *Logical) Eval(row map[string]interface{}) (interface{}, error) {
+	left, err := condition(l.Left, row)
+	if err != nil {
+		return nil, err
+	}
+	// short circuit, the right side can't change the result
+	if left != nil && *left == (l.Op == "OR") {
+		return *left, nil
+	}
+
+	right, err := condition(l.Right, row)
+	if err != nil {
+		return nil, err
+	}
+	if right != nil && *right == (l.Op == "OR") {
+		return *right, nil
+	}
+	if left == nil || right == nil {
+		return nil, nil
+	}
+	return *right, nil
+}
+
+func (l *Logical) String() string {
+	return fmt.Sprintf("(%v %v %v)", l.Left, l.Op, l.Right)
+}
+
+type Not struct {
+	Expr Expr
+}
+
+func (n *Not) Eval(row map[string]interface{}) (interface{}, error) {
+	value, err := condition(n.Expr, row)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return !*value, nil
+}
+
+func (n *Not) String() string {
+	return fmt.Sprintf("NOT %v", n.Expr)
+}
+
+type IsNull struct {
+	Expr   Expr
+	Negate bool
+}
+
+func (i *IsNull) Eval(row map[string]interface{}) (interface{}, error) {
+	value, err := i.Expr.Eval(row)
+	if err != nil {
+		return nil, err
+	}
+	return (value == nil) != i.Negate, nil
+}
+
+func (i *IsNull) String() string {
+	if i.Negate {
+		return fmt.Sprintf("%v IS NOT NULL", i.Expr)
+	}
+	return fmt.Sprintf("%v IS NULL", i.Expr)
+}
+
+type In struct {
+	Expr   Expr
+	Values []Expr
+	Negate bool
+}
+
+func (in *In) Eval(row map[string]interface{}) (interface{}, error) {
+	value, err := in.Expr.Eval(row)
+	if err != nil || value == nil {
+		return nil, err
+	}
+
+	for _, expr := range in.Values {
+		candidate, err := expr.Eval(row)
+		if err != nil {
+			return nil, err
+		}
+		if candidate == nil {
+			continue
+		}
+		if result, ok := compare(value, candidate); ok && result == 0 {
+			return !in.Negate, nil
+		}
+	}
+	return in.Negate, nil
+}
+
+func (in *In) String() string {
+	values := make([]string, 0, len(in.Values))
+	for _, value := range in.Values {
+		values = append(values, value.String())
+	}
+	op := "IN"
+	if in.Negate {
+		op = "NOT IN"
+	}
+	return fmt.Sprintf("%v %v (%v)", in.Expr, op, strings.Join(values, ", "))
+}
+
+// Like matches strings against a pattern where % matches any run of characters and _ any single character.
+type Like struct {
+	Expr    Expr
+	Pattern string
+	Negate  bool
+	regexp  *regexp.Regexp
+}
+
+func NewLike(expr Expr, pattern string, negate bool) *Like {
+	quoted := regexp.QuoteMeta(pattern)
+	quoted = strings.ReplaceAll(quoted, "%", ".*")
+	quoted = strings.ReplaceAll(quoted, "_", ".")
+	return &Like{Expr: expr, Pattern: pattern, Negate: negate, regexp: regexp.MustCompile("(?s)^" + quoted + "$")}
+}
+
+func (l *Like) Eval(row map[string]interface{}) (interface{}, error) {
+	value, err := l.Expr.Eval(row)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return l.regexp.MatchString(text(value)) != l.Negate, nil
+}
+
+func (l *Like) String() string {
+	op := "LIKE"
+	if l.Negate {
+		op = "NOT LIKE"
+	}
+	return fmt.Sprintf("%v %v %v", l.Expr, op, (&Literal{Value: l.Pattern}).String())
+}
+
+// condition evaluates an expression used as a condition, nil meaning NULL.
+func condition(expr Expr, row map[string]interface{}) (*bool, error) {
+	value, err := expr.Eval(row)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	b, ok := value.(bool)
+	if !ok {
+		return nil, fmt.Errorf("%v is not a condition", expr)
+	}
+	return &b, nil
+}
+
+// compare orders two non-null values. CSV values are strings, so a string compared with a number, boolean or
+// timestamp is read as one.
+func compare(left, right interface{}) (int, bool) {
+	left, right = normalize(left), normalize(right)
+
+	switch l := left.(type) {
+	case float64:
+		if r, ok := number(right); ok {
+			return compareFloats(l, r), true
+		}
+	case bool:
+		if r, ok := boolean(right); ok {
+			return compareBools(l, r), true
+		}
+	case time.Time:
+		if r, ok := timestamp(right); ok {
+			return l.Compare(r), true
+		}
+	case string:
+		switch r := right.(type) {
+		case string:
+			return strings.Compare(l, r), true
+		case float64, bool, time.Time:
+			result, ok := compare(right, left)
+			return -result, ok
+		}
+	}
+
+	return 0, false
+}
+
+// normalize turns every number into a float64 so they compare with each other.
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	case json.Number:
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+	}
+	return value
+}
+
+func number(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func boolean(value interface{}) (bool, bool) {
+	switch v := value.(type) {
+	case bool:
+		return v, true
+	case string:
+		b, err := strconv.ParseBool(strings.TrimSpace(v))
+		return b, err == nil
+	}
+	return false, false
+}
+
+func timestamp(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		t, err := schema.ParseTimestamp(strings.TrimSpace(v))
+		return t, err == nil
+	}
+	return time.Time{}, false
+}
+
+func compareFloats(l, r float64) int {
+	switch {
+	case l < r:
+		return -1
+	case l > r:
+		return 1
+	}
+	return 0
+}
+
+func compareBools(l, r bool) int {
+	switch {
+	case l == r:
+		return 0
+	case !l:
+		return -1
+	}
+	return 1
+}
+
+// text is the string a value is matched against in LIKE.
+func text(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case map[string]interface{}, []interface{}:
+		return describe(v)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+func describe(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
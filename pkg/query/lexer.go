@@ -0,0 +1,126 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenKeyword
+	tokenString
+	tokenNumber
+	tokenSymbol
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	start int
+}
+
+var keywords = map[string]bool{
+	"SELECT": true, "FROM": true, "WHERE": true, "GROUP": true, "BY": true, "LIMIT": true, "AS": true,
+	"AND": true, "OR": true, "NOT": true, "IS": true, "NULL": true, "IN": true, "LIKE": true, "BETWEEN": true,
+	"TRUE": true, "FALSE": true,
+}
+
+// SyntaxError is returned for statements that aren't valid SQL, or use parts of SQL we don't support. Position is the
+// offset of the offending token, or -1 when the statement as a whole is at fault.
+type SyntaxError struct {
+	Position int
+	Message  string
+}
+
+func (e *SyntaxError) Error() string {
+	if e.Position < 0 {
+		return fmt.Sprintf("syntax error: %v", e.Message)
+	}
+	return fmt.Sprintf("syntax error at position %d: %v", e.Position+1, e.Message)
+}
+
+// lex splits a statement into tokens. Keywords are upper cased, identifiers keep their case and can be quoted with
+// double quotes or backticks.
+func lex(sql string) ([]token, error) {
+	tokens := make([]token, 0)
+	runes := []rune(sql)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '\'':
+			text, end, err := quoted(runes, i, '\'')
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenString, text: text, start: i})
+			i = end
+		case r == '"' || r == '`':
+			text, end, err := quoted(runes, i, r)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: text, start: i})
+			i = end
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.' || runes[i] == 'e' || runes[i] == 'E' ||
+				((runes[i] == '-' || runes[i] == '+') && (runes[i-1] == 'e' || runes[i-1] == 'E'))) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: string(runes[start:i]), start: start})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '-') {
+				i++
+			}
+			text := string(runes[start:i])
+			if keywords[strings.ToUpper(text)] {
+				tokens = append(tokens, token{kind: tokenKeyword, text: strings.ToUpper(text), start: start})
+			} else {
+				tokens = append(tokens, token{kind: tokenIdent, text: text, start: start})
+			}
+		default:
+			start := i
+			text := string(r)
+			if i+1 < len(runes) {
+				switch pair := string(runes[i : i+2]); pair {
+				case "<=", ">=", "<>", "!=":
+					text = pair
+				}
+			}
+			if !strings.Contains("(),.*=<>!;-", text[:1]) {
+				return nil, &SyntaxError{Position: start, Message: fmt.Sprintf("unexpected character %q", r)}
+			}
+			i += len([]rune(text))
+			tokens = append(tokens, token{kind: tokenSymbol, text: text, start: start})
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, start: len(runes)}), nil
+}
+
+// quoted reads a quoted string or identifier starting at runes[start], a doubled quote stands for the quote itself.
+func quoted(runes []rune, start int, quote rune) (string, int, error) {
+	text := strings.Builder{}
+	for i := start + 1; i < len(runes); i++ {
+		if runes[i] != quote {
+			text.WriteRune(runes[i])
+			continue
+		}
+		if i+1 < len(runes) && runes[i+1] == quote {
+			text.WriteRune(quote)
+			i++
+			continue
+		}
+		return text.String(), i + 1, nil
+	}
+	return "", 0, &SyntaxError{Position: start, Message: "unterminated quoted text"}
+}
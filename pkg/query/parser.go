@@ -0,0 +1,459 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Statement is a parsed SELECT. Limit is -1 when the statement has no LIMIT.
+type Statement struct {
+	Items   []*SelectItem
+	Dataset string
+	Where   Expr
+	GroupBy []Expr
+	Limit   int
+}
+
+// SelectItem is an expression of the select list, or * for every column.
+type SelectItem struct {
+	Expr  Expr
+	Alias string
+	Star  bool
+}
+
+// Name is the column name the item gets in the result.
+func (item *SelectItem) Name() string {
+	if item.Alias != "" {
+		return item.Alias
+	}
+	return item.Expr.String()
+}
+
+// Aggregated reports whether the statement computes aggregates rather than returning rows.
+func (statement *Statement) Aggregated() bool {
+	if len(statement.GroupBy) > 0 {
+		return true
+	}
+	for _, item := range statement.Items {
+		if !item.Star && len(aggregates(item.Expr)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// Parse parses a single SELECT statement:
+//
+//	SELECT * | expr [[AS] alias], ... FROM dataset [WHERE condition] [GROUP BY expr, ...] [LIMIT n]
+func Parse(sql string) (*Statement, error) {
+	tokens, err := lex(sql)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	statement, err := p.statement()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validate(statement); err != nil {
+		return nil, err
+	}
+
+	return statement, nil
+}
+
+func (p *parser) statement() (*Statement, error) {
+	statement := &Statement{Limit: -1}
+
+	if err := p.expectKeyword("SELECT"); err != nil {
+		return nil, err
+	}
+
+	for {
+		item, err := p.selectItem()
+		if err != nil {
+			return nil, err
+		}
+		statement.Items = append(statement.Items, item)
+		if !p.acceptSymbol(",") {
+			break
+		}
+	}
+
+	if err := p.expectKeyword("FROM"); err != nil {
+		return nil, err
+	}
+	dataset := p.next()
+	if dataset.kind != tokenIdent {
+		return nil, p.unexpected(dataset, "a dataset name")
+	}
+	statement.Dataset = dataset.text
+
+	if p.acceptKeyword("WHERE") {
+		where, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		statement.Where = where
+	}
+
+	if p.acceptKeyword("GROUP") {
+		if err := p.expectKeyword("BY"); err != nil {
+			return nil, err
+		}
+		for {
+			expr, err := p.or()
+			if err != nil {
+				return nil, err
+			}
+			statement.GroupBy = append(statement.GroupBy, expr)
+			if !p.acceptSymbol(",") {
+				break
+			}
+		}
+	}
+
+	if p.acceptKeyword("LIMIT") {
+		limit := p.next()
+		n, err := strconv.Atoi(limit.text)
+		if limit.kind != tokenNumber || err != nil || n < 0 {
+			return nil, p.unexpected(limit, "a row count")
+		}
+		statement.Limit = n
+	}
+
+	p.acceptSymbol(";")
+	if end := p.next(); end.kind != tokenEOF {
+		return nil, p.unexpected(end, "the end of the statement")
+	}
+
+	return statement, nil
+}
+
+func (p *parser) selectItem() (*SelectItem, error) {
+	if p.acceptSymbol("*") {
+		return &SelectItem{Star: true}, nil
+	}
+
+	expr, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	item := &SelectItem{Expr: expr}
+
+	if p.acceptKeyword("AS") {
+		alias := p.next()
+		if alias.kind != tokenIdent {
+			return nil, p.unexpected(alias, "an alias")
+		}
+		item.Alias = alias.text
+	} else if p.peek().kind == tokenIdent {
+		item.Alias = p.next().text
+	}
+
+	return item, nil
+}
+
+func (p *parser) or() (Expr, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptKeyword("OR") {
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		left = &Logical{Op: "OR", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) and() (Expr, error) {
+	left, err := p.not()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptKeyword("AND") {
+		right, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		left = &Logical{Op: "AND", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) not() (Expr, error) {
+	if p.acceptKeyword("NOT") {
+		expr, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		return &Not{Expr: expr}, nil
+	}
+	return p.predicate()
+}
+
+func (p *parser) predicate() (Expr, error) {
+	left, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.kind == tokenSymbol {
+		switch t.text {
+		case "=", "<>", "!=", "<", "<=", ">", ">=":
+			p.next()
+			right, err := p.operand()
+			if err != nil {
+				return nil, err
+			}
+			return &Comparison{Op: t.text, Left: left, Right: right}, nil
+		}
+	}
+
+	if p.acceptKeyword("IS") {
+		negate := p.acceptKeyword("NOT")
+		if err := p.expectKeyword("NULL"); err != nil {
+			return nil, err
+		}
+		return &IsNull{Expr: left, Negate: negate}, nil
+	}
+
+	negate := p.acceptKeyword("NOT")
+	switch {
+	case p.acceptKeyword("IN"):
+		if err := p.expectSymbol("("); err != nil {
+			return nil, err
+		}
+		in := &In{Expr: left, Negate: negate}
+		for {
+			value, err := p.operand()
+			if err != nil {
+				return nil, err
+			}
+			in.Values = append(in.Values, value)
+			if !p.acceptSymbol(",") {
+				break
+			}
+		}
+		if err := p.expectSymbol(")"); err != nil {
+			return nil, err
+		}
+		return in, nil
+	case p.acceptKeyword("LIKE"):
+		pattern := p.next()
+		if pattern.kind != tokenString {
+			return nil, p.unexpected(pattern, "a pattern")
+		}
+		return NewLike(left, pattern.text, negate), nil
+	case p.acceptKeyword("BETWEEN"):
+		low, err := p.operand()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectKeyword("AND"); err != nil {
+			return nil, err
+		}
+		high, err := p.operand()
+		if err != nil {
+			return nil, err
+		}
+		var between Expr = &Logical{
+			Op:    "AND",
+			Left:  &Comparison{Op: ">=", Left: left, Right: low},
+			Right: &Comparison{Op: "<=", Left: left, Right: high},
+		}
+		if negate {
+			between = &Not{Expr: between}
+		}
+		return between, nil
+	case negate:
+		return nil, p.unexpected(p.peek(), "IN, LIKE or BETWEEN")
+	}
+
+	return left, nil
+}
+
+func (p *parser) operand() (Expr, error) {
+	t := p.next()
+
+	switch t.kind {
+	case tokenString:
+		return &Literal{Value: t.text}, nil
+	case tokenNumber:
+		return numberLiteral(t)
+	case tokenKeyword:
+		switch t.text {
+		case "TRUE":
+			return &Literal{Value: true}, nil
+		case "FALSE":
+			return &Literal{Value: false}, nil
+		case "NULL":
+			return &Literal{Value: nil}, nil
+		}
+	case tokenSymbol:
+		switch t.text {
+		case "-":
+			n := p.next()
+			if n.kind != tokenNumber {
+				return nil, p.unexpected(n, "a number")
+			}
+			n.text = "-" + n.text
+			return numberLiteral(n)
+		case "(":
+			expr, err := p.or()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectSymbol(")"); err != nil {
+				return nil, err
+			}
+			return expr, nil
+		}
+	case tokenIdent:
+		if p.peek().kind == tokenSymbol && p.peek().text == "(" {
+			return p.call(t)
+		}
+		column := &Column{Path: []string{t.text}}
+		for p.acceptSymbol(".") {
+			field := p.next()
+			if field.kind != tokenIdent {
+				return nil, p.unexpected(field, "a field name")
+			}
+			column.Path = append(column.Path, field.text)
+		}
+		return column, nil
+	}
+
+	return nil, p.unexpected(t, "a column or value")
+}
+
+func (p *parser) call(name token) (Expr, error) {
+	p.next()
+
+	aggregate := &Aggregate{Func: strings.ToUpper(name.text)}
+	if !aggregateFunctions[aggregate.Func] {
+		return nil, &SyntaxError{Position: name.start, Message: fmt.Sprintf("unknown function %v", name.text)}
+	}
+
+	if aggregate.Func == "COUNT" && p.acceptSymbol("*") {
+		return aggregate, p.expectSymbol(")")
+	}
+
+	arg, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	aggregate.Arg = arg
+
+	return aggregate, p.expectSymbol(")")
+}
+
+func numberLiteral(t token) (Expr, error) {
+	if i, err := strconv.ParseInt(t.text, 10, 64); err == nil {
+		return &Literal{Value: i}, nil
+	}
+	f, err := strconv.ParseFloat(t.text, 64)
+	if err != nil {
+		return nil, &SyntaxError{Position: t.start, Message: fmt.Sprintf("invalid number %v", t.text)}
+	}
+	return &Literal{Value: f}, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) acceptKeyword(keyword string) bool {
+	if t := p.peek(); t.kind == tokenKeyword && t.text == keyword {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) acceptSymbol(symbol string) bool {
+	if t := p.peek(); t.kind == tokenSymbol && t.text == symbol {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expectKeyword(keyword string) error {
+	if !p.acceptKeyword(keyword) {
+		return p.unexpected(p.peek(), keyword)
+	}
+	return nil
+}
+
+func (p *parser) expectSymbol(symbol string) error {
+	if !p.acceptSymbol(symbol) {
+		return p.unexpected(p.peek(), fmt.Sprintf("%q", symbol))
+	}
+	return nil
+}
+
+func (p *parser) unexpected(t token, expected string) error {
+	found := fmt.Sprintf("%q", t.text)
+	if t.kind == tokenEOF {
+		found = "end of statement"
+	}
+	return &SyntaxError{Position: t.start, Message: fmt.Sprintf("expected %v, found %v", expected, found)}
+}
+
+// validate checks what the grammar can't: where aggregates are used, and that columns outside of aggregates are
+// grouped by.
+func validate(statement *Statement) error {
+	if len(aggregates(statement.Where)) > 0 {
+		return &SyntaxError{Position: -1, Message: "aggregates can't be used in WHERE"}
+	}
+
+	grouped := make(map[string]bool)
+	for _, expr := range statement.GroupBy {
+		if len(aggregates(expr)) > 0 {
+			return &SyntaxError{Position: -1, Message: "aggregates can't be used in GROUP BY"}
+		}
+		grouped[expr.String()] = true
+	}
+
+	aggregated := statement.Aggregated()
+	for _, item := range statement.Items {
+		if item.Star {
+			if aggregated {
+				return &SyntaxError{Position: -1, Message: "* can't be selected with aggregates or GROUP BY"}
+			}
+			continue
+		}
+
+		for _, aggregate := range aggregates(item.Expr) {
+			if len(aggregates(aggregate.Arg)) > 0 {
+				return &SyntaxError{Position: -1, Message: fmt.Sprintf("aggregates can't be nested in %v", aggregate)}
+			}
+		}
+
+		if aggregated && len(aggregates(item.Expr)) == 0 && !grouped[item.Expr.String()] {
+			return &SyntaxError{Position: -1, Message: fmt.Sprintf("%v must be aggregated or appear in GROUP BY", item.Expr)}
+		}
+	}
+
+	return nil
+}
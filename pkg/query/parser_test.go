@@ -0,0 +1,70 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	statement, err := Parse("select id, customer.name AS name, count(*) from orders where amount >= 10.5 and status in ('paid', 'sent') or note is not null group by id, customer.name limit 5;")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "orders", statement.Dataset)
+	assert.Equal(t, 5, statement.Limit)
+	assert.Len(t, statement.Items, 3)
+	assert.Equal(t, "id", statement.Items[0].Name())
+	assert.Equal(t, "name", statement.Items[1].Name())
+	assert.Equal(t, "count(*)", statement.Items[2].Name())
+	assert.Equal(t, "((amount >= 10.5 AND status IN ('paid', 'sent')) OR note IS NOT NULL)", statement.Where.String())
+	assert.Len(t, statement.GroupBy, 2)
+	assert.True(t, statement.Aggregated())
+}
+
+func TestParse_Predicates(t *testing.T) {
+	tests := []struct {
+		sql   string
+		where string
+	}{
+		{sql: "SELECT * FROM orders WHERE amount BETWEEN -1 AND 2e3", where: "(amount >= -1 AND amount <= 2000)"},
+		{sql: "SELECT * FROM orders WHERE NOT paid = TRUE", where: "NOT paid = TRUE"},
+		{sql: "SELECT * FROM orders WHERE note NOT LIKE 'it''s%'", where: "note NOT LIKE 'it''s%'"},
+		{sql: "SELECT * FROM \"order items\" WHERE `group` <> NULL", where: "group <> NULL"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.sql, func(t *testing.T) {
+			statement, err := Parse(tc.sql)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.where, statement.Where.String())
+			assert.Equal(t, -1, statement.Limit)
+			assert.False(t, statement.Aggregated())
+		})
+	}
+}
+
+func TestParse_Failure(t *testing.T) {
+	tests := []struct {
+		sql string
+		err string
+	}{
+		{sql: "DELETE FROM orders", err: "syntax error at position 1: expected SELECT, found \"DELETE\""},
+		{sql: "SELECT id FROM", err: "syntax error at position 15: expected a dataset name, found end of statement"},
+		{sql: "SELECT id FROM orders LIMIT -1", err: "syntax error at position 29: expected a row count, found \"-\""},
+		{sql: "SELECT id FROM orders WHERE note = 'open", err: "syntax error at position 36: unterminated quoted text"},
+		{sql: "SELECT upper(id) FROM orders", err: "syntax error at position 8: unknown function upper"},
+		{sql: "SELECT id, count(*) FROM orders", err: "syntax error: id must be aggregated or appear in GROUP BY"},
+		{sql: "SELECT * FROM orders GROUP BY id", err: "syntax error: * can't be selected with aggregates or GROUP BY"},
+		{sql: "SELECT id FROM orders WHERE count(*) > 1", err: "syntax error: aggregates can't be used in WHERE"},
+		{sql: "SELECT sum(count(*)) FROM orders", err: "syntax error: aggregates can't be nested in sum(count(*))"},
+		{sql: "SELECT id FROM orders orders", err: "syntax error at position 23: expected the end of the statement, found \"orders\""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.sql, func(t *testing.T) {
+			statement, err := Parse(tc.sql)
+			assert.Nil(t, statement)
+			assert.Equal(t, tc.err, err.Error())
+		})
+	}
+}
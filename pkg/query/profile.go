@@ -0,0 +1,86 @@
+package query
+
+import (
+	"fmt"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/stats"
+)
+
+// Profile describes the content of a dataset from the statistics the catalog keeps of its files, no file is read.
+type Profile struct {
+	Dataset string `json:"dataset"`
+	Files   int    `json:"files"`
+	// FilesWithoutStatistics counts the files catalogued before statistics were collected, they aren't profiled
+	FilesWithoutStatistics int              `json:"files_without_statistics"`
+	Rows                   int64            `json:"rows"`
+	Columns                []*ColumnProfile `json:"columns"`
+}
+
+type ColumnProfile struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Values   int64  `json:"values"`
+	Nulls    int64  `json:"nulls"`
+	Distinct int64  `json:"distinct"`
+	// Min and Max are null when they aren't known
+	Min interface{} `json:"min"`
+	Max interface{} `json:"max"`
+}
+
+func (engine *EngineImpl) Profile(dataset string) (*Profile, error) {
+	datasets, err := engine.catalog.Datasets()
+	if err != nil {
+		return nil, err
+	}
+	if !contains(datasets, dataset) {
+		return nil, fmt.Errorf("catalog has no dataset %v: %w", dataset, ErrDatasetNotFound)
+	}
+
+	files, err := engine.catalog.Files(dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &Profile{Dataset: dataset, Files: len(files), Columns: make([]*ColumnProfile, 0)}
+	statistics := make([]*models_v1.Statistics, 0, len(files))
+	for _, file := range files {
+		if file.Statistics == nil {
+			profile.FilesWithoutStatistics++
+			continue
+		}
+		statistics = append(statistics, file.Statistics)
+	}
+
+	merged := stats.Merge(statistics...)
+	if merged == nil {
+		return profile, nil
+	}
+
+	profile.Rows = merged.Rows
+	for _, c := range merged.Columns {
+		profile.Columns = append(profile.Columns, &ColumnProfile{
+			Name:     c.Name,
+			Type:     c.Type.String(),
+			Values:   c.Values,
+			Nulls:    c.Nulls,
+			Distinct: c.Distinct,
+			Min:      stats.BoundValue(c.Min),
+			Max:      stats.BoundValue(c.Max),
+		})
+	}
+
+	return profile, nil
+}
+
+// Table returns the columns of the profile as rows of a table with ProfileColumns.
+func (profile *Profile) Table() [][]interface{} {
+	rows := make([][]interface{}, 0, len(profile.Columns))
+	for _, c := range profile.Columns {
+		rows = append(rows, []interface{}{c.Name, c.Type, c.Values, c.Nulls, c.Distinct, c.Min, c.Max})
+	}
+	return rows
+}
+
+// ProfileColumns are the columns of the rows Profile.Table returns.
+var ProfileColumns = []string{"column", "type", "values", "nulls", "distinct", "min", "max"}
@@ -0,0 +1,84 @@
+package query
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/convert"
+	"github.com/codingexplorations/data-lake/pkg/records"
+	"github.com/codingexplorations/data-lake/pkg/storage"
+)
+
+// scan calls fn with every row of a file until it returns false, and returns the columns of the file in order.
+func (engine *EngineImpl) scan(file *models_v1.DataFile, fn func(row map[string]interface{}) bool) ([]string, error) {
+	opened, err := engine.storage.Open(file.Location)
+	if err != nil {
+		return nil, err
+	}
+	defer opened.Close()
+
+	switch file.Format {
+	case models_v1.Schema_PARQUET:
+		return convert.ParquetRows(opened, opened.Size(), fn)
+	case models_v1.Schema_CSV, models_v1.Schema_JSON, models_v1.Schema_NDJSON:
+		body := bufio.NewReaderSize(io.NewSectionReader(opened, 0, opened.Size()), storage.ReadChunkSize)
+		return scanRecords(engine.schema(file), body, fn)
+	default:
+		return nil, fmt.Errorf("unsupported file format %v", file.Format)
+	}
+}
+
+// schema returns the registered schema of a CSV or JSON file, or one that reads it without knowing its fields.
+func (engine *EngineImpl) schema(file *models_v1.DataFile) *models_v1.Schema {
+	if engine.registry != nil {
+		if schema, err := engine.registry.Get(file.Dataset, file.SchemaVersion); err == nil && schema.GetFormat() == file.Format {
+			return schema
+		}
+	}
+	return &models_v1.Schema{Format: file.Format, Header: true}
+}
+
+func scanRecords(schema *models_v1.Schema, body io.Reader, fn func(row map[string]interface{}) bool) ([]string, error) {
+	reader, err := records.NewReader(schema, body)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]string, 0, len(schema.GetFields()))
+	for _, field := range schema.GetFields() {
+		columns = append(columns, field.GetName())
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		row := record.Fields
+		if len(schema.GetFields()) > 0 {
+			// typed values compare and aggregate the way the curated parquet files do
+			if values, err := convert.Values(schema, record.Fields); err == nil {
+				row = values
+			}
+		} else if len(columns) == 0 {
+			for name := range record.Fields {
+				columns = append(columns, name)
+			}
+			sort.Strings(columns)
+		}
+
+		if !fn(row) {
+			break
+		}
+	}
+
+	return columns, nil
+}
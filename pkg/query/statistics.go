@@ -0,0 +1,164 @@
+package query
+
+import (
+	"strings"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/stats"
+)
+
+// excluded reports whether the statistics of a file show that none of its rows satisfy where. Conditions on columns
+// the file has no statistics for never exclude it.
+func excluded(where Expr, statistics *models_v1.Statistics) bool {
+	if statistics == nil {
+		return false
+	}
+	for _, conjunct := range conjuncts(where) {
+		if impossible(conjunct, statistics) {
+			return true
+		}
+	}
+	return false
+}
+
+// impossible reports whether expr can't be true for any row the statistics describe.
+func impossible(expr Expr, statistics *models_v1.Statistics) bool {
+	switch e := expr.(type) {
+	case *Logical:
+		if e.Op == "AND" {
+			return impossible(e.Left, statistics) || impossible(e.Right, statistics)
+		}
+		return impossible(e.Left, statistics) && impossible(e.Right, statistics)
+	case *Comparison:
+		column, op, value, ok := columnComparison(e)
+		if !ok {
+			return false
+		}
+		c := columnStatistics(statistics, column)
+		if c == nil {
+			return false
+		}
+		// comparisons with NULL are never true
+		return value == nil || c.Values == 0 || outside(c, op, value)
+	case *IsNull:
+		column, ok := e.Expr.(*Column)
+		if !ok {
+			return false
+		}
+		c := columnStatistics(statistics, column)
+		if c == nil {
+			return false
+		}
+		if e.Negate {
+			return c.Values == 0
+		}
+		return c.Nulls == 0
+	case *In:
+		column, ok := e.Expr.(*Column)
+		if !ok || e.Negate {
+			return false
+		}
+		c := columnStatistics(statistics, column)
+		if c == nil {
+			return false
+		}
+		if c.Values == 0 {
+			return true
+		}
+		for _, item := range e.Values {
+			literal, ok := item.(*Literal)
+			if !ok || (literal.Value != nil && !outside(c, "=", literal.Value)) {
+				return false
+			}
+		}
+		return true
+	case *Like:
+		column, ok := e.Expr.(*Column)
+		if !ok {
+			return false
+		}
+		c := columnStatistics(statistics, column)
+		return c != nil && c.Values == 0
+	}
+	return false
+}
+
+// columnComparison returns the column, operator and value of a comparison of a column with a literal, with the
+// column on the left.
+func columnComparison(comparison *Comparison) (*Column, string, interface{}, bool) {
+	if column, ok := comparison.Left.(*Column); ok {
+		if literal, ok := comparison.Right.(*Literal); ok {
+			return column, comparison.Op, literal.Value, true
+		}
+	}
+	if column, ok := comparison.Right.(*Column); ok {
+		if literal, ok := comparison.Left.(*Literal); ok {
+			return column, flipped[comparison.Op], literal.Value, true
+		}
+	}
+	return nil, "", nil, false
+}
+
+var flipped = map[string]string{"=": "=", "<>": "<>", "!=": "!=", "<": ">", "<=": ">=", ">": "<", ">=": "<="}
+
+// outside reports whether no value between the min and max of a column compares to value as op asks.
+func outside(c *models_v1.ColumnStatistics, op string, value interface{}) bool {
+	min, hasMin := bound(c.Min, value)
+	max, hasMax := bound(c.Max, value)
+
+	switch op {
+	case "=":
+		return (hasMin && min > 0) || (hasMax && max < 0)
+	case "<":
+		return hasMin && min >= 0
+	case "<=":
+		return hasMin && min > 0
+	case ">":
+		return hasMax && max <= 0
+	case ">=":
+		return hasMax && max < 0
+	case "<>", "!=":
+		return hasMin && hasMax && min == 0 && max == 0
+	}
+	return false
+}
+
+// bound compares a bound to a value the way rows are compared to it. Strings are only compared to text bounds, the
+// order of the text doesn't say anything about the numbers or times it holds.
+func bound(b *models_v1.Bound, value interface{}) (int, bool) {
+	boundValue := stats.BoundValue(b)
+	if boundValue == nil {
+		return 0, false
+	}
+
+	switch v := boundValue.(type) {
+	case string:
+		if s, ok := value.(string); ok {
+			return strings.Compare(v, s), true
+		}
+		return 0, false
+	case int64:
+		// integers are compared exactly, as floats large ones would round to the same value
+		if i, ok := value.(int64); ok {
+			switch {
+			case v < i:
+				return -1, true
+			case v > i:
+				return 1, true
+			}
+			return 0, true
+		}
+	}
+
+	return compare(boundValue, value)
+}
+
+func columnStatistics(statistics *models_v1.Statistics, column *Column) *models_v1.ColumnStatistics {
+	name := strings.Join(column.Path, ".")
+	for _, c := range statistics.Columns {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
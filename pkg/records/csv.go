@@ -0,0 +1,174 @@
+package records
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+)
+
+// CsvReader reads delimited rows. Unlike encoding/csv it supports an arbitrary quote character, which we need for
+// files produced with single quotes.
+type CsvReader struct {
+	reader    *bufio.Reader
+	delimiter rune
+	quote     rune
+	line      int
+}
+
+func NewCsvReader(r io.Reader, delimiter rune, quote rune) *CsvReader {
+	return &CsvReader{
+		reader:    bufio.NewReader(r),
+		delimiter: delimiter,
+		quote:     quote,
+		line:      1,
+	}
+}
+
+// Read returns the next non-empty row along with the line number it started on.
+func (reader *CsvReader) Read() ([]string, int, error) {
+	for {
+		row, line, err := reader.readRow()
+		if err != nil {
+			return nil, line, err
+		}
+
+		if len(row) == 1 && row[0] == "" {
+			continue
+		}
+
+		return row, line, nil
+	}
+}
+
+func (reader *CsvReader) readRow() ([]string, int, error) {
+	start := reader.line
+	row := make([]string, 0)
+	field := strings.Builder{}
+	quoted := false
+	fieldStart := true
+	read := false
+
+	for {
+		r, _, err := reader.reader.ReadRune()
+		if errors.Is(err, io.EOF) {
+			if quoted {
+				return nil, start, fmt.Errorf("line %d: unterminated quoted field", start)
+			}
+			if !read {
+				return nil, start, io.EOF
+			}
+			return append(row, field.String()), start, nil
+		}
+		if err != nil {
+			return nil, start, err
+		}
+		read = true
+
+		if r == '\n' {
+			reader.line++
+		}
+
+		switch {
+		case quoted && r == reader.quote:
+			next, _, err := reader.reader.ReadRune()
+			if err == nil && next == reader.quote {
+				field.WriteRune(r)
+				continue
+			}
+			if err == nil {
+				_ = reader.reader.UnreadRune()
+			}
+			quoted = false
+		case quoted:
+			field.WriteRune(r)
+		case fieldStart && r == reader.quote:
+			quoted = true
+			fieldStart = false
+		case r == reader.delimiter:
+			row = append(row, field.String())
+			field.Reset()
+			fieldStart = true
+		case r == '\r':
+			next, _, err := reader.reader.ReadRune()
+			if err == nil && next != '\n' {
+				_ = reader.reader.UnreadRune()
+			} else if err == nil {
+				reader.line++
+			}
+			return append(row, field.String()), start, nil
+		case r == '\n':
+			return append(row, field.String()), start, nil
+		default:
+			field.WriteRune(r)
+			fieldStart = false
+		}
+	}
+}
+
+// CsvRecordReader maps CSV rows onto named fields using the file's header, or the schema's field names in order when
+// the file has no header.
+type CsvRecordReader struct {
+	rows    *CsvReader
+	columns []string
+	header  bool
+}
+
+func NewCsvRecordReader(r io.Reader, schema *models_v1.Schema) *CsvRecordReader {
+	delimiter, quote := ',', '"'
+	if schema.GetDelimiter() != "" {
+		delimiter, _ = utf8.DecodeRuneInString(schema.GetDelimiter())
+	}
+	if schema.GetQuote() != "" {
+		quote, _ = utf8.DecodeRuneInString(schema.GetQuote())
+	}
+
+	columns := make([]string, 0, len(schema.GetFields()))
+	for _, field := range schema.GetFields() {
+		columns = append(columns, field.GetName())
+	}
+
+	return &CsvRecordReader{
+		rows:    NewCsvReader(r, delimiter, quote),
+		columns: columns,
+		header:  schema.GetHeader(),
+	}
+}
+
+func (reader *CsvRecordReader) Read() (*Record, error) {
+	if reader.header {
+		reader.header = false
+		header, _, err := reader.rows.Read()
+		if err != nil {
+			return nil, err
+		}
+		reader.columns = header
+	}
+
+	row, line, err := reader.rows.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	record := &Record{
+		Line:   line,
+		Fields: make(map[string]interface{}, len(row)),
+	}
+	for i, value := range row {
+		record.Fields[ColumnName(reader.columns, i)] = value
+	}
+
+	return record, nil
+}
+
+// ColumnName returns the name of the i-th column, falling back to a positional name for headerless files.
+func ColumnName(columns []string, i int) string {
+	if i < len(columns) && columns[i] != "" {
+		return columns[i]
+	}
+	return fmt.Sprintf("column_%d", i+1)
+}
@@ -0,0 +1,106 @@
+package records
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCsvReader_Read(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		delimiter rune
+		quote     rune
+		rows      [][]string
+		lines     []int
+	}{
+		{
+			name:      "simple",
+			input:     "a,b,c\n1,2,3\n",
+			delimiter: ',',
+			quote:     '"',
+			rows:      [][]string{{"a", "b", "c"}, {"1", "2", "3"}},
+			lines:     []int{1, 2},
+		},
+		{
+			name:      "quoted delimiter and escaped quote",
+			input:     "\"a,b\",\"say \"\"hi\"\"\"\r\nx,y",
+			delimiter: ',',
+			quote:     '"',
+			rows:      [][]string{{"a,b", "say \"hi\""}, {"x", "y"}},
+			lines:     []int{1, 2},
+		},
+		{
+			name:      "single quotes and multi-line field",
+			input:     "'one\ntwo';3\n\n4;5",
+			delimiter: ';',
+			quote:     '\'',
+			rows:      [][]string{{"one\ntwo", "3"}, {"4", "5"}},
+			lines:     []int{1, 4},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			reader := NewCsvReader(strings.NewReader(tc.input), tc.delimiter, tc.quote)
+
+			for i := range tc.rows {
+				row, line, err := reader.Read()
+				assert.Nil(t, err)
+				assert.Equal(t, tc.rows[i], row)
+				assert.Equal(t, tc.lines[i], line)
+			}
+
+			_, _, err := reader.Read()
+			assert.ErrorIs(t, err, io.EOF)
+		})
+	}
+}
+
+func TestCsvReader_ReadUnterminatedQuote(t *testing.T) {
+	reader := NewCsvReader(strings.NewReader("a,\"b\n"), ',', '"')
+
+	_, _, err := reader.Read()
+
+	assert.Error(t, err)
+	assert.Equal(t, "line 1: unterminated quoted field", err.Error())
+}
+
+func TestCsvRecordReader_Read(t *testing.T) {
+	schema := &models_v1.Schema{
+		Format:    models_v1.Schema_CSV,
+		Delimiter: "|",
+		Header:    true,
+	}
+
+	reader := NewCsvRecordReader(strings.NewReader("id|name\n1|alice\n2\n"), schema)
+
+	record, err := reader.Read()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, record.Line)
+	assert.Equal(t, map[string]interface{}{"id": "1", "name": "alice"}, record.Fields)
+
+	record, err = reader.Read()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, record.Line)
+	assert.Equal(t, map[string]interface{}{"id": "2"}, record.Fields)
+
+	_, err = reader.Read()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestCsvRecordReader_ReadWithoutHeader(t *testing.T) {
+	schema := &models_v1.Schema{
+		Format: models_v1.Schema_CSV,
+	}
+
+	reader := NewCsvRecordReader(strings.NewReader("1,alice,extra\n"), schema)
+
+	record, err := reader.Read()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"column_1": "1", "column_2": "alice", "column_3": "extra"}, record.Fields)
+}
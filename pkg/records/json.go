@@ -0,0 +1,132 @@
+package records
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"unicode"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+)
+
+// JsonReader reads objects from a JSON array, a single JSON object or newline delimited JSON.
+type JsonReader struct {
+	reader   *bufio.Reader
+	lines    *lineCounter
+	decoder  *json.Decoder
+	started  bool
+	array    bool
+	read     int
+	finished bool
+}
+
+func NewJsonReader(r io.Reader) *JsonReader {
+	lines := &lineCounter{reader: r}
+
+	return &JsonReader{
+		reader: bufio.NewReader(lines),
+		lines:  lines,
+	}
+}
+
+func (reader *JsonReader) Read() (*Record, error) {
+	if !reader.started {
+		if err := reader.start(); err != nil {
+			return nil, err
+		}
+	}
+
+	if reader.finished || !reader.decoder.More() {
+		reader.finished = true
+		return nil, io.EOF
+	}
+
+	raw := json.RawMessage{}
+	if err := reader.decoder.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("line %d: %v", reader.lines.lineAt(reader.decoder.InputOffset()), err)
+	}
+	line := reader.lines.lineAt(reader.decoder.InputOffset() - int64(len(raw)))
+
+	fields := map[string]interface{}{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, fmt.Errorf("line %d: record is not a JSON object", line)
+	}
+	reader.read++
+
+	return &Record{
+		Line:   line,
+		Fields: fields,
+	}, nil
+}
+
+// Format reports whether the records read so far came from a single JSON document or from newline delimited JSON.
+func (reader *JsonReader) Format() models_v1.Schema_Format {
+	if reader.array || reader.read <= 1 {
+		return models_v1.Schema_JSON
+	}
+	return models_v1.Schema_NDJSON
+}
+
+func (reader *JsonReader) start() error {
+	reader.started = true
+
+	for {
+		r, _, err := reader.reader.ReadRune()
+		if err != nil {
+			return err
+		}
+		if unicode.IsSpace(r) || r == '\uFEFF' {
+			continue
+		}
+		_ = reader.reader.UnreadRune()
+		reader.array = r == '['
+		break
+	}
+
+	// the decoder counts offsets from where it starts, so keep the line counter in step with it
+	reader.lines.base = reader.lines.offset - int64(reader.reader.Buffered())
+	reader.decoder = json.NewDecoder(reader.reader)
+	if reader.array {
+		if _, err := reader.decoder.Token(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lineCounter remembers where newlines occur so decoder offsets can be turned back into line numbers.
+type lineCounter struct {
+	reader   io.Reader
+	offset   int64
+	base     int64
+	newlines []int64
+	passed   int
+}
+
+func (counter *lineCounter) Read(p []byte) (int, error) {
+	n, err := counter.reader.Read(p)
+	for i := 0; i < n; i++ {
+		if p[i] == '\n' {
+			counter.newlines = append(counter.newlines, counter.offset+int64(i))
+		}
+	}
+	counter.offset += int64(n)
+	return n, err
+}
+
+// lineAt returns the 1-based line of a decoder offset. Offsets must not decrease between calls.
+func (counter *lineCounter) lineAt(offset int64) int {
+	absolute := counter.base + offset
+	i := 0
+	for i < len(counter.newlines) && counter.newlines[i] < absolute {
+		i++
+	}
+	counter.passed += i
+	counter.newlines = counter.newlines[i:]
+	return counter.passed + 1
+}
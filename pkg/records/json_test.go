@@ -0,0 +1,75 @@
+package records
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJsonReader_Read(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		lines  []int
+		format models_v1.Schema_Format
+	}{
+		{
+			name:   "array",
+			input:  "[\n  {\"id\": 1},\n  {\"id\": 2}\n]\n",
+			lines:  []int{2, 3},
+			format: models_v1.Schema_JSON,
+		},
+		{
+			name:   "newline delimited",
+			input:  "{\"id\": 1}\n\n{\"id\": 2}\n",
+			lines:  []int{1, 3},
+			format: models_v1.Schema_NDJSON,
+		},
+		{
+			name:   "single object",
+			input:  "\n{\n  \"id\": 1\n}",
+			lines:  []int{2},
+			format: models_v1.Schema_JSON,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			reader := NewJsonReader(strings.NewReader(tc.input))
+
+			for i, line := range tc.lines {
+				record, err := reader.Read()
+				assert.Nil(t, err)
+				assert.Equal(t, line, record.Line)
+				assert.Equal(t, json.Number(string(rune('1'+i))), record.Fields["id"])
+			}
+
+			_, err := reader.Read()
+			assert.ErrorIs(t, err, io.EOF)
+			assert.Equal(t, tc.format, reader.Format())
+		})
+	}
+}
+
+func TestJsonReader_ReadNotAnObject(t *testing.T) {
+	reader := NewJsonReader(strings.NewReader("{\"id\": 1}\n[1, 2]\n"))
+
+	_, err := reader.Read()
+	assert.Nil(t, err)
+
+	_, err = reader.Read()
+	assert.Error(t, err)
+	assert.Equal(t, "line 2: record is not a JSON object", err.Error())
+}
+
+func TestJsonReader_ReadEmpty(t *testing.T) {
+	reader := NewJsonReader(strings.NewReader(" \n"))
+
+	_, err := reader.Read()
+
+	assert.ErrorIs(t, err, io.EOF)
+}
@@ -0,0 +1,31 @@
+package records
+
+import (
+	"fmt"
+	"io"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+)
+
+// Record is a single row or document read from a structured file. CSV values are always strings, JSON values are
+// whatever encoding/json produced with UseNumber enabled.
+type Record struct {
+	Line   int
+	Fields map[string]interface{}
+}
+
+type Reader interface {
+	Read() (*Record, error)
+}
+
+// NewReader returns a record reader for the format described by the schema.
+func NewReader(schema *models_v1.Schema, r io.Reader) (Reader, error) {
+	switch schema.GetFormat() {
+	case models_v1.Schema_CSV:
+		return NewCsvRecordReader(r, schema), nil
+	case models_v1.Schema_JSON, models_v1.Schema_NDJSON:
+		return NewJsonReader(r), nil
+	default:
+		return nil, fmt.Errorf("unsupported record format: %v", schema.GetFormat())
+	}
+}
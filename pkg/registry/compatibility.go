@@ -0,0 +1,145 @@
+package registry
+
+import (
+	"fmt"
+	"strings"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+)
+
+type Compatibility string
+
+const (
+	// Backward means the new schema can read files written with the previous one
+	Backward Compatibility = "BACKWARD"
+	// Forward means the previous schema can read files written with the new one
+	Forward Compatibility = "FORWARD"
+	// Full means both Backward and Forward
+	Full Compatibility = "FULL"
+	// None disables the check, every change is accepted
+	None Compatibility = "NONE"
+)
+
+func ParseCompatibility(value string) (Compatibility, error) {
+	switch compatibility := Compatibility(strings.ToUpper(value)); compatibility {
+	case Backward, Forward, Full, None:
+		return compatibility, nil
+	default:
+		return "", fmt.Errorf("unknown schema compatibility %q", value)
+	}
+}
+
+type IncompatibleSchemaError struct {
+	Dataset       string
+	Version       int32
+	Compatibility Compatibility
+	Reasons       []string
+}
+
+func (err *IncompatibleSchemaError) Error() string {
+	return fmt.Sprintf("schema is not %v compatible with version %d of dataset %v: %v",
+		strings.ToLower(string(err.Compatibility)), err.Version, err.Dataset, strings.Join(err.Reasons, "; "))
+}
+
+// CheckCompatibility returns the reasons the next schema breaks the compatibility mode, or nothing if it doesn't.
+func CheckCompatibility(compatibility Compatibility, previous *models_v1.Schema, next *models_v1.Schema) []string {
+	reasons := make([]string, 0)
+
+	if compatibility == Backward || compatibility == Full {
+		reasons = append(reasons, canRead(next.GetFields(), previous.GetFields(), "")...)
+	}
+	if compatibility == Forward || compatibility == Full {
+		reasons = append(reasons, canRead(previous.GetFields(), next.GetFields(), "")...)
+	}
+
+	return reasons
+}
+
+// conforms reports whether a file with the given schema matches the registered schema closely enough to not need a
+// new version: every field is known, and the registered schema can read it as it is.
+func conforms(registered *models_v1.Schema, schema *models_v1.Schema) bool {
+	return len(canRead(registered.GetFields(), schema.GetFields(), "")) == 0 &&
+		len(unknownFields(registered.GetFields(), schema.GetFields(), "")) == 0
+}
+
+// canRead lists what stops a reader with one set of fields from reading records written with another.
+func canRead(reader []*models_v1.Field, writer []*models_v1.Field, path string) []string {
+	reasons := make([]string, 0)
+	written := fieldsByName(writer)
+
+	for _, readerField := range reader {
+		name := path + readerField.GetName()
+		writerField, ok := written[readerField.GetName()]
+		if !ok {
+			if !readerField.GetNullable() {
+				reasons = append(reasons, fmt.Sprintf("field %v is required but missing", name))
+			}
+			continue
+		}
+
+		reasons = append(reasons, canReadField(readerField, writerField, name)...)
+	}
+
+	return reasons
+}
+
+func canReadField(reader *models_v1.Field, writer *models_v1.Field, name string) []string {
+	reasons := make([]string, 0)
+
+	if writer.GetNullable() && !reader.GetNullable() {
+		reasons = append(reasons, fmt.Sprintf("field %v is nullable but read as required", name))
+	}
+
+	if !promotable(writer.GetType(), reader.GetType()) {
+		return append(reasons, fmt.Sprintf("field %v changed type from %v to %v", name, writer.GetType(), reader.GetType()))
+	}
+
+	switch {
+	case reader.GetType() == models_v1.Field_OBJECT && writer.GetType() == models_v1.Field_OBJECT:
+		reasons = append(reasons, canRead(reader.GetFields(), writer.GetFields(), name+".")...)
+	case reader.GetType() == models_v1.Field_ARRAY && writer.GetType() == models_v1.Field_ARRAY &&
+		reader.GetItems() != nil && writer.GetItems() != nil:
+		reasons = append(reasons, canReadField(reader.GetItems(), writer.GetItems(), name+"[]")...)
+	}
+
+	return reasons
+}
+
+// promotable reports whether values of one type can be read as another without loss.
+func promotable(from models_v1.Field_Type, to models_v1.Field_Type) bool {
+	return from == to ||
+		from == models_v1.Field_NULL ||
+		(from == models_v1.Field_INTEGER && to == models_v1.Field_NUMBER)
+}
+
+// unknownFields lists the fields written that the reader doesn't know about.
+func unknownFields(reader []*models_v1.Field, writer []*models_v1.Field, path string) []string {
+	unknown := make([]string, 0)
+	known := fieldsByName(reader)
+
+	for _, writerField := range writer {
+		name := path + writerField.GetName()
+		readerField, ok := known[writerField.GetName()]
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+
+		if readerField.GetType() == models_v1.Field_OBJECT && writerField.GetType() == models_v1.Field_OBJECT {
+			unknown = append(unknown, unknownFields(readerField.GetFields(), writerField.GetFields(), name+".")...)
+		}
+		if readerField.GetItems().GetType() == models_v1.Field_OBJECT && writerField.GetItems().GetType() == models_v1.Field_OBJECT {
+			unknown = append(unknown, unknownFields(readerField.GetItems().GetFields(), writerField.GetItems().GetFields(), name+"[].")...)
+		}
+	}
+
+	return unknown
+}
+
+func fieldsByName(fields []*models_v1.Field) map[string]*models_v1.Field {
+	byName := make(map[string]*models_v1.Field, len(fields))
+	for _, field := range fields {
+		byName[field.GetName()] = field
+	}
+	return byName
+}
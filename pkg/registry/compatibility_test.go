@@ -0,0 +1,130 @@
+package registry
+
+import (
+	"testing"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func schemaOf(fields ...*models_v1.Field) *models_v1.Schema {
+	return &models_v1.Schema{Format: models_v1.Schema_CSV, Fields: fields}
+}
+
+func TestParseCompatibility(t *testing.T) {
+	compatibility, err := ParseCompatibility("full")
+	assert.Nil(t, err)
+	assert.Equal(t, Full, compatibility)
+
+	_, err = ParseCompatibility("sideways")
+	assert.Error(t, err)
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	id := &models_v1.Field{Name: "id", Type: models_v1.Field_INTEGER}
+	previous := schemaOf(id, &models_v1.Field{Name: "amount", Type: models_v1.Field_INTEGER})
+
+	tests := []struct {
+		name     string
+		next     *models_v1.Schema
+		expected map[Compatibility][]string
+	}{
+		{
+			name: "add nullable field",
+			next: schemaOf(id, &models_v1.Field{Name: "amount", Type: models_v1.Field_INTEGER}, &models_v1.Field{Name: "note", Type: models_v1.Field_STRING, Nullable: true}),
+			expected: map[Compatibility][]string{
+				Backward: {},
+				Forward:  {},
+				Full:     {},
+			},
+		},
+		{
+			name: "add required field",
+			next: schemaOf(id, &models_v1.Field{Name: "amount", Type: models_v1.Field_INTEGER}, &models_v1.Field{Name: "note", Type: models_v1.Field_STRING}),
+			expected: map[Compatibility][]string{
+				Backward: {"field note is required but missing"},
+				Forward:  {},
+				Full:     {"field note is required but missing"},
+			},
+		},
+		{
+			name: "remove required field",
+			next: schemaOf(id),
+			expected: map[Compatibility][]string{
+				Backward: {},
+				Forward:  {"field amount is required but missing"},
+				Full:     {"field amount is required but missing"},
+			},
+		},
+		{
+			name: "widen integer to number",
+			next: schemaOf(id, &models_v1.Field{Name: "amount", Type: models_v1.Field_NUMBER}),
+			expected: map[Compatibility][]string{
+				Backward: {},
+				Forward:  {"field amount changed type from NUMBER to INTEGER"},
+				Full:     {"field amount changed type from NUMBER to INTEGER"},
+			},
+		},
+		{
+			name: "make field nullable",
+			next: schemaOf(id, &models_v1.Field{Name: "amount", Type: models_v1.Field_INTEGER, Nullable: true}),
+			expected: map[Compatibility][]string{
+				Backward: {},
+				Forward:  {"field amount is nullable but read as required"},
+			},
+		},
+		{
+			name: "change type",
+			next: schemaOf(id, &models_v1.Field{Name: "amount", Type: models_v1.Field_STRING}),
+			expected: map[Compatibility][]string{
+				Backward: {"field amount changed type from INTEGER to STRING"},
+				None:     {},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for compatibility, reasons := range tc.expected {
+				assert.Equal(t, reasons, CheckCompatibility(compatibility, previous, tc.next), compatibility)
+			}
+		})
+	}
+}
+
+func TestCheckCompatibility_Nested(t *testing.T) {
+	previous := schemaOf(&models_v1.Field{Name: "customer", Type: models_v1.Field_OBJECT, Fields: []*models_v1.Field{
+		{Name: "name", Type: models_v1.Field_STRING},
+	}}, &models_v1.Field{Name: "tags", Type: models_v1.Field_ARRAY, Items: &models_v1.Field{Type: models_v1.Field_STRING}})
+	next := schemaOf(&models_v1.Field{Name: "customer", Type: models_v1.Field_OBJECT, Fields: []*models_v1.Field{
+		{Name: "name", Type: models_v1.Field_BOOLEAN},
+	}}, &models_v1.Field{Name: "tags", Type: models_v1.Field_ARRAY, Items: &models_v1.Field{Type: models_v1.Field_INTEGER}})
+
+	assert.Equal(t, []string{
+		"field customer.name changed type from STRING to BOOLEAN",
+		"field tags[] changed type from STRING to INTEGER",
+	}, CheckCompatibility(Backward, previous, next))
+}
+
+func Test_conforms(t *testing.T) {
+	registered := schemaOf(
+		&models_v1.Field{Name: "id", Type: models_v1.Field_INTEGER},
+		&models_v1.Field{Name: "amount", Type: models_v1.Field_NUMBER, Nullable: true},
+	)
+
+	assert.True(t, conforms(registered, schemaOf(&models_v1.Field{Name: "id", Type: models_v1.Field_INTEGER}, &models_v1.Field{Name: "amount", Type: models_v1.Field_INTEGER})))
+	assert.True(t, conforms(registered, schemaOf(&models_v1.Field{Name: "id", Type: models_v1.Field_INTEGER})))
+	assert.False(t, conforms(registered, schemaOf(&models_v1.Field{Name: "amount", Type: models_v1.Field_NUMBER})))
+	assert.False(t, conforms(registered, schemaOf(&models_v1.Field{Name: "id", Type: models_v1.Field_INTEGER}, &models_v1.Field{Name: "note", Type: models_v1.Field_STRING, Nullable: true})))
+}
+
+func TestIncompatibleSchemaError_Error(t *testing.T) {
+	err := &IncompatibleSchemaError{
+		Dataset:       "orders",
+		Version:       3,
+		Compatibility: Backward,
+		Reasons:       []string{"field a is required but missing", "field b changed type from INTEGER to STRING"},
+	}
+
+	assert.Equal(t, "schema is not backward compatible with version 3 of dataset orders: field a is required but missing; field b changed type from INTEGER to STRING", err.Error())
+}
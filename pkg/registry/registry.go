@@ -0,0 +1,16 @@
+package registry
+
+import (
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+)
+
+// SchemaRegistry stores the versioned schemas of each dataset.
+type SchemaRegistry interface {
+	// Latest returns the newest schema of the dataset, or nil when nothing has been registered yet.
+	Latest(dataset string) (*models_v1.Schema, error)
+	// Get returns a specific version of the dataset's schema.
+	Get(dataset string, version int32) (*models_v1.Schema, error)
+	// Register returns the version a file with the given schema belongs to, adding a new version when the schema has
+	// changed in a way the compatibility mode allows. Disallowed changes return an *IncompatibleSchemaError.
+	Register(dataset string, schema *models_v1.Schema, compatibility Compatibility) (*models_v1.Schema, error)
+}
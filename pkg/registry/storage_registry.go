@@ -0,0 +1,150 @@
+package registry
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/aws"
+	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/codingexplorations/data-lake/pkg/storage"
+	"golang.org/x/exp/slices"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// StorageSchemaRegistryImpl keeps each schema version as a JSON file at <root>/<dataset>/<version>.json in a storage,
+// a folder on disk or a prefix in a bucket.
+type StorageSchemaRegistryImpl struct {
+	storage storage.Storage
+	root    string
+	lock    sync.Mutex
+}
+
+func NewStorageSchemaRegistry(storage storage.Storage, root string) *StorageSchemaRegistryImpl {
+	return &StorageSchemaRegistryImpl{
+		storage: storage,
+		root:    root,
+	}
+}
+
+// NewLocalSchemaRegistry keeps the schemas in a folder on disk.
+func NewLocalSchemaRegistry(folder string) *StorageSchemaRegistryImpl {
+	return NewStorageSchemaRegistry(storage.NewLocalStorage(), folder)
+}
+
+// GetSchemaRegistry returns the registry for the configured ingest processor, kept next to the catalog in
+// SCHEMA_REGISTRY_FOLDER or under AWS_SCHEMA_REGISTRY_PREFIX in the curated bucket.
+func GetSchemaRegistry(conf *config.Config) (SchemaRegistry, error) {
+	switch conf.IngestProcessorType {
+	case "localstack":
+		s3Client, err := aws.NewS3()
+		if err != nil {
+			return nil, err
+		}
+		return NewStorageSchemaRegistry(storage.NewS3Storage(&s3Client, conf.AwsCuratedBucketName), conf.AwsSchemaRegistryPrefix), nil
+	default:
+		return NewLocalSchemaRegistry(conf.SchemaRegistryFolder), nil
+	}
+}
+
+func (registry *StorageSchemaRegistryImpl) Latest(dataset string) (*models_v1.Schema, error) {
+	versions, err := registry.versions(dataset)
+	if err != nil || len(versions) == 0 {
+		return nil, err
+	}
+
+	return registry.Get(dataset, versions[len(versions)-1])
+}
+
+func (registry *StorageSchemaRegistryImpl) Get(dataset string, version int32) (*models_v1.Schema, error) {
+	body, err := registry.storage.Read(registry.versionFile(dataset, version))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := &models_v1.Schema{}
+	if err := protojson.Unmarshal(data, schema); err != nil {
+		return nil, fmt.Errorf("failed to parse version %d of dataset %v: %v", version, dataset, err)
+	}
+
+	return schema, nil
+}
+
+func (registry *StorageSchemaRegistryImpl) Register(dataset string, schema *models_v1.Schema, compatibility Compatibility) (*models_v1.Schema, error) {
+	registry.lock.Lock()
+	defer registry.lock.Unlock()
+
+	latest, err := registry.Latest(dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	next := proto.Clone(schema).(*models_v1.Schema)
+	next.Version = 1
+
+	if latest != nil {
+		if conforms(latest, schema) {
+			return latest, nil
+		}
+
+		if reasons := CheckCompatibility(compatibility, latest, schema); len(reasons) > 0 {
+			return nil, &IncompatibleSchemaError{
+				Dataset:       dataset,
+				Version:       latest.Version,
+				Compatibility: compatibility,
+				Reasons:       reasons,
+			}
+		}
+
+		next.Version = latest.Version + 1
+	}
+
+	data, err := protojson.MarshalOptions{Multiline: true}.Marshal(next)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create stops another process that raced us to the same version from being overwritten
+	if err := registry.storage.Create(registry.versionFile(dataset, next.Version), bytes.NewReader(data), "application/json"); err != nil {
+		return nil, fmt.Errorf("failed to register version %d of dataset %v: %v", next.Version, dataset, err)
+	}
+
+	return next, nil
+}
+
+// versions lists the registered versions of a dataset in ascending order.
+func (registry *StorageSchemaRegistryImpl) versions(dataset string) ([]int32, error) {
+	locations, err := registry.storage.List(path.Join(registry.root, dataset) + "/")
+	if err != nil {
+		return nil, err
+	}
+
+	// listings sort by name, which isn't numeric order once there are ten versions
+	versions := make([]int32, 0, len(locations))
+	for _, location := range locations {
+		version, err := strconv.ParseInt(strings.TrimSuffix(path.Base(location), ".json"), 10, 32)
+		if err != nil {
+			continue
+		}
+		versions = append(versions, int32(version))
+	}
+	slices.Sort(versions)
+
+	return versions, nil
+}
+
+func (registry *StorageSchemaRegistryImpl) versionFile(dataset string, version int32) string {
+	return path.Join(registry.root, dataset, fmt.Sprintf("%d.json", version))
+}
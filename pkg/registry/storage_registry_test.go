@@ -0,0 +1,63 @@
+package registry
+
+import (
+	"testing"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalSchemaRegistry_Register(t *testing.T) {
+	registry := NewLocalSchemaRegistry(t.TempDir())
+
+	latest, err := registry.Latest("orders")
+	assert.Nil(t, err)
+	assert.Nil(t, latest)
+
+	first, err := registry.Register("orders", schemaOf(&models_v1.Field{Name: "id", Type: models_v1.Field_INTEGER}), Backward)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), first.Version)
+
+	// the same shape again belongs to the existing version
+	same, err := registry.Register("orders", schemaOf(&models_v1.Field{Name: "id", Type: models_v1.Field_INTEGER}), Backward)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), same.Version)
+
+	second, err := registry.Register("orders", schemaOf(
+		&models_v1.Field{Name: "id", Type: models_v1.Field_INTEGER},
+		&models_v1.Field{Name: "note", Type: models_v1.Field_STRING, Nullable: true},
+	), Backward)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), second.Version)
+
+	_, err = registry.Register("orders", schemaOf(&models_v1.Field{Name: "id", Type: models_v1.Field_STRING}), Backward)
+	assert.IsType(t, &IncompatibleSchemaError{}, err)
+
+	latest, err = registry.Latest("orders")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), latest.Version)
+	assert.Len(t, latest.Fields, 2)
+
+	stored, err := registry.Get("orders", 1)
+	assert.Nil(t, err)
+	assert.Equal(t, "id", stored.Fields[0].Name)
+
+	_, err = registry.Get("orders", 3)
+	assert.Error(t, err)
+}
+
+func TestLocalSchemaRegistry_LatestOrdersVersionsNumerically(t *testing.T) {
+	registry := NewLocalSchemaRegistry(t.TempDir())
+
+	fields := make([]*models_v1.Field, 0)
+	for i := 0; i < 11; i++ {
+		fields = append(fields, &models_v1.Field{Name: string(rune('a' + i)), Type: models_v1.Field_STRING, Nullable: true})
+		_, err := registry.Register("events", schemaOf(fields...), None)
+		assert.Nil(t, err)
+	}
+
+	latest, err := registry.Latest("events")
+
+	assert.Nil(t, err)
+	assert.Equal(t, int32(11), latest.Version)
+}
@@ -1,22 +1,46 @@
 package pkg
 
 import (
+	"context"
+
+	"github.com/codingexplorations/data-lake/pkg/compaction"
 	"github.com/codingexplorations/data-lake/pkg/config"
 	"github.com/codingexplorations/data-lake/pkg/ingest"
+	"github.com/codingexplorations/data-lake/pkg/log"
+	"github.com/codingexplorations/data-lake/pkg/tracing"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type Runner struct {
 	Config    *config.Config
 	Processor ingest.IngestProcessor
+	Compactor compaction.Compactor
 }
 
-func NewRunner(conf *config.Config, processor ingest.IngestProcessor) *Runner {
+func NewRunner(conf *config.Config, processor ingest.IngestProcessor, compactor compaction.Compactor) *Runner {
 	return &Runner{
 		Config:    conf,
 		Processor: processor,
+		Compactor: compactor,
 	}
 }
 
+// Run ingests the data folder and compacts what was ingested, every run is a trace of its own. What's logged during a
+// run carries its run id.
 func (r *Runner) Run() {
-	r.Processor.ProcessFolder(r.Config.DataFolder)
+	folder := r.Config.DataFolder
+
+	runId := uuid.NewString()
+	ctx := log.ContextWith(context.Background(), log.RunId(runId))
+	ctx, span := tracing.Start(ctx, "Runner.Run", attribute.String(log.LocationKey, folder), attribute.String(log.RunIdKey, runId))
+	_, err := r.Processor.ProcessFolder(ctx, folder)
+
+	if r.Compactor != nil {
+		_, compaction := tracing.Start(ctx, "Compact")
+		_, err := r.Compactor.Compact()
+		tracing.End(compaction, err)
+	}
+
+	tracing.End(span, err)
 }
@@ -1,18 +1,55 @@
 package pkg
 
 import (
+	"context"
 	"testing"
 
 	models_v1 "github.com/codingexplorations/data-lake/models/v1"
 	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/codingexplorations/data-lake/pkg/log"
+	compactionMocks "github.com/codingexplorations/data-lake/test/mocks/pkg/compaction"
 	mocks "github.com/codingexplorations/data-lake/test/mocks/pkg/ingest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestRunner(t *testing.T) {
 	conf := config.GetConfig()
 	processor := mocks.NewIngestProcessor(t)
+	compactor := compactionMocks.NewCompactor(t)
 
-	processor.On("ProcessFolder", "/tmp/data-lake").Return([]*models_v1.Object{}, nil)
+	processor.On("ProcessFolder", mock.Anything, "/tmp/data-lake").Return([]*models_v1.Object{}, nil)
+	compactor.On("Compact").Return([]*models_v1.DataFile{}, nil)
 
-	NewRunner(conf, processor).Run()
+	NewRunner(conf, processor, compactor).Run()
+}
+
+func TestRunner_WithoutCompactor(t *testing.T) {
+	conf := config.GetConfig()
+	processor := mocks.NewIngestProcessor(t)
+
+	processor.On("ProcessFolder", mock.Anything, "/tmp/data-lake").Return([]*models_v1.Object{}, nil)
+
+	NewRunner(conf, processor, nil).Run()
+}
+
+func TestRunner_RunId(t *testing.T) {
+	processor := mocks.NewIngestProcessor(t)
+
+	// every run hands the processor a context carrying an id of its own
+	runIds := make([]string, 0)
+	processor.On("ProcessFolder", mock.Anything, "/tmp/data-lake").Run(func(args mock.Arguments) {
+		attrs := log.Context(args.Get(0).(context.Context))
+		assert.Len(t, attrs, 1)
+		assert.Equal(t, log.RunIdKey, attrs[0].Key)
+		runIds = append(runIds, attrs[0].Value.String())
+	}).Return([]*models_v1.Object{}, nil)
+
+	r := NewRunner(&config.Config{DataFolder: "/tmp/data-lake"}, processor, nil)
+	r.Run()
+	r.Run()
+
+	assert.Len(t, runIds, 2)
+	assert.NotEmpty(t, runIds[0])
+	assert.NotEqual(t, runIds[0], runIds[1])
 }
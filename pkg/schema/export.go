@@ -0,0 +1,285 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	// the generated descriptors depend on these, importing them registers them for protodesc.NewFile
+	_ "google.golang.org/protobuf/types/known/structpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema renders the schema as a JSON Schema document describing a single record.
+func JSONSchema(schema *models_v1.Schema) ([]byte, error) {
+	document := objectJSONSchema(schema.GetFields())
+	document["$schema"] = jsonSchemaDraft
+
+	return json.MarshalIndent(document, "", "  ")
+}
+
+func objectJSONSchema(fields []*models_v1.Field) map[string]interface{} {
+	properties := map[string]interface{}{}
+	required := make([]string, 0)
+
+	for _, field := range fields {
+		properties[field.GetName()] = fieldJSONSchema(field)
+		if !field.GetNullable() {
+			required = append(required, field.GetName())
+		}
+	}
+
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+}
+
+func fieldJSONSchema(field *models_v1.Field) map[string]interface{} {
+	var property map[string]interface{}
+
+	switch field.GetType() {
+	case models_v1.Field_NULL:
+		return map[string]interface{}{"type": "null"}
+	case models_v1.Field_BOOLEAN:
+		property = map[string]interface{}{"type": "boolean"}
+	case models_v1.Field_INTEGER:
+		property = map[string]interface{}{"type": "integer"}
+	case models_v1.Field_NUMBER:
+		property = map[string]interface{}{"type": "number"}
+	case models_v1.Field_TIMESTAMP:
+		property = map[string]interface{}{"type": "string", "format": "date-time"}
+	case models_v1.Field_OBJECT:
+		property = objectJSONSchema(field.GetFields())
+	case models_v1.Field_ARRAY:
+		property = map[string]interface{}{"type": "array"}
+		if field.GetItems() != nil {
+			property["items"] = fieldJSONSchema(field.GetItems())
+		}
+	default:
+		property = map[string]interface{}{"type": "string"}
+	}
+
+	if field.GetNullable() {
+		property["type"] = []interface{}{property["type"], "null"}
+	}
+
+	return property
+}
+
+// FileDescriptor renders the schema as a proto3 file holding a single message named messageName. Nullable fields
+// become proto3 optional fields and values without a fixed shape become google.protobuf.Value.
+func FileDescriptor(schema *models_v1.Schema, pkg string, messageName string) (*descriptorpb.FileDescriptorProto, error) {
+	builder := &descriptorBuilder{imports: map[string]bool{}}
+
+	message, err := builder.message(messageName, schema.GetFields())
+	if err != nil {
+		return nil, err
+	}
+
+	file := &descriptorpb.FileDescriptorProto{
+		Name:        proto.String(strings.ReplaceAll(pkg, ".", "/") + "/" + strings.ToLower(messageName) + ".proto"),
+		Package:     proto.String(pkg),
+		Syntax:      proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{message},
+	}
+	for _, dependency := range []string{"google/protobuf/timestamp.proto", "google/protobuf/struct.proto"} {
+		if builder.imports[dependency] {
+			file.Dependency = append(file.Dependency, dependency)
+		}
+	}
+
+	return file, nil
+}
+
+type descriptorBuilder struct {
+	imports map[string]bool
+}
+
+func (builder *descriptorBuilder) message(name string, fields []*models_v1.Field) (*descriptorpb.DescriptorProto, error) {
+	message := &descriptorpb.DescriptorProto{Name: proto.String(name)}
+	names := map[string]bool{}
+
+	for i, field := range fields {
+		fieldName := protoFieldName(field.GetName())
+		if names[fieldName] {
+			return nil, fmt.Errorf("fields %q of %s collide as proto field %q", field.GetName(), name, fieldName)
+		}
+		names[fieldName] = true
+
+		descriptor := &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(fieldName),
+			JsonName: proto.String(field.GetName()),
+			Number:   proto.Int32(int32(i + 1)),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		}
+
+		element := field
+		if field.GetType() == models_v1.Field_ARRAY {
+			descriptor.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+			element = field.GetItems()
+			if element == nil || element.GetType() == models_v1.Field_ARRAY {
+				element = &models_v1.Field{Type: models_v1.Field_NULL}
+			}
+		}
+
+		switch element.GetType() {
+		case models_v1.Field_BOOLEAN:
+			descriptor.Type = descriptorpb.FieldDescriptorProto_TYPE_BOOL.Enum()
+		case models_v1.Field_INTEGER:
+			descriptor.Type = descriptorpb.FieldDescriptorProto_TYPE_INT64.Enum()
+		case models_v1.Field_NUMBER:
+			descriptor.Type = descriptorpb.FieldDescriptorProto_TYPE_DOUBLE.Enum()
+		case models_v1.Field_STRING:
+			descriptor.Type = descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()
+		case models_v1.Field_TIMESTAMP:
+			builder.imports["google/protobuf/timestamp.proto"] = true
+			descriptor.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+			descriptor.TypeName = proto.String(".google.protobuf.Timestamp")
+		case models_v1.Field_OBJECT:
+			nested, err := builder.message(protoMessageName(field.GetName()), element.GetFields())
+			if err != nil {
+				return nil, err
+			}
+			message.NestedType = append(message.NestedType, nested)
+			descriptor.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+			descriptor.TypeName = proto.String(nested.GetName())
+		default:
+			builder.imports["google/protobuf/struct.proto"] = true
+			descriptor.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+			descriptor.TypeName = proto.String(".google.protobuf.Value")
+		}
+
+		// message fields already carry presence, scalars need a synthetic oneof to be optional
+		if field.GetNullable() && descriptor.GetLabel() != descriptorpb.FieldDescriptorProto_LABEL_REPEATED &&
+			descriptor.GetType() != descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+			descriptor.Proto3Optional = proto.Bool(true)
+			descriptor.OneofIndex = proto.Int32(int32(len(message.OneofDecl)))
+			message.OneofDecl = append(message.OneofDecl, &descriptorpb.OneofDescriptorProto{
+				Name: proto.String("_" + fieldName),
+			})
+		}
+
+		message.Field = append(message.Field, descriptor)
+	}
+
+	return message, nil
+}
+
+// protoFieldName turns an arbitrary column or key name into a valid snake_case proto identifier.
+func protoFieldName(name string) string {
+	builder := strings.Builder{}
+	for i, r := range name {
+		switch {
+		case r < unicode.MaxASCII && unicode.IsUpper(r):
+			if i > 0 && !strings.HasSuffix(builder.String(), "_") {
+				builder.WriteRune('_')
+			}
+			builder.WriteRune(unicode.ToLower(r))
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			builder.WriteRune(r)
+		case builder.Len() > 0 && !strings.HasSuffix(builder.String(), "_"):
+			builder.WriteRune('_')
+		}
+	}
+
+	fieldName := strings.TrimSuffix(builder.String(), "_")
+	if fieldName == "" || unicode.IsDigit(rune(fieldName[0])) {
+		fieldName = "field_" + fieldName
+	}
+	return fieldName
+}
+
+// protoMessageName turns a key name into a PascalCase message name.
+func protoMessageName(name string) string {
+	builder := strings.Builder{}
+	for _, part := range strings.Split(protoFieldName(name), "_") {
+		if part != "" {
+			builder.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return builder.String()
+}
+
+// FromDescriptor describes the fields of a declared protobuf message the same way inferred fields are described, so
+// declared and inferred schemas can be registered and compared alike. Only fields marked required by protovalidate
+// are not nullable.
+func FromDescriptor(descriptor protoreflect.MessageDescriptor) []*models_v1.Field {
+	return fieldsFromDescriptor(descriptor, map[protoreflect.FullName]bool{})
+}
+
+func fieldsFromDescriptor(descriptor protoreflect.MessageDescriptor, visiting map[protoreflect.FullName]bool) []*models_v1.Field {
+	// recursive messages are cut off where they start repeating
+	if visiting[descriptor.FullName()] {
+		return nil
+	}
+	visiting[descriptor.FullName()] = true
+	defer delete(visiting, descriptor.FullName())
+
+	fields := make([]*models_v1.Field, 0, descriptor.Fields().Len())
+	for i := 0; i < descriptor.Fields().Len(); i++ {
+		fieldDescriptor := descriptor.Fields().Get(i)
+
+		field := fieldFromDescriptor(fieldDescriptor, visiting)
+		field.Name = string(fieldDescriptor.Name())
+		field.Nullable = true
+		if constraints, ok := proto.GetExtension(fieldDescriptor.Options(), validate.E_Field).(*validate.FieldConstraints); ok && constraints.GetRequired() {
+			field.Nullable = false
+		}
+
+		if fieldDescriptor.IsList() {
+			field = &models_v1.Field{
+				Name:     field.Name,
+				Type:     models_v1.Field_ARRAY,
+				Nullable: field.Nullable,
+				Items:    &models_v1.Field{Type: field.Type, Fields: field.Fields},
+			}
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields
+}
+
+func fieldFromDescriptor(descriptor protoreflect.FieldDescriptor, visiting map[protoreflect.FullName]bool) *models_v1.Field {
+	if descriptor.IsMap() {
+		return &models_v1.Field{Type: models_v1.Field_OBJECT}
+	}
+
+	switch descriptor.Kind() {
+	case protoreflect.BoolKind:
+		return &models_v1.Field{Type: models_v1.Field_BOOLEAN}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind, protoreflect.Uint32Kind,
+		protoreflect.Fixed32Kind, protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return &models_v1.Field{Type: models_v1.Field_INTEGER}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return &models_v1.Field{Type: models_v1.Field_NUMBER}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		switch descriptor.Message().FullName() {
+		case "google.protobuf.Timestamp":
+			return &models_v1.Field{Type: models_v1.Field_TIMESTAMP}
+		case "google.protobuf.Value":
+			return &models_v1.Field{Type: models_v1.Field_NULL}
+		case "google.protobuf.Struct":
+			return &models_v1.Field{Type: models_v1.Field_OBJECT}
+		}
+		return &models_v1.Field{
+			Type:   models_v1.Field_OBJECT,
+			Fields: fieldsFromDescriptor(descriptor.Message(), visiting),
+		}
+	default:
+		return &models_v1.Field{Type: models_v1.Field_STRING}
+	}
+}
@@ -0,0 +1,106 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+var exportSchema = &models_v1.Schema{
+	Format: models_v1.Schema_JSON,
+	Fields: []*models_v1.Field{
+		{Name: "orderId", Type: models_v1.Field_INTEGER},
+		{Name: "amount", Type: models_v1.Field_NUMBER, Nullable: true},
+		{Name: "created", Type: models_v1.Field_TIMESTAMP},
+		{Name: "customer", Type: models_v1.Field_OBJECT, Fields: []*models_v1.Field{
+			{Name: "name", Type: models_v1.Field_STRING},
+		}},
+		{Name: "tags", Type: models_v1.Field_ARRAY, Items: &models_v1.Field{Type: models_v1.Field_STRING}},
+		{Name: "extra", Type: models_v1.Field_NULL, Nullable: true},
+	},
+}
+
+func TestJSONSchema(t *testing.T) {
+	document, err := JSONSchema(exportSchema)
+	assert.Nil(t, err)
+
+	parsed := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal(document, &parsed))
+
+	assert.Equal(t, jsonSchemaDraft, parsed["$schema"])
+	assert.Equal(t, []interface{}{"orderId", "created", "customer", "tags"}, parsed["required"])
+
+	properties := parsed["properties"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"type": "integer"}, properties["orderId"])
+	assert.Equal(t, map[string]interface{}{"type": []interface{}{"number", "null"}}, properties["amount"])
+	assert.Equal(t, map[string]interface{}{"type": "string", "format": "date-time"}, properties["created"])
+	assert.Equal(t, map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}}, properties["tags"])
+	assert.Equal(t, map[string]interface{}{"type": "null"}, properties["extra"])
+	assert.Equal(t, "object", properties["customer"].(map[string]interface{})["type"])
+}
+
+func TestFileDescriptor(t *testing.T) {
+	file, err := FileDescriptor(exportSchema, "datasets.orders", "Order")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"google/protobuf/timestamp.proto", "google/protobuf/struct.proto"}, file.Dependency)
+
+	descriptor, err := protodesc.NewFile(file, protoregistry.GlobalFiles)
+	assert.Nil(t, err)
+
+	message := descriptor.Messages().ByName("Order")
+	assert.NotNil(t, message)
+	assert.Equal(t, "order_id", string(message.Fields().Get(0).Name()))
+	assert.True(t, message.Fields().ByName("amount").HasOptionalKeyword())
+	assert.Equal(t, "google.protobuf.Timestamp", string(message.Fields().ByName("created").Message().FullName()))
+	assert.Equal(t, "datasets.orders.Order.Customer", string(message.Fields().ByName("customer").Message().FullName()))
+	assert.True(t, message.Fields().ByName("tags").IsList())
+	assert.Equal(t, "google.protobuf.Value", string(message.Fields().ByName("extra").Message().FullName()))
+}
+
+func TestFileDescriptor_Collision(t *testing.T) {
+	schema := &models_v1.Schema{
+		Fields: []*models_v1.Field{
+			{Name: "order id", Type: models_v1.Field_STRING},
+			{Name: "order_id", Type: models_v1.Field_STRING},
+		},
+	}
+
+	_, err := FileDescriptor(schema, "datasets", "Order")
+
+	assert.Error(t, err)
+}
+
+func Test_protoFieldName(t *testing.T) {
+	assert.Equal(t, "order_id", protoFieldName("OrderId"))
+	assert.Equal(t, "order_id", protoFieldName("order-id"))
+	assert.Equal(t, "field_2nd_value", protoFieldName("2nd value"))
+	assert.Equal(t, "Customer", protoMessageName("customer"))
+}
+
+func TestFromDescriptor(t *testing.T) {
+	fields := FromDescriptor((&models_v1.Object{}).ProtoReflect().Descriptor())
+
+	assert.True(t, proto.Equal(&models_v1.Field{Name: "file_name", Type: models_v1.Field_STRING}, fields[0]))
+	assert.True(t, proto.Equal(&models_v1.Field{Name: "content_size", Type: models_v1.Field_INTEGER, Nullable: true}, fields[3]))
+	assert.Equal(t, "schema", fields[4].Name)
+	assert.Equal(t, models_v1.Field_OBJECT, fields[4].Type)
+	assert.True(t, fields[4].Nullable)
+
+	var format, repeated *models_v1.Field
+	for _, field := range fields[4].Fields {
+		switch field.Name {
+		case "format":
+			format = field
+		case "fields":
+			repeated = field
+		}
+	}
+	assert.Equal(t, models_v1.Field_STRING, format.Type)
+	assert.Equal(t, models_v1.Field_ARRAY, repeated.Type)
+	assert.Equal(t, models_v1.Field_OBJECT, repeated.Items.Type)
+}
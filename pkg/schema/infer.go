@@ -0,0 +1,388 @@
+package schema
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/records"
+)
+
+// sniffSize is how much of a CSV file is looked at to guess its delimiter and quote character
+const sniffSize = 64 * 1024
+
+// DetectFormat guesses the structured format of a file from its name, content type and first bytes.
+func DetectFormat(fileName string, contentType string, head []byte) models_v1.Schema_Format {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv", ".tsv", ".psv":
+		return models_v1.Schema_CSV
+	case ".json":
+		return models_v1.Schema_JSON
+	case ".ndjson", ".jsonl":
+		return models_v1.Schema_NDJSON
+	}
+
+	switch strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0])) {
+	case "text/csv", "text/tab-separated-values":
+		return models_v1.Schema_CSV
+	case "application/json":
+		return models_v1.Schema_JSON
+	case "application/x-ndjson", "application/jsonl":
+		return models_v1.Schema_NDJSON
+	}
+
+	head = bytes.TrimSpace(bytes.TrimPrefix(head, []byte("\xef\xbb\xbf")))
+	if len(head) > 0 && (head[0] == '{' || head[0] == '[') {
+		return models_v1.Schema_JSON
+	}
+
+	return models_v1.Schema_UNKNOWN
+}
+
+// Infer samples up to sampleSize records of a file and returns the schema they share.
+func Infer(format models_v1.Schema_Format, r io.Reader, sampleSize int) (*models_v1.Schema, error) {
+	if sampleSize <= 0 {
+		return nil, fmt.Errorf("sample size must be greater than 0")
+	}
+
+	switch format {
+	case models_v1.Schema_CSV:
+		return inferCsv(r, sampleSize)
+	case models_v1.Schema_JSON, models_v1.Schema_NDJSON:
+		return inferJson(format, r, sampleSize)
+	default:
+		return nil, fmt.Errorf("cannot infer a schema for format %v", format)
+	}
+}
+
+func inferCsv(r io.Reader, sampleSize int) (*models_v1.Schema, error) {
+	reader := bufio.NewReaderSize(r, sniffSize)
+	sample, err := reader.Peek(sniffSize)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return nil, err
+	}
+
+	delimiter, quote := sniffDialect(sample, err == nil)
+
+	rows := make([][]string, 0)
+	csvReader := records.NewCsvReader(reader, delimiter, quote)
+	// one extra row in case the first one turns out to be a header
+	for len(rows) <= sampleSize {
+		row, _, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+
+	schema := &models_v1.Schema{
+		Format:    models_v1.Schema_CSV,
+		Delimiter: string(delimiter),
+		Quote:     string(quote),
+		Header:    hasHeader(rows),
+	}
+
+	body := rows
+	var names []string
+	if schema.Header {
+		names = rows[0]
+		body = rows[1:]
+	} else if len(body) > sampleSize {
+		body = body[:sampleSize]
+	}
+
+	columns := 0
+	for _, row := range rows {
+		columns = max(columns, len(row))
+	}
+
+	for i := 0; i < columns; i++ {
+		field := &models_v1.Field{
+			Name: records.ColumnName(names, i),
+			Type: models_v1.Field_NULL,
+		}
+		for _, row := range body {
+			if i >= len(row) {
+				field.Nullable = true
+				continue
+			}
+			t := scalarType(row[i])
+			if t == models_v1.Field_NULL {
+				field.Nullable = true
+			}
+			field.Type = widen(field.Type, t)
+		}
+		schema.Fields = append(schema.Fields, field)
+	}
+	schema.SampledRecords = int32(len(body))
+
+	return schema, nil
+}
+
+// sniffDialect picks the delimiter that splits the sample into the most consistent rows, and the quote character
+// that is actually used to wrap fields.
+func sniffDialect(sample []byte, truncated bool) (rune, rune) {
+	lines := strings.Split(strings.ReplaceAll(string(sample), "\r\n", "\n"), "\n")
+	// the last line may have been cut off by the sample size
+	if truncated && len(lines) > 1 {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) > 20 {
+		lines = lines[:20]
+	}
+
+	bestDelimiter, bestQuote := ',', '"'
+	bestConsistent, bestColumns := 0, 1
+	for _, delimiter := range []rune{',', '\t', ';', '|'} {
+		quote := '"'
+		if quotedFields(lines, delimiter, '\'') > quotedFields(lines, delimiter, '"') {
+			quote = '\''
+		}
+
+		counts := map[int]int{}
+		reader := records.NewCsvReader(strings.NewReader(strings.Join(lines, "\n")), delimiter, quote)
+		for {
+			row, _, err := reader.Read()
+			if err != nil {
+				break
+			}
+			counts[len(row)]++
+		}
+
+		// the most common column count and how many rows share it
+		columns, consistent := 0, 0
+		for c, n := range counts {
+			if n > consistent || (n == consistent && c > columns) {
+				columns, consistent = c, n
+			}
+		}
+
+		if columns > 1 && (consistent > bestConsistent || (consistent == bestConsistent && columns > bestColumns)) {
+			bestDelimiter, bestQuote = delimiter, quote
+			bestConsistent, bestColumns = consistent, columns
+		}
+	}
+
+	if bestColumns == 1 {
+		for _, quote := range []rune{'\'', '"'} {
+			if quotedFields(lines, bestDelimiter, quote) > 0 {
+				bestQuote = quote
+				break
+			}
+		}
+	}
+
+	return bestDelimiter, bestQuote
+}
+
+// quotedFields counts the fields that are wrapped in the quote character.
+func quotedFields(lines []string, delimiter rune, quote rune) int {
+	count := 0
+	for _, line := range lines {
+		runes := []rune(line)
+		for i := 0; i < len(runes); i++ {
+			if runes[i] != quote || (i > 0 && runes[i-1] != delimiter) {
+				continue
+			}
+			end := i + 1
+			for end < len(runes) && runes[end] != quote {
+				end++
+			}
+			if end < len(runes) && (end == len(runes)-1 || runes[end+1] == delimiter) {
+				count++
+				i = end
+			}
+		}
+	}
+	return count
+}
+
+// hasHeader votes column by column on whether the first row looks different from the rows after it.
+func hasHeader(rows [][]string) bool {
+	if len(rows) == 0 {
+		return false
+	}
+
+	seen := map[string]bool{}
+	for _, name := range rows[0] {
+		if strings.TrimSpace(name) == "" || seen[name] {
+			return false
+		}
+		seen[name] = true
+	}
+
+	if len(rows) == 1 {
+		for _, name := range rows[0] {
+			if scalarType(name) != models_v1.Field_STRING {
+				return false
+			}
+		}
+		return true
+	}
+
+	votes := 0
+	for i, name := range rows[0] {
+		bodyType := models_v1.Field_NULL
+		lengths := map[int]bool{}
+		for _, row := range rows[1:] {
+			if i < len(row) {
+				bodyType = widen(bodyType, scalarType(row[i]))
+				lengths[len(row[i])] = true
+			}
+		}
+
+		switch bodyType {
+		case models_v1.Field_NULL:
+		case models_v1.Field_STRING:
+			if len(lengths) == 1 {
+				if lengths[len(name)] {
+					votes--
+				} else {
+					votes++
+				}
+			}
+		default:
+			if widen(scalarType(name), bodyType) != bodyType {
+				votes++
+			} else {
+				votes--
+			}
+		}
+	}
+
+	return votes > 0
+}
+
+func inferJson(format models_v1.Schema_Format, r io.Reader, sampleSize int) (*models_v1.Schema, error) {
+	reader := records.NewJsonReader(r)
+	root := newObjectBuilder()
+
+	for root.records < sampleSize {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		root.observe(record.Fields)
+	}
+
+	if format != models_v1.Schema_NDJSON {
+		format = reader.Format()
+	}
+
+	return &models_v1.Schema{
+		Format:         format,
+		Fields:         root.build(),
+		SampledRecords: int32(root.records),
+	}, nil
+}
+
+// objectBuilder accumulates the fields seen across JSON objects, keeping the order they were first seen in.
+type objectBuilder struct {
+	records int
+	order   []string
+	fields  map[string]*fieldBuilder
+}
+
+type fieldBuilder struct {
+	fieldType models_v1.Field_Type
+	nullable  bool
+	seen      int
+	object    *objectBuilder
+	items     *fieldBuilder
+}
+
+func newObjectBuilder() *objectBuilder {
+	return &objectBuilder{
+		fields: map[string]*fieldBuilder{},
+	}
+}
+
+func (builder *objectBuilder) observe(object map[string]interface{}) {
+	builder.records++
+
+	// maps don't keep the key order of the document, so fields first seen in the same record are sorted by name
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := object[name]
+		field, ok := builder.fields[name]
+		if !ok {
+			field = &fieldBuilder{}
+			builder.fields[name] = field
+			builder.order = append(builder.order, name)
+		}
+		field.seen++
+		field.observe(value)
+	}
+}
+
+func (builder *objectBuilder) build() []*models_v1.Field {
+	fields := make([]*models_v1.Field, 0, len(builder.order))
+	for _, name := range builder.order {
+		field := builder.fields[name].build()
+		field.Name = name
+		if builder.fields[name].seen < builder.records {
+			field.Nullable = true
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+func (builder *fieldBuilder) observe(value interface{}) {
+	t := valueType(value)
+	if t == models_v1.Field_NULL {
+		builder.nullable = true
+		return
+	}
+
+	builder.fieldType = widen(builder.fieldType, t)
+
+	switch builder.fieldType {
+	case models_v1.Field_OBJECT:
+		if builder.object == nil {
+			builder.object = newObjectBuilder()
+		}
+		builder.object.observe(value.(map[string]interface{}))
+	case models_v1.Field_ARRAY:
+		if builder.items == nil {
+			builder.items = &fieldBuilder{}
+		}
+		for _, item := range value.([]interface{}) {
+			builder.items.observe(item)
+		}
+	default:
+		builder.object = nil
+		builder.items = nil
+	}
+}
+
+func (builder *fieldBuilder) build() *models_v1.Field {
+	field := &models_v1.Field{
+		Type:     builder.fieldType,
+		Nullable: builder.nullable,
+	}
+	if builder.object != nil {
+		field.Fields = builder.object.build()
+	}
+	if builder.items != nil {
+		field.Items = builder.items.build()
+	}
+	return field
+}
@@ -0,0 +1,148 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name        string
+		fileName    string
+		contentType string
+		head        string
+		expected    models_v1.Schema_Format
+	}{
+		{name: "csv extension", fileName: "orders.CSV", expected: models_v1.Schema_CSV},
+		{name: "jsonl extension", fileName: "events.jsonl", expected: models_v1.Schema_NDJSON},
+		{name: "content type", fileName: "orders", contentType: "text/csv; charset=utf-8", expected: models_v1.Schema_CSV},
+		{name: "json content", fileName: "orders.txt", contentType: "text/plain", head: "\n  [{}]", expected: models_v1.Schema_JSON},
+		{name: "plain text", fileName: "test.txt", contentType: "text/plain", head: "This is a test.", expected: models_v1.Schema_UNKNOWN},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, DetectFormat(tc.fileName, tc.contentType, []byte(tc.head)))
+		})
+	}
+}
+
+func TestInfer_Csv(t *testing.T) {
+	content := "id,name,amount,created,active\n" +
+		"1,alice,10,2024-03-01,true\n" +
+		"2,bob,12.5,2024-03-02,false\n" +
+		"3,,7,2024-03-03,true\n"
+
+	schema, err := Infer(models_v1.Schema_CSV, strings.NewReader(content), 100)
+
+	assert.Nil(t, err)
+	assert.Equal(t, models_v1.Schema_CSV, schema.Format)
+	assert.Equal(t, ",", schema.Delimiter)
+	assert.Equal(t, "\"", schema.Quote)
+	assert.True(t, schema.Header)
+	assert.Equal(t, int32(3), schema.SampledRecords)
+	assert.Equal(t, []*models_v1.Field{
+		{Name: "id", Type: models_v1.Field_INTEGER},
+		{Name: "name", Type: models_v1.Field_STRING, Nullable: true},
+		{Name: "amount", Type: models_v1.Field_NUMBER},
+		{Name: "created", Type: models_v1.Field_TIMESTAMP},
+		{Name: "active", Type: models_v1.Field_BOOLEAN},
+	}, schema.Fields)
+}
+
+func TestInfer_CsvDialect(t *testing.T) {
+	content := "'a;1';2\n'b;3';4\n'c';5\n"
+
+	schema, err := Infer(models_v1.Schema_CSV, strings.NewReader(content), 100)
+
+	assert.Nil(t, err)
+	assert.Equal(t, ";", schema.Delimiter)
+	assert.Equal(t, "'", schema.Quote)
+	assert.False(t, schema.Header)
+	assert.Equal(t, []*models_v1.Field{
+		{Name: "column_1", Type: models_v1.Field_STRING},
+		{Name: "column_2", Type: models_v1.Field_INTEGER},
+	}, schema.Fields)
+}
+
+func TestInfer_CsvSampleSize(t *testing.T) {
+	content := "1\t2\n3\t4\n5\tfive\n"
+
+	schema, err := Infer(models_v1.Schema_CSV, strings.NewReader(content), 2)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "\t", schema.Delimiter)
+	assert.Equal(t, int32(2), schema.SampledRecords)
+	assert.Equal(t, models_v1.Field_INTEGER, schema.Fields[1].Type)
+}
+
+func Test_hasHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		rows     [][]string
+		expected bool
+	}{
+		{name: "typed body", rows: [][]string{{"id", "price"}, {"1", "2.5"}}, expected: true},
+		{name: "no header", rows: [][]string{{"1", "2.5"}, {"2", "3.5"}}, expected: false},
+		{name: "fixed length strings", rows: [][]string{{"code"}, {"AB"}, {"CD"}}, expected: true},
+		{name: "duplicate names", rows: [][]string{{"a", "a"}, {"1", "2"}}, expected: false},
+		{name: "header only", rows: [][]string{{"a", "b"}}, expected: true},
+		{name: "empty", rows: [][]string{}, expected: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, hasHeader(tc.rows))
+		})
+	}
+}
+
+func TestInfer_Json(t *testing.T) {
+	content := `[
+		{"id": 1, "customer": {"name": "alice", "vip": true}, "tags": ["a", "b"], "total": 10},
+		{"id": 2, "customer": {"name": "bob"}, "tags": [], "total": 10.5, "note": null}
+	]`
+
+	schema, err := Infer(models_v1.Schema_JSON, strings.NewReader(content), 100)
+
+	assert.Nil(t, err)
+	assert.Equal(t, models_v1.Schema_JSON, schema.Format)
+	assert.Equal(t, int32(2), schema.SampledRecords)
+	assert.Equal(t, []*models_v1.Field{
+		{Name: "customer", Type: models_v1.Field_OBJECT, Fields: []*models_v1.Field{
+			{Name: "name", Type: models_v1.Field_STRING},
+			{Name: "vip", Type: models_v1.Field_BOOLEAN, Nullable: true},
+		}},
+		{Name: "id", Type: models_v1.Field_INTEGER},
+		{Name: "tags", Type: models_v1.Field_ARRAY, Items: &models_v1.Field{Type: models_v1.Field_STRING}},
+		{Name: "total", Type: models_v1.Field_NUMBER},
+		{Name: "note", Type: models_v1.Field_NULL, Nullable: true},
+	}, schema.Fields)
+}
+
+func TestInfer_Ndjson(t *testing.T) {
+	content := "{\"id\": 1, \"value\": \"x\"}\n{\"id\": 2, \"value\": {\"a\": 1}}\n"
+
+	schema, err := Infer(models_v1.Schema_JSON, strings.NewReader(content), 100)
+
+	assert.Nil(t, err)
+	assert.Equal(t, models_v1.Schema_NDJSON, schema.Format)
+	assert.Equal(t, []*models_v1.Field{
+		{Name: "id", Type: models_v1.Field_INTEGER},
+		{Name: "value", Type: models_v1.Field_STRING},
+	}, schema.Fields)
+}
+
+func TestInfer_Failure(t *testing.T) {
+	_, err := Infer(models_v1.Schema_JSON, strings.NewReader("{\"id\": "), 100)
+	assert.Error(t, err)
+
+	_, err = Infer(models_v1.Schema_UNKNOWN, strings.NewReader(""), 100)
+	assert.Error(t, err)
+
+	_, err = Infer(models_v1.Schema_CSV, strings.NewReader(""), 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,110 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+)
+
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// scalarType detects the narrowest type a CSV value can be read as.
+func scalarType(value string) models_v1.Field_Type {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return models_v1.Field_NULL
+	}
+
+	if strings.EqualFold(value, "true") || strings.EqualFold(value, "false") {
+		return models_v1.Field_BOOLEAN
+	}
+
+	if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return models_v1.Field_INTEGER
+	}
+
+	// ParseFloat also accepts values like "inf" and "nan", which we'd rather keep as strings
+	if strings.ContainsAny(value, "0123456789") {
+		if _, err := strconv.ParseFloat(value, 64); err == nil {
+			return models_v1.Field_NUMBER
+		}
+	}
+
+	if isTimestamp(value) {
+		return models_v1.Field_TIMESTAMP
+	}
+
+	return models_v1.Field_STRING
+}
+
+// valueType detects the type of a value decoded from JSON with UseNumber.
+func valueType(value interface{}) models_v1.Field_Type {
+	switch v := value.(type) {
+	case nil:
+		return models_v1.Field_NULL
+	case bool:
+		return models_v1.Field_BOOLEAN
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return models_v1.Field_INTEGER
+		}
+		return models_v1.Field_NUMBER
+	case float64:
+		return models_v1.Field_NUMBER
+	case string:
+		if isTimestamp(v) {
+			return models_v1.Field_TIMESTAMP
+		}
+		return models_v1.Field_STRING
+	case map[string]interface{}:
+		return models_v1.Field_OBJECT
+	case []interface{}:
+		return models_v1.Field_ARRAY
+	default:
+		return models_v1.Field_STRING
+	}
+}
+
+func isTimestamp(value string) bool {
+	_, err := ParseTimestamp(value)
+	return err == nil
+}
+
+// ParseTimestamp reads a value in any of the layouts inference recognizes as TIMESTAMP.
+func ParseTimestamp(value string) (time.Time, error) {
+	for _, layout := range timestampLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q is not a timestamp", value)
+}
+
+// widen returns the narrowest type both a and b can be represented as.
+func widen(a, b models_v1.Field_Type) models_v1.Field_Type {
+	switch {
+	case a == b:
+		return a
+	case a == models_v1.Field_NULL:
+		return b
+	case b == models_v1.Field_NULL:
+		return a
+	case isNumeric(a) && isNumeric(b):
+		return models_v1.Field_NUMBER
+	default:
+		return models_v1.Field_STRING
+	}
+}
+
+func isNumeric(t models_v1.Field_Type) bool {
+	return t == models_v1.Field_INTEGER || t == models_v1.Field_NUMBER
+}
@@ -0,0 +1,65 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_scalarType(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected models_v1.Field_Type
+	}{
+		{value: "", expected: models_v1.Field_NULL},
+		{value: " ", expected: models_v1.Field_NULL},
+		{value: "TRUE", expected: models_v1.Field_BOOLEAN},
+		{value: "-42", expected: models_v1.Field_INTEGER},
+		{value: "4.2e3", expected: models_v1.Field_NUMBER},
+		{value: "nan", expected: models_v1.Field_STRING},
+		{value: "2024-03-01", expected: models_v1.Field_TIMESTAMP},
+		{value: "2024-03-01T10:00:00Z", expected: models_v1.Field_TIMESTAMP},
+		{value: "hello", expected: models_v1.Field_STRING},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.value, func(t *testing.T) {
+			assert.Equal(t, tc.expected, scalarType(tc.value))
+		})
+	}
+}
+
+func Test_valueType(t *testing.T) {
+	assert.Equal(t, models_v1.Field_NULL, valueType(nil))
+	assert.Equal(t, models_v1.Field_BOOLEAN, valueType(false))
+	assert.Equal(t, models_v1.Field_INTEGER, valueType(json.Number("7")))
+	assert.Equal(t, models_v1.Field_NUMBER, valueType(json.Number("7.5")))
+	assert.Equal(t, models_v1.Field_STRING, valueType("7"))
+	assert.Equal(t, models_v1.Field_TIMESTAMP, valueType("2024-03-01T10:00:00.123Z"))
+	assert.Equal(t, models_v1.Field_OBJECT, valueType(map[string]interface{}{}))
+	assert.Equal(t, models_v1.Field_ARRAY, valueType([]interface{}{}))
+}
+
+func Test_widen(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        models_v1.Field_Type
+		b        models_v1.Field_Type
+		expected models_v1.Field_Type
+	}{
+		{name: "same", a: models_v1.Field_INTEGER, b: models_v1.Field_INTEGER, expected: models_v1.Field_INTEGER},
+		{name: "null", a: models_v1.Field_NULL, b: models_v1.Field_BOOLEAN, expected: models_v1.Field_BOOLEAN},
+		{name: "numeric", a: models_v1.Field_INTEGER, b: models_v1.Field_NUMBER, expected: models_v1.Field_NUMBER},
+		{name: "incompatible", a: models_v1.Field_INTEGER, b: models_v1.Field_TIMESTAMP, expected: models_v1.Field_STRING},
+		{name: "nested", a: models_v1.Field_OBJECT, b: models_v1.Field_ARRAY, expected: models_v1.Field_STRING},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, widen(tc.a, tc.b))
+			assert.Equal(t, tc.expected, widen(tc.b, tc.a))
+		})
+	}
+}
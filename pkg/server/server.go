@@ -0,0 +1,259 @@
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/codingexplorations/data-lake/pkg/log"
+	"github.com/codingexplorations/data-lake/pkg/preview"
+	"github.com/codingexplorations/data-lake/pkg/query"
+	"github.com/google/uuid"
+)
+
+const (
+	requestIdHeader    = "X-Request-Id"
+	maxRequestIdLength = 128
+)
+
+// Server exposes the data lake over HTTP, it answers with JSON.
+type Server struct {
+	conf      *config.Config
+	logger    log.Logger
+	engine    query.Engine
+	previewer preview.Previewer
+	levels    *log.Levels
+	mux       *http.ServeMux
+}
+
+type queryRequest struct {
+	Sql string `json:"sql"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// levelsBody is the level logs are written from and the levels of the packages and sources logging from another one.
+type levelsBody struct {
+	Level     string            `json:"level"`
+	Overrides map[string]string `json:"overrides"`
+}
+
+// NewServer returns a server answering from the engine and previewer, logging to logger. The admin routes change the
+// levels, the ones the logger follows.
+func NewServer(conf *config.Config, logger log.Logger, levels *log.Levels, engine query.Engine, previewer preview.Previewer) *Server {
+	server := &Server{
+		conf:      conf,
+		logger:    logger,
+		engine:    engine,
+		previewer: previewer,
+		levels:    levels,
+		mux:       http.NewServeMux(),
+	}
+
+	server.mux.HandleFunc("GET /healthz", server.handleHealth)
+	server.mux.HandleFunc("GET /query", server.handleQuery)
+	server.mux.HandleFunc("POST /query", server.handleQuery)
+	server.mux.HandleFunc("GET /preview", server.handlePreview)
+	server.mux.HandleFunc("GET /profile", server.handleProfile)
+	server.mux.HandleFunc("GET /admin/log-levels", server.admin(server.handleGetLevels))
+	server.mux.HandleFunc("PUT /admin/log-levels", server.admin(server.handleSetLevels))
+	server.mux.HandleFunc("PUT /admin/log-levels/{name}", server.admin(server.handleSetLevel))
+	server.mux.HandleFunc("DELETE /admin/log-levels/{name}", server.admin(server.handleSetLevel))
+
+	return server
+}
+
+func (server *Server) Handler() http.Handler {
+	return requestIds(server.mux)
+}
+
+// ListenAndServe serves requests on HTTP_ADDRESS until the server fails. It only listens on the loopback interface
+// unless HTTP_ADDRESS says otherwise, the data endpoints take no token.
+func (server *Server) ListenAndServe() error {
+	server.logger.Info("Listening", slog.String("address", server.conf.HttpAddress))
+	return http.ListenAndServe(server.conf.HttpAddress, server.Handler())
+}
+
+// requestIds gives every request an id, the one in its X-Request-Id header or a new one, answered in the same header
+// and logged with what's logged handling the request.
+func requestIds(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestIdHeader)
+		if id == "" || len(id) > maxRequestIdLength {
+			id = uuid.NewString()
+		}
+
+		w.Header().Set(requestIdHeader, id)
+		next.ServeHTTP(w, r.WithContext(log.ContextWith(r.Context(), log.RequestId(id))))
+	})
+}
+
+func (server *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	server.writeJson(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// handleQuery runs the statement given as the sql parameter of a GET, or in the JSON body of a POST.
+func (server *Server) handleQuery(w http.ResponseWriter, r *http.Request) {
+	request := &queryRequest{Sql: r.URL.Query().Get("sql")}
+	if r.Method == http.MethodPost {
+		if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+			server.writeJson(w, http.StatusBadRequest, &errorResponse{Error: fmt.Sprintf("invalid request body: %v", err)})
+			return
+		}
+	}
+
+	if request.Sql == "" {
+		server.writeJson(w, http.StatusBadRequest, &errorResponse{Error: "sql is required"})
+		return
+	}
+
+	result, err := server.engine.Query(request.Sql)
+	if err != nil {
+		server.writeError(w, r, err)
+		return
+	}
+
+	server.writeJson(w, http.StatusOK, result)
+}
+
+// handlePreview previews the first records of the object at location, or of a dataset when dataset is given. A
+// location given along with a dataset has to be one of its files.
+func (server *Server) handlePreview(w http.ResponseWriter, r *http.Request) {
+	dataset := r.URL.Query().Get("dataset")
+	location := r.URL.Query().Get("location")
+	if dataset == "" && location == "" {
+		server.writeJson(w, http.StatusBadRequest, &errorResponse{Error: "dataset or location is required"})
+		return
+	}
+
+	limit := 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			server.writeJson(w, http.StatusBadRequest, &errorResponse{Error: fmt.Sprintf("limit must be a positive number, got %v", value)})
+			return
+		}
+		limit = parsed
+	}
+
+	var result *preview.Preview
+	var err error
+	if dataset != "" {
+		result, err = server.previewer.PreviewDataset(dataset, location, limit)
+	} else {
+		result, err = server.previewer.PreviewFile(location, limit)
+	}
+	if err != nil {
+		server.writeError(w, r, err)
+		return
+	}
+
+	server.writeJson(w, http.StatusOK, result)
+}
+
+// handleProfile returns the column statistics of the dataset given as the dataset parameter.
+func (server *Server) handleProfile(w http.ResponseWriter, r *http.Request) {
+	dataset := r.URL.Query().Get("dataset")
+	if dataset == "" {
+		server.writeJson(w, http.StatusBadRequest, &errorResponse{Error: "dataset is required"})
+		return
+	}
+
+	profile, err := server.engine.Profile(dataset)
+	if err != nil {
+		server.writeError(w, r, err)
+		return
+	}
+
+	server.writeJson(w, http.StatusOK, profile)
+}
+
+// admin serves an admin endpoint to requests bearing ADMIN_TOKEN. Without a token the admin endpoints aren't served.
+func (server *Server) admin(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if server.conf.AdminToken == "" {
+			server.writeJson(w, http.StatusNotFound, &errorResponse{Error: "admin endpoints need ADMIN_TOKEN"})
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+server.conf.AdminToken)) != 1 {
+			server.writeJson(w, http.StatusUnauthorized, &errorResponse{Error: "admin endpoints need the bearer token of ADMIN_TOKEN"})
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// handleGetLevels returns the log levels.
+func (server *Server) handleGetLevels(w http.ResponseWriter, r *http.Request) {
+	level, overrides := server.levels.Get()
+	server.writeJson(w, http.StatusOK, &levelsBody{Level: level, Overrides: overrides})
+}
+
+// handleSetLevels replaces the log levels with the ones in the JSON body.
+func (server *Server) handleSetLevels(w http.ResponseWriter, r *http.Request) {
+	request := &levelsBody{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		server.writeJson(w, http.StatusBadRequest, &errorResponse{Error: fmt.Sprintf("invalid request body: %v", err)})
+		return
+	}
+
+	if err := server.levels.Set(request.Level, request.Overrides); err != nil {
+		server.writeJson(w, http.StatusBadRequest, &errorResponse{Error: err.Error()})
+		return
+	}
+
+	log.WithTrace(server.logger, r.Context()).Info("log levels changed", slog.String("level", request.Level), slog.Any("overrides", request.Overrides))
+	server.handleGetLevels(w, r)
+}
+
+// handleSetLevel sets the log level of the package or source in the path to the level in the JSON body, deleting it
+// goes back to the level logs are written from.
+func (server *Server) handleSetLevel(w http.ResponseWriter, r *http.Request) {
+	request := &levelsBody{}
+	if r.Method == http.MethodPut {
+		if err := json.NewDecoder(r.Body).Decode(request); err != nil || request.Level == "" {
+			server.writeJson(w, http.StatusBadRequest, &errorResponse{Error: "the request body needs a level"})
+			return
+		}
+	}
+
+	name := r.PathValue("name")
+	if err := server.levels.SetOverride(name, request.Level); err != nil {
+		server.writeJson(w, http.StatusBadRequest, &errorResponse{Error: err.Error()})
+		return
+	}
+
+	log.WithTrace(server.logger, r.Context()).Info("log level changed", slog.String("name", name), slog.String("level", request.Level))
+	server.handleGetLevels(w, r)
+}
+
+// writeError answers with 400 for statements that can't be run as written, 404 for unknown datasets and files, and
+// 500 otherwise.
+func (server *Server) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	var syntaxErr *query.SyntaxError
+	switch {
+	case errors.As(err, &syntaxErr):
+		server.writeJson(w, http.StatusBadRequest, &errorResponse{Error: err.Error()})
+	case errors.Is(err, query.ErrDatasetNotFound), errors.Is(err, preview.ErrNotFound), errors.Is(err, fs.ErrNotExist):
+		server.writeJson(w, http.StatusNotFound, &errorResponse{Error: err.Error()})
+	default:
+		log.WithTrace(server.logger, r.Context()).Error("error handling request", slog.String("path", r.URL.Path), log.Err(err))
+		server.writeJson(w, http.StatusInternalServerError, &errorResponse{Error: err.Error()})
+	}
+}
+
+func (server *Server) writeJson(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		server.logger.Error("error writing response", log.Err(err))
+	}
+}
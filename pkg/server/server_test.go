@@ -0,0 +1,261 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/codingexplorations/data-lake/pkg/log"
+	"github.com/codingexplorations/data-lake/pkg/preview"
+	"github.com/codingexplorations/data-lake/pkg/query"
+	logMocks "github.com/codingexplorations/data-lake/test/mocks/pkg/log"
+	previewMocks "github.com/codingexplorations/data-lake/test/mocks/pkg/preview"
+	queryMocks "github.com/codingexplorations/data-lake/test/mocks/pkg/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestServer_Health(t *testing.T) {
+	server := NewServer(&config.Config{}, log.NewConsoleLog(), nil, queryMocks.NewEngine(t), previewMocks.NewPreviewer(t))
+
+	recorder := httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, "{\"status\": \"ok\"}", recorder.Body.String())
+}
+
+func TestServer_Query(t *testing.T) {
+	engine := queryMocks.NewEngine(t)
+	engine.On("Query", "SELECT id FROM orders").Return(&query.Result{
+		Columns:      []string{"id"},
+		Rows:         [][]interface{}{{int64(1)}, {int64(2)}},
+		FilesScanned: 1,
+	}, nil).Twice()
+
+	server := NewServer(&config.Config{}, log.NewConsoleLog(), nil, engine, previewMocks.NewPreviewer(t))
+
+	recorder := httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/query", strings.NewReader("{\"sql\": \"SELECT id FROM orders\"}")))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	assert.JSONEq(t, "{\"columns\": [\"id\"], \"rows\": [[1], [2]], \"truncated\": false, \"files_scanned\": 1, \"files_pruned\": 0}", recorder.Body.String())
+
+	recorder = httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/query?sql="+url.QueryEscape("SELECT id FROM orders"), nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestServer_Query_Failure(t *testing.T) {
+	engine := queryMocks.NewEngine(t)
+	engine.On("Query", "SELECT FROM orders").Return(nil, &query.SyntaxError{Position: 7, Message: "expected a column or value, found \"FROM\""})
+	engine.On("Query", "SELECT id FROM customers").Return(nil, query.ErrDatasetNotFound)
+	engine.On("Query", "SELECT id FROM broken").Return(nil, errors.New("failed to read broken/01.parquet"))
+
+	server := NewServer(&config.Config{}, log.NewConsoleLog(), nil, engine, previewMocks.NewPreviewer(t))
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+		error  string
+	}{
+		{name: "invalid body", body: "{", status: http.StatusBadRequest, error: "invalid request body: unexpected EOF"},
+		{name: "missing sql", body: "{}", status: http.StatusBadRequest, error: "sql is required"},
+		{name: "syntax error", body: "{\"sql\": \"SELECT FROM orders\"}", status: http.StatusBadRequest, error: "syntax error at position 8: expected a column or value, found \\\"FROM\\\""},
+		{name: "unknown dataset", body: "{\"sql\": \"SELECT id FROM customers\"}", status: http.StatusNotFound, error: "dataset not found"},
+		{name: "failure", body: "{\"sql\": \"SELECT id FROM broken\"}", status: http.StatusInternalServerError, error: "failed to read broken/01.parquet"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(tc.body)))
+
+			assert.Equal(t, tc.status, recorder.Code)
+			assert.JSONEq(t, "{\"error\": \""+tc.error+"\"}", recorder.Body.String())
+		})
+	}
+}
+
+func TestServer_RequestId(t *testing.T) {
+	engine := queryMocks.NewEngine(t)
+	engine.On("Query", "SELECT id FROM broken").Return(nil, errors.New("failed to read broken/01.parquet"))
+
+	logger := logMocks.NewLogger(t)
+	traced := logMocks.NewLogger(t)
+	server := NewServer(&config.Config{}, logger, nil, engine, previewMocks.NewPreviewer(t))
+
+	// the id a request comes with is answered and logged with what's logged handling it
+	logger.On("With", log.RequestId("r-1")).Return(traced)
+	traced.On("Error", "error handling request", mock.Anything, mock.Anything).Once()
+
+	request := httptest.NewRequest(http.MethodPost, "/query", strings.NewReader("{\"sql\": \"SELECT id FROM broken\"}"))
+	request.Header.Set("X-Request-Id", "r-1")
+	recorder := httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, request)
+
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	assert.Equal(t, "r-1", recorder.Header().Get("X-Request-Id"))
+
+	// requests without one are given one
+	recorder = httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Len(t, recorder.Header().Get("X-Request-Id"), 36)
+}
+
+func TestServer_Preview(t *testing.T) {
+	previewer := previewMocks.NewPreviewer(t)
+	result := &preview.Preview{
+		Columns: []string{"id"},
+		Records: []map[string]interface{}{{"id": int64(1)}},
+		Files:   []*preview.PreviewFile{{Location: "orders/01.csv", Format: "CSV", Records: 1}},
+	}
+	previewer.On("PreviewFile", "orders/01.csv", 0).Return(result, nil)
+	previewer.On("PreviewDataset", "orders", "", 5).Return(result, nil)
+	previewer.On("PreviewFile", "orders/02.csv", 0).Return(nil, fmt.Errorf("couldn't find object orders/02.csv: %w", fs.ErrNotExist))
+	previewer.On("PreviewDataset", "customers", "", 0).Return(nil, fmt.Errorf("catalog has no dataset customers: %w", preview.ErrNotFound))
+
+	server := NewServer(&config.Config{}, log.NewConsoleLog(), nil, queryMocks.NewEngine(t), previewer)
+
+	tests := []struct {
+		name   string
+		target string
+		status int
+		body   string
+	}{
+		{
+			name:   "file",
+			target: "/preview?location=orders/01.csv",
+			status: http.StatusOK,
+			body:   "{\"columns\": [\"id\"], \"records\": [{\"id\": 1}], \"truncated\": false, \"files\": [{\"location\": \"orders/01.csv\", \"format\": \"CSV\", \"records\": 1}]}",
+		},
+		{name: "dataset", target: "/preview?dataset=orders&limit=5", status: http.StatusOK},
+		{name: "missing file", target: "/preview?location=orders/02.csv", status: http.StatusNotFound, body: "{\"error\": \"couldn't find object orders/02.csv: file does not exist\"}"},
+		{name: "missing dataset", target: "/preview?dataset=customers", status: http.StatusNotFound, body: "{\"error\": \"catalog has no dataset customers: not found\"}"},
+		{name: "nothing to preview", target: "/preview", status: http.StatusBadRequest, body: "{\"error\": \"dataset or location is required\"}"},
+		{name: "invalid limit", target: "/preview?location=orders/01.csv&limit=-1", status: http.StatusBadRequest, body: "{\"error\": \"limit must be a positive number, got -1\"}"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tc.target, nil))
+
+			assert.Equal(t, tc.status, recorder.Code)
+			if tc.body != "" {
+				assert.JSONEq(t, tc.body, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestServer_Profile(t *testing.T) {
+	engine := queryMocks.NewEngine(t)
+	engine.On("Profile", "orders").Return(&query.Profile{
+		Dataset: "orders",
+		Files:   1,
+		Rows:    2,
+		Columns: []*query.ColumnProfile{{Name: "id", Type: "INTEGER", Values: 2, Distinct: 2, Min: int64(1), Max: int64(2)}},
+	}, nil)
+	engine.On("Profile", "customers").Return(nil, fmt.Errorf("catalog has no dataset customers: %w", query.ErrDatasetNotFound))
+
+	server := NewServer(&config.Config{}, log.NewConsoleLog(), nil, engine, previewMocks.NewPreviewer(t))
+
+	tests := []struct {
+		name   string
+		target string
+		status int
+		body   string
+	}{
+		{
+			name:   "dataset",
+			target: "/profile?dataset=orders",
+			status: http.StatusOK,
+			body: "{\"dataset\": \"orders\", \"files\": 1, \"files_without_statistics\": 0, \"rows\": 2, " +
+				"\"columns\": [{\"name\": \"id\", \"type\": \"INTEGER\", \"values\": 2, \"nulls\": 0, \"distinct\": 2, \"min\": 1, \"max\": 2}]}",
+		},
+		{name: "missing dataset", target: "/profile?dataset=customers", status: http.StatusNotFound, body: "{\"error\": \"catalog has no dataset customers: dataset not found\"}"},
+		{name: "no dataset", target: "/profile", status: http.StatusBadRequest, body: "{\"error\": \"dataset is required\"}"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tc.target, nil))
+
+			assert.Equal(t, tc.status, recorder.Code)
+			assert.JSONEq(t, tc.body, recorder.Body.String())
+		})
+	}
+}
+
+func TestServer_LogLevels(t *testing.T) {
+	levels, _ := log.NewLevels("INFO", nil)
+	server := NewServer(&config.Config{AdminToken: "secret"}, log.NewLeveledConsoleLog(levels), levels, queryMocks.NewEngine(t), previewMocks.NewPreviewer(t))
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		status int
+		answer string
+	}{
+		{name: "get", method: http.MethodGet, target: "/admin/log-levels", status: http.StatusOK, answer: "{\"level\": \"INFO\", \"overrides\": {}}"},
+		{name: "set one", method: http.MethodPut, target: "/admin/log-levels/ingest", body: "{\"level\": \"debug\"}", status: http.StatusOK, answer: "{\"level\": \"INFO\", \"overrides\": {\"ingest\": \"DEBUG\"}}"},
+		{name: "set all", method: http.MethodPut, target: "/admin/log-levels", body: "{\"level\": \"WARN\", \"overrides\": {\"ingest\": \"DEBUG\", \"parquet\": \"ERROR\"}}", status: http.StatusOK, answer: "{\"level\": \"WARN\", \"overrides\": {\"ingest\": \"DEBUG\", \"parquet\": \"ERROR\"}}"},
+		{name: "delete one", method: http.MethodDelete, target: "/admin/log-levels/parquet", status: http.StatusOK, answer: "{\"level\": \"WARN\", \"overrides\": {\"ingest\": \"DEBUG\"}}"},
+		{name: "unknown level", method: http.MethodPut, target: "/admin/log-levels/ingest", body: "{\"level\": \"LOUD\"}", status: http.StatusBadRequest, answer: "{\"error\": \"invalid level of ingest: unknown log level \\\"LOUD\\\"\"}"},
+		{name: "no level", method: http.MethodPut, target: "/admin/log-levels/ingest", body: "{}", status: http.StatusBadRequest, answer: "{\"error\": \"the request body needs a level\"}"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.body))
+			request.Header.Set("Authorization", "Bearer secret")
+			recorder := httptest.NewRecorder()
+			server.Handler().ServeHTTP(recorder, request)
+
+			assert.Equal(t, tc.status, recorder.Code)
+			assert.JSONEq(t, tc.answer, recorder.Body.String())
+		})
+	}
+}
+
+func TestServer_LogLevels_Unauthorized(t *testing.T) {
+	server := NewServer(&config.Config{AdminToken: "secret"}, log.NewConsoleLog(), nil, queryMocks.NewEngine(t), previewMocks.NewPreviewer(t))
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodPut, "/admin/log-levels/ingest", strings.NewReader("{\"level\": \"DEBUG\"}"))
+	request.Header.Set("Authorization", "Bearer guess")
+	server.Handler().ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+	// without a token there are no admin endpoints
+	server = NewServer(&config.Config{}, log.NewConsoleLog(), nil, queryMocks.NewEngine(t), previewMocks.NewPreviewer(t))
+	recorder = httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/admin/log-levels", nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+func TestServer_Preview_OutsideIngestZone(t *testing.T) {
+	conf := &config.Config{DataFolder: t.TempDir(), CatalogFolder: t.TempDir(), CuratedFolder: t.TempDir(), SchemaRegistryFolder: t.TempDir(), PreviewLimit: 20, PreviewMaxLimit: 100}
+	previewer, err := preview.NewPreviewer(conf, log.NewConsoleLog())
+	assert.Nil(t, err)
+	server := NewServer(conf, log.NewConsoleLog(), nil, queryMocks.NewEngine(t), previewer)
+
+	for _, location := range []string{"/etc/passwd", conf.DataFolder + "/../../etc/passwd"} {
+		recorder := httptest.NewRecorder()
+		server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/preview?location="+url.QueryEscape(location), nil))
+		assert.Equal(t, http.StatusNotFound, recorder.Code, location)
+	}
+}
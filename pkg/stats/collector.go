@@ -0,0 +1,254 @@
+package stats
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/convert"
+	"github.com/codingexplorations/data-lake/pkg/records"
+)
+
+// MaxTextBound is how many bytes of a string the min and max of a column keep. A shortened min still bounds the
+// values from below, a longer max can't be shortened and isn't kept.
+const MaxTextBound = 64
+
+// Collector collects the statistics of the columns of a schema from the values of records as convert.Values returns
+// them. Fields of objects with known fields are columns of their own.
+type Collector struct {
+	rows    int64
+	columns []*column
+}
+
+type column struct {
+	name   string
+	path   []string
+	kind   models_v1.Field_Type
+	values int64
+	nulls  int64
+	// sketch, min and max are only kept for values that can be ordered
+	sketch   *Sketch
+	min, max interface{}
+}
+
+func NewCollector(schema *models_v1.Schema) *Collector {
+	collector := &Collector{}
+	collector.addColumns(nil, schema.GetFields())
+	return collector
+}
+
+func (collector *Collector) addColumns(parent []string, fields []*models_v1.Field) {
+	for _, field := range fields {
+		path := append(append([]string{}, parent...), field.GetName())
+
+		if field.GetType() == models_v1.Field_OBJECT && len(field.GetFields()) > 0 {
+			collector.addColumns(path, field.GetFields())
+			continue
+		}
+
+		c := &column{name: strings.Join(path, "."), path: path, kind: field.GetType()}
+		if ordered(c.kind) {
+			c.sketch = NewSketch()
+		}
+		collector.columns = append(collector.columns, c)
+	}
+}
+
+// Add adds the values of a record.
+func (collector *Collector) Add(values map[string]interface{}) {
+	collector.rows++
+	for _, c := range collector.columns {
+		c.add(lookup(values, c.path))
+	}
+}
+
+// Statistics returns the statistics of the records added so far.
+func (collector *Collector) Statistics() *models_v1.Statistics {
+	statistics := &models_v1.Statistics{Rows: collector.rows}
+	for _, c := range collector.columns {
+		columnStatistics := &models_v1.ColumnStatistics{
+			Name:   c.name,
+			Type:   c.kind,
+			Values: c.values,
+			Nulls:  c.nulls,
+			Min:    lowerBound(c.min),
+			Max:    upperBound(c.max),
+		}
+		if c.sketch != nil {
+			columnStatistics.Distinct = c.sketch.Estimate()
+			columnStatistics.Sketch = c.sketch.Bytes()
+		}
+		statistics.Columns = append(statistics.Columns, columnStatistics)
+	}
+	return statistics
+}
+
+// Collect reads every record of a file and returns its statistics, for files that aren't converted.
+func Collect(schema *models_v1.Schema, reader records.Reader) (*models_v1.Statistics, error) {
+	collector := NewCollector(schema)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return collector.Statistics(), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		values, err := convert.Values(schema, record.Fields)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", record.Line, err)
+		}
+		collector.Add(values)
+	}
+}
+
+func (c *column) add(value interface{}) {
+	if value == nil {
+		c.nulls++
+		return
+	}
+	c.values++
+
+	if c.sketch == nil {
+		return
+	}
+
+	key, ok := encode(value)
+	if !ok {
+		return
+	}
+	c.sketch.Insert(key)
+
+	// NaN is neither smaller nor larger than anything, it would make the bounds wrong
+	if f, ok := value.(float64); ok && math.IsNaN(f) {
+		return
+	}
+	if c.min == nil || less(value, c.min) {
+		c.min = value
+	}
+	if c.max == nil || less(c.max, value) {
+		c.max = value
+	}
+}
+
+func ordered(kind models_v1.Field_Type) bool {
+	switch kind {
+	case models_v1.Field_BOOLEAN, models_v1.Field_INTEGER, models_v1.Field_NUMBER, models_v1.Field_STRING, models_v1.Field_TIMESTAMP:
+		return true
+	}
+	return false
+}
+
+// lookup returns the value at a path of nested records, nil when any of them is missing.
+func lookup(values map[string]interface{}, path []string) interface{} {
+	var value interface{} = values
+	for _, name := range path {
+		record, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = record[name]
+	}
+	return value
+}
+
+// encode returns the bytes a value is inserted into a sketch as.
+func encode(value interface{}) ([]byte, bool) {
+	switch v := value.(type) {
+	case string:
+		return []byte(v), true
+	case bool:
+		if v {
+			return []byte{1}, true
+		}
+		return []byte{0}, true
+	case int64:
+		return binary.BigEndian.AppendUint64(nil, uint64(v)), true
+	case float64:
+		return binary.BigEndian.AppendUint64(nil, math.Float64bits(v)), true
+	case time.Time:
+		return binary.BigEndian.AppendUint64(nil, uint64(v.UnixMicro())), true
+	}
+	return nil, false
+}
+
+// less orders two values of the same column.
+func less(a, b interface{}) bool {
+	switch a := a.(type) {
+	case string:
+		return a < b.(string)
+	case bool:
+		return !a && b.(bool)
+	case int64:
+		return a < b.(int64)
+	case float64:
+		return a < b.(float64)
+	case time.Time:
+		return a.Before(b.(time.Time))
+	}
+	return false
+}
+
+func lowerBound(value interface{}) *models_v1.Bound {
+	if s, ok := value.(string); ok && len(s) > MaxTextBound {
+		return &models_v1.Bound{Value: &models_v1.Bound_Text{Text: truncate(s)}}
+	}
+	return NewBound(value)
+}
+
+func upperBound(value interface{}) *models_v1.Bound {
+	if s, ok := value.(string); ok && len(s) > MaxTextBound {
+		return nil
+	}
+	return NewBound(value)
+}
+
+// NewBound returns the bound of a value, nil for values that can't be ordered.
+func NewBound(value interface{}) *models_v1.Bound {
+	switch v := value.(type) {
+	case string:
+		return &models_v1.Bound{Value: &models_v1.Bound_Text{Text: v}}
+	case bool:
+		return &models_v1.Bound{Value: &models_v1.Bound_Boolean{Boolean: v}}
+	case int64:
+		return &models_v1.Bound{Value: &models_v1.Bound_Integer{Integer: v}}
+	case float64:
+		return &models_v1.Bound{Value: &models_v1.Bound_Number{Number: v}}
+	case time.Time:
+		return &models_v1.Bound{Value: &models_v1.Bound_Timestamp{Timestamp: v.UnixMicro()}}
+	}
+	return nil
+}
+
+// BoundValue returns the value of a bound as the Go type records hold it as, nil when there is no bound.
+func BoundValue(bound *models_v1.Bound) interface{} {
+	switch v := bound.GetValue().(type) {
+	case *models_v1.Bound_Text:
+		return v.Text
+	case *models_v1.Bound_Boolean:
+		return v.Boolean
+	case *models_v1.Bound_Integer:
+		return v.Integer
+	case *models_v1.Bound_Number:
+		return v.Number
+	case *models_v1.Bound_Timestamp:
+		return time.UnixMicro(v.Timestamp).UTC()
+	}
+	return nil
+}
+
+// truncate shortens a string to MaxTextBound bytes without cutting a character in two.
+func truncate(s string) string {
+	end := MaxTextBound
+	for end > 0 && !utf8.RuneStart(s[end]) {
+		end--
+	}
+	return s[:end]
+}
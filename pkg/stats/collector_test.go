@@ -0,0 +1,128 @@
+package stats
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/records"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+var usersSchema = &models_v1.Schema{
+	Format: models_v1.Schema_NDJSON,
+	Fields: []*models_v1.Field{
+		{Name: "id", Type: models_v1.Field_INTEGER},
+		{Name: "name", Type: models_v1.Field_STRING, Nullable: true},
+		{Name: "score", Type: models_v1.Field_NUMBER, Nullable: true},
+		{Name: "joined", Type: models_v1.Field_TIMESTAMP},
+		{Name: "address", Type: models_v1.Field_OBJECT, Nullable: true, Fields: []*models_v1.Field{
+			{Name: "city", Type: models_v1.Field_STRING, Nullable: true},
+		}},
+		{Name: "tags", Type: models_v1.Field_ARRAY, Nullable: true, Items: &models_v1.Field{Type: models_v1.Field_STRING}},
+	},
+}
+
+func TestCollect(t *testing.T) {
+	content := "{\"id\": 2, \"name\": \"bo\", \"score\": 1.5, \"joined\": \"2024-01-02T00:00:00Z\", \"address\": {\"city\": \"Oslo\"}, \"tags\": [\"a\"]}\n" +
+		"{\"id\": 1, \"name\": null, \"score\": 3, \"joined\": \"2024-01-01T00:00:00Z\", \"tags\": [\"a\", \"b\"]}\n" +
+		"{\"id\": 3, \"name\": \"al\", \"joined\": \"2024-01-03T00:00:00Z\", \"address\": {\"city\": null}}\n"
+
+	statistics, err := Collect(usersSchema, records.NewJsonReader(strings.NewReader(content)))
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), statistics.Rows)
+
+	for _, c := range statistics.Columns {
+		c.Sketch = nil
+	}
+	expected := &models_v1.Statistics{
+		Rows: 3,
+		Columns: []*models_v1.ColumnStatistics{
+			{Name: "id", Type: models_v1.Field_INTEGER, Values: 3, Distinct: 3, Min: NewBound(int64(1)), Max: NewBound(int64(3))},
+			{Name: "name", Type: models_v1.Field_STRING, Values: 2, Nulls: 1, Distinct: 2, Min: NewBound("al"), Max: NewBound("bo")},
+			{Name: "score", Type: models_v1.Field_NUMBER, Values: 2, Nulls: 1, Distinct: 2, Min: NewBound(1.5), Max: NewBound(3.0)},
+			{Name: "joined", Type: models_v1.Field_TIMESTAMP, Values: 3, Distinct: 3,
+				Min: NewBound(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)), Max: NewBound(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC))},
+			// fields of objects are columns of their own, a missing object leaves them null
+			{Name: "address.city", Type: models_v1.Field_STRING, Values: 1, Nulls: 2, Distinct: 1, Min: NewBound("Oslo"), Max: NewBound("Oslo")},
+			// arrays can't be ordered, only their values are counted
+			{Name: "tags", Type: models_v1.Field_ARRAY, Values: 2, Nulls: 1},
+		},
+	}
+	assert.True(t, proto.Equal(expected, statistics), "got %v", statistics)
+}
+
+func TestCollect_Failure(t *testing.T) {
+	_, err := Collect(usersSchema, records.NewJsonReader(strings.NewReader("{\"id\": \"x\"}\n")))
+
+	assert.ErrorContains(t, err, "line 1")
+}
+
+func TestCollector_Bounds(t *testing.T) {
+	schema := &models_v1.Schema{Fields: []*models_v1.Field{
+		{Name: "text", Type: models_v1.Field_STRING},
+		{Name: "number", Type: models_v1.Field_NUMBER},
+	}}
+	long := strings.Repeat("é", MaxTextBound)
+
+	collector := NewCollector(schema)
+	collector.Add(map[string]interface{}{"text": "b" + long, "number": math.NaN()})
+	collector.Add(map[string]interface{}{"text": "c" + long, "number": 2.0})
+	statistics := collector.Statistics()
+
+	// a shortened min is still a lower bound, a shortened max isn't an upper bound anymore
+	text := statistics.Columns[0]
+	assert.Equal(t, "b"+strings.Repeat("é", (MaxTextBound-1)/2), text.Min.GetText())
+	assert.Nil(t, text.Max)
+
+	// NaN doesn't take part in the bounds, but is a distinct value
+	number := statistics.Columns[1]
+	assert.Equal(t, 2.0, number.Min.GetNumber())
+	assert.Equal(t, 2.0, number.Max.GetNumber())
+	assert.Equal(t, int64(2), number.Distinct)
+}
+
+func TestMerge(t *testing.T) {
+	collect := func(schema *models_v1.Schema, rows ...map[string]interface{}) *models_v1.Statistics {
+		collector := NewCollector(schema)
+		for _, row := range rows {
+			collector.Add(row)
+		}
+		return collector.Statistics()
+	}
+	v1 := &models_v1.Schema{Fields: []*models_v1.Field{{Name: "id", Type: models_v1.Field_INTEGER}}}
+	v2 := &models_v1.Schema{Fields: []*models_v1.Field{
+		{Name: "id", Type: models_v1.Field_INTEGER},
+		{Name: "name", Type: models_v1.Field_STRING},
+	}}
+
+	merged := Merge(
+		collect(v1, map[string]interface{}{"id": int64(5)}, map[string]interface{}{"id": int64(7)}),
+		collect(v2, map[string]interface{}{"id": int64(5), "name": "x"}),
+		collect(v1, map[string]interface{}{}),
+	)
+
+	assert.Equal(t, int64(4), merged.Rows)
+	id, name := merged.Columns[0], merged.Columns[1]
+	assert.Equal(t, []int64{3, 1, 2}, []int64{id.Values, id.Nulls, id.Distinct})
+	assert.Equal(t, int64(5), id.Min.GetInteger())
+	assert.Equal(t, int64(7), id.Max.GetInteger())
+	// rows of the files without the column are null in it
+	assert.Equal(t, []int64{1, 3, 1}, []int64{name.Values, name.Nulls, name.Distinct})
+	assert.Equal(t, "x", name.Max.GetText())
+
+	// a column that changed type keeps its counts only
+	changed := &models_v1.Schema{Fields: []*models_v1.Field{{Name: "id", Type: models_v1.Field_STRING}}}
+	merged = Merge(collect(v1, map[string]interface{}{"id": int64(5)}), collect(changed, map[string]interface{}{"id": "5"}))
+	assert.Equal(t, int64(2), merged.Columns[0].Values)
+	assert.Nil(t, merged.Columns[0].Min)
+	assert.Empty(t, merged.Columns[0].Sketch)
+
+	// a file without statistics leaves the merged ones unknown
+	assert.Nil(t, Merge(collect(v1), nil))
+	assert.Nil(t, Merge())
+}
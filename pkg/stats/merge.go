@@ -0,0 +1,109 @@
+package stats
+
+import (
+	"reflect"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// Merge returns the statistics of files together, like the file compacting them would have. It returns nil when any
+// of the files has no statistics. A column some of the files don't have is null in all of their rows.
+func Merge(statistics ...*models_v1.Statistics) *models_v1.Statistics {
+	if len(statistics) == 0 {
+		return nil
+	}
+
+	merged := &models_v1.Statistics{}
+	byName := make(map[string]*models_v1.ColumnStatistics)
+	for _, file := range statistics {
+		if file == nil {
+			return nil
+		}
+
+		for _, c := range file.Columns {
+			current, ok := byName[c.Name]
+			if !ok {
+				current = proto.Clone(c).(*models_v1.ColumnStatistics)
+				// rows of the files merged so far didn't have the column
+				current.Nulls += merged.Rows
+				byName[c.Name] = current
+				merged.Columns = append(merged.Columns, current)
+				continue
+			}
+			mergeColumn(current, c)
+		}
+
+		for _, current := range merged.Columns {
+			if !has(file, current.Name) {
+				current.Nulls += file.Rows
+			}
+		}
+
+		merged.Rows += file.Rows
+	}
+
+	return merged
+}
+
+func mergeColumn(merged, c *models_v1.ColumnStatistics) {
+	if merged.Type != c.Type {
+		// the type of the column changed between schema versions, the values can't be compared anymore
+		merged.Sketch, merged.Distinct, merged.Min, merged.Max = nil, 0, nil, nil
+	}
+
+	merged.Min = mergeBound(merged.Min, merged.Values, c.Min, c.Values, false)
+	merged.Max = mergeBound(merged.Max, merged.Values, c.Max, c.Values, true)
+	merged.Values += c.Values
+	merged.Nulls += c.Nulls
+
+	if merged.Type != c.Type || len(merged.Sketch) == 0 || len(c.Sketch) == 0 {
+		merged.Sketch, merged.Distinct = nil, 0
+		return
+	}
+
+	sketch, err := ParseSketch(merged.Sketch)
+	if err != nil {
+		merged.Sketch, merged.Distinct = nil, 0
+		return
+	}
+	other, err := ParseSketch(c.Sketch)
+	if err != nil {
+		merged.Sketch, merged.Distinct = nil, 0
+		return
+	}
+	sketch.Merge(other)
+	merged.Sketch = sketch.Bytes()
+	merged.Distinct = sketch.Estimate()
+}
+
+// mergeBound returns the smaller or, for upper bounds, the larger bound. A side without values doesn't bound
+// anything, while a missing bound of a side with values leaves the merged one unknown.
+func mergeBound(a *models_v1.Bound, aValues int64, b *models_v1.Bound, bValues int64, upper bool) *models_v1.Bound {
+	switch {
+	case bValues == 0:
+		return a
+	case aValues == 0:
+		return b
+	case a == nil || b == nil:
+		return nil
+	}
+
+	left, right := BoundValue(a), BoundValue(b)
+	if reflect.TypeOf(left) != reflect.TypeOf(right) {
+		return nil
+	}
+	if less(left, right) == upper {
+		return b
+	}
+	return a
+}
+
+func has(statistics *models_v1.Statistics, name string) bool {
+	for _, c := range statistics.Columns {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
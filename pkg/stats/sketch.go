@@ -0,0 +1,137 @@
+package stats
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/bits"
+)
+
+// precision is how many bits of a hash pick the register, 2^11 registers estimate within about 2.3%
+const precision = 11
+
+const registers = 1 << precision
+
+const (
+	denseEncoding  = 0
+	sparseEncoding = 1
+)
+
+// Sketch is a HyperLogLog sketch, it estimates how many distinct values were inserted in a fixed amount of memory.
+// Sketches of different files merge into the sketch of all of their values.
+type Sketch struct {
+	registers []byte
+}
+
+func NewSketch() *Sketch {
+	return &Sketch{registers: make([]byte, registers)}
+}
+
+// ParseSketch reads a sketch written by Bytes.
+func ParseSketch(data []byte) (*Sketch, error) {
+	sketch := NewSketch()
+	if len(data) == 0 {
+		return nil, fmt.Errorf("sketch is empty")
+	}
+
+	switch data[0] {
+	case denseEncoding:
+		if len(data) != registers+1 {
+			return nil, fmt.Errorf("sketch has %d registers, expected %d", len(data)-1, registers)
+		}
+		copy(sketch.registers, data[1:])
+	case sparseEncoding:
+		if (len(data)-1)%3 != 0 {
+			return nil, fmt.Errorf("sparse sketch has a truncated register")
+		}
+		for i := 1; i < len(data); i += 3 {
+			index := binary.BigEndian.Uint16(data[i:])
+			if int(index) >= registers {
+				return nil, fmt.Errorf("sparse sketch has register %d out of %d", index, registers)
+			}
+			sketch.registers[index] = data[i+2]
+		}
+	default:
+		return nil, fmt.Errorf("unknown sketch encoding %d", data[0])
+	}
+
+	return sketch, nil
+}
+
+// Insert adds a value to the sketch.
+func (sketch *Sketch) Insert(value []byte) {
+	h := fnv.New64a()
+	h.Write(value)
+	hash := mix(h.Sum64())
+
+	index := hash >> (64 - precision)
+	// the sentinel bit keeps the rank within the bits left once the index is taken off
+	rank := byte(bits.LeadingZeros64(hash<<precision|1<<(precision-1)) + 1)
+	if rank > sketch.registers[index] {
+		sketch.registers[index] = rank
+	}
+}
+
+// Merge adds the values of another sketch to this one.
+func (sketch *Sketch) Merge(other *Sketch) {
+	for i, rank := range other.registers {
+		if rank > sketch.registers[i] {
+			sketch.registers[i] = rank
+		}
+	}
+}
+
+// Estimate returns the estimated number of distinct values inserted.
+func (sketch *Sketch) Estimate() int64 {
+	m := float64(registers)
+	sum, zeros := 0.0, 0
+	for _, rank := range sketch.registers {
+		sum += math.Ldexp(1, -int(rank))
+		if rank == 0 {
+			zeros++
+		}
+	}
+
+	estimate := 0.7213 / (1 + 1.079/m) * m * m / sum
+	// few values leave most registers empty, counting them is more accurate than the harmonic mean then
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+
+	return int64(math.Round(estimate))
+}
+
+// Bytes encodes the sketch, sketches of few values only keep the registers that were set.
+func (sketch *Sketch) Bytes() []byte {
+	set := 0
+	for _, rank := range sketch.registers {
+		if rank != 0 {
+			set++
+		}
+	}
+
+	if set*3 >= registers {
+		return append([]byte{denseEncoding}, sketch.registers...)
+	}
+
+	data := make([]byte, 1, 1+set*3)
+	data[0] = sparseEncoding
+	for i, rank := range sketch.registers {
+		if rank != 0 {
+			data = binary.BigEndian.AppendUint16(data, uint16(i))
+			data = append(data, rank)
+		}
+	}
+	return data
+}
+
+// mix spreads the bits of an FNV hash, which differ little between similar short values, over the whole word.
+func mix(hash uint64) uint64 {
+	hash ^= hash >> 30
+	hash *= 0xbf58476d1ce4e5b9
+	hash ^= hash >> 27
+	hash *= 0x94d049bb133111eb
+	hash ^= hash >> 31
+	return hash
+}
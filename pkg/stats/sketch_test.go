@@ -0,0 +1,76 @@
+package stats
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSketch_Estimate(t *testing.T) {
+	tests := []int{0, 1, 10, 1000, 100000}
+
+	for _, distinct := range tests {
+		t.Run(fmt.Sprintf("%d values", distinct), func(t *testing.T) {
+			sketch := NewSketch()
+			// every value is inserted twice, duplicates don't count
+			for i := 0; i < distinct*2; i++ {
+				sketch.Insert([]byte(fmt.Sprintf("value-%d", i%distinct)))
+			}
+
+			assert.InEpsilon(t, float64(distinct)+1, float64(sketch.Estimate())+1, 0.1)
+		})
+	}
+}
+
+func TestSketch_Merge(t *testing.T) {
+	a, b := NewSketch(), NewSketch()
+	for i := 0; i < 5000; i++ {
+		a.Insert([]byte(fmt.Sprintf("%d", i)))
+		b.Insert([]byte(fmt.Sprintf("%d", i+2500)))
+	}
+
+	a.Merge(b)
+
+	assert.InEpsilon(t, 7500, a.Estimate(), 0.1)
+}
+
+func TestSketch_Bytes(t *testing.T) {
+	for _, distinct := range []int{3, 10000} {
+		sketch := NewSketch()
+		for i := 0; i < distinct; i++ {
+			sketch.Insert([]byte(fmt.Sprintf("%d", i)))
+		}
+
+		data := sketch.Bytes()
+		parsed, err := ParseSketch(data)
+
+		assert.Nil(t, err)
+		assert.Equal(t, sketch.registers, parsed.registers)
+		if distinct == 3 {
+			// few values only keep the registers they set
+			assert.Equal(t, byte(sparseEncoding), data[0])
+			assert.Len(t, data, 1+3*3)
+		} else {
+			assert.Equal(t, byte(denseEncoding), data[0])
+		}
+	}
+}
+
+func TestParseSketch_Failure(t *testing.T) {
+	tests := map[string][]byte{
+		"empty":                 {},
+		"unknown encoding":      {7, 1},
+		"short dense":           {denseEncoding, 1, 2},
+		"truncated sparse":      {sparseEncoding, 0, 1},
+		"register out of range": {sparseEncoding, 0xff, 0xff, 1},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseSketch(data)
+
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStorageImpl keeps files on disk, locations are file paths.
+type LocalStorageImpl struct{}
+
+func NewLocalStorage() *LocalStorageImpl {
+	return &LocalStorageImpl{}
+}
+
+func (storage *LocalStorageImpl) Read(location string) (io.ReadCloser, error) {
+	return os.Open(location)
+}
+
+func (storage *LocalStorageImpl) Open(location string) (File, error) {
+	file, err := os.Open(location)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &localFile{File: file, size: info.Size()}, nil
+}
+
+// Write creates the file under a temporary name and renames it into place once complete, so readers never see a
+// partial file.
+func (storage *LocalStorageImpl) Write(location string, body io.Reader, contentType string) error {
+	if err := os.MkdirAll(filepath.Dir(location), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(location), "."+filepath.Base(location)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := io.Copy(file, body); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), location)
+}
+
+// Create writes the file under a temporary name like Write, but links it into place instead of renaming it. Unlike
+// a rename, a link fails when the location is taken.
+func (storage *LocalStorageImpl) Create(location string, body io.Reader, contentType string) error {
+	if err := os.MkdirAll(filepath.Dir(location), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(location), "."+filepath.Base(location)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := io.Copy(file, body); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Link(file.Name(), location); err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return ErrExists
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Delete removes the file, files that are already gone are not an error.
+func (storage *LocalStorageImpl) Delete(location string) error {
+	if err := os.Remove(location); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// List walks the folder, skipping the temporary files of writes in progress. A folder that doesn't exist is empty.
+func (storage *LocalStorageImpl) List(prefix string) ([]string, error) {
+	locations := make([]string, 0)
+
+	err := filepath.WalkDir(prefix, func(location string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			locations = append(locations, location)
+		}
+		return nil
+	})
+	if errors.Is(err, os.ErrNotExist) {
+		return locations, nil
+	}
+
+	return locations, err
+}
+
+type localFile struct {
+	*os.File
+	size int64
+}
+
+func (file *localFile) Size() int64 {
+	return file.size
+}
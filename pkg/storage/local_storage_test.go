@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStorageImpl(t *testing.T) {
+	storage := NewLocalStorage()
+	folder := t.TempDir()
+	location := folder + "/orders/2024/01.parquet"
+
+	err := storage.Write(location, strings.NewReader("content"), "application/vnd.apache.parquet")
+	assert.Nil(t, err)
+
+	body, err := storage.Read(location)
+	assert.Nil(t, err)
+	content, _ := io.ReadAll(body)
+	body.Close()
+	assert.Equal(t, "content", string(content))
+
+	// no temporary files are left behind
+	entries, _ := os.ReadDir(folder + "/orders/2024")
+	assert.Len(t, entries, 1)
+
+	assert.Nil(t, storage.Delete(location))
+	assert.Nil(t, storage.Delete(location))
+
+	_, err = storage.Read(location)
+	assert.Error(t, err)
+}
+
+func TestLocalStorageImpl_CreateList(t *testing.T) {
+	storage := NewLocalStorage()
+	folder := t.TempDir()
+
+	assert.Nil(t, storage.Create(folder+"/orders/_log/1.json", strings.NewReader("first"), "application/json"))
+	assert.Equal(t, ErrExists, storage.Create(folder+"/orders/_log/1.json", strings.NewReader("second"), "application/json"))
+	assert.Nil(t, storage.Create(folder+"/orders/_log/2.json", strings.NewReader("second"), "application/json"))
+
+	body, _ := storage.Read(folder + "/orders/_log/1.json")
+	content, _ := io.ReadAll(body)
+	body.Close()
+	assert.Equal(t, "first", string(content))
+
+	locations, err := storage.List(folder + "/orders")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{folder + "/orders/_log/1.json", folder + "/orders/_log/2.json"}, locations)
+
+	locations, err = storage.List(folder + "/missing")
+	assert.Nil(t, err)
+	assert.Empty(t, locations)
+}
+
+func TestLocalStorageImpl_Open(t *testing.T) {
+	storage := NewLocalStorage()
+	location := t.TempDir() + "/orders.csv"
+	_ = storage.Write(location, strings.NewReader("id\n1\n"), "text/csv")
+
+	file, err := storage.Open(location)
+	assert.Nil(t, err)
+	defer file.Close()
+
+	assert.Equal(t, int64(5), file.Size())
+	part := make([]byte, 2)
+	_, err = file.ReadAt(part, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, "1\n", string(part))
+
+	_, err = storage.Open(location + ".missing")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
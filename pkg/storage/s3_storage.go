@@ -0,0 +1,131 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	awsClient "github.com/codingexplorations/data-lake/pkg/aws"
+)
+
+// S3StorageImpl keeps files in a bucket, locations are object keys.
+type S3StorageImpl struct {
+	s3Client   awsClient.S3Client
+	bucketName string
+}
+
+func NewS3Storage(s3Client awsClient.S3Client, bucketName string) *S3StorageImpl {
+	return &S3StorageImpl{
+		s3Client:   s3Client,
+		bucketName: bucketName,
+	}
+}
+
+func (storage *S3StorageImpl) Read(location string) (io.ReadCloser, error) {
+	getObject, err := storage.s3Client.GetObject(storage.bucketName, location)
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, fmt.Errorf("couldn't find object %v in bucket %v: %w", location, storage.bucketName, fs.ErrNotExist)
+		}
+		return nil, fmt.Errorf("couldn't get object %v in bucket %v: %v", location, storage.bucketName, err)
+	}
+	return getObject.Body, nil
+}
+
+// Open looks up the size of the object, reads are range requests for just the bytes asked for.
+func (storage *S3StorageImpl) Open(location string) (File, error) {
+	headObject, err := storage.s3Client.HeadObject(storage.bucketName, location)
+	if err != nil {
+		var notFound *types.NotFound
+		if errors.As(err, &notFound) {
+			return nil, fmt.Errorf("couldn't find object %v in bucket %v: %w", location, storage.bucketName, fs.ErrNotExist)
+		}
+		return nil, fmt.Errorf("couldn't head object %v in bucket %v: %v", location, storage.bucketName, err)
+	}
+
+	return &s3File{storage: storage, location: location, size: aws.ToInt64(headObject.ContentLength)}, nil
+}
+
+// Write uploads the object in one request, S3 only makes it visible once the upload completes.
+func (storage *S3StorageImpl) Write(location string, body io.Reader, contentType string) error {
+	if _, err := storage.s3Client.PutObject(storage.bucketName, location, body, contentType); err != nil {
+		return fmt.Errorf("couldn't put object %v in bucket %v: %v", location, storage.bucketName, err)
+	}
+	return nil
+}
+
+// Create uploads the object with If-None-Match, S3 rejects the upload if the key is taken.
+func (storage *S3StorageImpl) Create(location string, body io.Reader, contentType string) error {
+	if _, err := storage.s3Client.PutObjectIfAbsent(storage.bucketName, location, body, contentType); err != nil {
+		var apiErr smithy.APIError
+		// ConditionalRequestConflict means another upload to the key is in flight
+		if errors.As(err, &apiErr) && (apiErr.ErrorCode() == "PreconditionFailed" || apiErr.ErrorCode() == "ConditionalRequestConflict") {
+			return ErrExists
+		}
+		return fmt.Errorf("couldn't put object %v in bucket %v: %v", location, storage.bucketName, err)
+	}
+	return nil
+}
+
+func (storage *S3StorageImpl) Delete(location string) error {
+	if _, err := storage.s3Client.DeleteObject(storage.bucketName, location); err != nil {
+		return fmt.Errorf("couldn't delete object %v in bucket %v: %v", location, storage.bucketName, err)
+	}
+	return nil
+}
+
+func (storage *S3StorageImpl) List(prefix string) ([]string, error) {
+	objects, err := storage.s3Client.ListObjects(storage.bucketName, &prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	locations := make([]string, 0, len(objects))
+	for _, object := range objects {
+		locations = append(locations, *object.Key)
+	}
+
+	return locations, nil
+}
+
+type s3File struct {
+	storage  *S3StorageImpl
+	location string
+	size     int64
+}
+
+func (file *s3File) ReadAt(p []byte, offset int64) (int, error) {
+	if offset >= file.size {
+		return 0, io.EOF
+	}
+
+	length := int64(len(p))
+	if offset+length > file.size {
+		length = file.size - offset
+	}
+
+	getObject, err := file.storage.s3Client.GetObjectRange(file.storage.bucketName, file.location, offset, length)
+	if err != nil {
+		return 0, fmt.Errorf("couldn't get bytes %d-%d of object %v in bucket %v: %v", offset, offset+length-1, file.location, file.storage.bucketName, err)
+	}
+	defer getObject.Body.Close()
+
+	n, err := io.ReadFull(getObject.Body, p[:length])
+	if err == nil && n < len(p) {
+		err = io.EOF
+	}
+	return n, err
+}
+
+func (file *s3File) Close() error {
+	return nil
+}
+
+func (file *s3File) Size() int64 {
+	return file.size
+}
@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	mocks "github.com/codingexplorations/data-lake/test/mocks/pkg/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestS3StorageImpl(t *testing.T) {
+	s3Client := mocks.NewS3Client(t)
+	storage := NewS3Storage(s3Client, "curated-bucket")
+
+	s3Client.On("PutObject", "curated-bucket", "orders/01.parquet", mock.Anything, "application/vnd.apache.parquet").Return(&s3.PutObjectOutput{}, nil)
+	s3Client.On("GetObject", "curated-bucket", "orders/01.parquet").Return(&s3.GetObjectOutput{Body: io.NopCloser(strings.NewReader("content"))}, nil)
+	s3Client.On("DeleteObject", "curated-bucket", "orders/01.parquet").Return(&s3.DeleteObjectOutput{}, nil)
+
+	assert.Nil(t, storage.Write("orders/01.parquet", strings.NewReader("content"), "application/vnd.apache.parquet"))
+
+	body, err := storage.Read("orders/01.parquet")
+	assert.Nil(t, err)
+	content, _ := io.ReadAll(body)
+	assert.Equal(t, "content", string(content))
+
+	assert.Nil(t, storage.Delete("orders/01.parquet"))
+}
+
+func TestS3StorageImpl_Failure(t *testing.T) {
+	s3Client := mocks.NewS3Client(t)
+	storage := NewS3Storage(s3Client, "curated-bucket")
+
+	s3Client.On("GetObject", "curated-bucket", "orders/01.parquet").Return(nil, errors.New("NoSuchKey"))
+
+	_, err := storage.Read("orders/01.parquet")
+	assert.Equal(t, "couldn't get object orders/01.parquet in bucket curated-bucket: NoSuchKey", err.Error())
+}
+
+func TestS3StorageImpl_CreateList(t *testing.T) {
+	s3Client := mocks.NewS3Client(t)
+	storage := NewS3Storage(s3Client, "curated-bucket")
+
+	s3Client.On("PutObjectIfAbsent", "curated-bucket", "_catalog/orders/_log/1.json", mock.Anything, "application/json").Return(&s3.PutObjectOutput{}, nil).Once()
+	s3Client.On("PutObjectIfAbsent", "curated-bucket", "_catalog/orders/_log/1.json", mock.Anything, "application/json").Return(nil, &smithy.GenericAPIError{Code: "PreconditionFailed"}).Once()
+	s3Client.On("PutObjectIfAbsent", "curated-bucket", "_catalog/orders/_log/2.json", mock.Anything, "application/json").Return(nil, errors.New("AccessDenied"))
+	s3Client.On("ListObjects", "curated-bucket", aws.String("_catalog/orders")).Return([]types.Object{{Key: aws.String("_catalog/orders/_log/1.json")}}, nil)
+
+	assert.Nil(t, storage.Create("_catalog/orders/_log/1.json", strings.NewReader("first"), "application/json"))
+	assert.Equal(t, ErrExists, storage.Create("_catalog/orders/_log/1.json", strings.NewReader("second"), "application/json"))
+
+	err := storage.Create("_catalog/orders/_log/2.json", strings.NewReader("second"), "application/json")
+	assert.Equal(t, "couldn't put object _catalog/orders/_log/2.json in bucket curated-bucket: AccessDenied", err.Error())
+
+	locations, err := storage.List("_catalog/orders")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"_catalog/orders/_log/1.json"}, locations)
+}
+
+func TestS3StorageImpl_Read_Missing(t *testing.T) {
+	s3Client := mocks.NewS3Client(t)
+	storage := NewS3Storage(s3Client, "curated-bucket")
+
+	s3Client.On("GetObject", "curated-bucket", "_catalog/orders/_log/2.json").Return(nil, &types.NoSuchKey{})
+
+	_, err := storage.Read("_catalog/orders/_log/2.json")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestS3StorageImpl_Open(t *testing.T) {
+	s3Client := mocks.NewS3Client(t)
+	storage := NewS3Storage(s3Client, "curated-bucket")
+
+	s3Client.On("HeadObject", "curated-bucket", "orders/01.csv").Return(&s3.HeadObjectOutput{ContentLength: aws.Int64(10)}, nil)
+	s3Client.On("GetObjectRange", "curated-bucket", "orders/01.csv", int64(2), int64(4)).Return(&s3.GetObjectOutput{Body: io.NopCloser(strings.NewReader("2345"))}, nil)
+	s3Client.On("GetObjectRange", "curated-bucket", "orders/01.csv", int64(8), int64(2)).Return(&s3.GetObjectOutput{Body: io.NopCloser(strings.NewReader("89"))}, nil)
+	s3Client.On("HeadObject", "curated-bucket", "orders/02.csv").Return(nil, &types.NotFound{})
+
+	file, err := storage.Open("orders/01.csv")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10), file.Size())
+
+	part := make([]byte, 4)
+	n, err := file.ReadAt(part, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, "2345", string(part[:n]))
+
+	// reads past the end of the object only ask for what's left
+	n, err = file.ReadAt(part, 8)
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, "89", string(part[:n]))
+
+	_, err = file.ReadAt(part, 10)
+	assert.Equal(t, io.EOF, err)
+
+	_, err = storage.Open("orders/02.csv")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
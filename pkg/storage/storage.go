@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"errors"
+	"io"
+
+	"github.com/codingexplorations/data-lake/pkg/aws"
+	"github.com/codingexplorations/data-lake/pkg/config"
+)
+
+// ErrExists is returned by Create when there is already a file at the location.
+var ErrExists = errors.New("file already exists")
+
+// ReadChunkSize is how much of a File to read at once when reading it from start to end, each chunk is a request on S3.
+const ReadChunkSize = 1024 * 1024
+
+// File is an open file that can be read at any offset, on S3 every read is a range request.
+type File interface {
+	io.ReaderAt
+	io.Closer
+	Size() int64
+}
+
+// Storage reads and writes the files of a zone by the locations the catalog keeps for them.
+type Storage interface {
+	// Read opens a file for reading from start to end, missing files are reported as fs.ErrNotExist
+	Read(location string) (io.ReadCloser, error)
+	// Open opens a file for reads of parts of it, missing files are reported as fs.ErrNotExist
+	Open(location string) (File, error)
+	Write(location string, body io.Reader, contentType string) error
+	// Create writes a file only if there is none at the location yet, of any number of writers racing to create the
+	// same location exactly one succeeds
+	Create(location string, body io.Reader, contentType string) error
+	Delete(location string) error
+	// List returns the locations of all files below a folder or key prefix
+	List(prefix string) ([]string, error)
+}
+
+// GetIngestStorage returns the storage of the ingest zone, where locations are the FileLocation of ingested objects.
+func GetIngestStorage(conf *config.Config) (Storage, error) {
+	switch conf.IngestProcessorType {
+	case "localstack":
+		s3Client, err := aws.NewS3()
+		if err != nil {
+			return nil, err
+		}
+		return NewS3Storage(&s3Client, conf.AwsBucketName), nil
+	default:
+		return NewLocalStorage(), nil
+	}
+}
+
+// GetCuratedStorage returns the storage of the curated zone for the configured ingest processor.
+func GetCuratedStorage(conf *config.Config) (Storage, error) {
+	switch conf.IngestProcessorType {
+	case "localstack":
+		s3Client, err := aws.NewS3()
+		if err != nil {
+			return nil, err
+		}
+		return NewS3Storage(&s3Client, conf.AwsCuratedBucketName), nil
+	default:
+		return NewLocalStorage(), nil
+	}
+}
@@ -0,0 +1,87 @@
+package tracing
+
+import (
+	"context"
+
+	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
+	awsMiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/aws/smithy-go/middleware"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// maxMessageAttributes is the most attributes an SQS message can carry.
+const maxMessageAttributes = 10
+
+// AwsCalls traces every call of an AWS client, as a client span named after the service and operation, like
+// S3.GetObject, that is a child of the span in the context the call is made in. It goes in the APIOptions of the
+// config clients are made from.
+func AwsCalls(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("Tracing", func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+		service, operation := awsMiddleware.GetServiceID(ctx), awsMiddleware.GetOperationName(ctx)
+		ctx, span := otel.Tracer(TracerName).Start(ctx, service+"."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.RPCSystemKey.String("aws-api"),
+				semconv.RPCService(service),
+				semconv.RPCMethod(operation),
+				attribute.String("cloud.region", awsMiddleware.GetRegion(ctx)),
+			),
+		)
+
+		out, metadata, err := next.HandleInitialize(ctx, in)
+		End(span, err)
+		return out, metadata, err
+	}), middleware.Before)
+}
+
+// messageCarrier reads and writes the trace context of a message in its attributes.
+type messageCarrier map[string]types.MessageAttributeValue
+
+// Get falls back to the ce- prefixed attribute, where binary CloudEvents carry their trace context.
+func (carrier messageCarrier) Get(key string) string {
+	if value, ok := carrier[key]; ok {
+		return awsSdk.ToString(value.StringValue)
+	}
+	return awsSdk.ToString(carrier["ce-"+key].StringValue)
+}
+
+func (carrier messageCarrier) Set(key string, value string) {
+	carrier[key] = types.MessageAttributeValue{DataType: awsSdk.String("String"), StringValue: awsSdk.String(value)}
+}
+
+func (carrier messageCarrier) Keys() []string {
+	keys := make([]string, 0, len(carrier))
+	for key := range carrier {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Inject adds the trace context of the span in ctx to the attributes of a message, as traceparent and tracestate, so
+// whoever handles the message continues the trace. A message without room for them goes without, SQS takes no more
+// than 10 attributes.
+func Inject(ctx context.Context, attributes map[string]types.MessageAttributeValue) map[string]types.MessageAttributeValue {
+	injected := messageCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, injected)
+	if len(injected) == 0 || len(attributes)+len(injected) > maxMessageAttributes {
+		return attributes
+	}
+
+	if attributes == nil {
+		attributes = make(map[string]types.MessageAttributeValue, len(injected))
+	}
+	for key, value := range injected {
+		attributes[key] = value
+	}
+	return attributes
+}
+
+// Extract returns ctx holding the trace context a message was sent in, for the spans of handling it to be part of
+// the trace.
+func Extract(ctx context.Context, message types.Message) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, messageCarrier(message.MessageAttributes))
+}
@@ -0,0 +1,82 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/codingexplorations/data-lake/pkg/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the instrumentation scope of the spans of the lake.
+const TracerName = "github.com/codingexplorations/data-lake"
+
+// The exporters TRACING_EXPORTER can name. Without one spans aren't recorded.
+const (
+	// Stdout writes every span as JSON to standard output
+	Stdout = "stdout"
+	// Otlp sends spans over OTLP/HTTP to the collector at TRACING_OTLP_ENDPOINT
+	Otlp = "otlp"
+)
+
+// Setup installs the tracer provider exporting to TRACING_EXPORTER, sampling TRACING_SAMPLE_RATIO of the traces that
+// start in the lake, and the W3C trace context propagator. The returned function flushes the spans still buffered and
+// stops exporting.
+func Setup(conf *config.Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch strings.ToLower(conf.TracingExporter) {
+	case "", "none":
+		return func(context.Context) error { return nil }, nil
+	case Stdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case Otlp:
+		exporter, err = otlptracehttp.New(context.Background(), otlptracehttp.WithEndpointURL(conf.TracingOtlpEndpoint))
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", conf.TracingExporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create %v span exporter: %v", conf.TracingExporter, err)
+	}
+
+	serviceName := conf.TracingServiceName
+	if serviceName == "" {
+		serviceName = "data-lake"
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(conf.TracingSampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Start starts a span as a child of the span in ctx, returning the context holding it.
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// End ends a span, marking it failed when err isn't nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
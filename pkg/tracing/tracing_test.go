@@ -0,0 +1,112 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/codingexplorations/data-lake/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// record makes spans be recorded for the test, returning what records them.
+func record(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		_ = provider.Shutdown(context.Background())
+	})
+
+	return recorder
+}
+
+func TestSetup(t *testing.T) {
+	shutdown, err := Setup(&config.Config{})
+	assert.Nil(t, err)
+	assert.Nil(t, shutdown(context.Background()))
+
+	shutdown, err = Setup(&config.Config{TracingExporter: "Stdout", TracingSampleRatio: 1})
+	assert.Nil(t, err)
+	assert.Nil(t, shutdown(context.Background()))
+
+	_, err = Setup(&config.Config{TracingExporter: "zipkin"})
+	assert.Equal(t, `unknown tracing exporter "zipkin"`, err.Error())
+}
+
+func TestStart(t *testing.T) {
+	recorder := record(t)
+
+	ctx, run := Start(context.Background(), "Runner.Run")
+	_, file := Start(ctx, "ProcessFile", attribute.String("location", "orders/01.csv"))
+	End(file, errors.New("access denied"))
+	End(run, nil)
+
+	spans := recorder.Ended()
+	assert.Equal(t, 2, len(spans))
+
+	assert.Equal(t, "ProcessFile", spans[0].Name())
+	assert.Equal(t, run.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, []attribute.KeyValue{attribute.String("location", "orders/01.csv")}, spans[0].Attributes())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "access denied", spans[0].Status().Description)
+	assert.Equal(t, 1, len(spans[0].Events()))
+
+	assert.Equal(t, "Runner.Run", spans[1].Name())
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+}
+
+func TestInject(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	record(t)
+
+	ctx, span := Start(context.Background(), "ProcessFile")
+	defer span.End()
+
+	attributes := Inject(ctx, map[string]types.MessageAttributeValue{
+		"content-type": {DataType: awsSdk.String("String"), StringValue: awsSdk.String("application/json")},
+	})
+	assert.Equal(t, 2, len(attributes))
+	assert.Equal(t, "String", *attributes["traceparent"].DataType)
+
+	extracted := trace.SpanContextFromContext(Extract(context.Background(), types.Message{MessageAttributes: attributes}))
+	assert.True(t, extracted.IsRemote())
+	assert.Equal(t, span.SpanContext().TraceID(), extracted.TraceID())
+	assert.Equal(t, span.SpanContext().SpanID(), extracted.SpanID())
+
+	// messages without room for the trace context are sent without
+	full := make(map[string]types.MessageAttributeValue)
+	for i := 0; i < maxMessageAttributes; i++ {
+		full[strconv.Itoa(i)] = types.MessageAttributeValue{DataType: awsSdk.String("String"), StringValue: awsSdk.String("value")}
+	}
+	assert.Equal(t, maxMessageAttributes, len(Inject(ctx, full)))
+
+	// outside of a span there is nothing to add
+	assert.Nil(t, Inject(context.Background(), nil))
+}
+
+func TestExtract(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	// binary CloudEvents carry the trace context in ce- prefixed attributes
+	ctx := Extract(context.Background(), types.Message{MessageAttributes: map[string]types.MessageAttributeValue{
+		"ce-traceparent": {DataType: awsSdk.String("String"), StringValue: awsSdk.String("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")},
+	}})
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", trace.SpanContextFromContext(ctx).TraceID().String())
+
+	// messages sent outside of a trace start their own
+	assert.False(t, trace.SpanContextFromContext(Extract(context.Background(), types.Message{})).IsValid())
+}
@@ -0,0 +1,94 @@
+package validation
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+var descriptorSetLock = &sync.Mutex{}
+
+// descriptorSets caches the files of every descriptor set read so far, keyed by file name
+var descriptorSets = map[string]*protoregistry.Files{}
+
+// LoadMessageDescriptor finds a message in a descriptor set built with `buf build -o <file>`. Imports left out of the
+// set, such as buf/validate/validate.proto, are resolved from the descriptors compiled into the binary.
+func LoadMessageDescriptor(descriptorSetFile string, messageName string) (protoreflect.MessageDescriptor, error) {
+	files, err := loadDescriptorSet(descriptorSetFile)
+	if err != nil {
+		return nil, err
+	}
+
+	descriptor, err := files.FindDescriptorByName(protoreflect.FullName(messageName))
+	if err != nil {
+		return nil, fmt.Errorf("message %v not found in %v: %v", messageName, descriptorSetFile, err)
+	}
+
+	message, ok := descriptor.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%v in %v is not a message", messageName, descriptorSetFile)
+	}
+
+	return message, nil
+}
+
+func loadDescriptorSet(descriptorSetFile string) (*protoregistry.Files, error) {
+	descriptorSetLock.Lock()
+	defer descriptorSetLock.Unlock()
+
+	if files, ok := descriptorSets[descriptorSetFile]; ok {
+		return files, nil
+	}
+
+	data, err := os.ReadFile(descriptorSetFile)
+	if err != nil {
+		return nil, err
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(data, set); err != nil {
+		return nil, fmt.Errorf("failed to parse descriptor set %v: %v", descriptorSetFile, err)
+	}
+
+	files := &protoregistry.Files{}
+	resolver := &fallbackResolver{files: files}
+	// buf writes files after the files they import, so one pass in order is enough
+	for _, file := range set.GetFile() {
+		descriptor, err := protodesc.NewFile(file, resolver)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %v from descriptor set %v: %v", file.GetName(), descriptorSetFile, err)
+		}
+		if err := files.RegisterFile(descriptor); err != nil {
+			return nil, err
+		}
+	}
+
+	descriptorSets[descriptorSetFile] = files
+
+	return files, nil
+}
+
+// fallbackResolver looks in the descriptor set first and then in the global registry.
+type fallbackResolver struct {
+	files *protoregistry.Files
+}
+
+func (resolver *fallbackResolver) FindFileByPath(path string) (protoreflect.FileDescriptor, error) {
+	if file, err := resolver.files.FindFileByPath(path); err == nil {
+		return file, nil
+	}
+	return protoregistry.GlobalFiles.FindFileByPath(path)
+}
+
+func (resolver *fallbackResolver) FindDescriptorByName(name protoreflect.FullName) (protoreflect.Descriptor, error) {
+	if descriptor, err := resolver.files.FindDescriptorByName(name); err == nil {
+		return descriptor, nil
+	}
+	return protoregistry.GlobalFiles.FindDescriptorByName(name)
+}
@@ -0,0 +1,53 @@
+package validation
+
+import (
+	"os"
+	"testing"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// writeDescriptorSet writes the models descriptor without its imports, like `buf build --exclude-imports` would.
+func writeDescriptorSet(t *testing.T) string {
+	set := &descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{protodesc.ToFileDescriptorProto(models_v1.File_models_v1_schema_proto)},
+	}
+	data, _ := proto.Marshal(set)
+
+	fileName := t.TempDir() + "/descriptors.binpb"
+	_ = os.WriteFile(fileName, data, 0644)
+
+	return fileName
+}
+
+func TestLoadMessageDescriptor(t *testing.T) {
+	fileName := writeDescriptorSet(t)
+
+	descriptor, err := LoadMessageDescriptor(fileName, "models.v1.Object")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "models.v1.Object", string(descriptor.FullName()))
+	assert.NotNil(t, descriptor.Fields().ByName("file_name"))
+
+	// loaded from the cache the second time
+	cached, err := LoadMessageDescriptor(fileName, "models.v1.Object")
+	assert.Nil(t, err)
+	assert.Equal(t, descriptor, cached)
+}
+
+func TestLoadMessageDescriptor_Failure(t *testing.T) {
+	fileName := writeDescriptorSet(t)
+
+	_, err := LoadMessageDescriptor(fileName, "models.v1.Missing")
+	assert.Error(t, err)
+
+	_, err = LoadMessageDescriptor(fileName, "models.v1.Log.LogLevel")
+	assert.Error(t, err)
+
+	_, err = LoadMessageDescriptor("/tmp/should/not/be/there/descriptors.binpb", "models.v1.Object")
+	assert.Error(t, err)
+}
@@ -0,0 +1,233 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bufbuild/protovalidate-go"
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/records"
+	"github.com/codingexplorations/data-lake/pkg/schema"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// ParseConstraint is the constraint reported when a value can't be read as its field's type at all.
+const ParseConstraint = "parse"
+
+type RecordValidationError struct {
+	Report *models_v1.ValidationReport
+}
+
+func (err *RecordValidationError) Error() string {
+	message := fmt.Sprintf("%d of %d records are not valid %v messages", err.Report.InvalidRecords, err.Report.Records, err.Report.Message)
+	if len(err.Report.Violations) > 0 {
+		first := err.Report.Violations[0]
+		message += fmt.Sprintf(", first on line %d: %v: %v", first.Line, first.Field, first.Message)
+	}
+	return message
+}
+
+// RecordValidator reads each record of a file into a dynamic message and runs protovalidate on it.
+type RecordValidator struct {
+	descriptor    protoreflect.MessageDescriptor
+	validator     *protovalidate.Validator
+	maxViolations int
+}
+
+func NewRecordValidator(descriptor protoreflect.MessageDescriptor, maxViolations int) (*RecordValidator, error) {
+	validator, err := protovalidate.New(protovalidate.WithDescriptors(descriptor))
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize proto validator: %v", err)
+	}
+
+	return &RecordValidator{
+		descriptor:    descriptor,
+		validator:     validator,
+		maxViolations: maxViolations,
+	}, nil
+}
+
+// Validate checks every record the reader returns. Only problems with reading the file or with the constraints
+// themselves are returned as errors, invalid records are counted in the report.
+func (validator *RecordValidator) Validate(reader records.Reader) (*models_v1.ValidationReport, error) {
+	report := &models_v1.ValidationReport{
+		Message: string(validator.descriptor.FullName()),
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		violations, err := validator.validateRecord(record)
+		if err != nil {
+			return nil, err
+		}
+
+		report.Records++
+		if len(violations) == 0 {
+			continue
+		}
+
+		report.InvalidRecords++
+		for _, violation := range violations {
+			if len(report.Violations) >= validator.maxViolations {
+				report.Truncated = true
+				break
+			}
+			report.Violations = append(report.Violations, violation)
+		}
+	}
+
+	return report, nil
+}
+
+func (validator *RecordValidator) validateRecord(record *records.Record) ([]*models_v1.RecordViolation, error) {
+	message, violations := validator.toMessage(record)
+
+	err := validator.validator.Validate(message)
+	var validationErr *protovalidate.ValidationError
+	if errors.As(err, &validationErr) {
+		for _, violation := range validationErr.Violations {
+			violations = append(violations, &models_v1.RecordViolation{
+				Line:       int32(record.Line),
+				Field:      violation.GetFieldPath(),
+				Constraint: violation.GetConstraintId(),
+				Message:    violation.GetMessage(),
+			})
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to validate record on line %d: %v", record.Line, err)
+	}
+
+	return violations, nil
+}
+
+// toMessage sets each field of the record on a new message. Fields are read one at a time through protojson so a
+// bad value can be pinned to its field, unknown fields are ignored.
+func (validator *RecordValidator) toMessage(record *records.Record) (*dynamicpb.Message, []*models_v1.RecordViolation) {
+	message := dynamicpb.NewMessage(validator.descriptor)
+	violations := make([]*models_v1.RecordViolation, 0)
+
+	names := make([]string, 0, len(record.Fields))
+	for name := range record.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		field := findField(validator.descriptor, name)
+		if field == nil {
+			continue
+		}
+
+		value, err := jsonValue(field, record.Fields[name])
+		if err == nil && value == nil {
+			continue
+		}
+
+		part := dynamicpb.NewMessage(validator.descriptor)
+		if err == nil {
+			data, _ := json.Marshal(map[string]interface{}{field.JSONName(): value})
+			err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, part)
+		}
+		if err != nil {
+			violations = append(violations, &models_v1.RecordViolation{
+				Line:       int32(record.Line),
+				Field:      string(field.Name()),
+				Constraint: ParseConstraint,
+				Message:    fmt.Sprintf("value %v is not a valid %v", describe(record.Fields[name]), describeKind(field)),
+			})
+			continue
+		}
+
+		proto.Merge(message, part)
+	}
+
+	return message, violations
+}
+
+// findField matches a column or key to a field by its proto name or JSON name.
+func findField(descriptor protoreflect.MessageDescriptor, name string) protoreflect.FieldDescriptor {
+	if field := descriptor.Fields().ByName(protoreflect.Name(name)); field != nil {
+		return field
+	}
+	return descriptor.Fields().ByJSONName(name)
+}
+
+// jsonValue converts strings, which is all a CSV file has, into what protojson expects for the field. Empty strings
+// leave the field unset.
+func jsonValue(field protoreflect.FieldDescriptor, value interface{}) (interface{}, error) {
+	s, ok := value.(string)
+	if !ok {
+		return value, nil
+	}
+
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	if field.IsList() || field.IsMap() {
+		var decoded interface{}
+		err := json.Unmarshal([]byte(s), &decoded)
+		return decoded, err
+	}
+
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		return strconv.ParseBool(strings.TrimSpace(s))
+	case protoreflect.EnumKind:
+		if _, err := strconv.ParseInt(s, 10, 32); err == nil {
+			return json.Number(s), nil
+		}
+	case protoreflect.MessageKind:
+		if field.Message().FullName() == "google.protobuf.Timestamp" {
+			parsed, err := schema.ParseTimestamp(s)
+			if err != nil {
+				return nil, err
+			}
+			return parsed.UTC().Format(time.RFC3339Nano), nil
+		}
+
+		var decoded interface{}
+		err := json.Unmarshal([]byte(s), &decoded)
+		return decoded, err
+	}
+
+	return s, nil
+}
+
+func describe(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
+func describeKind(field protoreflect.FieldDescriptor) string {
+	kind := field.Kind().String()
+	if field.Kind() == protoreflect.MessageKind || field.Kind() == protoreflect.GroupKind {
+		kind = string(field.Message().FullName())
+	}
+	if field.Kind() == protoreflect.EnumKind {
+		kind = string(field.Enum().FullName())
+	}
+	if field.IsList() {
+		return "list of " + kind
+	}
+	return kind
+}
@@ -0,0 +1,73 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	models_v1 "github.com/codingexplorations/data-lake/models/v1"
+	"github.com/codingexplorations/data-lake/pkg/records"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordValidator_ValidateCsv(t *testing.T) {
+	descriptor, _ := LoadMessageDescriptor(writeDescriptorSet(t), "models.v1.Object")
+	validator, err := NewRecordValidator(descriptor, 100)
+	assert.Nil(t, err)
+
+	content := "file_name,fileLocation,content_type,content_size,extra\n" +
+		"a.txt,/a,text/plain,10,x\n" +
+		",/b,text/plain,5,x\n" +
+		"c.txt,/c,text/plain,abc,x\n"
+	schema := &models_v1.Schema{Format: models_v1.Schema_CSV, Header: true}
+
+	report, err := validator.Validate(records.NewCsvRecordReader(strings.NewReader(content), schema))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "models.v1.Object", report.Message)
+	assert.Equal(t, int32(3), report.Records)
+	assert.Equal(t, int32(2), report.InvalidRecords)
+	assert.False(t, report.Truncated)
+	assert.Equal(t, []*models_v1.RecordViolation{
+		{Line: 3, Field: "file_name", Constraint: "required", Message: "value is required"},
+		{Line: 4, Field: "content_size", Constraint: ParseConstraint, Message: "value \"abc\" is not a valid int32"},
+		{Line: 4, Field: "content_size", Constraint: "int32.gt_lte", Message: "value must be greater than 0 and less than or equal to 1048576"},
+	}, report.Violations)
+}
+
+func TestRecordValidator_ValidateJson(t *testing.T) {
+	descriptor, _ := LoadMessageDescriptor(writeDescriptorSet(t), "models.v1.Object")
+	validator, _ := NewRecordValidator(descriptor, 1)
+
+	content := "{\"fileName\": \"a.txt\", \"file_location\": \"/a\", \"content_type\": \"text/plain\", \"content_size\": 10, \"schema\": {\"format\": \"CSV\"}}\n" +
+		"{\"file_name\": \"b.txt\", \"file_location\": \"/b\", \"content_type\": \"text/plain\", \"content_size\": 4294967296, \"schema\": {\"format\": \"XML\"}}\n"
+
+	report, err := validator.Validate(records.NewJsonReader(strings.NewReader(content)))
+
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), report.Records)
+	assert.Equal(t, int32(1), report.InvalidRecords)
+	assert.True(t, report.Truncated)
+	assert.Equal(t, []*models_v1.RecordViolation{
+		{Line: 2, Field: "content_size", Constraint: ParseConstraint, Message: "value 4294967296 is not a valid int32"},
+	}, report.Violations)
+}
+
+func TestRecordValidator_ValidateReadFailure(t *testing.T) {
+	descriptor, _ := LoadMessageDescriptor(writeDescriptorSet(t), "models.v1.Object")
+	validator, _ := NewRecordValidator(descriptor, 100)
+
+	_, err := validator.Validate(records.NewJsonReader(strings.NewReader("{\"file_name\": ")))
+
+	assert.Error(t, err)
+}
+
+func TestRecordValidationError_Error(t *testing.T) {
+	err := &RecordValidationError{Report: &models_v1.ValidationReport{
+		Message:        "models.v1.Object",
+		Records:        10,
+		InvalidRecords: 2,
+		Violations:     []*models_v1.RecordViolation{{Line: 4, Field: "file_name", Constraint: "required", Message: "value is required"}},
+	}}
+
+	assert.Equal(t, "2 of 10 records are not valid models.v1.Object messages, first on line 4: file_name: value is required", err.Error())
+}
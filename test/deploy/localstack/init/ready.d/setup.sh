@@ -12,6 +12,10 @@ export AWS_DEFAULT_PROFILE=localstack
 
 # Create queues
 awslocal sqs create-queue --region $AWS_REGION --queue-name $TEST_INGEST_QUEUE_NAME --attributes '{"ReceiveMessageWaitTimeSeconds": "20"}'
+awslocal sqs create-queue --region $AWS_REGION --queue-name $TEST_INGEST_DEAD_LETTER_QUEUE_NAME
+
+# Create topics
+awslocal sns create-topic --region $AWS_REGION --name $TEST_INGEST_EVENT_TOPIC_NAME
 
 # Create buckets
 awslocal s3 mb s3://$TEST_INGEST_BUCKET_NAME
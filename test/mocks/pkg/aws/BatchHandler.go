@@ -0,0 +1,45 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	types "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BatchHandler is an autogenerated mock type for the BatchHandler type
+type BatchHandler struct {
+	mock.Mock
+}
+
+// Execute provides a mock function with given fields: messages
+func (_m *BatchHandler) Execute(messages []types.Message) error {
+	ret := _m.Called(messages)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Execute")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]types.Message) error); ok {
+		r0 = rf(messages)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewBatchHandler creates a new instance of BatchHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBatchHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BatchHandler {
+	mock := &BatchHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
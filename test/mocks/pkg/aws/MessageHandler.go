@@ -0,0 +1,47 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	types "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MessageHandler is an autogenerated mock type for the MessageHandler type
+type MessageHandler struct {
+	mock.Mock
+}
+
+// Execute provides a mock function with given fields: ctx, message
+func (_m *MessageHandler) Execute(ctx context.Context, message types.Message) error {
+	ret := _m.Called(ctx, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Execute")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.Message) error); ok {
+		r0 = rf(ctx, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewMessageHandler creates a new instance of MessageHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMessageHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MessageHandler {
+	mock := &MessageHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	io "io"
+
 	s3 "github.com/aws/aws-sdk-go-v2/service/s3"
 	mock "github.com/stretchr/testify/mock"
 
@@ -14,6 +16,96 @@ type S3Client struct {
 	mock.Mock
 }
 
+// DeleteObject provides a mock function with given fields: bucketName, objectKey
+func (_m *S3Client) DeleteObject(bucketName string, objectKey string) (*s3.DeleteObjectOutput, error) {
+	ret := _m.Called(bucketName, objectKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteObject")
+	}
+
+	var r0 *s3.DeleteObjectOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (*s3.DeleteObjectOutput, error)); ok {
+		return rf(bucketName, objectKey)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) *s3.DeleteObjectOutput); ok {
+		r0 = rf(bucketName, objectKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*s3.DeleteObjectOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(bucketName, objectKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetObject provides a mock function with given fields: bucketName, objectKey
+func (_m *S3Client) GetObject(bucketName string, objectKey string) (*s3.GetObjectOutput, error) {
+	ret := _m.Called(bucketName, objectKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetObject")
+	}
+
+	var r0 *s3.GetObjectOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (*s3.GetObjectOutput, error)); ok {
+		return rf(bucketName, objectKey)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) *s3.GetObjectOutput); ok {
+		r0 = rf(bucketName, objectKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*s3.GetObjectOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(bucketName, objectKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetObjectRange provides a mock function with given fields: bucketName, objectKey, offset, length
+func (_m *S3Client) GetObjectRange(bucketName string, objectKey string, offset int64, length int64) (*s3.GetObjectOutput, error) {
+	ret := _m.Called(bucketName, objectKey, offset, length)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetObjectRange")
+	}
+
+	var r0 *s3.GetObjectOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, int64, int64) (*s3.GetObjectOutput, error)); ok {
+		return rf(bucketName, objectKey, offset, length)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, int64, int64) *s3.GetObjectOutput); ok {
+		r0 = rf(bucketName, objectKey, offset, length)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*s3.GetObjectOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, int64, int64) error); ok {
+		r1 = rf(bucketName, objectKey, offset, length)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // HeadObject provides a mock function with given fields: bucketName, objectKey
 func (_m *S3Client) HeadObject(bucketName string, objectKey string) (*s3.HeadObjectOutput, error) {
 	ret := _m.Called(bucketName, objectKey)
@@ -74,6 +166,66 @@ func (_m *S3Client) ListObjects(bucketName string, prefix *string) ([]types.Obje
 	return r0, r1
 }
 
+// PutObject provides a mock function with given fields: bucketName, objectKey, body, contentType
+func (_m *S3Client) PutObject(bucketName string, objectKey string, body io.Reader, contentType string) (*s3.PutObjectOutput, error) {
+	ret := _m.Called(bucketName, objectKey, body, contentType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PutObject")
+	}
+
+	var r0 *s3.PutObjectOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, io.Reader, string) (*s3.PutObjectOutput, error)); ok {
+		return rf(bucketName, objectKey, body, contentType)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, io.Reader, string) *s3.PutObjectOutput); ok {
+		r0 = rf(bucketName, objectKey, body, contentType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*s3.PutObjectOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, io.Reader, string) error); ok {
+		r1 = rf(bucketName, objectKey, body, contentType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PutObjectIfAbsent provides a mock function with given fields: bucketName, objectKey, body, contentType
+func (_m *S3Client) PutObjectIfAbsent(bucketName string, objectKey string, body io.Reader, contentType string) (*s3.PutObjectOutput, error) {
+	ret := _m.Called(bucketName, objectKey, body, contentType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PutObjectIfAbsent")
+	}
+
+	var r0 *s3.PutObjectOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, io.Reader, string) (*s3.PutObjectOutput, error)); ok {
+		return rf(bucketName, objectKey, body, contentType)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, io.Reader, string) *s3.PutObjectOutput); ok {
+		r0 = rf(bucketName, objectKey, body, contentType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*s3.PutObjectOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, io.Reader, string) error); ok {
+		r1 = rf(bucketName, objectKey, body, contentType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewS3Client creates a new instance of S3Client. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewS3Client(t interface {
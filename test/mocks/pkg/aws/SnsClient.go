@@ -0,0 +1,57 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	sns "github.com/aws/aws-sdk-go-v2/service/sns"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SnsClient is an autogenerated mock type for the SnsClient type
+type SnsClient struct {
+	mock.Mock
+}
+
+// Publish provides a mock function with given fields: topicArn, message
+func (_m *SnsClient) Publish(topicArn string, message string) (*sns.PublishOutput, error) {
+	ret := _m.Called(topicArn, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Publish")
+	}
+
+	var r0 *sns.PublishOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (*sns.PublishOutput, error)); ok {
+		return rf(topicArn, message)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) *sns.PublishOutput); ok {
+		r0 = rf(topicArn, message)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sns.PublishOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(topicArn, message)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewSnsClient creates a new instance of SnsClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSnsClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SnsClient {
+	mock := &SnsClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
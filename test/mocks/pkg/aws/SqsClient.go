@@ -5,6 +5,8 @@ package mocks
 import (
 	sqs "github.com/aws/aws-sdk-go-v2/service/sqs"
 	mock "github.com/stretchr/testify/mock"
+
+	types "github.com/aws/aws-sdk-go-v2/service/sqs/types"
 )
 
 // SqsClient is an autogenerated mock type for the SqsClient type
@@ -12,9 +14,39 @@ type SqsClient struct {
 	mock.Mock
 }
 
-// GetMessages provides a mock function with given fields: attributeNames, queueURL, maxMessages, timeout
-func (_m *SqsClient) GetMessages(attributeNames []string, queueURL *string, maxMessages int32, timeout int32) (*sqs.ReceiveMessageOutput, error) {
-	ret := _m.Called(attributeNames, queueURL, maxMessages, timeout)
+// ChangeMessageVisibility provides a mock function with given fields: queueURL, messageHandle, timeout
+func (_m *SqsClient) ChangeMessageVisibility(queueURL *string, messageHandle *string, timeout int32) (*sqs.ChangeMessageVisibilityOutput, error) {
+	ret := _m.Called(queueURL, messageHandle, timeout)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeMessageVisibility")
+	}
+
+	var r0 *sqs.ChangeMessageVisibilityOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*string, *string, int32) (*sqs.ChangeMessageVisibilityOutput, error)); ok {
+		return rf(queueURL, messageHandle, timeout)
+	}
+	if rf, ok := ret.Get(0).(func(*string, *string, int32) *sqs.ChangeMessageVisibilityOutput); ok {
+		r0 = rf(queueURL, messageHandle, timeout)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sqs.ChangeMessageVisibilityOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*string, *string, int32) error); ok {
+		r1 = rf(queueURL, messageHandle, timeout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMessages provides a mock function with given fields: attributeNames, queueURL, maxMessages, timeout, waitTime
+func (_m *SqsClient) GetMessages(attributeNames []string, queueURL *string, maxMessages int32, timeout int32, waitTime int32) (*sqs.ReceiveMessageOutput, error) {
+	ret := _m.Called(attributeNames, queueURL, maxMessages, timeout, waitTime)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetMessages")
@@ -22,19 +54,19 @@ func (_m *SqsClient) GetMessages(attributeNames []string, queueURL *string, maxM
 
 	var r0 *sqs.ReceiveMessageOutput
 	var r1 error
-	if rf, ok := ret.Get(0).(func([]string, *string, int32, int32) (*sqs.ReceiveMessageOutput, error)); ok {
-		return rf(attributeNames, queueURL, maxMessages, timeout)
+	if rf, ok := ret.Get(0).(func([]string, *string, int32, int32, int32) (*sqs.ReceiveMessageOutput, error)); ok {
+		return rf(attributeNames, queueURL, maxMessages, timeout, waitTime)
 	}
-	if rf, ok := ret.Get(0).(func([]string, *string, int32, int32) *sqs.ReceiveMessageOutput); ok {
-		r0 = rf(attributeNames, queueURL, maxMessages, timeout)
+	if rf, ok := ret.Get(0).(func([]string, *string, int32, int32, int32) *sqs.ReceiveMessageOutput); ok {
+		r0 = rf(attributeNames, queueURL, maxMessages, timeout, waitTime)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*sqs.ReceiveMessageOutput)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func([]string, *string, int32, int32) error); ok {
-		r1 = rf(attributeNames, queueURL, maxMessages, timeout)
+	if rf, ok := ret.Get(1).(func([]string, *string, int32, int32, int32) error); ok {
+		r1 = rf(attributeNames, queueURL, maxMessages, timeout, waitTime)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -102,6 +134,36 @@ func (_m *SqsClient) RemoveMessage(queueURL *string, messageHandle *string) (*sq
 	return r0, r1
 }
 
+// SendMessage provides a mock function with given fields: queueURL, body, attributes
+func (_m *SqsClient) SendMessage(queueURL *string, body string, attributes map[string]types.MessageAttributeValue) (*sqs.SendMessageOutput, error) {
+	ret := _m.Called(queueURL, body, attributes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendMessage")
+	}
+
+	var r0 *sqs.SendMessageOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*string, string, map[string]types.MessageAttributeValue) (*sqs.SendMessageOutput, error)); ok {
+		return rf(queueURL, body, attributes)
+	}
+	if rf, ok := ret.Get(0).(func(*string, string, map[string]types.MessageAttributeValue) *sqs.SendMessageOutput); ok {
+		r0 = rf(queueURL, body, attributes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sqs.SendMessageOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*string, string, map[string]types.MessageAttributeValue) error); ok {
+		r1 = rf(queueURL, body, attributes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewSqsClient creates a new instance of SqsClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewSqsClient(t interface {
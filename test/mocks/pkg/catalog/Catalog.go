@@ -0,0 +1,405 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	time "time"
+
+	modelsv1 "github.com/codingexplorations/data-lake/models/v1"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Catalog is an autogenerated mock type for the Catalog type
+type Catalog struct {
+	mock.Mock
+}
+
+// Add provides a mock function with given fields: file, outbox
+func (_m *Catalog) Add(file *modelsv1.DataFile, outbox ...*modelsv1.OutboxEvent) error {
+	_va := make([]interface{}, len(outbox))
+	for _i := range outbox {
+		_va[_i] = outbox[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, file)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Add")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*modelsv1.DataFile, ...*modelsv1.OutboxEvent) error); ok {
+		r0 = rf(file, outbox...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Arrive provides a mock function with given fields: arrival, outbox
+func (_m *Catalog) Arrive(arrival *modelsv1.Lineage, outbox ...*modelsv1.OutboxEvent) error {
+	_va := make([]interface{}, len(outbox))
+	for _i := range outbox {
+		_va[_i] = outbox[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, arrival)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Arrive")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*modelsv1.Lineage, ...*modelsv1.OutboxEvent) error); ok {
+		r0 = rf(arrival, outbox...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Arrived provides a mock function with given fields: dataset
+func (_m *Catalog) Arrived(dataset string) ([]*modelsv1.Lineage, error) {
+	ret := _m.Called(dataset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Arrived")
+	}
+
+	var r0 []*modelsv1.Lineage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]*modelsv1.Lineage, error)); ok {
+		return rf(dataset)
+	}
+	if rf, ok := ret.Get(0).(func(string) []*modelsv1.Lineage); ok {
+		r0 = rf(dataset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*modelsv1.Lineage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(dataset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Datasets provides a mock function with no fields
+func (_m *Catalog) Datasets() ([]string, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Datasets")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]string, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delivered provides a mock function with given fields: dataset, ids
+func (_m *Catalog) Delivered(dataset string, ids []string) error {
+	ret := _m.Called(dataset, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delivered")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(dataset, ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Enqueue provides a mock function with given fields: dataset, outbox
+func (_m *Catalog) Enqueue(dataset string, outbox ...*modelsv1.OutboxEvent) error {
+	_va := make([]interface{}, len(outbox))
+	for _i := range outbox {
+		_va[_i] = outbox[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, dataset)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enqueue")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, ...*modelsv1.OutboxEvent) error); ok {
+		r0 = rf(dataset, outbox...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Files provides a mock function with given fields: dataset
+func (_m *Catalog) Files(dataset string) ([]*modelsv1.DataFile, error) {
+	ret := _m.Called(dataset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Files")
+	}
+
+	var r0 []*modelsv1.DataFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]*modelsv1.DataFile, error)); ok {
+		return rf(dataset)
+	}
+	if rf, ok := ret.Get(0).(func(string) []*modelsv1.DataFile); ok {
+		r0 = rf(dataset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*modelsv1.DataFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(dataset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FilesAsOf provides a mock function with given fields: dataset, timestamp
+func (_m *Catalog) FilesAsOf(dataset string, timestamp time.Time) ([]*modelsv1.DataFile, error) {
+	ret := _m.Called(dataset, timestamp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FilesAsOf")
+	}
+
+	var r0 []*modelsv1.DataFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) ([]*modelsv1.DataFile, error)); ok {
+		return rf(dataset, timestamp)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time) []*modelsv1.DataFile); ok {
+		r0 = rf(dataset, timestamp)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*modelsv1.DataFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time) error); ok {
+		r1 = rf(dataset, timestamp)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FilesAt provides a mock function with given fields: dataset, snapshotId
+func (_m *Catalog) FilesAt(dataset string, snapshotId int64) ([]*modelsv1.DataFile, error) {
+	ret := _m.Called(dataset, snapshotId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FilesAt")
+	}
+
+	var r0 []*modelsv1.DataFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int64) ([]*modelsv1.DataFile, error)); ok {
+		return rf(dataset, snapshotId)
+	}
+	if rf, ok := ret.Get(0).(func(string, int64) []*modelsv1.DataFile); ok {
+		r0 = rf(dataset, snapshotId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*modelsv1.DataFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int64) error); ok {
+		r1 = rf(dataset, snapshotId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Outbox provides a mock function with given fields: dataset
+func (_m *Catalog) Outbox(dataset string) ([]*modelsv1.OutboxEvent, error) {
+	ret := _m.Called(dataset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Outbox")
+	}
+
+	var r0 []*modelsv1.OutboxEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]*modelsv1.OutboxEvent, error)); ok {
+		return rf(dataset)
+	}
+	if rf, ok := ret.Get(0).(func(string) []*modelsv1.OutboxEvent); ok {
+		r0 = rf(dataset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*modelsv1.OutboxEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(dataset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Purge provides a mock function with given fields: dataset, locations, outbox
+func (_m *Catalog) Purge(dataset string, locations []string, outbox ...*modelsv1.OutboxEvent) error {
+	_va := make([]interface{}, len(outbox))
+	for _i := range outbox {
+		_va[_i] = outbox[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, dataset, locations)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Purge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string, ...*modelsv1.OutboxEvent) error); ok {
+		r0 = rf(dataset, locations, outbox...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Removed provides a mock function with given fields: dataset
+func (_m *Catalog) Removed(dataset string) ([]*modelsv1.DataFile, error) {
+	ret := _m.Called(dataset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Removed")
+	}
+
+	var r0 []*modelsv1.DataFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]*modelsv1.DataFile, error)); ok {
+		return rf(dataset)
+	}
+	if rf, ok := ret.Get(0).(func(string) []*modelsv1.DataFile); ok {
+		r0 = rf(dataset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*modelsv1.DataFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(dataset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Snapshots provides a mock function with given fields: dataset
+func (_m *Catalog) Snapshots(dataset string) ([]*modelsv1.Snapshot, error) {
+	ret := _m.Called(dataset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Snapshots")
+	}
+
+	var r0 []*modelsv1.Snapshot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]*modelsv1.Snapshot, error)); ok {
+		return rf(dataset)
+	}
+	if rf, ok := ret.Get(0).(func(string) []*modelsv1.Snapshot); ok {
+		r0 = rf(dataset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*modelsv1.Snapshot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(dataset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Swap provides a mock function with given fields: dataset, removed, added
+func (_m *Catalog) Swap(dataset string, removed []string, added []*modelsv1.DataFile) error {
+	ret := _m.Called(dataset, removed, added)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Swap")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string, []*modelsv1.DataFile) error); ok {
+		r0 = rf(dataset, removed, added)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewCatalog creates a new instance of Catalog. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCatalog(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Catalog {
+	mock := &Catalog{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,57 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	modelsv1 "github.com/codingexplorations/data-lake/models/v1"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Compactor is an autogenerated mock type for the Compactor type
+type Compactor struct {
+	mock.Mock
+}
+
+// Compact provides a mock function with no fields
+func (_m *Compactor) Compact() ([]*modelsv1.DataFile, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Compact")
+	}
+
+	var r0 []*modelsv1.DataFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*modelsv1.DataFile, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*modelsv1.DataFile); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*modelsv1.DataFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewCompactor creates a new instance of Compactor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCompactor(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Compactor {
+	mock := &Compactor{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
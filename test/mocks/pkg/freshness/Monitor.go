@@ -0,0 +1,57 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	freshness "github.com/codingexplorations/data-lake/pkg/freshness"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Monitor is an autogenerated mock type for the Monitor type
+type Monitor struct {
+	mock.Mock
+}
+
+// Check provides a mock function with no fields
+func (_m *Monitor) Check() ([]*freshness.Alert, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Check")
+	}
+
+	var r0 []*freshness.Alert
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*freshness.Alert, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*freshness.Alert); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*freshness.Alert)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMonitor creates a new instance of Monitor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMonitor(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Monitor {
+	mock := &Monitor{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,45 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	freshness "github.com/codingexplorations/data-lake/pkg/freshness"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NotifierFunc is an autogenerated mock type for the NotifierFunc type
+type NotifierFunc struct {
+	mock.Mock
+}
+
+// Execute provides a mock function with given fields: alert
+func (_m *NotifierFunc) Execute(alert *freshness.Alert) error {
+	ret := _m.Called(alert)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Execute")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*freshness.Alert) error); ok {
+		r0 = rf(alert)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewNotifierFunc creates a new instance of NotifierFunc. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNotifierFunc(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *NotifierFunc {
+	mock := &NotifierFunc{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -3,8 +3,11 @@
 package mocks
 
 import (
-	modelsv1 "github.com/codingexplorations/data-lake/models/v1"
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
+
+	modelsv1 "github.com/codingexplorations/data-lake/models/v1"
 )
 
 // IngestProcessor is an autogenerated mock type for the IngestProcessor type
@@ -12,9 +15,9 @@ type IngestProcessor struct {
 	mock.Mock
 }
 
-// ProcessFile provides a mock function with given fields: fileName
-func (_m *IngestProcessor) ProcessFile(fileName string) (*modelsv1.Object, error) {
-	ret := _m.Called(fileName)
+// ProcessFile provides a mock function with given fields: ctx, fileName
+func (_m *IngestProcessor) ProcessFile(ctx context.Context, fileName string) (*modelsv1.Object, error) {
+	ret := _m.Called(ctx, fileName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ProcessFile")
@@ -22,19 +25,19 @@ func (_m *IngestProcessor) ProcessFile(fileName string) (*modelsv1.Object, error
 
 	var r0 *modelsv1.Object
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*modelsv1.Object, error)); ok {
-		return rf(fileName)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*modelsv1.Object, error)); ok {
+		return rf(ctx, fileName)
 	}
-	if rf, ok := ret.Get(0).(func(string) *modelsv1.Object); ok {
-		r0 = rf(fileName)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *modelsv1.Object); ok {
+		r0 = rf(ctx, fileName)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*modelsv1.Object)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(fileName)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, fileName)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -42,9 +45,9 @@ func (_m *IngestProcessor) ProcessFile(fileName string) (*modelsv1.Object, error
 	return r0, r1
 }
 
-// ProcessFolder provides a mock function with given fields: folder
-func (_m *IngestProcessor) ProcessFolder(folder string) ([]*modelsv1.Object, error) {
-	ret := _m.Called(folder)
+// ProcessFolder provides a mock function with given fields: ctx, folder
+func (_m *IngestProcessor) ProcessFolder(ctx context.Context, folder string) ([]*modelsv1.Object, error) {
+	ret := _m.Called(ctx, folder)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ProcessFolder")
@@ -52,19 +55,19 @@ func (_m *IngestProcessor) ProcessFolder(folder string) ([]*modelsv1.Object, err
 
 	var r0 []*modelsv1.Object
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]*modelsv1.Object, error)); ok {
-		return rf(folder)
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*modelsv1.Object, error)); ok {
+		return rf(ctx, folder)
 	}
-	if rf, ok := ret.Get(0).(func(string) []*modelsv1.Object); ok {
-		r0 = rf(folder)
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*modelsv1.Object); ok {
+		r0 = rf(ctx, folder)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*modelsv1.Object)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(folder)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, folder)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,103 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	modelsv1 "github.com/codingexplorations/data-lake/models/v1"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Codec is an autogenerated mock type for the Codec type
+type Codec struct {
+	mock.Mock
+}
+
+// ContentType provides a mock function with no fields
+func (_m *Codec) ContentType() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ContentType")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Decode provides a mock function with given fields: body
+func (_m *Codec) Decode(body string) (*modelsv1.Log, error) {
+	ret := _m.Called(body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Decode")
+	}
+
+	var r0 *modelsv1.Log
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*modelsv1.Log, error)); ok {
+		return rf(body)
+	}
+	if rf, ok := ret.Get(0).(func(string) *modelsv1.Log); ok {
+		r0 = rf(body)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*modelsv1.Log)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Encode provides a mock function with given fields: message
+func (_m *Codec) Encode(message *modelsv1.Log) (string, error) {
+	ret := _m.Called(message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Encode")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*modelsv1.Log) (string, error)); ok {
+		return rf(message)
+	}
+	if rf, ok := ret.Get(0).(func(*modelsv1.Log) string); ok {
+		r0 = rf(message)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(*modelsv1.Log) error); ok {
+		r1 = rf(message)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewCodec creates a new instance of Codec. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCodec(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Codec {
+	mock := &Codec{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
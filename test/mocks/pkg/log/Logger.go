@@ -2,31 +2,68 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	log "github.com/codingexplorations/data-lake/pkg/log"
+	mock "github.com/stretchr/testify/mock"
+)
 
 // Logger is an autogenerated mock type for the Logger type
 type Logger struct {
 	mock.Mock
 }
 
-// Debug provides a mock function with given fields: msg
-func (_m *Logger) Debug(msg string) {
-	_m.Called(msg)
+// Debug provides a mock function with given fields: msg, args
+func (_m *Logger) Debug(msg string, args ...interface{}) {
+	var _ca []interface{}
+	_ca = append(_ca, msg)
+	_ca = append(_ca, args...)
+	_m.Called(_ca...)
 }
 
-// Error provides a mock function with given fields: msg
-func (_m *Logger) Error(msg string) {
-	_m.Called(msg)
+// Error provides a mock function with given fields: msg, args
+func (_m *Logger) Error(msg string, args ...interface{}) {
+	var _ca []interface{}
+	_ca = append(_ca, msg)
+	_ca = append(_ca, args...)
+	_m.Called(_ca...)
 }
 
-// Info provides a mock function with given fields: msg
-func (_m *Logger) Info(msg string) {
-	_m.Called(msg)
+// Info provides a mock function with given fields: msg, args
+func (_m *Logger) Info(msg string, args ...interface{}) {
+	var _ca []interface{}
+	_ca = append(_ca, msg)
+	_ca = append(_ca, args...)
+	_m.Called(_ca...)
 }
 
-// Warn provides a mock function with given fields: msg
-func (_m *Logger) Warn(msg string) {
-	_m.Called(msg)
+// Warn provides a mock function with given fields: msg, args
+func (_m *Logger) Warn(msg string, args ...interface{}) {
+	var _ca []interface{}
+	_ca = append(_ca, msg)
+	_ca = append(_ca, args...)
+	_m.Called(_ca...)
+}
+
+// With provides a mock function with given fields: args
+func (_m *Logger) With(args ...interface{}) log.Logger {
+	var _ca []interface{}
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for With")
+	}
+
+	var r0 log.Logger
+	if rf, ok := ret.Get(0).(func(...interface{}) log.Logger); ok {
+		r0 = rf(args...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(log.Logger)
+		}
+	}
+
+	return r0
 }
 
 // NewLogger creates a new instance of Logger. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -3,9 +3,12 @@
 package mocks
 
 import (
-	sqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
 
+	sqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+
 	types "github.com/aws/aws-sdk-go-v2/service/sqs/types"
 )
 
@@ -74,6 +77,36 @@ func (_m *LoggerSqsClient) SendMessage(delay int32, attributes map[string]types.
 	return r0, r1
 }
 
+// SendMessageBatch provides a mock function with given fields: ctx, entries, queueUrl
+func (_m *LoggerSqsClient) SendMessageBatch(ctx context.Context, entries []types.SendMessageBatchRequestEntry, queueUrl *string) (*sqs.SendMessageBatchOutput, error) {
+	ret := _m.Called(ctx, entries, queueUrl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendMessageBatch")
+	}
+
+	var r0 *sqs.SendMessageBatchOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []types.SendMessageBatchRequestEntry, *string) (*sqs.SendMessageBatchOutput, error)); ok {
+		return rf(ctx, entries, queueUrl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []types.SendMessageBatchRequestEntry, *string) *sqs.SendMessageBatchOutput); ok {
+		r0 = rf(ctx, entries, queueUrl)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sqs.SendMessageBatchOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []types.SendMessageBatchRequestEntry, *string) error); ok {
+		r1 = rf(ctx, entries, queueUrl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewLoggerSqsClient creates a new instance of LoggerSqsClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewLoggerSqsClient(t interface {
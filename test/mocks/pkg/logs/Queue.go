@@ -0,0 +1,58 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	aws "github.com/codingexplorations/data-lake/pkg/aws"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// Queue is an autogenerated mock type for the Queue type
+type Queue struct {
+	mock.Mock
+}
+
+// PollBatch provides a mock function with given fields: handler, size, wait
+func (_m *Queue) PollBatch(handler aws.BatchHandler, size int, wait time.Duration) (int, error) {
+	ret := _m.Called(handler, size, wait)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PollBatch")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(aws.BatchHandler, int, time.Duration) (int, error)); ok {
+		return rf(handler, size, wait)
+	}
+	if rf, ok := ret.Get(0).(func(aws.BatchHandler, int, time.Duration) int); ok {
+		r0 = rf(handler, size, wait)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(aws.BatchHandler, int, time.Duration) error); ok {
+		r1 = rf(handler, size, wait)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewQueue creates a new instance of Queue. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewQueue(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Queue {
+	mock := &Queue{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
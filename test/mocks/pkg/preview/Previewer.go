@@ -0,0 +1,87 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	preview "github.com/codingexplorations/data-lake/pkg/preview"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Previewer is an autogenerated mock type for the Previewer type
+type Previewer struct {
+	mock.Mock
+}
+
+// PreviewDataset provides a mock function with given fields: dataset, location, limit
+func (_m *Previewer) PreviewDataset(dataset string, location string, limit int) (*preview.Preview, error) {
+	ret := _m.Called(dataset, location, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PreviewDataset")
+	}
+
+	var r0 *preview.Preview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, int) (*preview.Preview, error)); ok {
+		return rf(dataset, location, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, int) *preview.Preview); ok {
+		r0 = rf(dataset, location, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*preview.Preview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, int) error); ok {
+		r1 = rf(dataset, location, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PreviewFile provides a mock function with given fields: location, limit
+func (_m *Previewer) PreviewFile(location string, limit int) (*preview.Preview, error) {
+	ret := _m.Called(location, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PreviewFile")
+	}
+
+	var r0 *preview.Preview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int) (*preview.Preview, error)); ok {
+		return rf(location, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, int) *preview.Preview); ok {
+		r0 = rf(location, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*preview.Preview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(location, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPreviewer creates a new instance of Previewer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPreviewer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Previewer {
+	mock := &Previewer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,63 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// History is an autogenerated mock type for the History type
+type History struct {
+	mock.Mock
+}
+
+// RowsPerObject provides a mock function with given fields: dataset, since
+func (_m *History) RowsPerObject(dataset string, since time.Time) (float64, bool, error) {
+	ret := _m.Called(dataset, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RowsPerObject")
+	}
+
+	var r0 float64
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) (float64, bool, error)); ok {
+		return rf(dataset, since)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time) float64); ok {
+		r0 = rf(dataset, since)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time) bool); ok {
+		r1 = rf(dataset, since)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(string, time.Time) error); ok {
+		r2 = rf(dataset, since)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NewHistory creates a new instance of History. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHistory(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *History {
+	mock := &History{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,87 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	query "github.com/codingexplorations/data-lake/pkg/query"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Engine is an autogenerated mock type for the Engine type
+type Engine struct {
+	mock.Mock
+}
+
+// Profile provides a mock function with given fields: dataset
+func (_m *Engine) Profile(dataset string) (*query.Profile, error) {
+	ret := _m.Called(dataset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Profile")
+	}
+
+	var r0 *query.Profile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*query.Profile, error)); ok {
+		return rf(dataset)
+	}
+	if rf, ok := ret.Get(0).(func(string) *query.Profile); ok {
+		r0 = rf(dataset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*query.Profile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(dataset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Query provides a mock function with given fields: sql
+func (_m *Engine) Query(sql string) (*query.Result, error) {
+	ret := _m.Called(sql)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Query")
+	}
+
+	var r0 *query.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*query.Result, error)); ok {
+		return rf(sql)
+	}
+	if rf, ok := ret.Get(0).(func(string) *query.Result); ok {
+		r0 = rf(sql)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*query.Result)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(sql)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewEngine creates a new instance of Engine. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEngine(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Engine {
+	mock := &Engine{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
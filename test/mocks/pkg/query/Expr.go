@@ -0,0 +1,72 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// Expr is an autogenerated mock type for the Expr type
+type Expr struct {
+	mock.Mock
+}
+
+// Eval provides a mock function with given fields: row
+func (_m *Expr) Eval(row map[string]interface{}) (interface{}, error) {
+	ret := _m.Called(row)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Eval")
+	}
+
+	var r0 interface{}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) (interface{}, error)); ok {
+		return rf(row)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) interface{}); ok {
+		r0 = rf(row)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
+		r1 = rf(row)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// String provides a mock function with no fields
+func (_m *Expr) String() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for String")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// NewExpr creates a new instance of Expr. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExpr(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Expr {
+	mock := &Expr{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,118 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	modelsv1 "github.com/codingexplorations/data-lake/models/v1"
+	registry "github.com/codingexplorations/data-lake/pkg/registry"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SchemaRegistry is an autogenerated mock type for the SchemaRegistry type
+type SchemaRegistry struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: dataset, version
+func (_m *SchemaRegistry) Get(dataset string, version int32) (*modelsv1.Schema, error) {
+	ret := _m.Called(dataset, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *modelsv1.Schema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int32) (*modelsv1.Schema, error)); ok {
+		return rf(dataset, version)
+	}
+	if rf, ok := ret.Get(0).(func(string, int32) *modelsv1.Schema); ok {
+		r0 = rf(dataset, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*modelsv1.Schema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int32) error); ok {
+		r1 = rf(dataset, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Latest provides a mock function with given fields: dataset
+func (_m *SchemaRegistry) Latest(dataset string) (*modelsv1.Schema, error) {
+	ret := _m.Called(dataset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Latest")
+	}
+
+	var r0 *modelsv1.Schema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*modelsv1.Schema, error)); ok {
+		return rf(dataset)
+	}
+	if rf, ok := ret.Get(0).(func(string) *modelsv1.Schema); ok {
+		r0 = rf(dataset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*modelsv1.Schema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(dataset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Register provides a mock function with given fields: dataset, schema, compatibility
+func (_m *SchemaRegistry) Register(dataset string, schema *modelsv1.Schema, compatibility registry.Compatibility) (*modelsv1.Schema, error) {
+	ret := _m.Called(dataset, schema, compatibility)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Register")
+	}
+
+	var r0 *modelsv1.Schema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, *modelsv1.Schema, registry.Compatibility) (*modelsv1.Schema, error)); ok {
+		return rf(dataset, schema, compatibility)
+	}
+	if rf, ok := ret.Get(0).(func(string, *modelsv1.Schema, registry.Compatibility) *modelsv1.Schema); ok {
+		r0 = rf(dataset, schema, compatibility)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*modelsv1.Schema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, *modelsv1.Schema, registry.Compatibility) error); ok {
+		r1 = rf(dataset, schema, compatibility)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewSchemaRegistry creates a new instance of SchemaRegistry. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSchemaRegistry(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SchemaRegistry {
+	mock := &SchemaRegistry{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
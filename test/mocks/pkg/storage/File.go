@@ -0,0 +1,88 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// File is an autogenerated mock type for the File type
+type File struct {
+	mock.Mock
+}
+
+// Close provides a mock function with no fields
+func (_m *File) Close() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Close")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReadAt provides a mock function with given fields: p, off
+func (_m *File) ReadAt(p []byte, off int64) (int, error) {
+	ret := _m.Called(p, off)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadAt")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]byte, int64) (int, error)); ok {
+		return rf(p, off)
+	}
+	if rf, ok := ret.Get(0).(func([]byte, int64) int); ok {
+		r0 = rf(p, off)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func([]byte, int64) error); ok {
+		r1 = rf(p, off)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Size provides a mock function with no fields
+func (_m *File) Size() int64 {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Size")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// NewFile creates a new instance of File. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFile(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *File {
+	mock := &File{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}